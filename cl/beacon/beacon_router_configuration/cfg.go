@@ -43,6 +43,10 @@ type RouterConfiguration struct {
 	Node       bool
 	Validator  bool
 	Lighthouse bool
+
+	// BuilderBoostFactor is the percentage applied to builder bids against the local payload value when the validator
+	// client doesn't send builder_boost_factor
+	BuilderBoostFactor uint64
 }
 
 func (r *RouterConfiguration) UnwrapEndpointsList(l []string) error {
@@ -47,15 +47,10 @@ type builderClient struct {
 }
 
 func NewBlockBuilderClient(baseUrl string, beaconConfig *clparams.BeaconChainConfig) *builderClient {
-	u, err := url.Parse(baseUrl)
+	c, err := newBuilderClient(baseUrl, beaconConfig)
 	if err != nil {
 		panic(err)
 	}
-	c := &builderClient{
-		httpClient:   &http.Client{},
-		url:          u,
-		beaconConfig: beaconConfig,
-	}
 	if err := c.GetStatus(context.Background()); err != nil {
 		log.Error("cannot connect to builder client", "url", baseUrl, "error", err)
 		panic("cannot connect to builder client")
@@ -64,6 +59,18 @@ func NewBlockBuilderClient(baseUrl string, beaconConfig *clparams.BeaconChainCon
 	return c
 }
 
+func newBuilderClient(baseUrl string, beaconConfig *clparams.BeaconChainConfig) (*builderClient, error) {
+	u, err := url.Parse(baseUrl)
+	if err != nil {
+		return nil, err
+	}
+	return &builderClient{
+		httpClient:   &http.Client{},
+		url:          u,
+		beaconConfig: beaconConfig,
+	}, nil
+}
+
 func (b *builderClient) RegisterValidator(ctx context.Context, registers []*cltypes.ValidatorRegistration) error {
 	// https://ethereum.github.io/builder-specs/#/Builder/registerValidator
 	path := "/eth/v1/builder/validators"
@@ -0,0 +1,234 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package builder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/cl/clparams"
+	"github.com/erigontech/erigon/cl/cltypes"
+	"github.com/erigontech/erigon/turbo/engineapi/engine_types"
+)
+
+// DefaultRelayTimeout is how long block production waits for bids, it leaves enough of the slot to sign and publish
+const DefaultRelayTimeout = 950 * time.Millisecond
+
+// bidsCacheSize is the number of recent winning bids remembered, so that a blinded block goes to the relays which offered it
+const bidsCacheSize = 64
+
+var _ BuilderClient = &multiRelayClient{}
+
+var ErrNoValidBid = errors.New("no valid bid from any relay")
+
+// multiRelayClient asks several relays at once and picks the highest valid bid
+type multiRelayClient struct {
+	relays  []*builderClient
+	timeout time.Duration
+	// block hash => indices of the relays which offered it
+	bids *lru.Cache[common.Hash, []int]
+}
+
+// NewMultiRelayClient connects to all the given relays. Relays which are down at startup are still queried later on,
+// but at least one of them must be up.
+func NewMultiRelayClient(baseUrls []string, timeout time.Duration, beaconConfig *clparams.BeaconChainConfig) (*multiRelayClient, error) {
+	if len(baseUrls) == 0 {
+		return nil, errors.New("no relay urls")
+	}
+	if timeout == 0 {
+		timeout = DefaultRelayTimeout
+	}
+	bids, err := lru.New[common.Hash, []int](bidsCacheSize)
+	if err != nil {
+		return nil, err
+	}
+	c := &multiRelayClient{
+		timeout: timeout,
+		bids:    bids,
+	}
+	ready := 0
+	for _, baseUrl := range baseUrls {
+		relay, err := newBuilderClient(baseUrl, beaconConfig)
+		if err != nil {
+			return nil, fmt.Errorf("invalid relay url %q: %w", baseUrl, err)
+		}
+		if err := relay.GetStatus(context.Background()); err != nil {
+			log.Warn("cannot connect to builder client", "url", baseUrl, "error", err)
+		} else {
+			ready++
+		}
+		c.relays = append(c.relays, relay)
+	}
+	if ready == 0 {
+		return nil, errors.New("cannot connect to any builder client")
+	}
+	log.Info("Builder client is ready", "relays", len(c.relays), "connected", ready, "timeout", timeout)
+	return c, nil
+}
+
+// forEachRelay calls f for the given relays concurrently and returns the errors by relay
+func (m *multiRelayClient) forEachRelay(relays []int, f func(i int, relay *builderClient) error) []error {
+	errs := make([]error, len(m.relays))
+	var wg sync.WaitGroup
+	for _, i := range relays {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = f(i, m.relays[i])
+		}(i)
+	}
+	wg.Wait()
+	return errs
+}
+
+func (m *multiRelayClient) allRelays() []int {
+	relays := make([]int, len(m.relays))
+	for i := range relays {
+		relays[i] = i
+	}
+	return relays
+}
+
+// RegisterValidator registers with every relay, it fails only if no relay accepted the registrations
+func (m *multiRelayClient) RegisterValidator(ctx context.Context, registers []*cltypes.ValidatorRegistration) error {
+	errs := m.forEachRelay(m.allRelays(), func(_ int, relay *builderClient) error {
+		return relay.RegisterValidator(ctx, registers)
+	})
+	for _, err := range errs {
+		if err == nil {
+			return nil
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// GetHeader asks all relays for a bid within the timeout and returns the highest valid one
+func (m *multiRelayClient) GetHeader(ctx context.Context, slot int64, parentHash common.Hash, pubKey common.Bytes48) (*ExecutionHeader, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+
+	headers := make([]*ExecutionHeader, len(m.relays))
+	errs := m.forEachRelay(m.allRelays(), func(i int, relay *builderClient) error {
+		header, err := relay.GetHeader(ctx, slot, parentHash, pubKey)
+		if err != nil {
+			return err
+		}
+		if err := validateBid(header, parentHash); err != nil {
+			log.Warn("[mev builder] invalid bid", "url", relay.url.String(), "slot", slot, "err", err)
+			return err
+		}
+		headers[i] = header
+		return nil
+	})
+
+	var best *ExecutionHeader
+	var bestRelays []int
+	for i, header := range headers {
+		if header == nil {
+			continue
+		}
+		if best == nil {
+			best, bestRelays = header, []int{i}
+			continue
+		}
+		switch header.BlockValue().Cmp(best.BlockValue()) {
+		case 1:
+			best, bestRelays = header, []int{i}
+		case 0:
+			// relays often share builders, the same block may come from several of them
+			if header.Data.Message.Header.BlockHash == best.Data.Message.Header.BlockHash {
+				bestRelays = append(bestRelays, i)
+			}
+		}
+	}
+	if best == nil {
+		return nil, fmt.Errorf("%w: %w", ErrNoValidBid, errors.Join(errs...))
+	}
+	m.bids.Add(best.Data.Message.Header.BlockHash, bestRelays)
+	log.Debug("[mev builder] best bid", "slot", slot, "value", best.BlockValue(), "blockHash", best.Data.Message.Header.BlockHash, "relays", len(bestRelays))
+	return best, nil
+}
+
+// SubmitBlindedBlocks sends the block to the relays which offered it, or to all of them if it wasn't seen, and returns
+// the first payload revealed
+func (m *multiRelayClient) SubmitBlindedBlocks(ctx context.Context, block *cltypes.SignedBlindedBeaconBlock) (*cltypes.Eth1Block, *engine_types.BlobsBundleV1, error) {
+	relays := m.allRelays()
+	if block.Block != nil && block.Block.Body != nil && block.Block.Body.ExecutionPayload != nil {
+		if bidRelays, ok := m.bids.Get(block.Block.Body.ExecutionPayload.BlockHash); ok {
+			relays = bidRelays
+		}
+	}
+
+	var (
+		lock        sync.Mutex
+		eth1Block   *cltypes.Eth1Block
+		blobsBundle *engine_types.BlobsBundleV1
+	)
+	errs := m.forEachRelay(relays, func(_ int, relay *builderClient) error {
+		payload, blobs, err := relay.SubmitBlindedBlocks(ctx, block)
+		if err != nil {
+			return err
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		if eth1Block == nil {
+			eth1Block, blobsBundle = payload, blobs
+		}
+		return nil
+	})
+	if eth1Block == nil {
+		return nil, nil, errors.Join(errs...)
+	}
+	return eth1Block, blobsBundle, nil
+}
+
+// GetStatus succeeds if any relay is up
+func (m *multiRelayClient) GetStatus(ctx context.Context) error {
+	errs := m.forEachRelay(m.allRelays(), func(_ int, relay *builderClient) error {
+		return relay.GetStatus(ctx)
+	})
+	for _, err := range errs {
+		if err == nil {
+			return nil
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validateBid checks what can be checked about a bid without the beacon state
+func validateBid(header *ExecutionHeader, parentHash common.Hash) error {
+	if header == nil {
+		return errors.New("no error but nil header")
+	}
+	if header.Data.Message.Header == nil {
+		return errors.New("nil execution payload header")
+	}
+	if header.Data.Message.Header.ParentHash != parentHash {
+		return fmt.Errorf("invalid parent hash %x, expected %x", header.Data.Message.Header.ParentHash, parentHash)
+	}
+	if value := header.BlockValue(); value == nil || value.Sign() <= 0 {
+		return fmt.Errorf("invalid block value %q", header.Data.Message.Value)
+	}
+	return nil
+}
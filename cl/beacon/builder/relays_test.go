@@ -0,0 +1,141 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package builder
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon/cl/cltypes"
+	"github.com/stretchr/testify/require"
+)
+
+// mockRelay is a local stand-in relay offering a single bid
+type mockRelay struct {
+	server     *httptest.Server
+	value      string
+	blockHash  common.Hash
+	parentHash common.Hash
+	delay      time.Duration
+	fail       atomic.Bool
+	submitted  atomic.Int32
+}
+
+func newMockRelay(t *testing.T, value string, blockHash, parentHash common.Hash) *mockRelay {
+	r := &mockRelay{value: value, blockHash: blockHash, parentHash: parentHash}
+	r.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.URL.Path == "/eth/v1/builder/status":
+			w.WriteHeader(http.StatusOK)
+		case r.fail.Load():
+			w.WriteHeader(http.StatusInternalServerError)
+		case req.URL.Path == "/eth/v1/builder/validators":
+			w.WriteHeader(http.StatusOK)
+		case strings.HasPrefix(req.URL.Path, "/eth/v1/builder/header/"):
+			time.Sleep(r.delay)
+			header := ExecutionHeader{}
+			require.NoError(t, json.Unmarshal(mockHeaderBytes, &header))
+			header.Data.Message.Value = r.value
+			header.Data.Message.Header.BlockHash = r.blockHash
+			header.Data.Message.Header.ParentHash = r.parentHash
+			require.NoError(t, json.NewEncoder(w).Encode(header))
+		case req.URL.Path == "/eth/v1/builder/blinded_blocks":
+			r.submitted.Add(1)
+			_, err := w.Write(mockBlindedResponseBytes)
+			require.NoError(t, err)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(r.server.Close)
+	return r
+}
+
+func TestMultiRelayClient(t *testing.T) {
+	ctx := context.Background()
+	mockBlindedBlock := &cltypes.SignedBlindedBeaconBlock{}
+	require.NoError(t, json.Unmarshal(mockBlindedBlockBytes, mockBlindedBlock))
+	winningHash := mockBlindedBlock.Block.Body.ExecutionPayload.BlockHash
+	parentHash := common.HexToHash("0x1234567")
+	pubKey := newBytes48FromString("0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef")
+
+	low := newMockRelay(t, "5", common.HexToHash("0x01"), parentHash)
+	best := newMockRelay(t, "7", winningHash, parentHash)
+	sameBid := newMockRelay(t, "7", winningHash, parentHash)
+	wrongParent := newMockRelay(t, "100", common.HexToHash("0x02"), common.HexToHash("0x03"))
+	slow := newMockRelay(t, "1000", common.HexToHash("0x04"), parentHash)
+	slow.delay = 500 * time.Millisecond
+	relays := []*mockRelay{low, best, sameBid, wrongParent, slow}
+	urls := make([]string, len(relays))
+	for i, r := range relays {
+		urls[i] = r.server.URL
+	}
+	client, err := NewMultiRelayClient(urls, 200*time.Millisecond, mockBeaconConfig)
+	require.NoError(t, err)
+
+	t.Run("highest valid bid", func(t *testing.T) {
+		header, err := client.GetHeader(ctx, 123, parentHash, pubKey)
+		require.NoError(t, err)
+		require.Equal(t, "7", header.Data.Message.Value)
+		require.Equal(t, winningHash, header.Data.Message.Header.BlockHash)
+	})
+
+	t.Run("submit to relays which offered the block", func(t *testing.T) {
+		block, _, err := client.SubmitBlindedBlocks(ctx, mockBlindedBlock)
+		require.NoError(t, err)
+		require.NotNil(t, block)
+		for _, r := range relays {
+			expected := int32(0)
+			if r == best || r == sameBid {
+				expected = 1
+			}
+			require.Equal(t, expected, r.submitted.Load(), r.server.URL)
+		}
+	})
+
+	t.Run("register with any relay", func(t *testing.T) {
+		low.fail.Store(true)
+		defer func() { low.fail.Store(false) }()
+		registrations := []*cltypes.ValidatorRegistration{{Message: cltypes.ValidatorRegistrationMessage{PubKey: pubKey}}}
+		require.NoError(t, client.RegisterValidator(ctx, registrations))
+	})
+
+	t.Run("no valid bid", func(t *testing.T) {
+		client, err := NewMultiRelayClient([]string{wrongParent.server.URL, slow.server.URL}, 200*time.Millisecond, mockBeaconConfig)
+		require.NoError(t, err)
+		_, err = client.GetHeader(ctx, 123, parentHash, pubKey)
+		require.ErrorIs(t, err, ErrNoValidBid)
+	})
+
+	t.Run("bad configuration", func(t *testing.T) {
+		_, err := NewMultiRelayClient(nil, 0, mockBeaconConfig)
+		require.ErrorContains(t, err, "no relay urls")
+		_, err = NewMultiRelayClient([]string{low.server.URL, "http://relay\x7f"}, 0, mockBeaconConfig)
+		require.ErrorContains(t, err, "invalid relay url")
+		down := httptest.NewServer(http.NotFoundHandler())
+		down.Close()
+		_, err = NewMultiRelayClient([]string{down.URL}, 0, mockBeaconConfig)
+		require.ErrorContains(t, err, "cannot connect to any builder client")
+	})
+}
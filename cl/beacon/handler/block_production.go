@@ -71,6 +71,8 @@ const (
 
 var (
 	errBuilderNotEnabled = errors.New("builder is not enabled")
+	// errBuilderCircuitBreak is returned when too many slots were missed recently and blocks are built locally
+	errBuilderCircuitBreak = errors.New("builder circuit breaker triggered")
 )
 
 var defaultGraffitiString = "Caplin"
@@ -156,7 +158,7 @@ func (a *ApiHandler) GetEthV3ValidatorBlock(
 	}
 
 	// builder boost factor controls block choice between local execution node or builder
-	var builderBoostFactor uint64
+	builderBoostFactor := a.routerCfg.BuilderBoostFactor
 	builderBoostFactorStr := r.URL.Query().Get("builder_boost_factor")
 	if builderBoostFactorStr != "" {
 		builderBoostFactor, err = strconv.ParseUint(builderBoostFactorStr, 10, 64)
@@ -306,6 +308,10 @@ func (a *ApiHandler) produceBlock(
 	go func() {
 		defer wg.Done()
 		if a.routerCfg.Builder && a.builderClient != nil {
+			if a.builderCircuitBreak(baseState, targetSlot) {
+				builderErr = errBuilderCircuitBreak
+				return
+			}
 			builderHeader, builderErr = a.getBuilderPayload(ctx, baseBlock, baseState, targetSlot)
 			if builderErr != nil && builderErr != errBuilderNotEnabled {
 				log.Warn("Failed to get builder payload", "err", builderErr)
@@ -383,6 +389,45 @@ func (a *ApiHandler) produceBlock(
 	return block, nil
 }
 
+// builderCircuitBreak reports whether the chain missed too many slots recently to trust the builders: either too many
+// slots in a row right before the target slot, or too many slots within the last epoch. The state must be processed up
+// to the target slot, a missed slot has the same block root as the slot before it.
+func (a *ApiHandler) builderCircuitBreak(s *state.CachingBeaconState, targetSlot uint64) bool {
+	if targetSlot == 0 {
+		return false
+	}
+	var (
+		consecutive, total uint64
+		inARow             = true
+	)
+	for slot := targetSlot - 1; slot > 0 && slot+a.beaconChainCfg.SlotsPerEpoch >= targetSlot; slot-- {
+		root, err := s.GetBlockRootAtSlot(slot)
+		if err != nil {
+			log.Warn("Failed to get block root for builder circuit breaker", "slot", slot, "err", err)
+			return false
+		}
+		prevRoot, err := s.GetBlockRootAtSlot(slot - 1)
+		if err != nil {
+			log.Warn("Failed to get block root for builder circuit breaker", "slot", slot-1, "err", err)
+			return false
+		}
+		if root != prevRoot {
+			inARow = false
+			continue
+		}
+		total++
+		if inARow {
+			consecutive++
+		}
+	}
+	maxConsecutive, maxTotal := a.beaconChainCfg.MaxBuilderConsecutiveMissedSlots, a.beaconChainCfg.MaxBuilderEpochMissedSlots
+	if (maxConsecutive > 0 && consecutive >= maxConsecutive) || (maxTotal > 0 && total >= maxTotal) {
+		log.Warn("Builder circuit breaker triggered, building the block locally", "slot", targetSlot, "consecutiveMissed", consecutive, "epochMissed", total)
+		return true
+	}
+	return false
+}
+
 func (a *ApiHandler) getBuilderPayload(
 	ctx context.Context,
 	baseBlock *cltypes.BeaconBlock,
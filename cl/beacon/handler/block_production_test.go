@@ -0,0 +1,71 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package handler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon/cl/clparams"
+	"github.com/erigontech/erigon/cl/phase1/core/state"
+)
+
+func TestBuilderCircuitBreak(t *testing.T) {
+	cfg := clparams.MainnetBeaconConfig
+	cfg.MaxBuilderConsecutiveMissedSlots, cfg.MaxBuilderEpochMissedSlots = 3, 8
+	a := &ApiHandler{beaconChainCfg: &cfg}
+
+	const targetSlot = 100
+	// newState makes a state at the target slot in which the given slots were missed
+	newState := func(missed ...uint64) *state.CachingBeaconState {
+		s := state.New(&cfg)
+		s.SetSlot(targetSlot)
+		isMissed := map[uint64]bool{}
+		for _, slot := range missed {
+			isMissed[slot] = true
+		}
+		var root libcommon.Hash
+		for slot := uint64(0); slot < targetSlot; slot++ {
+			if !isMissed[slot] {
+				root = libcommon.Hash{byte(slot), 1}
+			}
+			s.SetBlockRootAt(int(slot%cfg.SlotsPerHistoricalRoot), root)
+		}
+		return s
+	}
+
+	require.False(t, a.builderCircuitBreak(newState(), targetSlot))
+	require.False(t, a.builderCircuitBreak(newState(), 0))
+
+	// missed slots in a row right before the target slot
+	require.False(t, a.builderCircuitBreak(newState(98, 99), targetSlot))
+	require.True(t, a.builderCircuitBreak(newState(97, 98, 99), targetSlot))
+	// the same run of missed slots further back only counts towards the epoch total
+	require.False(t, a.builderCircuitBreak(newState(95, 96, 97), targetSlot))
+
+	// missed slots within the last epoch
+	require.False(t, a.builderCircuitBreak(newState(70, 72, 74, 76, 78, 80, 82), targetSlot))
+	require.True(t, a.builderCircuitBreak(newState(70, 72, 74, 76, 78, 80, 82, 84), targetSlot))
+	// slots missed before the last epoch don't count
+	require.False(t, a.builderCircuitBreak(newState(50, 52, 54, 56, 58, 60, 62, 64), targetSlot))
+
+	// the checks can be disabled
+	cfg.MaxBuilderConsecutiveMissedSlots, cfg.MaxBuilderEpochMissedSlots = 0, 0
+	require.False(t, a.builderCircuitBreak(newState(70, 72, 74, 76, 78, 80, 82, 84, 97, 98, 99), targetSlot))
+}
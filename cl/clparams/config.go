@@ -26,6 +26,7 @@ import (
 	"os"
 	"path"
 	"strconv"
+	"strings"
 	"time"
 
 	"gopkg.in/yaml.v2"
@@ -49,8 +50,10 @@ type CaplinConfig struct {
 	// DisableCheckpointSync is optional and is used to disable checkpoint sync used by default in the node
 	DisabledCheckpointSync bool
 	// CaplinMeVRelayUrl is optional and is used to connect to the external builder service.
-	// If it's set, the node will start in builder mode
+	// If it's set, the node will start in builder mode. Several relays can be given separated by commas.
 	MevRelayUrl string
+	// MevRelayTimeout bounds how long block production waits for the relays' bids
+	MevRelayTimeout time.Duration
 	// EnableValidatorMonitor is used to enable the validator monitor metrics and corresponding logs
 	EnableValidatorMonitor bool
 
@@ -85,6 +88,16 @@ func (c CaplinConfig) RelayUrlExist() bool {
 	return c.MevRelayUrl != ""
 }
 
+func (c CaplinConfig) MevRelayUrls() []string {
+	var urls []string
+	for _, u := range strings.Split(c.MevRelayUrl, ",") {
+		if u = strings.TrimSpace(u); u != "" {
+			urls = append(urls, u)
+		}
+	}
+	return urls
+}
+
 type NetworkType int
 
 const (
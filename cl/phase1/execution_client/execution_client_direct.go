@@ -24,7 +24,10 @@ import (
 	"math/big"
 
 	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/gointerfaces"
 	execution "github.com/erigontech/erigon-lib/gointerfaces/executionproto"
+	"github.com/erigontech/erigon-lib/gointerfaces/txpoolproto"
+	"github.com/erigontech/erigon-lib/gointerfaces/typesproto"
 	"github.com/erigontech/erigon/cl/cltypes"
 	"github.com/erigontech/erigon/core/types"
 	"github.com/erigontech/erigon/turbo/engineapi/engine_types"
@@ -33,11 +36,13 @@ import (
 
 type ExecutionClientDirect struct {
 	chainRW eth1_chain_reader.ChainReaderWriterEth1
+	txpool  txpoolproto.TxpoolClient
 }
 
-func NewExecutionClientDirect(chainRW eth1_chain_reader.ChainReaderWriterEth1) (*ExecutionClientDirect, error) {
+func NewExecutionClientDirect(chainRW eth1_chain_reader.ChainReaderWriterEth1, txpool txpoolproto.TxpoolClient) (*ExecutionClientDirect, error) {
 	return &ExecutionClientDirect{
 		chainRW: chainRW,
+		txpool:  txpool,
 	}, nil
 }
 
@@ -162,3 +167,26 @@ func (cc *ExecutionClientDirect) HasGapInSnapshots(ctx context.Context) bool {
 	_, hasGap := cc.chainRW.FrozenBlocks(ctx)
 	return hasGap
 }
+
+// GetBlobs looks up blobs by versioned hashes in the transaction pool, result has nil for blobs missing in the pool
+func (cc *ExecutionClientDirect) GetBlobs(ctx context.Context, versionedHashes []libcommon.Hash) ([]*engine_types.BlobAndProofV1, error) {
+	req := &txpoolproto.GetBlobsRequest{BlobHashes: make([]*typesproto.H256, len(versionedHashes))}
+	for i := range versionedHashes {
+		req.BlobHashes[i] = gointerfaces.ConvertHashToH256(versionedHashes[i])
+	}
+	reply, err := cc.txpool.GetBlobs(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if len(reply.BlobsAndProofs) != len(versionedHashes) {
+		return nil, fmt.Errorf("txpool returned %d blobs, requested %d", len(reply.BlobsAndProofs), len(versionedHashes))
+	}
+	res := make([]*engine_types.BlobAndProofV1, len(versionedHashes))
+	for i, blobAndProof := range reply.BlobsAndProofs {
+		if len(blobAndProof.Blob) == 0 {
+			continue
+		}
+		res[i] = &engine_types.BlobAndProofV1{Blob: blobAndProof.Blob, Proof: blobAndProof.Proof}
+	}
+	return res, nil
+}
@@ -268,6 +268,17 @@ func (cc *ExecutionClientRpc) GetAssembledBlock(ctx context.Context, id []byte)
 	panic("unimplemented")
 }
 
+func (cc *ExecutionClientRpc) GetBlobs(ctx context.Context, versionedHashes []libcommon.Hash) ([]*engine_types.BlobAndProofV1, error) {
+	result := []*engine_types.BlobAndProofV1{}
+	if err := cc.client.CallContext(ctx, &result, rpc_helper.GetBlobsV1, versionedHashes); err != nil {
+		return nil, err
+	}
+	if len(result) != len(versionedHashes) {
+		return nil, fmt.Errorf("execution client returned %d blobs, requested %d", len(result), len(versionedHashes))
+	}
+	return result, nil
+}
+
 func (cc *ExecutionClientRpc) HasGapInSnapshots(ctx context.Context) bool {
 	panic("unimplemented")
 }
@@ -201,6 +201,45 @@ func (c *MockExecutionEngineGetAssembledBlockCall) DoAndReturn(f func(context.Co
 	return c
 }
 
+// GetBlobs mocks base method.
+func (m *MockExecutionEngine) GetBlobs(ctx context.Context, versionedHashes []common.Hash) ([]*engine_types.BlobAndProofV1, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlobs", ctx, versionedHashes)
+	ret0, _ := ret[0].([]*engine_types.BlobAndProofV1)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlobs indicates an expected call of GetBlobs.
+func (mr *MockExecutionEngineMockRecorder) GetBlobs(ctx, versionedHashes any) *MockExecutionEngineGetBlobsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlobs", reflect.TypeOf((*MockExecutionEngine)(nil).GetBlobs), ctx, versionedHashes)
+	return &MockExecutionEngineGetBlobsCall{Call: call}
+}
+
+// MockExecutionEngineGetBlobsCall wrap *gomock.Call
+type MockExecutionEngineGetBlobsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockExecutionEngineGetBlobsCall) Return(arg0 []*engine_types.BlobAndProofV1, arg1 error) *MockExecutionEngineGetBlobsCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockExecutionEngineGetBlobsCall) Do(f func(context.Context, []common.Hash) ([]*engine_types.BlobAndProofV1, error)) *MockExecutionEngineGetBlobsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockExecutionEngineGetBlobsCall) DoAndReturn(f func(context.Context, []common.Hash) ([]*engine_types.BlobAndProofV1, error)) *MockExecutionEngineGetBlobsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // GetBodiesByHashes mocks base method.
 func (m *MockExecutionEngine) GetBodiesByHashes(ctx context.Context, hashes []common.Hash) ([]*types.RawBody, error) {
 	m.ctrl.T.Helper()
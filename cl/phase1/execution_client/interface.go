@@ -51,4 +51,6 @@ type ExecutionEngine interface {
 	HasGapInSnapshots(ctx context.Context) bool
 	// Block production
 	GetAssembledBlock(ctx context.Context, id []byte) (*cltypes.Eth1Block, *engine_types.BlobsBundleV1, *big.Int, error)
+	// Blobs
+	GetBlobs(ctx context.Context, versionedHashes []libcommon.Hash) ([]*engine_types.BlobAndProofV1, error)
 }
@@ -70,8 +70,14 @@ func fetchBlocksFromReqResp(ctx context.Context, cfg *Cfg, from uint64, count ui
 		return nil, nil
 	}
 
-	// Generate blob identifiers from the retrieved blocks
-	ids, err := network2.BlobsIdentifiersFromBlocks(blocks)
+	// Take whatever blobs the execution layer has first
+	_, missing, err := fetchBlobsFromExecutionEngine(ctx, log.Root(), cfg, blocks)
+	if err != nil {
+		return nil, err
+	}
+
+	// Generate blob identifiers from the blocks still missing blobs
+	ids, err := network2.BlobsIdentifiersFromBlocks(missing)
 	if err != nil {
 		return nil, err
 	}
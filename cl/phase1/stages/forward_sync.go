@@ -17,6 +17,7 @@ import (
 	"github.com/erigontech/erigon/cl/persistence/blob_storage"
 	"github.com/erigontech/erigon/cl/phase1/core/state"
 	network2 "github.com/erigontech/erigon/cl/phase1/network"
+	"github.com/erigontech/erigon/cl/utils"
 )
 
 // shouldProcessBlobs checks if any block in the given list of blocks
@@ -31,6 +32,85 @@ func shouldProcessBlobs(blocks []*cltypes.SignedBeaconBlock) bool {
 	return false
 }
 
+// fetchBlobsFromExecutionEngine is the fast path of blob retrieval: blob transactions of recent blocks are likely still
+// known to the execution layer's transaction pool. Sidecars of blocks whose blobs are all found there are assembled
+// locally and inserted into the blob store. It returns the highest slot whose blobs were inserted this way and the blocks
+// whose blobs still have to be requested from peers.
+func fetchBlobsFromExecutionEngine(ctx context.Context, logger log.Logger, cfg *Cfg, blocks []*cltypes.SignedBeaconBlock) (highestProcessed uint64, missing []*cltypes.SignedBeaconBlock, err error) {
+	if cfg.executionClient == nil {
+		return 0, blocks, nil
+	}
+	missing = make([]*cltypes.SignedBeaconBlock, 0, len(blocks))
+	for _, block := range blocks {
+		if block.Version() < clparams.DenebVersion || block.Block.Body.BlobKzgCommitments.Len() == 0 {
+			continue
+		}
+		sidecars, err := blobSidecarsFromExecutionEngine(ctx, cfg, block)
+		if err != nil {
+			// the execution layer is only a shortcut, peers are still there
+			logger.Debug("[Caplin] Failed to get blobs from execution engine", "slot", block.Block.Slot, "err", err)
+			missing = append(missing, block)
+			continue
+		}
+		if sidecars == nil {
+			missing = append(missing, block)
+			continue
+		}
+		ids, err := network2.BlobsIdentifiersFromBlocks([]*cltypes.SignedBeaconBlock{block})
+		if err != nil {
+			return 0, nil, err
+		}
+		_, inserted, err := blob_storage.VerifyAgainstIdentifiersAndInsertIntoTheBlobStore(ctx, cfg.blobStore, ids, sidecars, nil)
+		if err != nil || inserted != uint64(ids.Len()) {
+			logger.Debug("[Caplin] Execution engine blobs were not inserted", "slot", block.Block.Slot, "inserted", inserted, "err", err)
+			missing = append(missing, block)
+			continue
+		}
+		highestProcessed = max(highestProcessed, block.Block.Slot)
+	}
+	return highestProcessed, missing, nil
+}
+
+// blobSidecarsFromExecutionEngine assembles blob sidecars of the block out of the blobs held by the execution layer.
+// Returns nil if any of the blobs is unknown to the execution layer.
+func blobSidecarsFromExecutionEngine(ctx context.Context, cfg *Cfg, block *cltypes.SignedBeaconBlock) ([]*cltypes.BlobSidecar, error) {
+	commitments := block.Block.Body.BlobKzgCommitments
+	versionedHashes := make([]common.Hash, commitments.Len())
+	for i := range versionedHashes {
+		versionedHash, err := utils.KzgCommitmentToVersionedHash(common.Bytes48(*commitments.Get(i)))
+		if err != nil {
+			return nil, err
+		}
+		versionedHashes[i] = versionedHash
+	}
+	blobsAndProofs, err := cfg.executionClient.GetBlobs(ctx, versionedHashes)
+	if err != nil {
+		return nil, err
+	}
+	header := block.SignedBeaconBlockHeader()
+	sidecars := make([]*cltypes.BlobSidecar, len(blobsAndProofs))
+	for i, blobAndProof := range blobsAndProofs {
+		if blobAndProof == nil {
+			return nil, nil
+		}
+		var blob cltypes.Blob
+		if len(blobAndProof.Blob) != len(blob) || len(blobAndProof.Proof) != len(common.Bytes48{}) {
+			return nil, fmt.Errorf("malformed blob %d", i)
+		}
+		copy(blob[:], blobAndProof.Blob)
+		inclusionProofRaw, err := block.Block.Body.KzgCommitmentMerkleProof(i)
+		if err != nil {
+			return nil, err
+		}
+		inclusionProof := solid.NewHashVector(cltypes.CommitmentBranchSize)
+		for j, h := range inclusionProofRaw {
+			inclusionProof.Set(j, h)
+		}
+		sidecars[i] = cltypes.NewBlobSidecar(uint64(i), &blob, common.Bytes48(*commitments.Get(i)), common.Bytes48(blobAndProof.Proof), header, inclusionProof)
+	}
+	return sidecars, nil
+}
+
 // downloadAndProcessEip4844DA handles downloading and processing of EIP-4844 data availability blobs.
 // It takes highest slot processed, and a list of signed beacon blocks as input.
 // It returns the highest blob slot processed and an error if any.
@@ -40,8 +120,15 @@ func downloadAndProcessEip4844DA(ctx context.Context, logger log.Logger, cfg *Cf
 		blobs *network2.PeerAndSidecars
 	)
 
-	// Retrieve blob identifiers from the given blocks
-	ids, err = network2.BlobsIdentifiersFromBlocks(blocks)
+	// Take whatever blobs the execution layer has first
+	highestEngineProcessed, missing, err := fetchBlobsFromExecutionEngine(ctx, logger, cfg, blocks)
+	if err != nil {
+		err = fmt.Errorf("failed to get blobs from execution engine: %w", err)
+		return
+	}
+
+	// Retrieve blob identifiers of the blocks still missing blobs
+	ids, err = network2.BlobsIdentifiersFromBlocks(missing)
 	if err != nil {
 		// Return an error if blob identifiers could not be retrieved
 		err = fmt.Errorf("failed to get blob identifiers: %w", err)
@@ -50,7 +137,7 @@ func downloadAndProcessEip4844DA(ctx context.Context, logger log.Logger, cfg *Cf
 
 	// If there are no blobs to retrieve, return the highest slot processed
 	if ids.Len() == 0 {
-		return highestSlotProcessed, nil
+		return max(highestSlotProcessed, highestEngineProcessed), nil
 	}
 
 	// Request blobs from the network
@@ -74,7 +161,7 @@ func downloadAndProcessEip4844DA(ctx context.Context, logger log.Logger, cfg *Cf
 
 	// If all blobs were inserted successfully, return the highest processed slot
 	if inserted == uint64(ids.Len()) {
-		return highestProcessed, nil
+		return max(highestProcessed, highestEngineProcessed), nil
 	}
 
 	// If not all blobs were inserted, return the highest processed slot minus one
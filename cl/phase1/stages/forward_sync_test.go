@@ -0,0 +1,153 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package stages
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/crypto/kzg"
+	"github.com/erigontech/erigon-lib/kv/memdb"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/cl/clparams"
+	"github.com/erigontech/erigon/cl/cltypes"
+	"github.com/erigontech/erigon/cl/persistence/blob_storage"
+	"github.com/erigontech/erigon/cl/phase1/execution_client"
+	"github.com/erigontech/erigon/cl/utils"
+	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/turbo/engineapi/engine_types"
+)
+
+// blockWithBlobs makes a deneb block committing to the given number of blobs, the blobs and proofs are returned as the
+// execution layer would serve them, keyed by versioned hash
+func blockWithBlobs(t *testing.T, slot uint64, blobs int) (*cltypes.SignedBeaconBlock, map[common.Hash]*engine_types.BlobAndProofV1) {
+	block := cltypes.NewSignedBeaconBlock(&clparams.MainnetBeaconConfig)
+	block.Block.Body.SetVersion(clparams.DenebVersion)
+	block.Block.Body.SyncAggregate = &cltypes.SyncAggregate{}
+	var blobGas uint64
+	block.Block.Body.ExecutionPayload = cltypes.NewEth1BlockFromHeaderAndBody(&types.Header{Number: big.NewInt(int64(slot)), BaseFee: big.NewInt(1), BlobGasUsed: &blobGas, ExcessBlobGas: &blobGas}, &types.RawBody{}, &clparams.MainnetBeaconConfig)
+	block.Block.Slot = slot
+	known := make(map[common.Hash]*engine_types.BlobAndProofV1, blobs)
+	for i := 0; i < blobs; i++ {
+		var blob gokzg4844.Blob
+		blob[31], blob[63] = byte(slot), byte(i+1) // distinct, but still valid field elements
+		commitment, err := kzg.Ctx().BlobToKZGCommitment(blob, 1)
+		require.NoError(t, err)
+		proof, err := kzg.Ctx().ComputeBlobKZGProof(blob, commitment, 1)
+		require.NoError(t, err)
+		c := cltypes.KZGCommitment(commitment)
+		block.Block.Body.BlobKzgCommitments.Append(&c)
+		versionedHash, err := utils.KzgCommitmentToVersionedHash(common.Bytes48(commitment))
+		require.NoError(t, err)
+		known[versionedHash] = &engine_types.BlobAndProofV1{Blob: blob[:], Proof: proof[:]}
+	}
+	return block, known
+}
+
+func TestFetchBlobsFromExecutionEngine(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	engine := execution_client.NewMockExecutionEngine(ctrl)
+	cfg := &Cfg{
+		executionClient: engine,
+		blobStore:       blob_storage.NewBlobStore(memdb.NewTestDB(t), afero.NewMemMapFs(), 1_000_000, &clparams.MainnetBeaconConfig, nil),
+	}
+
+	// the execution layer knows all blobs of the first block, only one of the blobs of the second and none of the third
+	served, servedBlobs := blockWithBlobs(t, 10, 2)
+	partial, partialBlobs := blockWithBlobs(t, 11, 2)
+	unknown, _ := blockWithBlobs(t, 12, 1)
+	noBlobs, _ := blockWithBlobs(t, 13, 0)
+	for h := range partialBlobs {
+		servedBlobs[h] = partialBlobs[h]
+		break
+	}
+	engine.EXPECT().GetBlobs(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, versionedHashes []common.Hash) ([]*engine_types.BlobAndProofV1, error) {
+		res := make([]*engine_types.BlobAndProofV1, len(versionedHashes))
+		for i, h := range versionedHashes {
+			res[i] = servedBlobs[h]
+		}
+		return res, nil
+	}).Times(3)
+
+	highest, missing, err := fetchBlobsFromExecutionEngine(ctx, log.New(), cfg, []*cltypes.SignedBeaconBlock{served, partial, unknown, noBlobs})
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), highest)
+	require.Equal(t, []*cltypes.SignedBeaconBlock{partial, unknown}, missing)
+
+	blockRoot, err := served.Block.HashSSZ()
+	require.NoError(t, err)
+	sidecars, found, err := cfg.blobStore.ReadBlobSidecars(ctx, 10, blockRoot)
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Len(t, sidecars, 2)
+	for i, sidecar := range sidecars {
+		require.Equal(t, uint64(i), sidecar.Index)
+		require.Equal(t, common.Bytes48(*served.Block.Body.BlobKzgCommitments.Get(i)), sidecar.KzgCommitment)
+	}
+	blockRoot, err = partial.Block.HashSSZ()
+	require.NoError(t, err)
+	_, found, err = cfg.blobStore.ReadBlobSidecars(ctx, 11, blockRoot)
+	require.NoError(t, err)
+	require.False(t, found)
+
+	// without an execution client every block goes to the peers
+	highest, missing, err = fetchBlobsFromExecutionEngine(ctx, log.New(), &Cfg{}, []*cltypes.SignedBeaconBlock{served})
+	require.NoError(t, err)
+	require.Zero(t, highest)
+	require.Equal(t, []*cltypes.SignedBeaconBlock{served}, missing)
+}
+
+func TestDownloadAndProcessEip4844DAFromExecutionEngine(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	engine := execution_client.NewMockExecutionEngine(ctrl)
+	cfg := &Cfg{
+		executionClient: engine,
+		blobStore:       blob_storage.NewBlobStore(memdb.NewTestDB(t), afero.NewMemMapFs(), 1_000_000, &clparams.MainnetBeaconConfig, nil),
+	}
+	first, known := blockWithBlobs(t, 20, 1)
+	second, secondBlobs := blockWithBlobs(t, 21, 3)
+	last, _ := blockWithBlobs(t, 22, 0)
+	for h, blob := range secondBlobs {
+		known[h] = blob
+	}
+	engine.EXPECT().GetBlobs(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, versionedHashes []common.Hash) ([]*engine_types.BlobAndProofV1, error) {
+		res := make([]*engine_types.BlobAndProofV1, len(versionedHashes))
+		for i, h := range versionedHashes {
+			res[i] = known[h]
+		}
+		return res, nil
+	}).Times(2)
+
+	// all blobs come from the execution layer, no peers are asked: the highest slot with blobs is processed
+	highest, err := downloadAndProcessEip4844DA(ctx, log.New(), cfg, 19, []*cltypes.SignedBeaconBlock{first, second, last})
+	require.NoError(t, err)
+	require.Equal(t, uint64(21), highest)
+
+	// nothing to retrieve: no progress is reported
+	highest, err = downloadAndProcessEip4844DA(ctx, log.New(), cfg, 21, []*cltypes.SignedBeaconBlock{last})
+	require.NoError(t, err)
+	require.Equal(t, uint64(21), highest)
+}
@@ -18,7 +18,6 @@ package caplin1
 
 import (
 	"github.com/erigontech/erigon/cl/beacon/builder"
-	"github.com/erigontech/erigon/cl/clparams"
 )
 
 type option struct {
@@ -27,8 +26,8 @@ type option struct {
 
 type CaplinOption func(*option)
 
-func WithBuilder(mevRelayUrl string, beaconConfig *clparams.BeaconChainConfig) CaplinOption {
+func WithBuilder(builderClient builder.BuilderClient) CaplinOption {
 	return func(o *option) {
-		o.builderClient = builder.NewBlockBuilderClient(mevRelayUrl, beaconConfig)
+		o.builderClient = builderClient
 	}
 }
@@ -36,6 +36,7 @@ import (
 	"github.com/erigontech/erigon/cl/antiquary"
 	"github.com/erigontech/erigon/cl/beacon"
 	"github.com/erigontech/erigon/cl/beacon/beaconevents"
+	"github.com/erigontech/erigon/cl/beacon/builder"
 	"github.com/erigontech/erigon/cl/beacon/handler"
 	"github.com/erigontech/erigon/cl/beacon/synced_data"
 	"github.com/erigontech/erigon/cl/clparams/initial_state"
@@ -217,11 +218,14 @@ func RunCaplinService(ctx context.Context, engine execution_client.ExecutionEngi
 
 	caplinOptions := []CaplinOption{}
 	if config.BeaconAPIRouter.Builder {
-		if config.RelayUrlExist() {
-			caplinOptions = append(caplinOptions, WithBuilder(config.MevRelayUrl, beaconConfig))
-		} else {
+		if !config.RelayUrlExist() {
 			log.Warn("builder api enable but relay url not set. Skipping builder mode")
 			config.BeaconAPIRouter.Builder = false
+		} else if builderClient, err := builder.NewMultiRelayClient(config.MevRelayUrls(), config.MevRelayTimeout, beaconConfig); err != nil {
+			log.Warn("cannot start builder client. Skipping builder mode", "err", err)
+			config.BeaconAPIRouter.Builder = false
+		} else {
+			caplinOptions = append(caplinOptions, WithBuilder(builderClient))
 		}
 	}
 	log.Info("Starting caplin")
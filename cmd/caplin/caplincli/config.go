@@ -49,6 +49,8 @@ type CaplinCliCfg struct {
 	EngineAPIAddr         string        `json:"engine_api_addr"`
 	EngineAPIPort         int           `json:"engine_api_port"`
 	MevRelayUrl           string        `json:"mev_relay_url"`
+	MevRelayTimeout       time.Duration `json:"mev_relay_timeout"`
+	MevBuilderBoostFactor uint64        `json:"mev_builder_boost_factor"`
 	CustomConfig          string        `json:"custom_config"`
 	CustomGenesisState    string        `json:"custom_genesis_state"`
 	JwtSecret             []byte
@@ -102,6 +104,8 @@ func SetupCaplinCli(ctx *cli.Context) (cfg *CaplinCliCfg, err error) {
 	cfg.Chaindata = ctx.String(caplinflags.ChaindataFlag.Name)
 
 	cfg.MevRelayUrl = ctx.String(caplinflags.MevRelayUrl.Name)
+	cfg.MevRelayTimeout = ctx.Duration(utils.CaplinMevRelayTimeoutFlag.Name)
+	cfg.MevBuilderBoostFactor = ctx.Uint64(utils.CaplinMevBuilderBoostFactorFlag.Name)
 
 	// Custom Chain
 	cfg.CustomConfig = ctx.String(caplinflags.CustomConfig.Name)
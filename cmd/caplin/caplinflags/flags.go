@@ -41,6 +41,8 @@ var CliFlags = []cli.Flag{
 	&utils.BeaconApiAllowMethodsFlag,
 	&utils.BeaconApiAllowOriginsFlag,
 	&utils.CaplinCheckpointSyncUrlFlag,
+	&utils.CaplinMevRelayTimeoutFlag,
+	&utils.CaplinMevBuilderBoostFactorFlag,
 }
 
 var (
@@ -103,7 +105,7 @@ var (
 	}
 	MevRelayUrl = cli.StringFlag{
 		Name:  "mev-relay-url",
-		Usage: "Http URL of the MEV relay",
+		Usage: "Http URL of the MEV relay, or comma separated list of URLs",
 		Value: "",
 	}
 	CustomConfig = cli.StringFlag{
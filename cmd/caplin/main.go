@@ -70,6 +70,8 @@ func runCaplinNode(cliCtx *cli.Context) error {
 		AllowedOrigins:   cfg.AllowedOrigins,
 		AllowedMethods:   cfg.AllowedMethods,
 		AllowCredentials: cfg.AllowCredentials,
+
+		BuilderBoostFactor: cfg.MevBuilderBoostFactor,
 	}
 	if err := rcfg.UnwrapEndpointsList(cfg.AllowedEndpoints); err != nil {
 		return err
@@ -115,6 +117,7 @@ func runCaplinNode(cliCtx *cli.Context) error {
 		BeaconAPIRouter:        rcfg,
 		NetworkId:              networkId,
 		MevRelayUrl:            cfg.MevRelayUrl,
+		MevRelayTimeout:        cfg.MevRelayTimeout,
 		CustomConfigPath:       cfg.CustomConfig,
 		CustomGenesisStatePath: cfg.CustomGenesisState,
 	}, cfg.Dirs, nil, nil, nil, blockSnapBuildSema)
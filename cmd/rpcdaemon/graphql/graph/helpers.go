@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+	"strings"
 
 	hexutil2 "github.com/erigontech/erigon-lib/common/hexutil"
 
@@ -13,6 +14,7 @@ import (
 	libcommon "github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/hexutility"
 
+	"github.com/erigontech/erigon/cmd/rpcdaemon/graphql/graph/model"
 	"github.com/erigontech/erigon/core/types"
 )
 
@@ -115,6 +117,13 @@ func convertDataToUint64P(abstractMap map[string]interface{}, field string) *uin
 		result = abstractMap[field].(uint64)
 	case uint64:
 		result = abstractMap[field].(uint64)
+	case string:
+		resultUint, err := hexutil2.DecodeUint64(v)
+		if err != nil {
+			result = 0
+		} else {
+			result = resultUint
+		}
 	default:
 		fmt.Println("unhandled/uint64", reflect.TypeOf(abstractMap[field]), field, abstractMap[field])
 		result = 0
@@ -135,3 +144,84 @@ func convertStrHexToDec(hexString *string) *string {
 
 	return &result
 }
+
+// convertDataToTransaction builds a transaction from the flattened transaction/receipt map
+// returned by GraphQLAPI. Receipt fields are only filled in when present.
+func convertDataToTransaction(abstractMap map[string]interface{}) *model.Transaction {
+	trans := &model.Transaction{}
+	trans.Hash = *convertDataToStringP(abstractMap, "transactionHash")
+	trans.Nonce = *convertDataToStringP(abstractMap, "nonce")
+	trans.InputData = *convertDataToStringP(abstractMap, "data")
+	trans.Type = convertDataToIntP(abstractMap, "type")
+
+	if value := convertDataToStringP(abstractMap, "value"); value != nil {
+		trans.Value = *value
+	}
+	if _, ok := abstractMap["gas"]; ok {
+		trans.Gas = *convertDataToUint64P(abstractMap, "gas")
+	}
+	if gasPrice := convertDataToStringP(abstractMap, "gasPrice"); gasPrice != nil {
+		trans.GasPrice = *gasPrice
+	}
+	if _, ok := abstractMap["maxFeePerGas"]; ok {
+		trans.MaxFeePerGas = convertDataToStringP(abstractMap, "maxFeePerGas")
+		trans.MaxPriorityFeePerGas = convertDataToStringP(abstractMap, "maxPriorityFeePerGas")
+	}
+	for field, target := range map[string]*string{"r": &trans.R, "s": &trans.S, "v": &trans.V} {
+		if _, ok := abstractMap[field]; !ok {
+			continue
+		}
+		if sig := convertDataToStringP(abstractMap, field); sig != nil {
+			*target = *sig
+		}
+	}
+	if _, ok := abstractMap["transactionIndex"]; ok {
+		trans.Index = convertDataToIntP(abstractMap, "transactionIndex")
+	}
+
+	trans.From = &model.Account{Address: strings.ToLower(*convertDataToStringP(abstractMap, "from"))}
+	// To address could be nil in case of contract creation
+	if address := convertDataToStringP(abstractMap, "to"); address != nil {
+		trans.To = &model.Account{Address: strings.ToLower(*address)}
+	}
+
+	if _, ok := abstractMap["blockHash"]; ok {
+		trans.Block = &model.Block{
+			Hash:   *convertDataToStringP(abstractMap, "blockHash"),
+			Number: *convertDataToUint64P(abstractMap, "blockNumber"),
+		}
+	}
+
+	// Receipt
+	if _, ok := abstractMap["status"]; ok {
+		trans.Status = convertDataToUint64P(abstractMap, "status")
+		trans.GasUsed = convertDataToUint64P(abstractMap, "gasUsed")
+		trans.CumulativeGasUsed = convertDataToUint64P(abstractMap, "cumulativeGasUsed")
+		trans.EffectiveGasPrice = convertDataToStringP(abstractMap, "effectiveGasPrice")
+		if address, ok := abstractMap["contractAddress"].(libcommon.Address); ok {
+			trans.CreatedContract = &model.Account{Address: strings.ToLower(address.String())}
+		}
+		trans.Logs = make([]*model.Log, 0)
+		if logs, ok := abstractMap["logs"].(types.Logs); ok {
+			for _, rlog := range logs {
+				trans.Logs = append(trans.Logs, convertLog(rlog))
+			}
+		}
+	}
+
+	return trans
+}
+
+func convertLog(rlog *types.Log) *model.Log {
+	tlog := &model.Log{
+		Index:       int(rlog.Index),
+		Account:     &model.Account{Address: strings.ToLower(rlog.Address.String())},
+		Topics:      make([]string, 0, len(rlog.Topics)),
+		Data:        "0x" + hex.EncodeToString(rlog.Data),
+		Transaction: &model.Transaction{Hash: rlog.TxHash.String()},
+	}
+	for _, rtopic := range rlog.Topics {
+		tlog.Topics = append(tlog.Topics, rtopic.String())
+	}
+	return tlog
+}
@@ -6,21 +6,33 @@ package graph
 
 import (
 	"context"
-	"encoding/hex"
 	"fmt"
+	"math/big"
 	"strconv"
 	"strings"
 
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/common/length"
 	"github.com/erigontech/erigon/cmd/rpcdaemon/graphql/graph/model"
 	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/eth/filters"
 	"github.com/erigontech/erigon/rpc"
 )
 
 // SendRawTransaction is the resolver for the sendRawTransaction field.
 func (r *mutationResolver) SendRawTransaction(ctx context.Context, data string) (string, error) {
-	panic("not implemented: SendRawTransaction - sendRawTransaction")
+	encodedTx, err := hexutil.Decode(data)
+	if err != nil {
+		return "", err
+	}
+
+	hash, err := r.GraphQLAPI.SendRawTransaction(ctx, encodedTx)
+	if err != nil {
+		return "", err
+	}
+
+	return hash.String(), nil
 }
 
 // Block is the resolver for the block field.
@@ -137,18 +149,7 @@ func (r *queryResolver) Block(ctx context.Context, number *string, hash *string)
 
 			trans.Logs = make([]*model.Log, 0)
 			for _, rlog := range transReceipt["logs"].(types.Logs) {
-				tlog := model.Log{
-					Index: int(rlog.Index),
-					Data:  "0x" + hex.EncodeToString(rlog.Data),
-				}
-				tlog.Account = &model.Account{}
-				tlog.Account.Address = strings.ToLower(rlog.Address.String())
-
-				for _, rtopic := range rlog.Topics {
-					tlog.Topics = append(tlog.Topics, rtopic.String())
-				}
-
-				trans.Logs = append(trans.Logs, &tlog)
+				trans.Logs = append(trans.Logs, convertLog(rlog))
 			}
 
 			trans.From = &model.Account{}
@@ -207,32 +208,111 @@ func (r *queryResolver) Blocks(ctx context.Context, from *uint64, to *uint64) ([
 
 // Pending is the resolver for the pending field.
 func (r *queryResolver) Pending(ctx context.Context) (*model.Pending, error) {
-	panic("not implemented: Pending - pending")
+	res, err := r.GraphQLAPI.GetPendingTransactions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := &model.Pending{}
+	pending.TransactionCount = len(res)
+	pending.Transactions = make([]*model.Transaction, 0, len(res))
+	for _, trans := range res {
+		pending.Transactions = append(pending.Transactions, convertDataToTransaction(trans))
+	}
+
+	return pending, ctx.Err()
 }
 
 // Transaction is the resolver for the transaction field.
 func (r *queryResolver) Transaction(ctx context.Context, hash string) (*model.Transaction, error) {
-	panic("not implemented: Transaction - transaction")
+	hashBytes, err := hexutil.Decode(hash)
+	if err != nil || len(hashBytes) != length.Hash {
+		return nil, fmt.Errorf("invalid transaction hash: %s", hash)
+	}
+
+	res, err := r.GraphQLAPI.GetTransactionDetails(ctx, common.BytesToHash(hashBytes))
+	if err != nil {
+		return nil, err
+	}
+	if res == nil {
+		return nil, nil
+	}
+
+	return convertDataToTransaction(res), ctx.Err()
 }
 
 // Logs is the resolver for the logs field.
 func (r *queryResolver) Logs(ctx context.Context, filter model.FilterCriteria) ([]*model.Log, error) {
-	panic("not implemented: Logs - logs")
+	crit := filters.FilterCriteria{}
+	if filter.FromBlock != nil {
+		crit.FromBlock = new(big.Int).SetUint64(*filter.FromBlock)
+	}
+	if filter.ToBlock != nil {
+		crit.ToBlock = new(big.Int).SetUint64(*filter.ToBlock)
+	}
+	for _, address := range filter.Addresses {
+		crit.Addresses = append(crit.Addresses, common.HexToAddress(address))
+	}
+	for _, topics := range filter.Topics {
+		hashes := make([]common.Hash, 0, len(topics))
+		for _, topic := range topics {
+			hashes = append(hashes, common.HexToHash(topic))
+		}
+		crit.Topics = append(crit.Topics, hashes)
+	}
+
+	res, err := r.GraphQLAPI.GetLogs(ctx, crit)
+	if err != nil {
+		return nil, err
+	}
+
+	logs := make([]*model.Log, 0, len(res))
+	for _, rlog := range res {
+		logs = append(logs, convertLog(rlog))
+	}
+
+	return logs, ctx.Err()
 }
 
 // GasPrice is the resolver for the gasPrice field.
 func (r *queryResolver) GasPrice(ctx context.Context) (string, error) {
-	panic("not implemented: GasPrice - gasPrice")
+	gasPrice, err := r.GraphQLAPI.GasPrice(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return gasPrice.String(), nil
 }
 
 // MaxPriorityFeePerGas is the resolver for the maxPriorityFeePerGas field.
 func (r *queryResolver) MaxPriorityFeePerGas(ctx context.Context) (string, error) {
-	panic("not implemented: MaxPriorityFeePerGas - maxPriorityFeePerGas")
+	tipCap, err := r.GraphQLAPI.MaxPriorityFeePerGas(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return tipCap.String(), nil
 }
 
 // Syncing is the resolver for the syncing field.
 func (r *queryResolver) Syncing(ctx context.Context) (*model.SyncState, error) {
-	panic("not implemented: Syncing - syncing")
+	res, err := r.GraphQLAPI.Syncing(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Syncing returns false when the node is in sync
+	progress, ok := res.(map[string]interface{})
+	if !ok {
+		return nil, nil
+	}
+
+	syncState := &model.SyncState{}
+	syncState.StartingBlock = *convertDataToUint64P(progress, "startingBlock")
+	syncState.CurrentBlock = *convertDataToUint64P(progress, "currentBlock")
+	syncState.HighestBlock = *convertDataToUint64P(progress, "highestBlock")
+
+	return syncState, nil
 }
 
 // ChainID is the resolver for the chainID field.
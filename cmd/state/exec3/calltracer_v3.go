@@ -20,31 +20,54 @@ import (
 	"github.com/holiman/uint256"
 
 	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon/core/tracing"
 	"github.com/erigontech/erigon/core/vm"
 )
 
 type CallTracer struct {
 	froms map[libcommon.Address]struct{}
 	tos   map[libcommon.Address]struct{}
+
+	live vm.EVMLogger // optional, forwards all events to a live tracer
 }
 
 func NewCallTracer() *CallTracer {
 	return &CallTracer{}
 }
+
+// SetHooks makes the tracer forward every captured event to the given live tracing hooks.
+func (ct *CallTracer) SetHooks(hooks *tracing.Hooks) {
+	if hooks == nil {
+		ct.live = nil
+		return
+	}
+	ct.live = vm.NewHooksLogger(hooks)
+}
 func (ct *CallTracer) Reset() {
 	ct.froms, ct.tos = nil, nil
 }
 func (ct *CallTracer) Froms() map[libcommon.Address]struct{} { return ct.froms }
 func (ct *CallTracer) Tos() map[libcommon.Address]struct{}   { return ct.tos }
 
-func (ct *CallTracer) CaptureTxStart(gasLimit uint64) {}
-func (ct *CallTracer) CaptureTxEnd(restGas uint64)    {}
+func (ct *CallTracer) CaptureTxStart(gasLimit uint64) {
+	if ct.live != nil {
+		ct.live.CaptureTxStart(gasLimit)
+	}
+}
+func (ct *CallTracer) CaptureTxEnd(restGas uint64) {
+	if ct.live != nil {
+		ct.live.CaptureTxEnd(restGas)
+	}
+}
 func (ct *CallTracer) CaptureStart(env *vm.EVM, from libcommon.Address, to libcommon.Address, precompile bool, create bool, input []byte, gas uint64, value *uint256.Int, code []byte) {
 	if ct.froms == nil {
 		ct.froms = map[libcommon.Address]struct{}{}
 		ct.tos = map[libcommon.Address]struct{}{}
 	}
 	ct.froms[from], ct.tos[to] = struct{}{}, struct{}{}
+	if ct.live != nil {
+		ct.live.CaptureStart(env, from, to, precompile, create, input, gas, value, code)
+	}
 }
 func (ct *CallTracer) CaptureEnter(typ vm.OpCode, from libcommon.Address, to libcommon.Address, precompile bool, create bool, input []byte, gas uint64, value *uint256.Int, code []byte) {
 	if ct.froms == nil {
@@ -52,12 +75,32 @@ func (ct *CallTracer) CaptureEnter(typ vm.OpCode, from libcommon.Address, to lib
 		ct.tos = map[libcommon.Address]struct{}{}
 	}
 	ct.froms[from], ct.tos[to] = struct{}{}, struct{}{}
+	if ct.live != nil {
+		ct.live.CaptureEnter(typ, from, to, precompile, create, input, gas, value, code)
+	}
 }
 func (ct *CallTracer) CaptureState(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, rData []byte, depth int, err error) {
+	if ct.live != nil {
+		ct.live.CaptureState(pc, op, gas, cost, scope, rData, depth, err)
+	}
 }
 func (ct *CallTracer) CaptureFault(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, depth int, err error) {
+	if ct.live != nil {
+		ct.live.CaptureFault(pc, op, gas, cost, scope, depth, err)
+	}
+}
+func (ct *CallTracer) CaptureGasChange(old, new uint64, reason tracing.GasChangeReason) {
+	if ct.live != nil {
+		vm.CaptureGasChange(ct.live, old, new, reason)
+	}
 }
 func (ct *CallTracer) CaptureEnd(output []byte, usedGas uint64, err error) {
+	if ct.live != nil {
+		ct.live.CaptureEnd(output, usedGas, err)
+	}
 }
 func (ct *CallTracer) CaptureExit(output []byte, usedGas uint64, err error) {
+	if ct.live != nil {
+		ct.live.CaptureExit(output, usedGas, err)
+	}
 }
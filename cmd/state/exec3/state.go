@@ -34,6 +34,7 @@ import (
 	"github.com/erigontech/erigon/consensus"
 	"github.com/erigontech/erigon/core"
 	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/core/tracing"
 	"github.com/erigontech/erigon/core/types"
 	"github.com/erigontech/erigon/core/vm"
 	"github.com/erigontech/erigon/core/vm/evmtypes"
@@ -63,6 +64,7 @@ type Worker struct {
 
 	callTracer  *CallTracer
 	taskGasPool *core.GasPool
+	tracer      *tracing.Hooks // optional live tracer, see SetTracer
 
 	evm   *vm.EVM
 	ibs   *state.IntraBlockState
@@ -104,6 +106,15 @@ func NewWorker(lock sync.Locker, logger log.Logger, ctx context.Context, backgro
 
 func (rw *Worker) LogLRUStats() { rw.evm.JumpDestCache.LogStats() }
 
+// SetTracer attaches a live tracer to the worker: it receives the transaction, call frame,
+// state change and system call hooks of every task executed by this worker. Block level hooks
+// are the responsibility of the caller, which knows when a block is started, validated and done.
+func (rw *Worker) SetTracer(tracer *tracing.Hooks) {
+	rw.tracer = tracer
+	rw.callTracer.SetHooks(tracer)
+	rw.ibs.SetHooks(tracer)
+}
+
 func (rw *Worker) ResetState(rs *state.StateV3, accumulator *shards.Accumulator) {
 	rw.rs = rs
 	if rw.background {
@@ -151,6 +162,7 @@ func (rw *Worker) SetReader(reader state.ResettableStateReader) {
 	rw.stateReader.SetTx(rw.Tx())
 	rw.ibs.Reset()
 	rw.ibs = state.New(rw.stateReader)
+	rw.ibs.SetHooks(rw.tracer)
 
 	switch reader.(type) {
 	case *state.HistoryReaderV3:
@@ -219,7 +231,7 @@ func (rw *Worker) RunTxTaskNoLock(txTask *state.TxTask, isMining bool) {
 		syscall := func(contract libcommon.Address, data []byte, ibs *state.IntraBlockState, header *types.Header, constCall bool) ([]byte, error) {
 			return core.SysCallContract(contract, data, rw.chainConfig, ibs, header, rw.engine, constCall /* constCall */)
 		}
-		rw.engine.Initialize(rw.chainConfig, rw.chain, header, ibs, syscall, rw.logger, nil)
+		rw.engine.Initialize(rw.chainConfig, rw.chain, header, ibs, syscall, rw.logger, rw.tracer)
 		txTask.Error = ibs.FinalizeTx(rules, noop)
 	case txTask.Final:
 		if txTask.BlockNum == 0 {
@@ -265,11 +277,17 @@ func (rw *Worker) RunTxTaskNoLock(txTask *state.TxTask, isMining bool) {
 		}
 
 		rw.evm.ResetBetweenBlocks(txTask.EvmBlockContext, core.NewEVMTxContext(msg), ibs, rw.vmCfg, rules)
+		if rw.tracer != nil && rw.tracer.OnTxStart != nil {
+			rw.tracer.OnTxStart(rw.evm.GetVMContext(), txTask.Tx, msg.From())
+		}
 
 		// MA applytx
 		applyRes, err := core.ApplyMessage(rw.evm, msg, rw.taskGasPool, true /* refunds */, false /* gasBailout */)
 		if err != nil {
 			txTask.Error = err
+			if rw.tracer != nil && rw.tracer.OnTxEnd != nil {
+				rw.tracer.OnTxEnd(nil, err)
+			}
 		} else {
 			txTask.Failed = applyRes.Failed()
 			txTask.UsedGas = applyRes.UsedGas
@@ -279,6 +297,10 @@ func (rw *Worker) RunTxTaskNoLock(txTask *state.TxTask, isMining bool) {
 			txTask.Logs = ibs.GetLogs(txTask.TxIndex, txTask.Tx.Hash(), txTask.BlockNum, txTask.BlockHash)
 			txTask.TraceFroms = rw.callTracer.Froms()
 			txTask.TraceTos = rw.callTracer.Tos()
+			if rw.tracer != nil && rw.tracer.OnTxEnd != nil {
+				txTask.CreateReceipt(rw.Tx())
+				rw.tracer.OnTxEnd(txTask.BlockReceipts[txTask.TxIndex], nil)
+			}
 		}
 
 	}
@@ -25,6 +25,7 @@ import (
 	"github.com/erigontech/erigon/consensus"
 	"github.com/erigontech/erigon/core"
 	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/core/tracing"
 	"github.com/erigontech/erigon/core/types"
 	"github.com/erigontech/erigon/core/vm"
 	"github.com/erigontech/erigon/core/vm/evmtypes"
@@ -98,6 +99,9 @@ func (e *TraceWorker) ChangeBlock(header *types.Header) {
 	e.vmConfig.SkipAnalysis = core.SkipAnalysis(e.chainConfig, e.blockNum)
 }
 
+// SetStateHooks sets the hooks which are told about the state changes of the executed txns
+func (e *TraceWorker) SetStateHooks(hooks *tracing.Hooks) { e.ibs.SetHooks(hooks) }
+
 func (e *TraceWorker) GetRawLogs(txIdx int) types.Logs { return e.ibs.GetRawLogs(txIdx) }
 func (e *TraceWorker) GetLogs(txIndex int, txnHash common.Hash, blockNumber uint64, blockHash common.Hash) []*types.Log {
 	return e.ibs.GetLogs(txIndex, txnHash, blockNumber, blockHash)
@@ -0,0 +1,188 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/gointerfaces/grpcutil"
+	"github.com/erigontech/erigon-lib/gointerfaces/txpoolproto"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/mdbx"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/txpool"
+
+	"github.com/erigontech/erigon/cmd/utils"
+	"github.com/erigontech/erigon/common/paths"
+	"github.com/erigontech/erigon/turbo/debug"
+)
+
+const (
+	importBatchTxs   = 64
+	importBatchBytes = 2 * 1024 * 1024 // blob txs are big, keep requests well under the grpc message limit
+)
+
+var (
+	journalFile  string
+	exportLocals bool
+)
+
+func init() {
+	exportCmd.Flags().StringVar(&datadirCli, utils.DataDirFlag.Name, paths.DefaultDataDir(), utils.DataDirFlag.Usage)
+	exportCmd.Flags().StringVar(&journalFile, "file", "transactions.rlp", "file to write the transactions to")
+	exportCmd.Flags().BoolVar(&exportLocals, "locals", false, "export only the local transactions")
+	rootCmd.AddCommand(exportCmd)
+
+	importCmd.Flags().StringVar(&txpoolApiAddr, "txpool.api.addr", "localhost:9094", "txpool service <host>:<port>")
+	importCmd.Flags().StringVar(&journalFile, "file", "transactions.rlp", "file to read the transactions from")
+	rootCmd.AddCommand(importCmd)
+}
+
+var exportCmd = &cobra.Command{
+	Use:     "export",
+	Short:   "Write the transactions committed to the pool db into a file, in the format of the local transaction journal",
+	Example: "go run ./cmd/txpool export --datadir=<datadir> --file=transactions.rlp",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := debug.SetupCobra(cmd, "txpool")
+		return exportTxs(cmd.Context(), logger)
+	},
+}
+
+var importCmd = &cobra.Command{
+	Use:     "import",
+	Short:   "Add the transactions of an exported file or of a local transaction journal to a running pool",
+	Example: "go run ./cmd/txpool import --txpool.api.addr=localhost:9094 --file=transactions.rlp",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := debug.SetupCobra(cmd, "txpool")
+		return importTxs(cmd.Context(), logger)
+	},
+}
+
+func exportTxs(ctx context.Context, logger log.Logger) error {
+	db, err := mdbx.NewMDBX(logger).Label(kv.TxPoolDB).Path(datadir.New(datadirCli).TxPool).
+		WithTableCfg(func(defaultBuckets kv.TableCfg) kv.TableCfg { return kv.TxpoolTablesCfg }).
+		Readonly().Open(ctx)
+	if err != nil {
+		return fmt.Errorf("opening txpool db: %w", err)
+	}
+	defer db.Close()
+
+	file, err := os.Create(journalFile)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	w := bufio.NewWriter(file)
+
+	count := 0
+	if err = db.View(ctx, func(tx kv.Tx) error {
+		var locals map[string]struct{}
+		if exportLocals {
+			locals = map[string]struct{}{}
+			if err := tx.ForEach(kv.RecentLocalTransaction, nil, func(_, hash []byte) error {
+				locals[string(hash)] = struct{}{}
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		return tx.ForEach(kv.PoolTransaction, nil, func(hash, v []byte) error {
+			if locals != nil {
+				if _, ok := locals[string(hash)]; !ok {
+					return nil
+				}
+			}
+			count++
+			return txpool.WriteJournal(w, txpool.JournalEntry{Sender: common.BytesToAddress(v[:20]), Rlp: v[20:]})
+		})
+	}); err != nil {
+		return err
+	}
+	if err = w.Flush(); err != nil {
+		return err
+	}
+	logger.Info("Exported transactions", "count", count, "file", journalFile)
+	return file.Close()
+}
+
+func importTxs(ctx context.Context, logger log.Logger) error {
+	creds, err := grpcutil.TLS(TLSCACert, TLSCertfile, TLSKeyFile)
+	if err != nil {
+		return fmt.Errorf("could not connect to txpool: %w", err)
+	}
+	conn, err := grpcutil.Connect(creds, txpoolApiAddr)
+	if err != nil {
+		return fmt.Errorf("could not connect to txpool: %w", err)
+	}
+	defer conn.Close()
+	client := txpoolproto.NewTxpoolClient(conn)
+
+	file, err := os.Open(journalFile)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var batch [][]byte
+	var batchBytes int
+	results := make(map[txpoolproto.ImportResult]int)
+	send := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		reply, err := client.Add(ctx, &txpoolproto.AddRequest{RlpTxs: batch})
+		if err != nil {
+			return err
+		}
+		for i, result := range reply.Imported {
+			results[result]++
+			if result != txpoolproto.ImportResult_SUCCESS && result != txpoolproto.ImportResult_ALREADY_EXISTS {
+				logger.Debug("Transaction not imported", "result", result, "err", reply.Errors[i])
+			}
+		}
+		batch, batchBytes = batch[:0], 0
+		return nil
+	}
+	if err = txpool.ReadJournal(bufio.NewReader(file), func(entry txpool.JournalEntry) error {
+		batch = append(batch, entry.Rlp)
+		batchBytes += len(entry.Rlp)
+		if len(batch) < importBatchTxs && batchBytes < importBatchBytes {
+			return nil
+		}
+		return send()
+	}); err != nil {
+		return err
+	}
+	if err = send(); err != nil {
+		return err
+	}
+
+	logArgs := []interface{}{"file", journalFile}
+	for result := txpoolproto.ImportResult_SUCCESS; result <= txpoolproto.ImportResult_INTERNAL_ERROR; result++ {
+		logArgs = append(logArgs, strings.ToLower(result.String()), results[result])
+	}
+	logger.Info("Imported transactions", logArgs...)
+	return nil
+}
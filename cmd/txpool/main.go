@@ -71,6 +71,7 @@ var (
 	accountSlots       uint64
 	blobSlots          uint64
 	totalBlobPoolLimit uint64
+	delegatedSlots     uint64
 	priceBump          uint64
 	blobPriceBump      uint64
 
@@ -79,6 +80,10 @@ var (
 	mdbxWriteMap bool
 
 	commitEvery time.Duration
+	journal     string
+	rejournal   time.Duration
+	allowList   string
+	denyList    string
 )
 
 func init() {
@@ -101,9 +106,14 @@ func init() {
 	rootCmd.PersistentFlags().Uint64Var(&accountSlots, "txpool.accountslots", txpoolcfg.DefaultConfig.AccountSlots, "Minimum number of executable transaction slots guaranteed per account")
 	rootCmd.PersistentFlags().Uint64Var(&blobSlots, "txpool.blobslots", txpoolcfg.DefaultConfig.BlobSlots, "Max allowed total number of blobs (within type-3 txs) per account")
 	rootCmd.PersistentFlags().Uint64Var(&totalBlobPoolLimit, "txpool.totalblobpoollimit", txpoolcfg.DefaultConfig.TotalBlobPoolLimit, "Total limit of number of all blobs in txs within the txpool")
+	rootCmd.PersistentFlags().Uint64Var(&delegatedSlots, "txpool.delegatedslots", txpoolcfg.DefaultConfig.DelegatedSlots, "Max allowed number of in-flight transactions per EIP-7702 delegated account")
 	rootCmd.PersistentFlags().Uint64Var(&priceBump, "txpool.pricebump", txpoolcfg.DefaultConfig.PriceBump, "Price bump percentage to replace an already existing transaction")
 	rootCmd.PersistentFlags().Uint64Var(&blobPriceBump, "txpool.blobpricebump", txpoolcfg.DefaultConfig.BlobPriceBump, "Price bump percentage to replace an existing blob (type-3) transaction")
 	rootCmd.PersistentFlags().DurationVar(&commitEvery, utils.TxPoolCommitEveryFlag.Name, utils.TxPoolCommitEveryFlag.Value, utils.TxPoolCommitEveryFlag.Usage)
+	rootCmd.Flags().StringVar(&journal, utils.TxPoolJournalFlag.Name, utils.TxPoolJournalFlag.Value, utils.TxPoolJournalFlag.Usage)
+	rootCmd.Flags().DurationVar(&rejournal, utils.TxPoolRejournalFlag.Name, utils.TxPoolRejournalFlag.Value, utils.TxPoolRejournalFlag.Usage)
+	rootCmd.Flags().StringVar(&allowList, utils.TxPoolAllowListFlag.Name, utils.TxPoolAllowListFlag.Value, utils.TxPoolAllowListFlag.Usage)
+	rootCmd.Flags().StringVar(&denyList, utils.TxPoolDenyListFlag.Name, utils.TxPoolDenyListFlag.Value, utils.TxPoolDenyListFlag.Usage)
 	rootCmd.PersistentFlags().BoolVar(&noTxGossip, utils.TxPoolGossipDisableFlag.Name, utils.TxPoolGossipDisableFlag.Value, utils.TxPoolGossipDisableFlag.Usage)
 	rootCmd.PersistentFlags().BoolVar(&mdbxWriteMap, utils.DbWriteMapFlag.Name, utils.DbWriteMapFlag.Value, utils.DbWriteMapFlag.Usage)
 	rootCmd.Flags().StringSliceVar(&traceSenders, utils.TxPoolTraceSendersFlag.Name, []string{}, utils.TxPoolTraceSendersFlag.Usage)
@@ -162,6 +172,10 @@ func doTxpool(ctx context.Context, logger log.Logger) error {
 	dirs := datadir.New(datadirCli)
 
 	cfg.DBDir = dirs.TxPool
+	cfg.Journal = utils.TxPoolJournalPath(journal, dirs.DataDir)
+	cfg.Rejournal = rejournal
+	cfg.AllowList = allowList
+	cfg.DenyList = denyList
 
 	cfg.CommitEvery = common2.RandomizeDuration(commitEvery)
 	cfg.PendingSubPoolLimit = pendingPoolLimit
@@ -171,6 +185,7 @@ func doTxpool(ctx context.Context, logger log.Logger) error {
 	cfg.AccountSlots = accountSlots
 	cfg.BlobSlots = blobSlots
 	cfg.TotalBlobPoolLimit = totalBlobPoolLimit
+	cfg.DelegatedSlots = delegatedSlots
 	cfg.PriceBump = priceBump
 	cfg.BlobPriceBump = blobPriceBump
 	cfg.NoGossip = noTxGossip
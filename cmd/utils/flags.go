@@ -49,6 +49,7 @@ import (
 	downloadercfg2 "github.com/erigontech/erigon-lib/downloader/downloadercfg"
 	"github.com/erigontech/erigon-lib/txpool/txpoolcfg"
 
+	"github.com/erigontech/erigon/cl/beacon/builder"
 	"github.com/erigontech/erigon/cl/clparams"
 	"github.com/erigontech/erigon/cmd/downloader/downloadernat"
 	"github.com/erigontech/erigon/cmd/utils/flags"
@@ -119,6 +120,27 @@ var (
 		Name:  "override.prague",
 		Usage: "Manually specify the Prague fork time, overriding the bundled setting",
 	}
+	VMTraceFlag = cli.StringFlag{
+		Name:  "vmtrace",
+		Usage: "Name of the live tracer which receives the hooks of every block executed by the Execution stage (e.g. noop)",
+	}
+	VMTraceJsonConfigFlag = cli.StringFlag{
+		Name:  "vmtrace.jsonconfig",
+		Usage: "Tracer configuration (JSON) passed to the live tracer selected by --vmtrace",
+	}
+	ExportDirFlag = flags.DirectoryFlag{
+		Name:  "export.dir",
+		Usage: "Enables the Export stage: blocks are written to Parquet files in this directory after Execution",
+	}
+	ExportBlocksPerFileFlag = cli.Uint64Flag{
+		Name:  "export.blocks-per-file",
+		Usage: "Blocks in one Parquet file of the Export stage, must divide the snapshot merge limit",
+		Value: 10_000,
+	}
+	ExportTablesFlag = cli.StringFlag{
+		Name:  "export.tables",
+		Usage: "Comma separated tables of the Export stage: headers,transactions,receipts,logs,balance_changes,traces. Empty - all",
+	}
 	TrustedSetupFile = cli.StringFlag{
 		Name:  "trusted-setup-file",
 		Usage: "Absolute path to trusted_setup.json file",
@@ -190,6 +212,11 @@ var (
 		Usage: "Max allowed total number of blobs (within type-3 txs) per account",
 		Value: txpoolcfg.DefaultConfig.BlobSlots,
 	}
+	TxPoolDelegatedSlotsFlag = cli.Uint64Flag{
+		Name:  "txpool.delegatedslots",
+		Usage: "Max allowed number of in-flight transactions per EIP-7702 delegated account",
+		Value: txpoolcfg.DefaultConfig.DelegatedSlots,
+	}
 	TxPoolTotalBlobPoolLimit = cli.Uint64Flag{
 		Name:  "txpool.totalblobpoollimit",
 		Usage: "Total limit of number of all blobs in txs within the txpool",
@@ -230,6 +257,35 @@ var (
 		Usage: "How often transactions should be committed to the storage",
 		Value: txpoolcfg.DefaultConfig.CommitEvery,
 	}
+	TxPoolJournalFlag = cli.StringFlag{
+		Name:  "txpool.journal",
+		Usage: "Disk journal for local transactions to survive txpool db resets, relative to the datadir (empty to disable)",
+		Value: "transactions.rlp",
+	}
+	TxPoolRejournalFlag = cli.DurationFlag{
+		Name:  "txpool.rejournal",
+		Usage: "Time interval to regenerate the local transaction journal",
+		Value: txpoolcfg.DefaultConfig.Rejournal,
+	}
+	TxPoolAllowListFlag = cli.StringFlag{
+		Name:  "txpool.allowlist",
+		Usage: "File of the only senders admitted to the txpool, one address per line. Reloaded when changed",
+		Value: "",
+	}
+	TxPoolDenyListFlag = cli.StringFlag{
+		Name:  "txpool.denylist",
+		Usage: "File of the senders never admitted to the txpool, one address per line. Reloaded when changed",
+		Value: "",
+	}
+	TxPoolOrderingFlag = cli.StringFlag{
+		Name:  "txpool.ordering",
+		Usage: "Order in which the txpool offers transactions for mined blocks: price (by effective tip), fifo (by arrival to the pool), roundrobin (one transaction per sender in turn)",
+		Value: "price",
+	}
+	TxPoolPrioritySendersFlag = cli.StringFlag{
+		Name:  "txpool.prioritysenders",
+		Usage: "Comma separated list of senders whose transactions the txpool offers for mined blocks ahead of any other",
+	}
 	// Miner settings
 	MiningEnabledFlag = cli.BoolFlag{
 		Name:  "mine",
@@ -696,6 +752,16 @@ var (
 		Name:  ethconfig.FlagSnapStateStop,
 		Usage: "Workaround to stop producing new state files, if you meet some state-related critical bug. It will stop aggregate DB history in a state files. DB will grow and may slightly slow-down - and removing this flag in future will not fix this effect (db size will not greatly reduce).",
 	}
+	SnapScrubIntervalFlag = cli.DurationFlag{
+		Name:  "snap.scrub.interval",
+		Usage: "Pause between passes of the background scrubber, which re-hashes snapshot files against their torrents, checks their indices and heals corrupted ones. Rebuilt indices are used after the node is restarted. 0 - disabled",
+		Value: 0,
+	}
+	SnapScrubRateFlag = cli.StringFlag{
+		Name:  "snap.scrub.rate",
+		Value: "16mb",
+		Usage: "Bytes per second the snapshot scrubber reads from disk, example: 32mb",
+	}
 	TorrentVerbosityFlag = cli.IntFlag{
 		Name:  "torrent.verbosity",
 		Value: 2,
@@ -853,9 +919,19 @@ var (
 	}
 	CaplinMevRelayUrl = cli.StringFlag{
 		Name:  "caplin.mev-relay-url",
-		Usage: "MEV relay endpoint. Caplin runs in builder mode if this is set",
+		Usage: "MEV relay endpoint, or comma separated list of endpoints. Caplin runs in builder mode if this is set",
 		Value: "",
 	}
+	CaplinMevRelayTimeoutFlag = cli.DurationFlag{
+		Name:  "caplin.mev-relay-timeout",
+		Usage: "How long block production waits for bids of the MEV relays",
+		Value: builder.DefaultRelayTimeout,
+	}
+	CaplinMevBuilderBoostFactorFlag = cli.Uint64Flag{
+		Name:  "caplin.mev-builder-boost-factor",
+		Usage: "Percentage applied to the best MEV relay bid when comparing it with the local payload value, unless the validator client sets builder_boost_factor. 0 always builds locally",
+		Value: 100,
+	}
 	CaplinValidatorMonitorFlag = cli.BoolFlag{
 		Name:  "caplin.validator-monitor",
 		Usage: "Enable caplin validator monitoring metrics",
@@ -1520,6 +1596,9 @@ func setTxPool(ctx *cli.Context, fullCfg *ethconfig.Config) {
 	if ctx.IsSet(TxPoolBlobSlotsFlag.Name) {
 		fullCfg.TxPool.BlobSlots = ctx.Uint64(TxPoolBlobSlotsFlag.Name)
 	}
+	if ctx.IsSet(TxPoolDelegatedSlotsFlag.Name) {
+		fullCfg.TxPool.DelegatedSlots = ctx.Uint64(TxPoolDelegatedSlotsFlag.Name)
+	}
 	if ctx.IsSet(TxPoolTotalBlobPoolLimit.Name) {
 		fullCfg.TxPool.TotalBlobPoolLimit = ctx.Uint64(TxPoolTotalBlobPoolLimit.Name)
 	}
@@ -1553,9 +1632,37 @@ func setTxPool(ctx *cli.Context, fullCfg *ethconfig.Config) {
 	if ctx.IsSet(DbWriteMapFlag.Name) {
 		fullCfg.TxPool.MdbxWriteMap = ctx.Bool(DbWriteMapFlag.Name)
 	}
+	if ctx.IsSet(TxPoolRejournalFlag.Name) {
+		fullCfg.TxPool.Rejournal = ctx.Duration(TxPoolRejournalFlag.Name)
+	}
+	if ctx.IsSet(TxPoolAllowListFlag.Name) {
+		fullCfg.TxPool.AllowList = ctx.String(TxPoolAllowListFlag.Name)
+	}
+	if ctx.IsSet(TxPoolDenyListFlag.Name) {
+		fullCfg.TxPool.DenyList = ctx.String(TxPoolDenyListFlag.Name)
+	}
+	if ctx.IsSet(TxPoolOrderingFlag.Name) {
+		fullCfg.TxPool.TxOrdering = ctx.String(TxPoolOrderingFlag.Name)
+	}
+	if ctx.IsSet(TxPoolPrioritySendersFlag.Name) {
+		for _, sender := range libcommon.CliString2Array(ctx.String(TxPoolPrioritySendersFlag.Name)) {
+			if !libcommon.IsHexAddress(sender) {
+				Fatalf("Invalid address in --%s: %s", TxPoolPrioritySendersFlag.Name, sender)
+			}
+			fullCfg.TxPool.PrioritySenders = append(fullCfg.TxPool.PrioritySenders, libcommon.HexToAddress(sender))
+		}
+	}
 	cfg.CommitEvery = common2.RandomizeDuration(ctx.Duration(TxPoolCommitEveryFlag.Name))
 }
 
+// TxPoolJournalPath resolves the local transaction journal path against the datadir, empty means no journal
+func TxPoolJournalPath(journal, datadir string) string {
+	if journal == "" || filepath.IsAbs(journal) {
+		return journal
+	}
+	return filepath.Join(datadir, journal)
+}
+
 func setEthash(ctx *cli.Context, datadir string, cfg *ethconfig.Config) {
 	if ctx.IsSet(EthashDatasetDirFlag.Name) {
 		cfg.Ethash.DatasetDir = ctx.String(EthashDatasetDirFlag.Name)
@@ -1725,6 +1832,8 @@ func setCaplin(ctx *cli.Context, cfg *ethconfig.Config) {
 	cfg.CaplinConfig.DisabledCheckpointSync = ctx.Bool(CaplinDisableCheckpointSyncFlag.Name)
 	cfg.CaplinConfig.Archive = ctx.Bool(CaplinArchiveFlag.Name)
 	cfg.CaplinConfig.MevRelayUrl = ctx.String(CaplinMevRelayUrl.Name)
+	cfg.CaplinConfig.MevRelayTimeout = ctx.Duration(CaplinMevRelayTimeoutFlag.Name)
+	cfg.CaplinConfig.BeaconAPIRouter.BuilderBoostFactor = ctx.Uint64(CaplinMevBuilderBoostFactorFlag.Name)
 	cfg.CaplinConfig.EnableValidatorMonitor = ctx.Bool(CaplinValidatorMonitorFlag.Name)
 	if checkpointUrls := ctx.StringSlice(CaplinCheckpointSyncUrlFlag.Name); len(checkpointUrls) > 0 {
 		clparams.ConfigurableCheckpointsURLs = checkpointUrls
@@ -1820,6 +1929,10 @@ func SetEthConfig(ctx *cli.Context, nodeConfig *nodecfg.Config, cfg *ethconfig.C
 	cfg.Snapshot.ProduceE3 = !ctx.Bool(SnapStateStopFlag.Name)
 	cfg.Snapshot.NoDownloader = ctx.Bool(NoDownloaderFlag.Name)
 	cfg.Snapshot.Verify = ctx.Bool(DownloaderVerifyFlag.Name)
+	cfg.Snapshot.ScrubInterval = ctx.Duration(SnapScrubIntervalFlag.Name)
+	if err := cfg.Snapshot.ScrubRate.UnmarshalText([]byte(ctx.String(SnapScrubRateFlag.Name))); err != nil {
+		panic(err)
+	}
 	cfg.Snapshot.DownloaderAddr = strings.TrimSpace(ctx.String(DownloaderAddrFlag.Name))
 	if cfg.Snapshot.DownloaderAddr == "" {
 		downloadRateStr := ctx.String(TorrentDownloadRateFlag.Name)
@@ -1864,6 +1977,7 @@ func SetEthConfig(ctx *cli.Context, nodeConfig *nodecfg.Config, cfg *ethconfig.C
 	setTxPool(ctx, cfg)
 	cfg.TxPool = ethconfig.DefaultTxPool2Config(cfg)
 	cfg.TxPool.DBDir = nodeConfig.Dirs.TxPool
+	cfg.TxPool.Journal = TxPoolJournalPath(ctx.String(TxPoolJournalFlag.Name), nodeConfig.Dirs.DataDir)
 
 	setEthash(ctx, nodeConfig.Dirs.DataDir, cfg)
 	setClique(ctx, &cfg.Clique, nodeConfig.Dirs.DataDir)
@@ -1940,6 +2054,17 @@ func SetEthConfig(ctx *cli.Context, nodeConfig *nodecfg.Config, cfg *ethconfig.C
 		cfg.InternalCL = !ctx.Bool(ExternalConsensusFlag.Name)
 	}
 
+	if ctx.IsSet(VMTraceFlag.Name) {
+		cfg.VMTrace = ctx.String(VMTraceFlag.Name)
+		cfg.VMTraceJsonConfig = ctx.String(VMTraceJsonConfigFlag.Name)
+	}
+
+	if ctx.IsSet(ExportDirFlag.Name) {
+		cfg.Export.Dir = ctx.String(ExportDirFlag.Name)
+		cfg.Export.BlocksPerFile = ctx.Uint64(ExportBlocksPerFileFlag.Name)
+		cfg.Export.Tables = libcommon.CliString2Array(ctx.String(ExportTablesFlag.Name))
+	}
+
 	if ctx.IsSet(TrustedSetupFile.Name) {
 		libkzg.SetTrustedSetupFilePath(ctx.String(TrustedSetupFile.Name))
 	}
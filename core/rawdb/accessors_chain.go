@@ -26,6 +26,7 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"slices"
 	"time"
 
 	"github.com/gballet/go-verkle"
@@ -1350,3 +1351,54 @@ func ReadLastNewBlockSeen(tx kv.Tx) (uint64, error) {
 	}
 	return dbutils.DecodeBlockNumber(v)
 }
+
+// BadBlockLimit is the maximum number of rejected blocks kept in kv.BadBlocks, the lowest ones are evicted first
+const BadBlockLimit = 10
+
+// BadBlock is the rejected block as stored in kv.BadBlocks, along with the reason it was rejected for
+type BadBlock struct {
+	Block  *types.Block
+	Reason string
+}
+
+// WriteBadBlock stores the rejected block, keeping at most BadBlockLimit of the highest ones.
+func WriteBadBlock(tx kv.RwTx, block *types.Block, reason string) error {
+	v, err := rlp.EncodeToBytes(&BadBlock{Block: block, Reason: reason})
+	if err != nil {
+		return fmt.Errorf("failed to RLP encode bad block: %w", err)
+	}
+	if err := tx.Put(kv.BadBlocks, dbutils.HeaderKey(block.NumberU64(), block.Hash()), v); err != nil {
+		return fmt.Errorf("failed to store bad block: %w", err)
+	}
+
+	var keys [][]byte
+	if err := tx.ForEach(kv.BadBlocks, nil, func(k, _ []byte) error {
+		keys = append(keys, common.Copy(k))
+		return nil
+	}); err != nil {
+		return err
+	}
+	for ; len(keys) > BadBlockLimit; keys = keys[1:] {
+		if err := tx.Delete(kv.BadBlocks, keys[0]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadAllBadBlocks returns the stored rejected blocks, the highest first.
+func ReadAllBadBlocks(tx kv.Tx) ([]*BadBlock, error) {
+	var badBlocks []*BadBlock
+	if err := tx.ForEach(kv.BadBlocks, nil, func(k, v []byte) error {
+		badBlock := &BadBlock{}
+		if err := rlp.DecodeBytes(v, badBlock); err != nil {
+			return fmt.Errorf("invalid bad block RLP %x: %w", k, err)
+		}
+		badBlocks = append(badBlocks, badBlock)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	slices.Reverse(badBlocks)
+	return badBlocks, nil
+}
@@ -367,6 +367,34 @@ func TestTdStorage(t *testing.T) {
 	}
 }
 
+// Tests that only the highest rejected blocks are kept and retrieved along with the reason.
+func TestBadBlockStorage(t *testing.T) {
+	t.Parallel()
+	_, tx := memdb.NewTestTx(t)
+
+	badBlocks, err := rawdb.ReadAllBadBlocks(tx)
+	require.NoError(t, err)
+	require.Empty(t, badBlocks)
+
+	txn := types.NewTransaction(1, libcommon.HexToAddress("0x1"), u256.Num1, 21000, u256.Num1, nil)
+	for i := rawdb.BadBlockLimit + 3; i > 0; i-- {
+		header := &types.Header{Number: big.NewInt(int64(i)), Extra: []byte("bad block")}
+		block := types.NewBlock(header, types.Transactions{txn}, nil, nil, nil, nil)
+		require.NoError(t, rawdb.WriteBadBlock(tx, block, fmt.Sprintf("reason %d", i)))
+	}
+
+	badBlocks, err = rawdb.ReadAllBadBlocks(tx)
+	require.NoError(t, err)
+	require.Len(t, badBlocks, rawdb.BadBlockLimit)
+	for i, badBlock := range badBlocks {
+		number := uint64(rawdb.BadBlockLimit + 3 - i)
+		require.Equal(t, number, badBlock.Block.NumberU64())
+		require.Equal(t, fmt.Sprintf("reason %d", number), badBlock.Reason)
+		require.Len(t, badBlock.Block.Transactions(), 1)
+		require.Equal(t, txn.Hash(), badBlock.Block.Transactions()[0].Hash())
+	}
+}
+
 // Tests that canonical numbers can be mapped to hashes and retrieved.
 func TestCanonicalMappingStorage(t *testing.T) {
 	t.Parallel()
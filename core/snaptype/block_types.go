@@ -129,6 +129,7 @@ var (
 				}
 				return nil
 			}),
+		seg.CodecHuffman,
 	)
 
 	Bodies = snaptype.RegisterType(
@@ -166,6 +167,7 @@ var (
 				}
 				return nil
 			}),
+		seg.CodecHuffman,
 	)
 
 	Transactions = snaptype.RegisterType(
@@ -338,6 +340,7 @@ var (
 					return nil
 				}
 			}),
+		seg.CodecHuffman,
 	)
 	Domains = snaptype.RegisterType(
 		Enums.Domains,
@@ -349,6 +352,7 @@ var (
 		nil,
 		nil,
 		nil,
+		seg.CodecHuffman,
 	)
 	Histories = snaptype.RegisterType(
 		Enums.Histories,
@@ -360,6 +364,7 @@ var (
 		nil,
 		nil,
 		nil,
+		seg.CodecHuffman,
 	)
 	InvertedIndicies = snaptype.RegisterType(
 		Enums.InvertedIndicies,
@@ -371,6 +376,7 @@ var (
 		nil,
 		nil,
 		nil,
+		seg.CodecHuffman,
 	)
 
 	Accessors = snaptype.RegisterType(
@@ -383,6 +389,7 @@ var (
 		nil,
 		nil,
 		nil,
+		seg.CodecHuffman,
 	)
 
 	Txt = snaptype.RegisterType(
@@ -395,6 +402,7 @@ var (
 		nil,
 		nil,
 		nil,
+		seg.CodecHuffman,
 	)
 	BlockSnapshotTypes = []snaptype.Type{Headers, Bodies, Transactions}
 	E3StateTypes       = []snaptype.Type{Domains, Histories, InvertedIndicies, Accessors, Txt}
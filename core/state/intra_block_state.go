@@ -97,6 +97,7 @@ type IntraBlockState struct {
 	nextRevisionID int
 	trace          bool
 	balanceInc     map[libcommon.Address]*BalanceIncrease // Map of balance increases (without first reading the account)
+	tracingHooks   *tracing.Hooks
 }
 
 // Create a new state from a given trie
@@ -120,6 +121,12 @@ func (sdb *IntraBlockState) SetTrace(trace bool) {
 	sdb.trace = trace
 }
 
+// SetHooks sets the hooks which are told about state changes: balance, nonce, code, storage and logs.
+// Changes which are reverted later are reported too.
+func (sdb *IntraBlockState) SetHooks(hooks *tracing.Hooks) {
+	sdb.tracingHooks = hooks
+}
+
 // setErrorUnsafe sets error but should be called in medhods that already have locks
 func (sdb *IntraBlockState) setErrorUnsafe(err error) {
 	if sdb.savedErr == nil {
@@ -172,6 +179,9 @@ func (sdb *IntraBlockState) AddLog(log2 *types.Log) {
 		sdb.logs = append(sdb.logs, nil)
 	}
 	sdb.logs[sdb.txIndex] = append(sdb.logs[sdb.txIndex], log2)
+	if sdb.tracingHooks != nil && sdb.tracingHooks.OnLog != nil {
+		sdb.tracingHooks.OnLog(log2)
+	}
 }
 
 func (sdb *IntraBlockState) GetLogs(txIndex int, txnHash libcommon.Hash, blockNumber uint64, blockHash libcommon.Hash) types.Logs {
@@ -388,6 +398,10 @@ func (sdb *IntraBlockState) AddBalance(addr libcommon.Address, amount *uint256.I
 	if !needAccount && addr == ripemd && amount.IsZero() {
 		needAccount = true
 	}
+	if !needAccount && sdb.tracingHooks != nil && sdb.tracingHooks.OnBalanceChange != nil {
+		// the hook needs the previous balance
+		needAccount = true
+	}
 	if !needAccount {
 		sdb.journal.append(balanceIncrease{
 			account:  &addr,
@@ -493,6 +507,10 @@ func (sdb *IntraBlockState) Selfdestruct(addr libcommon.Address) bool {
 		prev:        stateObject.selfdestructed,
 		prevbalance: *stateObject.Balance(),
 	})
+	if sdb.tracingHooks != nil && sdb.tracingHooks.OnBalanceChange != nil && !stateObject.Balance().IsZero() {
+		prev := stateObject.data.Balance
+		sdb.tracingHooks.OnBalanceChange(addr, &prev, new(uint256.Int), tracing.BalanceDecreaseSelfdestruct)
+	}
 	stateObject.markSelfdestructed()
 	stateObject.createdContract = false
 	stateObject.data.Balance.Clear()
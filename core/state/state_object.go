@@ -217,6 +217,9 @@ func (so *stateObject) SetState(key *libcommon.Hash, value uint256.Int) {
 	if prev == value {
 		return
 	}
+	if so.db.tracingHooks != nil && so.db.tracingHooks.OnStorageChange != nil {
+		so.db.tracingHooks.OnStorageChange(so.address, key, prev, value)
+	}
 	// New value is different, update and journal the change
 	so.db.journal.append(storageChange{
 		account:  &so.address,
@@ -292,6 +295,10 @@ func (so *stateObject) SubBalance(amount *uint256.Int, reason tracing.BalanceCha
 }
 
 func (so *stateObject) SetBalance(amount *uint256.Int, reason tracing.BalanceChangeReason) {
+	if so.db.tracingHooks != nil && so.db.tracingHooks.OnBalanceChange != nil {
+		prev := so.data.Balance
+		so.db.tracingHooks.OnBalanceChange(so.address, &prev, amount, reason)
+	}
 	so.db.journal.append(balanceChange{
 		account: &so.address,
 		prev:    so.data.Balance,
@@ -338,6 +345,9 @@ func (so *stateObject) Code() []byte {
 
 func (so *stateObject) SetCode(codeHash libcommon.Hash, code []byte) {
 	prevcode := so.Code()
+	if so.db.tracingHooks != nil && so.db.tracingHooks.OnCodeChange != nil {
+		so.db.tracingHooks.OnCodeChange(so.address, so.data.CodeHash, prevcode, codeHash, code)
+	}
 	so.db.journal.append(codeChange{
 		account:  &so.address,
 		prevhash: so.data.CodeHash,
@@ -353,6 +363,9 @@ func (so *stateObject) setCode(codeHash libcommon.Hash, code []byte) {
 }
 
 func (so *stateObject) SetNonce(nonce uint64) {
+	if so.db.tracingHooks != nil && so.db.tracingHooks.OnNonceChange != nil {
+		so.db.tracingHooks.OnNonceChange(so.address, so.data.Nonce, nonce)
+	}
 	so.db.journal.append(nonceChange{
 		account: &so.address,
 		prev:    so.data.Nonce,
@@ -464,3 +464,39 @@ func TestDump(t *testing.T) {
 		t.Fatalf("dump mismatch:\ngot: %s\nwant: %s\n", got, want)
 	}
 }
+
+func TestBalanceChangeHook(t *testing.T) {
+	t.Parallel()
+	_, tx, _ := NewTestTemporalDb(t)
+
+	domains, err := state.NewSharedDomains(tx, log.New())
+	require.NoError(t, err)
+	defer domains.Close()
+
+	type change struct {
+		addr      common.Address
+		prev, new uint64
+		reason    tracing.BalanceChangeReason
+	}
+	var changes []change
+	st := New(NewReaderV3(domains))
+	st.SetHooks(&tracing.Hooks{OnBalanceChange: func(addr common.Address, prev, new *uint256.Int, reason tracing.BalanceChangeReason) {
+		changes = append(changes, change{addr, prev.Uint64(), new.Uint64(), reason})
+	}})
+
+	addr1, addr2 := toAddr([]byte{0x01}), toAddr([]byte{0x02})
+	st.AddBalance(addr1, uint256.NewInt(10), tracing.BalanceIncreaseWithdrawal) // not loaded yet
+	st.SubBalance(addr1, uint256.NewInt(3), tracing.BalanceDecreaseGasBuy)
+	snapshot := st.Snapshot()
+	st.AddBalance(addr2, uint256.NewInt(5), tracing.BalanceChangeTransfer)
+	st.RevertToSnapshot(snapshot)
+	st.Selfdestruct(addr1)
+
+	require.Equal(t, []change{
+		{addr1, 0, 10, tracing.BalanceIncreaseWithdrawal},
+		{addr1, 10, 7, tracing.BalanceDecreaseGasBuy},
+		{addr2, 0, 5, tracing.BalanceChangeTransfer}, // reverted changes are reported too
+		{addr1, 7, 0, tracing.BalanceDecreaseSelfdestruct},
+	}, changes)
+	require.True(t, st.GetBalance(addr2).IsZero())
+}
@@ -333,6 +333,7 @@ func (st *StateTransition) TransitionDb(refunds bool, gasBailout bool) (*evmtype
 	}
 	if st.evm.Config().Debug {
 		st.evm.Config().Tracer.CaptureTxStart(st.initialGas)
+		vm.CaptureGasChange(st.evm.Config().Tracer, 0, st.initialGas, tracing.GasChangeTxInitialBalance)
 		defer func() {
 			st.evm.Config().Tracer.CaptureTxEnd(st.gasRemaining)
 		}()
@@ -420,6 +421,9 @@ func (st *StateTransition) TransitionDb(refunds bool, gasBailout bool) (*evmtype
 	if st.gasRemaining < gas {
 		return nil, fmt.Errorf("%w: have %d, want %d", ErrIntrinsicGas, st.gasRemaining, gas)
 	}
+	if st.evm.Config().Debug {
+		vm.CaptureGasChange(st.evm.Config().Tracer, st.gasRemaining, st.gasRemaining-gas, tracing.GasChangeTxIntrinsicGas)
+	}
 	st.gasRemaining -= gas
 
 	var bailout bool
@@ -438,7 +442,7 @@ func (st *StateTransition) TransitionDb(refunds bool, gasBailout bool) (*evmtype
 	// Execute the preparatory steps for state transition which includes:
 	// - prepare accessList(post-berlin; eip-7702)
 	// - reset transient storage(eip 1153)
-	st.state.Prepare(rules, msg.From(), coinbase, msg.To(), vm.ActivePrecompiles(rules), accessTuples, verifiedAuthorities)
+	st.state.Prepare(rules, msg.From(), coinbase, msg.To(), st.evm.ActivePrecompiles(), accessTuples, verifiedAuthorities)
 
 	var (
 		ret   []byte
@@ -504,6 +508,9 @@ func (st *StateTransition) refundGas(refundQuotient uint64) {
 	if refund > st.state.GetRefund() {
 		refund = st.state.GetRefund()
 	}
+	if st.evm.Config().Debug {
+		vm.CaptureGasChange(st.evm.Config().Tracer, st.gasRemaining, st.gasRemaining+refund, tracing.GasChangeTxRefunds)
+	}
 	st.gasRemaining += refund
 
 	// Return ETH for remaining gas, exchanged at the original rate.
@@ -513,6 +520,9 @@ func (st *StateTransition) refundGas(refundQuotient uint64) {
 	// Also return remaining gas to the block gas counter so it is
 	// available for the next transaction.
 	st.gp.AddGas(st.gasRemaining)
+	if st.evm.Config().Debug {
+		vm.CaptureGasChange(st.evm.Config().Tracer, st.gasRemaining, 0, tracing.GasChangeTxLeftOverReturned)
+	}
 }
 
 // gasUsed returns the amount of gas used up by the state transition.
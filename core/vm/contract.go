@@ -192,26 +192,23 @@ func (c *Contract) Caller() libcommon.Address {
 	return c.CallerAddress
 }
 
-// UseGas attempts the use gas and subtracts it and returns true on success
-func (c *Contract) UseGas(gas uint64, reason tracing.GasChangeReason) (ok bool) {
-	// We collect the gas change reason today, future changes will add gas change(s) tracking with reason
-	_ = reason
-
+// UseGas attempts the use gas and subtracts it and returns true on success.
+// The change is reported to the tracer, which may be nil, unless the reason is GasChangeIgnored.
+func (c *Contract) UseGas(gas uint64, tracer EVMLogger, reason tracing.GasChangeReason) (ok bool) {
 	if c.Gas < gas {
 		return false
 	}
+	CaptureGasChange(tracer, c.Gas, c.Gas-gas, reason)
 	c.Gas -= gas
 	return true
 }
 
-// RefundGas refunds gas to the contract
-func (c *Contract) RefundGas(gas uint64, reason tracing.GasChangeReason) {
-	// We collect the gas change reason today, future changes will add gas change(s) tracking with reason
-	_ = reason
-
+// RefundGas refunds gas to the contract, the change is reported to the tracer like in UseGas
+func (c *Contract) RefundGas(gas uint64, tracer EVMLogger, reason tracing.GasChangeReason) {
 	if gas == 0 {
 		return
 	}
+	CaptureGasChange(tracer, c.Gas, c.Gas+gas, reason)
 	c.Gas += gas
 }
 
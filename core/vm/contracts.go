@@ -23,6 +23,7 @@ import (
 	"crypto/sha256"
 	"encoding/binary"
 	"errors"
+	"maps"
 	"math/big"
 
 	"github.com/consensys/gnark-crypto/ecc"
@@ -188,6 +189,33 @@ func init() {
 	}
 }
 
+// PrecompiledContracts is a set of precompiled contracts indexed by address.
+type PrecompiledContracts map[libcommon.Address]PrecompiledContract
+
+// ActivePrecompiledContracts returns a copy of precompiled contracts enabled with the current configuration.
+func ActivePrecompiledContracts(rules *chain.Rules) PrecompiledContracts {
+	return maps.Clone(activePrecompiledContracts(rules))
+}
+
+func activePrecompiledContracts(rules *chain.Rules) map[libcommon.Address]PrecompiledContract {
+	switch {
+	case rules.IsPrague:
+		return PrecompiledContractsPrague
+	case rules.IsNapoli:
+		return PrecompiledContractsNapoli
+	case rules.IsCancun:
+		return PrecompiledContractsCancun
+	case rules.IsBerlin:
+		return PrecompiledContractsBerlin
+	case rules.IsIstanbul:
+		return PrecompiledContractsIstanbul
+	case rules.IsByzantium:
+		return PrecompiledContractsByzantium
+	default:
+		return PrecompiledContractsHomestead
+	}
+}
+
 // ActivePrecompiles returns the precompiles enabled with the current configuration.
 func ActivePrecompiles(rules *chain.Rules) []libcommon.Address {
 	switch {
@@ -38,24 +38,11 @@ import (
 var emptyHash = libcommon.Hash{}
 
 func (evm *EVM) precompile(addr libcommon.Address) (PrecompiledContract, bool) {
-	var precompiles map[libcommon.Address]PrecompiledContract
-	switch {
-	case evm.chainRules.IsPrague:
-		precompiles = PrecompiledContractsPrague
-	case evm.chainRules.IsNapoli:
-		precompiles = PrecompiledContractsNapoli
-	case evm.chainRules.IsCancun:
-		precompiles = PrecompiledContractsCancun
-	case evm.chainRules.IsBerlin:
-		precompiles = PrecompiledContractsBerlin
-	case evm.chainRules.IsIstanbul:
-		precompiles = PrecompiledContractsIstanbul
-	case evm.chainRules.IsByzantium:
-		precompiles = PrecompiledContractsByzantium
-	default:
-		precompiles = PrecompiledContractsHomestead
+	if evm.precompiles != nil {
+		p, ok := evm.precompiles[addr]
+		return p, ok
 	}
-	p, ok := precompiles[addr]
+	p, ok := activePrecompiledContracts(evm.chainRules)[addr]
 	return p, ok
 }
 
@@ -97,6 +84,8 @@ type EVM struct {
 	// available gas is calculated in gasCall* according to the 63/64 rule and later
 	// applied in opCall*.
 	callGasTemp uint64
+	// precompiles overrides the set of precompiled contracts defined by chain rules (see SetPrecompiles)
+	precompiles PrecompiledContracts
 
 	JumpDestCache *JumpDestCache
 }
@@ -147,6 +136,24 @@ func (evm *EVM) ResetBetweenBlocks(blockCtx evmtypes.BlockContext, txCtx evmtype
 	atomic.StoreInt32(&evm.abort, 0)
 }
 
+// SetPrecompiles replaces the set of precompiled contracts activated by chain rules,
+// used by RPC simulations to move precompiles to other addresses.
+func (evm *EVM) SetPrecompiles(precompiles PrecompiledContracts) {
+	evm.precompiles = precompiles
+}
+
+// ActivePrecompiles returns addresses of precompiled contracts available to this EVM.
+func (evm *EVM) ActivePrecompiles() []libcommon.Address {
+	if evm.precompiles == nil {
+		return ActivePrecompiles(evm.chainRules)
+	}
+	addrs := make([]libcommon.Address, 0, len(evm.precompiles))
+	for addr := range evm.precompiles {
+		addrs = append(addrs, addr)
+	}
+	return addrs
+}
+
 // Cancel cancels any running EVM operation. This may be called concurrently and
 // it's safe to be called multiple times.
 func (evm *EVM) Cancel() {
@@ -444,7 +451,7 @@ func (evm *EVM) create(caller ContractRef, codeAndHash *codeAndHash, gasRemainin
 	// by the error checking condition below.
 	if err == nil {
 		createDataGas := uint64(len(ret)) * params.CreateDataGas
-		if contract.UseGas(createDataGas, tracing.GasChangeCallCodeStorage) {
+		if contract.UseGas(createDataGas, evm.config.Tracer, tracing.GasChangeCallCodeStorage) {
 			evm.intraBlockState.SetCode(address, ret)
 		} else if evm.chainRules.IsHomestead {
 			err = ErrCodeStoreOutOfGas
@@ -457,7 +464,7 @@ func (evm *EVM) create(caller ContractRef, codeAndHash *codeAndHash, gasRemainin
 	if err != nil && (evm.chainRules.IsHomestead || err != ErrCodeStoreOutOfGas) {
 		evm.intraBlockState.RevertToSnapshot(snapshot)
 		if err != ErrExecutionReverted {
-			contract.UseGas(contract.Gas, tracing.GasChangeCallFailedExecution)
+			contract.UseGas(contract.Gas, evm.config.Tracer, tracing.GasChangeCallFailedExecution)
 		}
 	}
 
@@ -518,3 +525,18 @@ func (evm *EVM) ChainRules() *chain.Rules {
 func (evm *EVM) IntraBlockState() evmtypes.IntraBlockState {
 	return evm.intraBlockState
 }
+
+// GetVMContext provides context about the block being executed as well as state
+// to the tracers.
+func (evm *EVM) GetVMContext() *tracing.VMContext {
+	return &tracing.VMContext{
+		Coinbase:        evm.Context.Coinbase,
+		BlockNumber:     evm.Context.BlockNumber,
+		Time:            evm.Context.Time,
+		Random:          evm.Context.PrevRanDao,
+		GasPrice:        evm.TxContext.GasPrice,
+		ChainConfig:     evm.ChainConfig(),
+		IntraBlockState: evm.IntraBlockState(),
+		TxHash:          evm.TxContext.TxHash,
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package vm
+
+import (
+	"github.com/holiman/uint256"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+
+	"github.com/erigontech/erigon/core/tracing"
+)
+
+// hooksLogger forwards EVMLogger events to core/tracing hooks. Transaction level
+// events are not forwarded: OnTxStart/OnTxEnd need the transaction and the receipt,
+// which only the caller of the EVM knows about.
+type hooksLogger struct {
+	hooks *tracing.Hooks
+	depth int
+}
+
+// NewHooksLogger returns an EVMLogger which reports call frames and opcodes to the
+// given tracing hooks.
+func NewHooksLogger(hooks *tracing.Hooks) EVMLogger {
+	return &hooksLogger{hooks: hooks}
+}
+
+func (l *hooksLogger) CaptureTxStart(gasLimit uint64) {}
+func (l *hooksLogger) CaptureTxEnd(restGas uint64)    {}
+
+func (l *hooksLogger) CaptureStart(env *EVM, from libcommon.Address, to libcommon.Address, precompile bool, create bool, input []byte, gas uint64, value *uint256.Int, code []byte) {
+	l.depth = 0
+	typ := CALL
+	if create {
+		typ = CREATE
+	}
+	if l.hooks.OnEnter != nil {
+		l.hooks.OnEnter(0, byte(typ), from, to, precompile, input, gas, value, code)
+	}
+}
+
+func (l *hooksLogger) CaptureEnd(output []byte, usedGas uint64, err error) {
+	if l.hooks.OnExit != nil {
+		l.hooks.OnExit(0, output, usedGas, err, err != nil)
+	}
+}
+
+func (l *hooksLogger) CaptureEnter(typ OpCode, from libcommon.Address, to libcommon.Address, precompile bool, create bool, input []byte, gas uint64, value *uint256.Int, code []byte) {
+	l.depth++
+	if l.hooks.OnEnter != nil {
+		l.hooks.OnEnter(l.depth, byte(typ), from, to, precompile, input, gas, value, code)
+	}
+}
+
+func (l *hooksLogger) CaptureExit(output []byte, usedGas uint64, err error) {
+	if l.hooks.OnExit != nil {
+		l.hooks.OnExit(l.depth, output, usedGas, err, err != nil)
+	}
+	l.depth--
+}
+
+func (l *hooksLogger) CaptureState(pc uint64, op OpCode, gas, cost uint64, scope *ScopeContext, rData []byte, depth int, err error) {
+	if l.hooks.OnOpcode != nil {
+		l.hooks.OnOpcode(pc, byte(op), gas, cost, scope, rData, depth, err)
+	}
+}
+
+func (l *hooksLogger) CaptureGasChange(old, new uint64, reason tracing.GasChangeReason) {
+	if l.hooks.OnGasChange != nil {
+		l.hooks.OnGasChange(old, new, reason)
+	}
+}
+
+func (l *hooksLogger) CaptureFault(pc uint64, op OpCode, gas, cost uint64, scope *ScopeContext, depth int, err error) {
+	if l.hooks.OnFault != nil {
+		l.hooks.OnFault(pc, byte(op), gas, cost, scope, depth, err)
+	}
+}
@@ -24,6 +24,7 @@ import (
 
 	libcommon "github.com/erigontech/erigon-lib/common"
 
+	"github.com/erigontech/erigon/core/tracing"
 	"github.com/erigontech/erigon/core/types"
 )
 
@@ -47,6 +48,22 @@ type EVMLogger interface {
 	CaptureFault(pc uint64, op OpCode, gas, cost uint64, scope *ScopeContext, depth int, err error)
 }
 
+// GasChangeLogger is an EVMLogger extension for tracers which want to be told about
+// the changes of the gas left, see tracing.Hooks.OnGasChange.
+type GasChangeLogger interface {
+	CaptureGasChange(old, new uint64, reason tracing.GasChangeReason)
+}
+
+// CaptureGasChange tells the tracer about a change of the gas left, if it is a GasChangeLogger.
+func CaptureGasChange(tracer EVMLogger, old, new uint64, reason tracing.GasChangeReason) {
+	if reason == tracing.GasChangeIgnored || old == new {
+		return
+	}
+	if l, ok := tracer.(GasChangeLogger); ok {
+		l.CaptureGasChange(old, new, reason)
+	}
+}
+
 // FlushableTracer is a Tracer extension whose accumulated traces has to be
 // flushed once the tracing is completed.
 type FlushableTracer interface {
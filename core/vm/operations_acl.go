@@ -168,7 +168,7 @@ func makeCallVariantGasCallEIP2929(oldCalculator gasFunc) gasFunc {
 		if addrMod {
 			// Charge the remaining difference here already, to correctly calculate available
 			// gas for call
-			if !contract.UseGas(coldCost, tracing.GasChangeCallStorageColdAccess) {
+			if !contract.UseGas(coldCost, evm.config.Tracer, tracing.GasChangeCallStorageColdAccess) {
 				return 0, ErrOutOfGas
 			}
 		}
@@ -260,7 +260,7 @@ func makeCallVariantGasCallEIP7702(oldCalculator gasFunc) gasFunc {
 			dynCost = params.ColdAccountAccessCostEIP2929 - params.WarmStorageReadCostEIP2929
 			// Charge the remaining difference here already, to correctly calculate available
 			// gas for call
-			if !contract.UseGas(dynCost, tracing.GasChangeCallStorageColdAccess) {
+			if !contract.UseGas(dynCost, evm.config.Tracer, tracing.GasChangeCallStorageColdAccess) {
 				return 0, ErrOutOfGas
 			}
 		}
@@ -274,7 +274,7 @@ func makeCallVariantGasCallEIP7702(oldCalculator gasFunc) gasFunc {
 				ddCost = params.WarmStorageReadCostEIP2929
 			}
 
-			if !contract.UseGas(ddCost, tracing.GasChangeDelegatedDesignation) {
+			if !contract.UseGas(ddCost, evm.config.Tracer, tracing.GasChangeDelegatedDesignation) {
 				return 0, ErrOutOfGas
 			}
 			dynCost += ddCost
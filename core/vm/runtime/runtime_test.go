@@ -46,6 +46,7 @@ import (
 	"github.com/erigontech/erigon/core"
 	"github.com/erigontech/erigon/core/asm"
 	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/core/tracing"
 	"github.com/erigontech/erigon/core/types"
 	"github.com/erigontech/erigon/core/vm"
 	"github.com/erigontech/erigon/eth/tracers/logger"
@@ -744,3 +745,58 @@ func TestEip2929Cases(t *testing.T) {
 			"account (cheap)", code)
 	}
 }
+
+func TestHooksLogger(t *testing.T) {
+	t.Parallel()
+	_, tx, _ := NewTestTemporalDb(t)
+	domains, err := stateLib.NewSharedDomains(tx, log.New())
+	require.NoError(t, err)
+	defer domains.Close()
+	state := state.New(state.NewReaderV3(domains))
+	caller, callee := libcommon.HexToAddress("0xaa"), libcommon.HexToAddress("0xbb")
+	state.SetCode(caller, []byte{
+		byte(vm.PUSH1), 0, // out size
+		byte(vm.DUP1), // out offset
+		byte(vm.DUP1), // in size
+		byte(vm.DUP1), // in offset
+		byte(vm.DUP1), // value
+		byte(vm.PUSH1), 0xbb,
+		byte(vm.GAS),
+		byte(vm.CALL),
+		byte(vm.STOP),
+	})
+	state.SetCode(callee, []byte{
+		byte(vm.PUSH1), 0,
+		byte(vm.DUP1),
+		byte(vm.REVERT),
+	})
+
+	type frame struct {
+		depth int
+		typ   vm.OpCode
+		to    libcommon.Address
+	}
+	var enters []frame
+	var exits []int
+	var reverted []bool
+	opcodes := map[libcommon.Address]int{}
+	hooks := &tracing.Hooks{
+		OnEnter: func(depth int, typ byte, from libcommon.Address, to libcommon.Address, precompile bool, input []byte, gas uint64, value *uint256.Int, code []byte) {
+			enters = append(enters, frame{depth, vm.OpCode(typ), to})
+		},
+		OnExit: func(depth int, output []byte, gasUsed uint64, err error, revert bool) {
+			exits = append(exits, depth)
+			reverted = append(reverted, revert)
+		},
+		OnOpcode: func(pc uint64, op byte, gas, cost uint64, scope tracing.OpContext, rData []byte, depth int, err error) {
+			opcodes[scope.Address()]++
+		},
+	}
+
+	_, _, err = Call(caller, nil, &Config{State: state, EVMConfig: vm.Config{Debug: true, Tracer: vm.NewHooksLogger(hooks)}})
+	require.NoError(t, err)
+	require.Equal(t, []frame{{0, vm.CALL, caller}, {1, vm.CALL, callee}}, enters)
+	require.Equal(t, []int{1, 0}, exits)
+	require.Equal(t, []bool{true, false}, reverted)
+	require.Equal(t, map[libcommon.Address]int{caller: 9, callee: 3}, opcodes)
+}
@@ -1809,3 +1809,305 @@ func (hph *HexPatriciaHashed) hashAndNibblizeKey(key []byte) []byte {
 	}
 	return nibblized
 }
+
+// ProveAccount returns RLP-encoded trie nodes on the path from the root to the account with given plain key,
+// ordered as required by EIP-1186. If account is absent, returned nodes prove its absence and storageRoot is nil.
+// Trie is not modified, so it is safe to call ProveAccount on restored or freshly processed state.
+func (hph *HexPatriciaHashed) ProveAccount(plainKey []byte) (proof [][]byte, storageRoot []byte, err error) {
+	if len(plainKey) != hph.accountKeyLen {
+		return nil, nil, fmt.Errorf("account key expected to be %d bytes, got %d", hph.accountKeyLen, len(plainKey))
+	}
+	hashedKey := hph.hashAndNibblizeKey(plainKey)
+	proof, leaf, err := hph.proveFromRoot(hashedKey, plainKey)
+	if err != nil || leaf == nil {
+		return proof, nil, err
+	}
+	root, err := hph.storageRootHash(leaf)
+	if err != nil {
+		return nil, nil, err
+	}
+	return proof, root[:], nil
+}
+
+// ProveStorage returns RLP-encoded storage trie nodes on the path from the storage root of the account
+// to the storage slot with given plain key (account address concatenated with the location).
+// Empty proof is returned if account does not exist or has empty storage.
+func (hph *HexPatriciaHashed) ProveStorage(plainKey []byte) (proof [][]byte, err error) {
+	if len(plainKey) != hph.accountKeyLen+length.Hash {
+		return nil, fmt.Errorf("storage key expected to be %d bytes, got %d", hph.accountKeyLen+length.Hash, len(plainKey))
+	}
+	hashedKey := hph.hashAndNibblizeKey(plainKey)
+	_, leaf, err := hph.proveFromRoot(hashedKey[:64], plainKey[:hph.accountKeyLen])
+	if err != nil || leaf == nil {
+		return nil, err
+	}
+	if leaf.storageAddrLen == 0 && leaf.hashLen == 0 {
+		return nil, nil // empty storage
+	}
+	expected, err := hph.storageRootHash(leaf)
+	if err != nil {
+		return nil, err
+	}
+	// storage subtree of the account starts right after the account nibbles
+	var storageRoot cell
+	storageRoot.extLen, storageRoot.hashLen, storageRoot.storageAddrLen = leaf.extLen, leaf.hashLen, leaf.storageAddrLen
+	copy(storageRoot.extension[:], leaf.extension[:leaf.extLen])
+	copy(storageRoot.hash[:], leaf.hash[:leaf.hashLen])
+	copy(storageRoot.storageAddr[:], leaf.storageAddr[:leaf.storageAddrLen])
+	storageRoot.StorageLen = leaf.StorageLen
+	copy(storageRoot.Storage[:], leaf.Storage[:leaf.StorageLen])
+
+	proof, _, err = hph.prove(&storageRoot, 64, expected[:], hashedKey, plainKey)
+	return proof, err
+}
+
+func (hph *HexPatriciaHashed) proveFromRoot(hashedKey, plainKey []byte) ([][]byte, *cell, error) {
+	root := hph.root
+	if root.hashLen == 0 && root.accountAddrLen == 0 && root.storageAddrLen == 0 {
+		return nil, nil, nil // empty trie
+	}
+	rootHash, err := hph.RootHash()
+	if err != nil {
+		return nil, nil, err
+	}
+	return hph.prove(&root, 0, rootHash, hashedKey, plainKey)
+}
+
+// prove walks down the trie starting from cell c located at given depth and collects encoded nodes
+// on the path to the hashedKey. Hash of each collected node is checked against the reference kept by its parent,
+// so any inconsistency of branches read from the context is reported as an error.
+// Returns leaf cell if leaf with given plainKey is found.
+func (hph *HexPatriciaHashed) prove(c *cell, depth int, expected []byte, hashedKey, plainKey []byte) (proof [][]byte, leaf *cell, err error) {
+	var row [16]cell
+	isStorage := len(plainKey) > hph.accountKeyLen
+	appendNode := func(node []byte) error {
+		if len(node) < length.Hash && expected == nil {
+			return nil // embedded into the parent node
+		}
+		if h := hph.nodeHash(node); !bytes.Equal(h, expected) {
+			return fmt.Errorf("proof node hash mismatch at [%x]: expected %x, got %x", hashedKey[:depth], expected, h)
+		}
+		proof = append(proof, node)
+		return nil
+	}
+	for {
+		switch {
+		case !isStorage && c.accountAddrLen > 0:
+			node, err := hph.accountLeafNode(c, depth)
+			if err != nil {
+				return nil, nil, err
+			}
+			if err = appendNode(node); err != nil {
+				return nil, nil, err
+			}
+			if bytes.Equal(c.accountAddr[:c.accountAddrLen], plainKey) {
+				return proof, c, nil
+			}
+			return proof, nil, nil
+		case isStorage && c.storageAddrLen > 0:
+			node := hph.storageLeafNode(c, depth)
+			if err = appendNode(node); err != nil {
+				return nil, nil, err
+			}
+			if bytes.Equal(c.storageAddr[:c.storageAddrLen], plainKey) {
+				return proof, c, nil
+			}
+			return proof, nil, nil
+		case c.hashLen == 0:
+			return proof, nil, nil
+		}
+
+		if c.extLen > 0 {
+			node := extensionNode(c.extension[:c.extLen], c.hash[:c.hashLen])
+			if err = appendNode(node); err != nil {
+				return nil, nil, err
+			}
+			if !bytes.HasPrefix(hashedKey[depth:], c.extension[:c.extLen]) {
+				return proof, nil, nil
+			}
+			depth += c.extLen
+		}
+		expected = c.hash[:c.hashLen]
+
+		bitmap, err := hph.loadBranchRow(hashedKey[:depth], depth+1, &row)
+		if err != nil {
+			return nil, nil, err
+		}
+		node, refs, err := hph.branchNode(&row, bitmap, depth+1)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err = appendNode(node); err != nil {
+			return nil, nil, err
+		}
+		nibble := hashedKey[depth]
+		if bitmap&(uint16(1)<<nibble) == 0 {
+			return proof, nil, nil
+		}
+		child := row[nibble]
+		c, depth = &child, depth+1
+		if ref := refs[nibble]; len(ref) == length.Hash+1 {
+			expected = ref[1:]
+		} else {
+			expected = nil // embedded node
+		}
+	}
+}
+
+// loadBranchRow reads branch node at given prefix from the context and fills up the row with its cells
+func (hph *HexPatriciaHashed) loadBranchRow(prefix []byte, depth int, row *[16]cell) (uint16, error) {
+	key := hexToCompact(prefix)
+	if len(key) == 0 {
+		key = temporalReplacementForEmpty
+	}
+	branchData, _, err := hph.ctx.Branch(key)
+	if err != nil {
+		return 0, err
+	}
+	if len(branchData) < 4 {
+		return 0, fmt.Errorf("missing branch data for prefix [%x]", prefix)
+	}
+	branchData = branchData[2:] // skip touch map
+	bitmap := binary.BigEndian.Uint16(branchData[0:])
+	pos := 2
+	for bitset := bitmap; bitset != 0; {
+		bit := bitset & -bitset
+		nibble := bits.TrailingZeros16(bit)
+		cell := &row[nibble]
+		cell.reset()
+		fieldBits := branchData[pos]
+		pos++
+		if pos, err = cell.fillFromFields(branchData, pos, cellFields(fieldBits)); err != nil {
+			return 0, fmt.Errorf("prefix [%x], branchData[%x]: %w", prefix, branchData, err)
+		}
+		if cell.accountAddrLen > 0 {
+			update, err := hph.ctx.Account(cell.accountAddr[:cell.accountAddrLen])
+			if err != nil {
+				return 0, fmt.Errorf("loadBranchRow GetAccount: %w", err)
+			}
+			cell.setFromUpdate(update)
+		}
+		if cell.storageAddrLen > 0 {
+			update, err := hph.ctx.Storage(cell.storageAddr[:cell.storageAddrLen])
+			if err != nil {
+				return 0, fmt.Errorf("loadBranchRow GetStorage: %w", err)
+			}
+			cell.setFromUpdate(update)
+		}
+		bitset ^= bit
+	}
+	return bitmap, nil
+}
+
+// branchNode encodes full node out of the row cells. Returns node and references to its children
+func (hph *HexPatriciaHashed) branchNode(row *[16]cell, bitmap uint16, depth int) ([]byte, [16][]byte, error) {
+	var refs [16][]byte
+	totalLen := 1 // value slot is always empty
+	for nibble := 0; nibble < 16; nibble++ {
+		if bitmap&(uint16(1)<<nibble) == 0 {
+			totalLen++
+			continue
+		}
+		ref, err := hph.computeCellHash(&row[nibble], depth, nil)
+		if err != nil {
+			return nil, refs, err
+		}
+		refs[nibble] = common.Copy(ref) // embedded leaf refers to the aux buffer
+		totalLen += len(refs[nibble])
+	}
+	var lenPrefix [4]byte
+	pt := rlp.GenerateStructLen(lenPrefix[:], totalLen)
+	node := make([]byte, 0, pt+totalLen)
+	node = append(node, lenPrefix[:pt]...)
+	for nibble := 0; nibble < 16; nibble++ {
+		if refs[nibble] == nil {
+			node = append(node, 0x80)
+			continue
+		}
+		node = append(node, refs[nibble]...)
+	}
+	return append(node, 0x80), refs, nil
+}
+
+// storageRootHash returns root hash of the storage trie of given account cell
+func (hph *HexPatriciaHashed) storageRootHash(c *cell) ([length.Hash]byte, error) {
+	switch {
+	case c.storageAddrLen > 0:
+		if err := hashKey(hph.keccak, c.storageAddr[hph.accountKeyLen:c.storageAddrLen], c.hashedExtension[:], 0); err != nil {
+			return [length.Hash]byte{}, err
+		}
+		c.hashedExtension[64] = 16 // Add terminator
+		aux, err := hph.leafHashWithKeyVal(make([]byte, 0, 33), c.hashedExtension[:65], c.Storage[:c.StorageLen], true)
+		if err != nil {
+			return [length.Hash]byte{}, err
+		}
+		return *(*[length.Hash]byte)(aux[1:]), nil
+	case c.extLen > 0:
+		return hph.extensionHash(c.extension[:c.extLen], c.hash[:c.hashLen])
+	case c.hashLen > 0:
+		return c.hash, nil
+	default:
+		return *(*[length.Hash]byte)(EmptyRootHash), nil
+	}
+}
+
+func (hph *HexPatriciaHashed) accountLeafNode(c *cell, depth int) ([]byte, error) {
+	storageRoot, err := hph.storageRootHash(c)
+	if err != nil {
+		return nil, err
+	}
+	if err := hashKey(hph.keccak, c.accountAddr[:c.accountAddrLen], c.hashedExtension[:], depth); err != nil {
+		return nil, err
+	}
+	c.hashedExtension[64-depth] = 16 // Add terminator
+	var valBuf [128]byte
+	valLen := c.accountForHashing(valBuf[:], storageRoot)
+	return leafNode(c.hashedExtension[:65-depth], rlp.RlpEncodedBytes(valBuf[:valLen])), nil
+}
+
+func (hph *HexPatriciaHashed) storageLeafNode(c *cell, depth int) []byte {
+	var hashedKey [65]byte
+	// storage slot keys are hashed without account prefix, hashKey can't fail on keccak writes
+	_ = hashKey(hph.keccak, c.storageAddr[hph.accountKeyLen:c.storageAddrLen], hashedKey[:], depth-64)
+	keyLen := 128 - depth
+	hashedKey[keyLen] = 16 // Add terminator
+	return leafNode(hashedKey[:keyLen+1], rlp.RlpSerializableBytes(c.Storage[:c.StorageLen]))
+}
+
+func (hph *HexPatriciaHashed) nodeHash(node []byte) []byte {
+	hph.keccak2.Reset()
+	hph.keccak2.Write(node)
+	var h [length.Hash]byte
+	hph.keccak2.Read(h[:])
+	return h[:]
+}
+
+func leafNode(key []byte, val rlp.RlpSerializable) []byte {
+	compactKey := hexToCompact(key)
+	keyLen := rlp.StringLen(compactKey)
+	totalLen := keyLen + val.DoubleRLPLen()
+	var lenPrefix [4]byte
+	pt := rlp.GenerateStructLen(lenPrefix[:], totalLen)
+
+	buf := bytes.NewBuffer(make([]byte, 0, pt+totalLen))
+	buf.Write(lenPrefix[:pt])
+	var keyBuf [length.Hash + 2]byte
+	buf.Write(keyBuf[:rlp.EncodeString(compactKey, keyBuf[:])])
+	var prefixBuf [8]byte
+	_ = val.ToDoubleRLP(buf, prefixBuf[:]) // bytes.Buffer never fails on write
+	return buf.Bytes()
+}
+
+func extensionNode(key []byte, hash []byte) []byte {
+	compactKey := hexToCompact(key)
+	keyLen := rlp.StringLen(compactKey)
+	totalLen := keyLen + len(hash) + 1
+	var lenPrefix [4]byte
+	pt := rlp.GenerateStructLen(lenPrefix[:], totalLen)
+
+	node := make([]byte, pt+totalLen)
+	copy(node, lenPrefix[:pt])
+	n := pt + rlp.EncodeString(compactKey, node[pt:])
+	rlp.EncodeHash(hash, node[n:])
+	return node
+}
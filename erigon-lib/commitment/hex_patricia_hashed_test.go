@@ -27,9 +27,11 @@ import (
 
 	"github.com/holiman/uint256"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
 
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/length"
+	"github.com/erigontech/erigon-lib/rlp"
 )
 
 func Test_HexPatriciaHashed_ResetThenSingularUpdates(t *testing.T) {
@@ -1159,3 +1161,149 @@ func TestCell_setFromUpdate(t *testing.T) {
 	require.EqualValues(t, update.StorageLen, target.StorageLen)
 	require.EqualValues(t, update.Storage[:update.StorageLen], target.Storage[:target.StorageLen])
 }
+
+func Test_HexPatriciaHashed_ProveAccountAndStorage(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ms := NewMockState(t)
+	hph := NewHexPatriciaHashed(length.Addr, ms, ms.TempDir())
+
+	plainKeys, updates := NewUpdateBuilder().
+		Balance("a2a6d93439144ffe4d27c9e088dcd8b783946263", 4).
+		Balance("bc11295936aa79d594139de1b2e12629414f3bdb", 5).
+		Nonce("bc11295936aa79d594139de1b2e12629414f3bdb", 17).
+		Balance("7cf5b79bfe291a67ab02b393e456ccc4c266f753", 6).
+		Balance("aaec86394441f915bce3e6ab399977e9906f3b69", 7).
+		Storage("aaec86394441f915bce3e6ab399977e9906f3b69", "0000000000000000000000000000000000000000000000000000000000000001", "0401").
+		Balance("f47cae1cf79ca6758bfc787dbd21e6bdbe7112b8", 8).
+		Storage("f47cae1cf79ca6758bfc787dbd21e6bdbe7112b8", "0000000000000000000000000000000000000000000000000000000000000001", "050505").
+		Storage("f47cae1cf79ca6758bfc787dbd21e6bdbe7112b8", "0000000000000000000000000000000000000000000000000000000000000002", "060606").
+		Storage("f47cae1cf79ca6758bfc787dbd21e6bdbe7112b8", "0000000000000000000000000000000000000000000000000000000000000003", "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff").
+		Build()
+
+	err := ms.applyPlainUpdates(plainKeys, updates)
+	require.NoError(t, err)
+
+	upds := WrapKeyUpdates(t, ModeDirect, hph.hashAndNibblizeKey, plainKeys, updates)
+	defer upds.Close()
+
+	rootHash, err := hph.Process(ctx, upds, "")
+	require.NoError(t, err)
+
+	storageRoots := make(map[string][]byte)
+	for i, pk := range plainKeys {
+		if len(pk) != length.Addr {
+			continue
+		}
+		proof, storageRoot, err := hph.ProveAccount(pk)
+		require.NoError(t, err)
+		require.NotNil(t, storageRoot)
+		require.NotNil(t, verifyTestProof(t, rootHash, hph.hashAndNibblizeKey(pk), proof), "account %x must be present", pk)
+		if updates[i].Flags&StorageUpdate == 0 {
+			storageRoots[string(pk)] = storageRoot
+		}
+	}
+	for _, pk := range plainKeys {
+		if len(pk) == length.Addr {
+			continue
+		}
+		proof, err := hph.ProveStorage(pk)
+		require.NoError(t, err)
+		_, storageRoot, err := hph.ProveAccount(pk[:length.Addr])
+		require.NoError(t, err)
+		require.NotEqualValues(t, EmptyRootHash, storageRoot)
+		require.NotNil(t, verifyTestProof(t, storageRoot, hph.hashAndNibblizeKey(pk)[64:], proof), "storage %x must be present", pk)
+	}
+
+	t.Run("absent", func(t *testing.T) {
+		absent := decodeHex("0000006916a87b82333f4245046623b23794c65c")
+		proof, storageRoot, err := hph.ProveAccount(absent)
+		require.NoError(t, err)
+		require.Nil(t, storageRoot)
+		require.NotEmpty(t, proof)
+		require.Nil(t, verifyTestProof(t, rootHash, hph.hashAndNibblizeKey(absent), proof))
+
+		slot := decodeHex("f47cae1cf79ca6758bfc787dbd21e6bdbe7112b80000000000000000000000000000000000000000000000000000000000000004")
+		proof, err = hph.ProveStorage(slot)
+		require.NoError(t, err)
+		_, storageRoot, err = hph.ProveAccount(slot[:length.Addr])
+		require.NoError(t, err)
+		require.Nil(t, verifyTestProof(t, storageRoot, hph.hashAndNibblizeKey(slot)[64:], proof))
+
+		proof, err = hph.ProveStorage(decodeHex("a2a6d93439144ffe4d27c9e088dcd8b7839462630000000000000000000000000000000000000000000000000000000000000001"))
+		require.NoError(t, err)
+		require.Empty(t, proof)
+	})
+}
+
+// verifyTestProof checks that proof nodes are linked by their hashes starting from the root and
+// returns value of the leaf found by hashedKey, nil if proof shows that key is absent.
+func verifyTestProof(t *testing.T, root []byte, hashedKey []byte, proof [][]byte) []byte {
+	t.Helper()
+
+	items := func(node []byte) (res [][]byte) {
+		pos, dataLen, err := rlp.List(node, 0)
+		require.NoError(t, err)
+		for end := pos + dataLen; pos < end; {
+			dataPos, l, _, err := rlp.Prefix(node, pos)
+			require.NoError(t, err)
+			res = append(res, node[pos:dataPos+l])
+			pos = dataPos + l
+		}
+		return res
+	}
+	str := func(item []byte) []byte {
+		pos, l, err := rlp.String(item, 0)
+		require.NoError(t, err)
+		return item[pos : pos+l]
+	}
+
+	require.NotEmpty(t, proof)
+	keccak := sha3.NewLegacyKeccak256()
+	used := 0
+	resolve := func(ref []byte) []byte {
+		if len(ref) != length.Hash+1 {
+			return ref // embedded node
+		}
+		require.Less(t, used, len(proof))
+		keccak.Reset()
+		keccak.Write(proof[used])
+		require.EqualValues(t, ref[1:], keccak.Sum(nil), "proof node %d is not referenced by its parent", used)
+		used++
+		return proof[used-1]
+	}
+
+	node := resolve(append([]byte{0x80 + length.Hash}, root...))
+	key := hashedKey
+	for {
+		switch it := items(node); len(it) {
+		case 17:
+			require.NotEmpty(t, key)
+			if bytes.Equal(it[key[0]], []byte{0x80}) {
+				require.Equal(t, len(proof), used)
+				return nil
+			}
+			node, key = resolve(it[key[0]]), key[1:]
+		case 2:
+			compact := str(it[0])
+			nibbles := CompactedKeyToHex(compact)
+			if hasTerm(nibbles) {
+				nibbles = nibbles[:len(nibbles)-1]
+			}
+			if !bytes.HasPrefix(key, nibbles) {
+				require.Equal(t, len(proof), used)
+				return nil
+			}
+			key = key[len(nibbles):]
+			if compact[0]&0x20 != 0 { // leaf
+				require.Empty(t, key)
+				require.Equal(t, len(proof), used)
+				return str(it[1])
+			}
+			node = resolve(it[1])
+		default:
+			t.Fatalf("unexpected node with %d items", len(it))
+		}
+	}
+}
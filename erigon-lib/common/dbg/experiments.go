@@ -45,11 +45,12 @@ var (
 	mergeTr = EnvInt("MERGE_THRESHOLD", -1)
 
 	//state v3
-	noPrune              = EnvBool("NO_PRUNE", false)
-	noMerge              = EnvBool("NO_MERGE", false)
-	discardHistory       = EnvBool("DISCARD_HISTORY", false)
-	discardCommitment    = EnvBool("DISCARD_COMMITMENT", false)
-	pruneTotalDifficulty = EnvBool("PRUNE_TOTAL_DIFFICULTY", true)
+	noPrune               = EnvBool("NO_PRUNE", false)
+	noMerge               = EnvBool("NO_MERGE", false)
+	discardHistory        = EnvBool("DISCARD_HISTORY", false)
+	discardCommitment     = EnvBool("DISCARD_COMMITMENT", false)
+	keepCommitmentHistory = EnvBool("KEEP_COMMITMENT_HISTORY", false)
+	pruneTotalDifficulty  = EnvBool("PRUNE_TOTAL_DIFFICULTY", true)
 
 	// force skipping of any non-Erigon2 .torrent files
 	DownloaderOnlyBlocks = EnvBool("DOWNLOADER_ONLY_BLOCKS", false)
@@ -83,11 +84,12 @@ func NoSync() bool        { return noSync }
 func MdbxReadAhead() bool { return mdbxReadahead }
 func MdbxLockInRam() bool { return mdbxLockInRam }
 
-func DiscardHistory() bool       { return discardHistory }
-func DiscardCommitment() bool    { return discardCommitment }
-func NoPrune() bool              { return noPrune }
-func NoMerge() bool              { return noMerge }
-func PruneTotalDifficulty() bool { return pruneTotalDifficulty }
+func DiscardHistory() bool        { return discardHistory }
+func DiscardCommitment() bool     { return discardCommitment }
+func KeepCommitmentHistory() bool { return keepCommitmentHistory }
+func NoPrune() bool               { return noPrune }
+func NoMerge() bool               { return noMerge }
+func PruneTotalDifficulty() bool  { return pruneTotalDifficulty }
 
 var (
 	dirtySace     uint64
@@ -212,3 +212,7 @@ func (s *MiningClient) HashRate(ctx context.Context, in *txpool_proto.HashRateRe
 func (s *MiningClient) Mining(ctx context.Context, in *txpool_proto.MiningRequest, opts ...grpc.CallOption) (*txpool_proto.MiningReply, error) {
 	return s.server.Mining(ctx, in)
 }
+
+func (s *MiningClient) PreviewBlock(ctx context.Context, in *txpool_proto.PreviewBlockRequest, opts ...grpc.CallOption) (*txpool_proto.PreviewBlockReply, error) {
+	return s.server.PreviewBlock(ctx, in)
+}
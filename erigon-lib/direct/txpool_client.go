@@ -119,3 +119,23 @@ func (s *TxPoolClient) Status(ctx context.Context, in *txpool_proto.StatusReques
 func (s *TxPoolClient) Nonce(ctx context.Context, in *txpool_proto.NonceRequest, opts ...grpc.CallOption) (*txpool_proto.NonceReply, error) {
 	return s.server.Nonce(ctx, in)
 }
+
+func (s *TxPoolClient) Inspect(ctx context.Context, in *txpool_proto.InspectRequest, opts ...grpc.CallOption) (*txpool_proto.InspectReply, error) {
+	return s.server.Inspect(ctx, in)
+}
+
+func (s *TxPoolClient) AddPrivate(ctx context.Context, in *txpool_proto.AddPrivateRequest, opts ...grpc.CallOption) (*txpool_proto.AddReply, error) {
+	return s.server.AddPrivate(ctx, in)
+}
+
+func (s *TxPoolClient) AddBundle(ctx context.Context, in *txpool_proto.AddBundleRequest, opts ...grpc.CallOption) (*txpool_proto.AddBundleReply, error) {
+	return s.server.AddBundle(ctx, in)
+}
+
+func (s *TxPoolClient) UpdateSenderList(ctx context.Context, in *txpool_proto.UpdateSenderListRequest, opts ...grpc.CallOption) (*txpool_proto.UpdateSenderListReply, error) {
+	return s.server.UpdateSenderList(ctx, in)
+}
+
+func (s *TxPoolClient) GetBlobs(ctx context.Context, in *txpool_proto.GetBlobsRequest, opts ...grpc.CallOption) (*txpool_proto.GetBlobsReply, error) {
+	return s.server.GetBlobs(ctx, in)
+}
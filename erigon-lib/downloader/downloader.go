@@ -2518,6 +2518,10 @@ func (d *Downloader) VerifyData(ctx context.Context, whiteList []string, failFas
 				if err := d.db.Update(ctx, torrentInfoReset(t.Name(), t.InfoHash().Bytes(), 0)); err != nil {
 					return fmt.Errorf("verify data: %s: reset failed: %w", t.Name(), err)
 				}
+				// let the main loop pick the torrent up again and re-download the bad pieces
+				d.lock.Lock()
+				delete(d.completedTorrents, t.Name())
+				d.lock.Unlock()
 			}
 
 			return err
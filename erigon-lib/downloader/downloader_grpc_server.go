@@ -128,7 +128,7 @@ func (s *GrpcServer) Delete(ctx context.Context, request *proto_downloader.Delet
 }
 
 func (s *GrpcServer) Verify(ctx context.Context, request *proto_downloader.VerifyRequest) (*emptypb.Empty, error) {
-	err := s.d.VerifyData(ctx, nil, false)
+	err := s.d.VerifyData(ctx, request.Paths, false)
 	if err != nil {
 		return nil, err
 	}
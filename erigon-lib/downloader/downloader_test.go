@@ -18,6 +18,7 @@ package downloader
 
 import (
 	"context"
+	"os"
 	"path/filepath"
 	"runtime"
 	"testing"
@@ -87,3 +88,36 @@ func TestNoEscape(t *testing.T) {
 	_, err = BuildTorrentIfNeed(ctx, "./../a.seg", dirs.Snap, tf)
 	require.Error(err)
 }
+
+func TestVerifyFilePieces(t *testing.T) {
+	require := require.New(t)
+	dirs := datadir.New(t.TempDir())
+	ctx := context.Background()
+
+	fPath := filepath.Join(dirs.Snap, "a.seg")
+	data := make([]byte, 2*downloadercfg2.DefaultPieceSize+100)
+	for i := range data {
+		data[i] = byte(i * 7)
+	}
+	require.NoError(os.WriteFile(fPath, data, 0644))
+	_, err := BuildTorrentIfNeed(ctx, "a.seg", dirs.Snap, NewAtomicTorrentFS(dirs.Snap))
+	require.NoError(err)
+
+	var read int64
+	bad, err := VerifyFilePieces(ctx, fPath, func(length int64) error { read += length; return nil })
+	require.NoError(err)
+	require.Empty(bad)
+	require.Equal(int64(len(data)), read)
+
+	data[downloadercfg2.DefaultPieceSize+1]++
+	require.NoError(os.WriteFile(fPath, data, 0644))
+	bad, err = VerifyFilePieces(ctx, fPath, nil)
+	require.NoError(err)
+	require.Equal([]int{1}, bad)
+
+	// truncated tail
+	require.NoError(os.Truncate(fPath, int64(len(data)-1)))
+	bad, err = VerifyFilePieces(ctx, fPath, nil)
+	require.NoError(err)
+	require.Equal([]int{1, 2}, bad)
+}
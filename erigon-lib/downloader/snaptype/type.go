@@ -194,6 +194,8 @@ type Type interface {
 	IdxFileName(version Version, from uint64, to uint64, index ...Index) string
 	IdxFileNames(version Version, from uint64, to uint64) []string
 	Indexes() []Index
+	// Codec - of new files of the type, existing files are read with the codec they were built with
+	Codec() seg.Codec
 	HasIndexFiles(info FileInfo, logger log.Logger) bool
 	BuildIndexes(ctx context.Context, info FileInfo, chainConfig *chain.Config, tmpDir string, p *background.Progress, lvl log.Lvl, logger log.Logger) error
 	ExtractRange(ctx context.Context, info FileInfo, firstKeyGetter FirstKeyGetter, db kv.RoDB, chainConfig *chain.Config, tmpDir string, workers int, lvl log.Lvl, logger log.Logger) (uint64, error)
@@ -206,6 +208,7 @@ type snapType struct {
 	indexes        []Index
 	indexBuilder   IndexBuilder
 	rangeExtractor RangeExtractor
+	codec          seg.Codec
 }
 
 // These are raw maps with no mutex protection becuase they are
@@ -214,9 +217,9 @@ type snapType struct {
 var registeredTypes = map[Enum]Type{}
 var namedTypes = map[string]Type{}
 
-func RegisterType(enum Enum, name string, versions Versions, rangeExtractor RangeExtractor, indexes []Index, indexBuilder IndexBuilder) Type {
+func RegisterType(enum Enum, name string, versions Versions, rangeExtractor RangeExtractor, indexes []Index, indexBuilder IndexBuilder, codec seg.Codec) Type {
 	t := snapType{
-		enum: enum, name: name, versions: versions, indexes: indexes, rangeExtractor: rangeExtractor, indexBuilder: indexBuilder,
+		enum: enum, name: name, versions: versions, indexes: indexes, rangeExtractor: rangeExtractor, indexBuilder: indexBuilder, codec: codec,
 	}
 
 	registeredTypes[enum] = t
@@ -237,6 +240,10 @@ func (s snapType) Name() string {
 	return s.name
 }
 
+func (s snapType) Codec() seg.Codec {
+	return s.codec
+}
+
 func (s snapType) String() string {
 	return s.Name()
 }
@@ -472,7 +479,9 @@ func BuildIndex(ctx context.Context, info FileInfo, cfg recsplit.RecSplitArgs, l
 func ExtractRange(ctx context.Context, f FileInfo, extractor RangeExtractor, firstKey FirstKeyGetter, chainDB kv.RoDB, chainConfig *chain.Config, tmpDir string, workers int, lvl log.Lvl, logger log.Logger) (uint64, error) {
 	var lastKeyValue uint64
 
-	sn, err := seg.NewCompressor(ctx, "Snapshot "+f.Type.Name(), f.Path, tmpDir, seg.DefaultCfg, log.LvlTrace, logger)
+	compressCfg := seg.DefaultCfg
+	compressCfg.Codec = f.Type.Codec()
+	sn, err := seg.NewCompressor(ctx, "Snapshot "+f.Type.Name(), f.Path, tmpDir, compressCfg, log.LvlTrace, logger)
 
 	if err != nil {
 		return lastKeyValue, err
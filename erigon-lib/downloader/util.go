@@ -566,3 +566,47 @@ func VerifyFileFailFast(ctx context.Context, t *torrent.Torrent, root string, co
 	}
 	return nil
 }
+
+// VerifyFilePieces re-hashes the file against the piece hashes of its .torrent, which must lay next to it, and
+// returns the indices of the pieces that don't match. onPiece, if not nil, is called before every piece is read -
+// it allows the caller to throttle the disk reads.
+func VerifyFilePieces(ctx context.Context, fPath string, onPiece func(length int64) error) (badPieces []int, err error) {
+	mi, err := metainfo.LoadFromFile(fPath + ".torrent")
+	if err != nil {
+		return nil, err
+	}
+	info, err := mi.UnmarshalInfo()
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(fPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	hasher := sha1.New()
+	for i := 0; i < info.NumPieces(); i++ {
+		p := info.Piece(i)
+		if onPiece != nil {
+			if err := onPiece(p.Length()); err != nil {
+				return badPieces, err
+			}
+		}
+		hasher.Reset()
+		n, err := io.Copy(hasher, io.NewSectionReader(f, p.Offset(), p.Length()))
+		if err != nil {
+			return badPieces, err
+		}
+		if n != p.Length() || !bytes.Equal(hasher.Sum(nil), p.Hash().Bytes()) {
+			badPieces = append(badPieces, i)
+		}
+
+		select {
+		case <-ctx.Done():
+			return badPieces, ctx.Err()
+		default:
+		}
+	}
+	return badPieces, nil
+}
@@ -42,6 +42,50 @@ func (m *MockKVClient) EXPECT() *MockKVClientMockRecorder {
 	return m.recorder
 }
 
+// Count mocks base method.
+func (m *MockKVClient) Count(arg0 context.Context, arg1 *CountReq, arg2 ...grpc.CallOption) (*CountReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Count", varargs...)
+	ret0, _ := ret[0].(*CountReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockKVClientMockRecorder) Count(arg0, arg1 any, arg2 ...any) *MockKVClientCountCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1}, arg2...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockKVClient)(nil).Count), varargs...)
+	return &MockKVClientCountCall{Call: call}
+}
+
+// MockKVClientCountCall wrap *gomock.Call
+type MockKVClientCountCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockKVClientCountCall) Return(arg0 *CountReply, arg1 error) *MockKVClientCountCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockKVClientCountCall) Do(f func(context.Context, *CountReq, ...grpc.CallOption) (*CountReply, error)) *MockKVClientCountCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockKVClientCountCall) DoAndReturn(f func(context.Context, *CountReq, ...grpc.CallOption) (*CountReply, error)) *MockKVClientCountCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // DomainGet mocks base method.
 func (m *MockKVClient) DomainGet(arg0 context.Context, arg1 *DomainGetReq, arg2 ...grpc.CallOption) (*DomainGetReply, error) {
 	m.ctrl.T.Helper()
@@ -262,6 +306,50 @@ func (c *MockKVClientIndexRangeCall) DoAndReturn(f func(context.Context, *IndexR
 	return c
 }
 
+// ListTables mocks base method.
+func (m *MockKVClient) ListTables(arg0 context.Context, arg1 *ListTablesReq, arg2 ...grpc.CallOption) (*ListTablesReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListTables", varargs...)
+	ret0, _ := ret[0].(*ListTablesReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTables indicates an expected call of ListTables.
+func (mr *MockKVClientMockRecorder) ListTables(arg0, arg1 any, arg2 ...any) *MockKVClientListTablesCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1}, arg2...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTables", reflect.TypeOf((*MockKVClient)(nil).ListTables), varargs...)
+	return &MockKVClientListTablesCall{Call: call}
+}
+
+// MockKVClientListTablesCall wrap *gomock.Call
+type MockKVClientListTablesCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockKVClientListTablesCall) Return(arg0 *ListTablesReply, arg1 error) *MockKVClientListTablesCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockKVClientListTablesCall) Do(f func(context.Context, *ListTablesReq, ...grpc.CallOption) (*ListTablesReply, error)) *MockKVClientListTablesCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockKVClientListTablesCall) DoAndReturn(f func(context.Context, *ListTablesReq, ...grpc.CallOption) (*ListTablesReply, error)) *MockKVClientListTablesCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // Range mocks base method.
 func (m *MockKVClient) Range(arg0 context.Context, arg1 *RangeReq, arg2 ...grpc.CallOption) (*Pairs, error) {
 	m.ctrl.T.Helper()
@@ -306,6 +394,94 @@ func (c *MockKVClientRangeCall) DoAndReturn(f func(context.Context, *RangeReq, .
 	return c
 }
 
+// Sequence mocks base method.
+func (m *MockKVClient) Sequence(arg0 context.Context, arg1 *SequenceReq, arg2 ...grpc.CallOption) (*SequenceReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Sequence", varargs...)
+	ret0, _ := ret[0].(*SequenceReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Sequence indicates an expected call of Sequence.
+func (mr *MockKVClientMockRecorder) Sequence(arg0, arg1 any, arg2 ...any) *MockKVClientSequenceCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1}, arg2...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sequence", reflect.TypeOf((*MockKVClient)(nil).Sequence), varargs...)
+	return &MockKVClientSequenceCall{Call: call}
+}
+
+// MockKVClientSequenceCall wrap *gomock.Call
+type MockKVClientSequenceCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockKVClientSequenceCall) Return(arg0 *SequenceReply, arg1 error) *MockKVClientSequenceCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockKVClientSequenceCall) Do(f func(context.Context, *SequenceReq, ...grpc.CallOption) (*SequenceReply, error)) *MockKVClientSequenceCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockKVClientSequenceCall) DoAndReturn(f func(context.Context, *SequenceReq, ...grpc.CallOption) (*SequenceReply, error)) *MockKVClientSequenceCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// Size mocks base method.
+func (m *MockKVClient) Size(arg0 context.Context, arg1 *SizeReq, arg2 ...grpc.CallOption) (*SizeReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Size", varargs...)
+	ret0, _ := ret[0].(*SizeReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Size indicates an expected call of Size.
+func (mr *MockKVClientMockRecorder) Size(arg0, arg1 any, arg2 ...any) *MockKVClientSizeCall {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{arg0, arg1}, arg2...)
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Size", reflect.TypeOf((*MockKVClient)(nil).Size), varargs...)
+	return &MockKVClientSizeCall{Call: call}
+}
+
+// MockKVClientSizeCall wrap *gomock.Call
+type MockKVClientSizeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockKVClientSizeCall) Return(arg0 *SizeReply, arg1 error) *MockKVClientSizeCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockKVClientSizeCall) Do(f func(context.Context, *SizeReq, ...grpc.CallOption) (*SizeReply, error)) *MockKVClientSizeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockKVClientSizeCall) DoAndReturn(f func(context.Context, *SizeReq, ...grpc.CallOption) (*SizeReply, error)) *MockKVClientSizeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // Snapshots mocks base method.
 func (m *MockKVClient) Snapshots(arg0 context.Context, arg1 *SnapshotsRequest, arg2 ...grpc.CallOption) (*SnapshotsReply, error) {
 	m.ctrl.T.Helper()
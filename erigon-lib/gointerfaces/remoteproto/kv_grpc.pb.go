@@ -31,6 +31,10 @@ const (
 	KV_IndexRange_FullMethodName   = "/remote.KV/IndexRange"
 	KV_HistoryRange_FullMethodName = "/remote.KV/HistoryRange"
 	KV_DomainRange_FullMethodName  = "/remote.KV/DomainRange"
+	KV_Sequence_FullMethodName     = "/remote.KV/Sequence"
+	KV_Count_FullMethodName        = "/remote.KV/Count"
+	KV_Size_FullMethodName         = "/remote.KV/Size"
+	KV_ListTables_FullMethodName   = "/remote.KV/ListTables"
 )
 
 // KVClient is the client API for KV service.
@@ -61,6 +65,14 @@ type KVClient interface {
 	IndexRange(ctx context.Context, in *IndexRangeReq, opts ...grpc.CallOption) (*IndexRangeReply, error)
 	HistoryRange(ctx context.Context, in *HistoryRangeReq, opts ...grpc.CallOption) (*Pairs, error)
 	DomainRange(ctx context.Context, in *DomainRangeReq, opts ...grpc.CallOption) (*Pairs, error)
+	// Sequence returns the current value of the table's sequence
+	Sequence(ctx context.Context, in *SequenceReq, opts ...grpc.CallOption) (*SequenceReply, error)
+	// Count returns the number of entries in the table
+	Count(ctx context.Context, in *CountReq, opts ...grpc.CallOption) (*CountReply, error)
+	// Size returns the size of the table (or the whole db if table is empty) and the db page size
+	Size(ctx context.Context, in *SizeReq, opts ...grpc.CallOption) (*SizeReply, error)
+	// ListTables returns the names of all tables
+	ListTables(ctx context.Context, in *ListTablesReq, opts ...grpc.CallOption) (*ListTablesReply, error)
 }
 
 type kVClient struct {
@@ -216,6 +228,46 @@ func (c *kVClient) DomainRange(ctx context.Context, in *DomainRangeReq, opts ...
 	return out, nil
 }
 
+func (c *kVClient) Sequence(ctx context.Context, in *SequenceReq, opts ...grpc.CallOption) (*SequenceReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SequenceReply)
+	err := c.cc.Invoke(ctx, KV_Sequence_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVClient) Count(ctx context.Context, in *CountReq, opts ...grpc.CallOption) (*CountReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CountReply)
+	err := c.cc.Invoke(ctx, KV_Count_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVClient) Size(ctx context.Context, in *SizeReq, opts ...grpc.CallOption) (*SizeReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SizeReply)
+	err := c.cc.Invoke(ctx, KV_Size_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kVClient) ListTables(ctx context.Context, in *ListTablesReq, opts ...grpc.CallOption) (*ListTablesReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTablesReply)
+	err := c.cc.Invoke(ctx, KV_ListTables_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // KVServer is the server API for KV service.
 // All implementations must embed UnimplementedKVServer
 // for forward compatibility
@@ -244,6 +296,14 @@ type KVServer interface {
 	IndexRange(context.Context, *IndexRangeReq) (*IndexRangeReply, error)
 	HistoryRange(context.Context, *HistoryRangeReq) (*Pairs, error)
 	DomainRange(context.Context, *DomainRangeReq) (*Pairs, error)
+	// Sequence returns the current value of the table's sequence
+	Sequence(context.Context, *SequenceReq) (*SequenceReply, error)
+	// Count returns the number of entries in the table
+	Count(context.Context, *CountReq) (*CountReply, error)
+	// Size returns the size of the table (or the whole db if table is empty) and the db page size
+	Size(context.Context, *SizeReq) (*SizeReply, error)
+	// ListTables returns the names of all tables
+	ListTables(context.Context, *ListTablesReq) (*ListTablesReply, error)
 	mustEmbedUnimplementedKVServer()
 }
 
@@ -281,6 +341,18 @@ func (UnimplementedKVServer) HistoryRange(context.Context, *HistoryRangeReq) (*P
 func (UnimplementedKVServer) DomainRange(context.Context, *DomainRangeReq) (*Pairs, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DomainRange not implemented")
 }
+func (UnimplementedKVServer) Sequence(context.Context, *SequenceReq) (*SequenceReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Sequence not implemented")
+}
+func (UnimplementedKVServer) Count(context.Context, *CountReq) (*CountReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Count not implemented")
+}
+func (UnimplementedKVServer) Size(context.Context, *SizeReq) (*SizeReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Size not implemented")
+}
+func (UnimplementedKVServer) ListTables(context.Context, *ListTablesReq) (*ListTablesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTables not implemented")
+}
 func (UnimplementedKVServer) mustEmbedUnimplementedKVServer() {}
 
 // UnsafeKVServer may be embedded to opt out of forward compatibility for this service.
@@ -485,6 +557,78 @@ func _KV_DomainRange_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _KV_Sequence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SequenceReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServer).Sequence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KV_Sequence_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServer).Sequence(ctx, req.(*SequenceReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KV_Count_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CountReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServer).Count(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KV_Count_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServer).Count(ctx, req.(*CountReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KV_Size_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SizeReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServer).Size(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KV_Size_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServer).Size(ctx, req.(*SizeReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KV_ListTables_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTablesReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KVServer).ListTables(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KV_ListTables_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KVServer).ListTables(ctx, req.(*ListTablesReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // KV_ServiceDesc is the grpc.ServiceDesc for KV service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -524,6 +668,22 @@ var KV_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DomainRange",
 			Handler:    _KV_DomainRange_Handler,
 		},
+		{
+			MethodName: "Sequence",
+			Handler:    _KV_Sequence_Handler,
+		},
+		{
+			MethodName: "Count",
+			Handler:    _KV_Count_Handler,
+		},
+		{
+			MethodName: "Size",
+			Handler:    _KV_Size_Handler,
+		},
+		{
+			MethodName: "ListTables",
+			Handler:    _KV_ListTables_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -776,6 +776,330 @@ func (x *MiningReply) GetRunning() bool {
 	return false
 }
 
+type PreviewBlockRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FeeRecipient *typesproto.H160 `protobuf:"bytes,1,opt,name=fee_recipient,json=feeRecipient,proto3" json:"fee_recipient,omitempty"` // used when the node has no etherbase
+}
+
+func (x *PreviewBlockRequest) Reset() {
+	*x = PreviewBlockRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_txpool_mining_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PreviewBlockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewBlockRequest) ProtoMessage() {}
+
+func (x *PreviewBlockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_mining_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewBlockRequest.ProtoReflect.Descriptor instead.
+func (*PreviewBlockRequest) Descriptor() ([]byte, []int) {
+	return file_txpool_mining_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *PreviewBlockRequest) GetFeeRecipient() *typesproto.H160 {
+	if x != nil {
+		return x.FeeRecipient
+	}
+	return nil
+}
+
+type PreviewBlockReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockNumber  uint64                       `protobuf:"varint,1,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	ParentHash   *typesproto.H256             `protobuf:"bytes,2,opt,name=parent_hash,json=parentHash,proto3" json:"parent_hash,omitempty"`
+	Timestamp    uint64                       `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	GasLimit     uint64                       `protobuf:"varint,4,opt,name=gas_limit,json=gasLimit,proto3" json:"gas_limit,omitempty"`
+	GasUsed      uint64                       `protobuf:"varint,5,opt,name=gas_used,json=gasUsed,proto3" json:"gas_used,omitempty"`
+	BaseFee      *typesproto.H256             `protobuf:"bytes,6,opt,name=base_fee,json=baseFee,proto3" json:"base_fee,omitempty"`
+	PriorityFees *typesproto.H256             `protobuf:"bytes,7,opt,name=priority_fees,json=priorityFees,proto3" json:"priority_fees,omitempty"` // total priority fees paid to the fee recipient
+	BlobGasUsed  uint64                       `protobuf:"varint,8,opt,name=blob_gas_used,json=blobGasUsed,proto3" json:"blob_gas_used,omitempty"`
+	BlobCount    uint64                       `protobuf:"varint,9,opt,name=blob_count,json=blobCount,proto3" json:"blob_count,omitempty"`
+	Txs          []*PreviewBlockReply_Txn     `protobuf:"bytes,10,rep,name=txs,proto3" json:"txs,omitempty"`
+	Skipped      []*PreviewBlockReply_Skipped `protobuf:"bytes,11,rep,name=skipped,proto3" json:"skipped,omitempty"` // pool txns left out of the block
+}
+
+func (x *PreviewBlockReply) Reset() {
+	*x = PreviewBlockReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_txpool_mining_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PreviewBlockReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewBlockReply) ProtoMessage() {}
+
+func (x *PreviewBlockReply) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_mining_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewBlockReply.ProtoReflect.Descriptor instead.
+func (*PreviewBlockReply) Descriptor() ([]byte, []int) {
+	return file_txpool_mining_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *PreviewBlockReply) GetBlockNumber() uint64 {
+	if x != nil {
+		return x.BlockNumber
+	}
+	return 0
+}
+
+func (x *PreviewBlockReply) GetParentHash() *typesproto.H256 {
+	if x != nil {
+		return x.ParentHash
+	}
+	return nil
+}
+
+func (x *PreviewBlockReply) GetTimestamp() uint64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *PreviewBlockReply) GetGasLimit() uint64 {
+	if x != nil {
+		return x.GasLimit
+	}
+	return 0
+}
+
+func (x *PreviewBlockReply) GetGasUsed() uint64 {
+	if x != nil {
+		return x.GasUsed
+	}
+	return 0
+}
+
+func (x *PreviewBlockReply) GetBaseFee() *typesproto.H256 {
+	if x != nil {
+		return x.BaseFee
+	}
+	return nil
+}
+
+func (x *PreviewBlockReply) GetPriorityFees() *typesproto.H256 {
+	if x != nil {
+		return x.PriorityFees
+	}
+	return nil
+}
+
+func (x *PreviewBlockReply) GetBlobGasUsed() uint64 {
+	if x != nil {
+		return x.BlobGasUsed
+	}
+	return 0
+}
+
+func (x *PreviewBlockReply) GetBlobCount() uint64 {
+	if x != nil {
+		return x.BlobCount
+	}
+	return 0
+}
+
+func (x *PreviewBlockReply) GetTxs() []*PreviewBlockReply_Txn {
+	if x != nil {
+		return x.Txs
+	}
+	return nil
+}
+
+func (x *PreviewBlockReply) GetSkipped() []*PreviewBlockReply_Skipped {
+	if x != nil {
+		return x.Skipped
+	}
+	return nil
+}
+
+type PreviewBlockReply_Txn struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hash        *typesproto.H256 `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Sender      *typesproto.H160 `protobuf:"bytes,2,opt,name=sender,proto3" json:"sender,omitempty"`
+	Nonce       uint64           `protobuf:"varint,3,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	GasUsed     uint64           `protobuf:"varint,4,opt,name=gas_used,json=gasUsed,proto3" json:"gas_used,omitempty"`
+	PriorityFee *typesproto.H256 `protobuf:"bytes,5,opt,name=priority_fee,json=priorityFee,proto3" json:"priority_fee,omitempty"` // effective tip times gas used
+	BlobCount   uint64           `protobuf:"varint,6,opt,name=blob_count,json=blobCount,proto3" json:"blob_count,omitempty"`
+}
+
+func (x *PreviewBlockReply_Txn) Reset() {
+	*x = PreviewBlockReply_Txn{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_txpool_mining_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PreviewBlockReply_Txn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewBlockReply_Txn) ProtoMessage() {}
+
+func (x *PreviewBlockReply_Txn) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_mining_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewBlockReply_Txn.ProtoReflect.Descriptor instead.
+func (*PreviewBlockReply_Txn) Descriptor() ([]byte, []int) {
+	return file_txpool_mining_proto_rawDescGZIP(), []int{17, 0}
+}
+
+func (x *PreviewBlockReply_Txn) GetHash() *typesproto.H256 {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+func (x *PreviewBlockReply_Txn) GetSender() *typesproto.H160 {
+	if x != nil {
+		return x.Sender
+	}
+	return nil
+}
+
+func (x *PreviewBlockReply_Txn) GetNonce() uint64 {
+	if x != nil {
+		return x.Nonce
+	}
+	return 0
+}
+
+func (x *PreviewBlockReply_Txn) GetGasUsed() uint64 {
+	if x != nil {
+		return x.GasUsed
+	}
+	return 0
+}
+
+func (x *PreviewBlockReply_Txn) GetPriorityFee() *typesproto.H256 {
+	if x != nil {
+		return x.PriorityFee
+	}
+	return nil
+}
+
+func (x *PreviewBlockReply_Txn) GetBlobCount() uint64 {
+	if x != nil {
+		return x.BlobCount
+	}
+	return 0
+}
+
+type PreviewBlockReply_Skipped struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hash   *typesproto.H256 `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Sender *typesproto.H160 `protobuf:"bytes,2,opt,name=sender,proto3" json:"sender,omitempty"`
+	Reason string           `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *PreviewBlockReply_Skipped) Reset() {
+	*x = PreviewBlockReply_Skipped{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_txpool_mining_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PreviewBlockReply_Skipped) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewBlockReply_Skipped) ProtoMessage() {}
+
+func (x *PreviewBlockReply_Skipped) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_mining_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewBlockReply_Skipped.ProtoReflect.Descriptor instead.
+func (*PreviewBlockReply_Skipped) Descriptor() ([]byte, []int) {
+	return file_txpool_mining_proto_rawDescGZIP(), []int{17, 1}
+}
+
+func (x *PreviewBlockReply_Skipped) GetHash() *typesproto.H256 {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+func (x *PreviewBlockReply_Skipped) GetSender() *typesproto.H160 {
+	if x != nil {
+		return x.Sender
+	}
+	return nil
+}
+
+func (x *PreviewBlockReply_Skipped) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
 var File_txpool_mining_proto protoreflect.FileDescriptor
 
 var file_txpool_mining_proto_rawDesc = []byte{
@@ -831,45 +1155,102 @@ var file_txpool_mining_proto_rawDesc = []byte{
 	0x6e, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x61,
 	0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62,
 	0x6c, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x32, 0xe2, 0x04,
-	0x0a, 0x06, 0x4d, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x12, 0x36, 0x0a, 0x07, 0x56, 0x65, 0x72, 0x73,
-	0x69, 0x6f, 0x6e, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x13, 0x2e, 0x74, 0x79,
-	0x70, 0x65, 0x73, 0x2e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79,
-	0x12, 0x4e, 0x0a, 0x0e, 0x4f, 0x6e, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x42, 0x6c, 0x6f,
-	0x63, 0x6b, 0x12, 0x1d, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x4f, 0x6e, 0x50, 0x65,
-	0x6e, 0x64, 0x69, 0x6e, 0x67, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x1b, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x4f, 0x6e, 0x50, 0x65, 0x6e,
-	0x64, 0x69, 0x6e, 0x67, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x30, 0x01,
-	0x12, 0x48, 0x0a, 0x0c, 0x4f, 0x6e, 0x4d, 0x69, 0x6e, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
-	0x12, 0x1b, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x4f, 0x6e, 0x4d, 0x69, 0x6e, 0x65,
-	0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e,
-	0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x4f, 0x6e, 0x4d, 0x69, 0x6e, 0x65, 0x64, 0x42, 0x6c,
-	0x6f, 0x63, 0x6b, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x30, 0x01, 0x12, 0x4b, 0x0a, 0x0d, 0x4f, 0x6e,
-	0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x4c, 0x6f, 0x67, 0x73, 0x12, 0x1c, 0x2e, 0x74, 0x78,
-	0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x4f, 0x6e, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x4c, 0x6f,
-	0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x74, 0x78, 0x70, 0x6f,
-	0x6f, 0x6c, 0x2e, 0x4f, 0x6e, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x4c, 0x6f, 0x67, 0x73,
-	0x52, 0x65, 0x70, 0x6c, 0x79, 0x30, 0x01, 0x12, 0x37, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x57, 0x6f,
-	0x72, 0x6b, 0x12, 0x16, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x47, 0x65, 0x74, 0x57,
-	0x6f, 0x72, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x74, 0x78, 0x70,
-	0x6f, 0x6f, 0x6c, 0x2e, 0x47, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x52, 0x65, 0x70, 0x6c, 0x79,
-	0x12, 0x40, 0x0a, 0x0a, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x12, 0x19,
-	0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x57, 0x6f,
-	0x72, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x74, 0x78, 0x70, 0x6f,
-	0x6f, 0x6c, 0x2e, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x52, 0x65, 0x70,
-	0x6c, 0x79, 0x12, 0x4c, 0x0a, 0x0e, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x48, 0x61, 0x73, 0x68,
-	0x52, 0x61, 0x74, 0x65, 0x12, 0x1d, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x53, 0x75,
-	0x62, 0x6d, 0x69, 0x74, 0x48, 0x61, 0x73, 0x68, 0x52, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x53, 0x75, 0x62,
-	0x6d, 0x69, 0x74, 0x48, 0x61, 0x73, 0x68, 0x52, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79,
-	0x12, 0x3a, 0x0a, 0x08, 0x48, 0x61, 0x73, 0x68, 0x52, 0x61, 0x74, 0x65, 0x12, 0x17, 0x2e, 0x74,
-	0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x52, 0x61, 0x74, 0x65, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x48,
-	0x61, 0x73, 0x68, 0x52, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x34, 0x0a, 0x06,
-	0x4d, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x12, 0x15, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e,
-	0x4d, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e,
-	0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x4d, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x70,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x22, 0x47, 0x0a,
+	0x13, 0x50, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x30, 0x0a, 0x0d, 0x66, 0x65, 0x65, 0x5f, 0x72, 0x65, 0x63, 0x69,
+	0x70, 0x69, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x74, 0x79,
+	0x70, 0x65, 0x73, 0x2e, 0x48, 0x31, 0x36, 0x30, 0x52, 0x0c, 0x66, 0x65, 0x65, 0x52, 0x65, 0x63,
+	0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x22, 0xfc, 0x05, 0x0a, 0x11, 0x50, 0x72, 0x65, 0x76, 0x69,
+	0x65, 0x77, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x21, 0x0a, 0x0c,
+	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12,
+	0x2c, 0x0a, 0x0b, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x48, 0x32, 0x35,
+	0x36, 0x52, 0x0a, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x48, 0x61, 0x73, 0x68, 0x12, 0x1c, 0x0a,
+	0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x1b, 0x0a, 0x09, 0x67,
+	0x61, 0x73, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08,
+	0x67, 0x61, 0x73, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x67, 0x61, 0x73, 0x5f,
+	0x75, 0x73, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x67, 0x61, 0x73, 0x55,
+	0x73, 0x65, 0x64, 0x12, 0x26, 0x0a, 0x08, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x66, 0x65, 0x65, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x48, 0x32,
+	0x35, 0x36, 0x52, 0x07, 0x62, 0x61, 0x73, 0x65, 0x46, 0x65, 0x65, 0x12, 0x30, 0x0a, 0x0d, 0x70,
+	0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x5f, 0x66, 0x65, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x48, 0x32, 0x35, 0x36, 0x52,
+	0x0c, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x46, 0x65, 0x65, 0x73, 0x12, 0x22, 0x0a,
+	0x0d, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x67, 0x61, 0x73, 0x5f, 0x75, 0x73, 0x65, 0x64, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x62, 0x6c, 0x6f, 0x62, 0x47, 0x61, 0x73, 0x55, 0x73, 0x65,
+	0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x62, 0x6c, 0x6f, 0x62, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x12, 0x2f, 0x0a, 0x03, 0x74, 0x78, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e,
+	0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x50, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x54, 0x78, 0x6e, 0x52, 0x03, 0x74, 0x78,
+	0x73, 0x12, 0x3b, 0x0a, 0x07, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x18, 0x0b, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x21, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x50, 0x72, 0x65, 0x76,
+	0x69, 0x65, 0x77, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x53, 0x6b,
+	0x69, 0x70, 0x70, 0x65, 0x64, 0x52, 0x07, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x1a, 0xcb,
+	0x01, 0x0a, 0x03, 0x54, 0x78, 0x6e, 0x12, 0x1f, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x48, 0x32, 0x35,
+	0x36, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x23, 0x0a, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e,
+	0x48, 0x31, 0x36, 0x30, 0x52, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05,
+	0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x6e, 0x6f, 0x6e,
+	0x63, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x67, 0x61, 0x73, 0x5f, 0x75, 0x73, 0x65, 0x64, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x67, 0x61, 0x73, 0x55, 0x73, 0x65, 0x64, 0x12, 0x2e, 0x0a,
+	0x0c, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x5f, 0x66, 0x65, 0x65, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x48, 0x32, 0x35, 0x36,
+	0x52, 0x0b, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x46, 0x65, 0x65, 0x12, 0x1d, 0x0a,
+	0x0a, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x09, 0x62, 0x6c, 0x6f, 0x62, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x1a, 0x67, 0x0a, 0x07,
+	0x53, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x12, 0x1f, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x48, 0x32,
+	0x35, 0x36, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x23, 0x0a, 0x06, 0x73, 0x65, 0x6e, 0x64,
+	0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73,
+	0x2e, 0x48, 0x31, 0x36, 0x30, 0x52, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x12, 0x16, 0x0a,
+	0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x32, 0xaa, 0x05, 0x0a, 0x06, 0x4d, 0x69, 0x6e, 0x69, 0x6e, 0x67,
+	0x12, 0x36, 0x0a, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x1a, 0x13, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x56, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x4e, 0x0a, 0x0e, 0x4f, 0x6e, 0x50, 0x65,
+	0x6e, 0x64, 0x69, 0x6e, 0x67, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x1d, 0x2e, 0x74, 0x78, 0x70,
+	0x6f, 0x6f, 0x6c, 0x2e, 0x4f, 0x6e, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x74, 0x78, 0x70, 0x6f,
+	0x6f, 0x6c, 0x2e, 0x4f, 0x6e, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x30, 0x01, 0x12, 0x48, 0x0a, 0x0c, 0x4f, 0x6e, 0x4d, 0x69,
+	0x6e, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x1b, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f,
+	0x6c, 0x2e, 0x4f, 0x6e, 0x4d, 0x69, 0x6e, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x4f,
+	0x6e, 0x4d, 0x69, 0x6e, 0x65, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x30, 0x01, 0x12, 0x4b, 0x0a, 0x0d, 0x4f, 0x6e, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x4c,
+	0x6f, 0x67, 0x73, 0x12, 0x1c, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x4f, 0x6e, 0x50,
+	0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1a, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x4f, 0x6e, 0x50, 0x65, 0x6e,
+	0x64, 0x69, 0x6e, 0x67, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x30, 0x01, 0x12,
+	0x37, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x12, 0x16, 0x2e, 0x74, 0x78, 0x70,
+	0x6f, 0x6f, 0x6c, 0x2e, 0x47, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x14, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x47, 0x65, 0x74, 0x57,
+	0x6f, 0x72, 0x6b, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x40, 0x0a, 0x0a, 0x53, 0x75, 0x62, 0x6d,
+	0x69, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x12, 0x19, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e,
+	0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x57, 0x6f, 0x72, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x17, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x53, 0x75, 0x62, 0x6d, 0x69,
+	0x74, 0x57, 0x6f, 0x72, 0x6b, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x4c, 0x0a, 0x0e, 0x53, 0x75,
+	0x62, 0x6d, 0x69, 0x74, 0x48, 0x61, 0x73, 0x68, 0x52, 0x61, 0x74, 0x65, 0x12, 0x1d, 0x2e, 0x74,
+	0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x48, 0x61, 0x73, 0x68,
+	0x52, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x74, 0x78,
+	0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x48, 0x61, 0x73, 0x68, 0x52,
+	0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x3a, 0x0a, 0x08, 0x48, 0x61, 0x73, 0x68,
+	0x52, 0x61, 0x74, 0x65, 0x12, 0x17, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x48, 0x61,
+	0x73, 0x68, 0x52, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e,
+	0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x52, 0x61, 0x74, 0x65, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x12, 0x34, 0x0a, 0x06, 0x4d, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x12, 0x15,
+	0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x4d, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x4d,
+	0x69, 0x6e, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x46, 0x0a, 0x0c, 0x50, 0x72,
+	0x65, 0x76, 0x69, 0x65, 0x77, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x1b, 0x2e, 0x74, 0x78, 0x70,
+	0x6f, 0x6f, 0x6c, 0x2e, 0x50, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c,
+	0x2e, 0x50, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x70,
 	0x6c, 0x79, 0x42, 0x16, 0x5a, 0x14, 0x2e, 0x2f, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x3b, 0x74,
 	0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x33,
@@ -887,51 +1268,70 @@ func file_txpool_mining_proto_rawDescGZIP() []byte {
 	return file_txpool_mining_proto_rawDescData
 }
 
-var file_txpool_mining_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_txpool_mining_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
 var file_txpool_mining_proto_goTypes = []any{
-	(*OnPendingBlockRequest)(nil),   // 0: txpool.OnPendingBlockRequest
-	(*OnPendingBlockReply)(nil),     // 1: txpool.OnPendingBlockReply
-	(*OnMinedBlockRequest)(nil),     // 2: txpool.OnMinedBlockRequest
-	(*OnMinedBlockReply)(nil),       // 3: txpool.OnMinedBlockReply
-	(*OnPendingLogsRequest)(nil),    // 4: txpool.OnPendingLogsRequest
-	(*OnPendingLogsReply)(nil),      // 5: txpool.OnPendingLogsReply
-	(*GetWorkRequest)(nil),          // 6: txpool.GetWorkRequest
-	(*GetWorkReply)(nil),            // 7: txpool.GetWorkReply
-	(*SubmitWorkRequest)(nil),       // 8: txpool.SubmitWorkRequest
-	(*SubmitWorkReply)(nil),         // 9: txpool.SubmitWorkReply
-	(*SubmitHashRateRequest)(nil),   // 10: txpool.SubmitHashRateRequest
-	(*SubmitHashRateReply)(nil),     // 11: txpool.SubmitHashRateReply
-	(*HashRateRequest)(nil),         // 12: txpool.HashRateRequest
-	(*HashRateReply)(nil),           // 13: txpool.HashRateReply
-	(*MiningRequest)(nil),           // 14: txpool.MiningRequest
-	(*MiningReply)(nil),             // 15: txpool.MiningReply
-	(*emptypb.Empty)(nil),           // 16: google.protobuf.Empty
-	(*typesproto.VersionReply)(nil), // 17: types.VersionReply
+	(*OnPendingBlockRequest)(nil),     // 0: txpool.OnPendingBlockRequest
+	(*OnPendingBlockReply)(nil),       // 1: txpool.OnPendingBlockReply
+	(*OnMinedBlockRequest)(nil),       // 2: txpool.OnMinedBlockRequest
+	(*OnMinedBlockReply)(nil),         // 3: txpool.OnMinedBlockReply
+	(*OnPendingLogsRequest)(nil),      // 4: txpool.OnPendingLogsRequest
+	(*OnPendingLogsReply)(nil),        // 5: txpool.OnPendingLogsReply
+	(*GetWorkRequest)(nil),            // 6: txpool.GetWorkRequest
+	(*GetWorkReply)(nil),              // 7: txpool.GetWorkReply
+	(*SubmitWorkRequest)(nil),         // 8: txpool.SubmitWorkRequest
+	(*SubmitWorkReply)(nil),           // 9: txpool.SubmitWorkReply
+	(*SubmitHashRateRequest)(nil),     // 10: txpool.SubmitHashRateRequest
+	(*SubmitHashRateReply)(nil),       // 11: txpool.SubmitHashRateReply
+	(*HashRateRequest)(nil),           // 12: txpool.HashRateRequest
+	(*HashRateReply)(nil),             // 13: txpool.HashRateReply
+	(*MiningRequest)(nil),             // 14: txpool.MiningRequest
+	(*MiningReply)(nil),               // 15: txpool.MiningReply
+	(*PreviewBlockRequest)(nil),       // 16: txpool.PreviewBlockRequest
+	(*PreviewBlockReply)(nil),         // 17: txpool.PreviewBlockReply
+	(*PreviewBlockReply_Txn)(nil),     // 18: txpool.PreviewBlockReply.Txn
+	(*PreviewBlockReply_Skipped)(nil), // 19: txpool.PreviewBlockReply.Skipped
+	(*typesproto.H160)(nil),           // 20: types.H160
+	(*typesproto.H256)(nil),           // 21: types.H256
+	(*emptypb.Empty)(nil),             // 22: google.protobuf.Empty
+	(*typesproto.VersionReply)(nil),   // 23: types.VersionReply
 }
 var file_txpool_mining_proto_depIdxs = []int32{
-	16, // 0: txpool.Mining.Version:input_type -> google.protobuf.Empty
-	0,  // 1: txpool.Mining.OnPendingBlock:input_type -> txpool.OnPendingBlockRequest
-	2,  // 2: txpool.Mining.OnMinedBlock:input_type -> txpool.OnMinedBlockRequest
-	4,  // 3: txpool.Mining.OnPendingLogs:input_type -> txpool.OnPendingLogsRequest
-	6,  // 4: txpool.Mining.GetWork:input_type -> txpool.GetWorkRequest
-	8,  // 5: txpool.Mining.SubmitWork:input_type -> txpool.SubmitWorkRequest
-	10, // 6: txpool.Mining.SubmitHashRate:input_type -> txpool.SubmitHashRateRequest
-	12, // 7: txpool.Mining.HashRate:input_type -> txpool.HashRateRequest
-	14, // 8: txpool.Mining.Mining:input_type -> txpool.MiningRequest
-	17, // 9: txpool.Mining.Version:output_type -> types.VersionReply
-	1,  // 10: txpool.Mining.OnPendingBlock:output_type -> txpool.OnPendingBlockReply
-	3,  // 11: txpool.Mining.OnMinedBlock:output_type -> txpool.OnMinedBlockReply
-	5,  // 12: txpool.Mining.OnPendingLogs:output_type -> txpool.OnPendingLogsReply
-	7,  // 13: txpool.Mining.GetWork:output_type -> txpool.GetWorkReply
-	9,  // 14: txpool.Mining.SubmitWork:output_type -> txpool.SubmitWorkReply
-	11, // 15: txpool.Mining.SubmitHashRate:output_type -> txpool.SubmitHashRateReply
-	13, // 16: txpool.Mining.HashRate:output_type -> txpool.HashRateReply
-	15, // 17: txpool.Mining.Mining:output_type -> txpool.MiningReply
-	9,  // [9:18] is the sub-list for method output_type
-	0,  // [0:9] is the sub-list for method input_type
-	0,  // [0:0] is the sub-list for extension type_name
-	0,  // [0:0] is the sub-list for extension extendee
-	0,  // [0:0] is the sub-list for field type_name
+	20, // 0: txpool.PreviewBlockRequest.fee_recipient:type_name -> types.H160
+	21, // 1: txpool.PreviewBlockReply.parent_hash:type_name -> types.H256
+	21, // 2: txpool.PreviewBlockReply.base_fee:type_name -> types.H256
+	21, // 3: txpool.PreviewBlockReply.priority_fees:type_name -> types.H256
+	18, // 4: txpool.PreviewBlockReply.txs:type_name -> txpool.PreviewBlockReply.Txn
+	19, // 5: txpool.PreviewBlockReply.skipped:type_name -> txpool.PreviewBlockReply.Skipped
+	21, // 6: txpool.PreviewBlockReply.Txn.hash:type_name -> types.H256
+	20, // 7: txpool.PreviewBlockReply.Txn.sender:type_name -> types.H160
+	21, // 8: txpool.PreviewBlockReply.Txn.priority_fee:type_name -> types.H256
+	21, // 9: txpool.PreviewBlockReply.Skipped.hash:type_name -> types.H256
+	20, // 10: txpool.PreviewBlockReply.Skipped.sender:type_name -> types.H160
+	22, // 11: txpool.Mining.Version:input_type -> google.protobuf.Empty
+	0,  // 12: txpool.Mining.OnPendingBlock:input_type -> txpool.OnPendingBlockRequest
+	2,  // 13: txpool.Mining.OnMinedBlock:input_type -> txpool.OnMinedBlockRequest
+	4,  // 14: txpool.Mining.OnPendingLogs:input_type -> txpool.OnPendingLogsRequest
+	6,  // 15: txpool.Mining.GetWork:input_type -> txpool.GetWorkRequest
+	8,  // 16: txpool.Mining.SubmitWork:input_type -> txpool.SubmitWorkRequest
+	10, // 17: txpool.Mining.SubmitHashRate:input_type -> txpool.SubmitHashRateRequest
+	12, // 18: txpool.Mining.HashRate:input_type -> txpool.HashRateRequest
+	14, // 19: txpool.Mining.Mining:input_type -> txpool.MiningRequest
+	16, // 20: txpool.Mining.PreviewBlock:input_type -> txpool.PreviewBlockRequest
+	23, // 21: txpool.Mining.Version:output_type -> types.VersionReply
+	1,  // 22: txpool.Mining.OnPendingBlock:output_type -> txpool.OnPendingBlockReply
+	3,  // 23: txpool.Mining.OnMinedBlock:output_type -> txpool.OnMinedBlockReply
+	5,  // 24: txpool.Mining.OnPendingLogs:output_type -> txpool.OnPendingLogsReply
+	7,  // 25: txpool.Mining.GetWork:output_type -> txpool.GetWorkReply
+	9,  // 26: txpool.Mining.SubmitWork:output_type -> txpool.SubmitWorkReply
+	11, // 27: txpool.Mining.SubmitHashRate:output_type -> txpool.SubmitHashRateReply
+	13, // 28: txpool.Mining.HashRate:output_type -> txpool.HashRateReply
+	15, // 29: txpool.Mining.Mining:output_type -> txpool.MiningReply
+	17, // 30: txpool.Mining.PreviewBlock:output_type -> txpool.PreviewBlockReply
+	21, // [21:31] is the sub-list for method output_type
+	11, // [11:21] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
 }
 
 func init() { file_txpool_mining_proto_init() }
@@ -1132,6 +1532,54 @@ func file_txpool_mining_proto_init() {
 				return nil
 			}
 		}
+		file_txpool_mining_proto_msgTypes[16].Exporter = func(v any, i int) any {
+			switch v := v.(*PreviewBlockRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_txpool_mining_proto_msgTypes[17].Exporter = func(v any, i int) any {
+			switch v := v.(*PreviewBlockReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_txpool_mining_proto_msgTypes[18].Exporter = func(v any, i int) any {
+			switch v := v.(*PreviewBlockReply_Txn); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_txpool_mining_proto_msgTypes[19].Exporter = func(v any, i int) any {
+			switch v := v.(*PreviewBlockReply_Skipped); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1139,7 +1587,7 @@ func file_txpool_mining_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_txpool_mining_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   16,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
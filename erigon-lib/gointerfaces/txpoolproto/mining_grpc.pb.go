@@ -30,6 +30,7 @@ const (
 	Mining_SubmitHashRate_FullMethodName = "/txpool.Mining/SubmitHashRate"
 	Mining_HashRate_FullMethodName       = "/txpool.Mining/HashRate"
 	Mining_Mining_FullMethodName         = "/txpool.Mining/Mining"
+	Mining_PreviewBlock_FullMethodName   = "/txpool.Mining/PreviewBlock"
 )
 
 // MiningClient is the client API for Mining service.
@@ -68,6 +69,8 @@ type MiningClient interface {
 	HashRate(ctx context.Context, in *HashRateRequest, opts ...grpc.CallOption) (*HashRateReply, error)
 	// Mining returns an indication if this node is currently mining and its mining configuration
 	Mining(ctx context.Context, in *MiningRequest, opts ...grpc.CallOption) (*MiningReply, error)
+	// PreviewBlock builds the block this node would produce on top of its head, without sealing or announcing it
+	PreviewBlock(ctx context.Context, in *PreviewBlockRequest, opts ...grpc.CallOption) (*PreviewBlockReply, error)
 }
 
 type miningClient struct {
@@ -237,6 +240,16 @@ func (c *miningClient) Mining(ctx context.Context, in *MiningRequest, opts ...gr
 	return out, nil
 }
 
+func (c *miningClient) PreviewBlock(ctx context.Context, in *PreviewBlockRequest, opts ...grpc.CallOption) (*PreviewBlockReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PreviewBlockReply)
+	err := c.cc.Invoke(ctx, Mining_PreviewBlock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MiningServer is the server API for Mining service.
 // All implementations must embed UnimplementedMiningServer
 // for forward compatibility
@@ -273,6 +286,8 @@ type MiningServer interface {
 	HashRate(context.Context, *HashRateRequest) (*HashRateReply, error)
 	// Mining returns an indication if this node is currently mining and its mining configuration
 	Mining(context.Context, *MiningRequest) (*MiningReply, error)
+	// PreviewBlock builds the block this node would produce on top of its head, without sealing or announcing it
+	PreviewBlock(context.Context, *PreviewBlockRequest) (*PreviewBlockReply, error)
 	mustEmbedUnimplementedMiningServer()
 }
 
@@ -307,6 +322,9 @@ func (UnimplementedMiningServer) HashRate(context.Context, *HashRateRequest) (*H
 func (UnimplementedMiningServer) Mining(context.Context, *MiningRequest) (*MiningReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Mining not implemented")
 }
+func (UnimplementedMiningServer) PreviewBlock(context.Context, *PreviewBlockRequest) (*PreviewBlockReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreviewBlock not implemented")
+}
 func (UnimplementedMiningServer) mustEmbedUnimplementedMiningServer() {}
 
 // UnsafeMiningServer may be embedded to opt out of forward compatibility for this service.
@@ -491,6 +509,24 @@ func _Mining_Mining_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Mining_PreviewBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreviewBlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiningServer).PreviewBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Mining_PreviewBlock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiningServer).PreviewBlock(ctx, req.(*PreviewBlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Mining_ServiceDesc is the grpc.ServiceDesc for Mining service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -522,6 +558,10 @@ var Mining_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Mining",
 			Handler:    _Mining_Mining_Handler,
 		},
+		{
+			MethodName: "PreviewBlock",
+			Handler:    _Mining_PreviewBlock_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -690,31 +690,801 @@ func (x *StatusReply) GetBaseFeeCount() uint32 {
 	return 0
 }
 
+type InspectRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *InspectRequest) Reset() {
+	*x = InspectRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_txpool_txpool_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InspectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InspectRequest) ProtoMessage() {}
+
+func (x *InspectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_txpool_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InspectRequest.ProtoReflect.Descriptor instead.
+func (*InspectRequest) Descriptor() ([]byte, []int) {
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{12}
+}
+
+type InspectReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Txs       []*InspectReply_Tx        `protobuf:"bytes,1,rep,name=txs,proto3" json:"txs,omitempty"`
+	Senders   []*InspectReply_Sender    `protobuf:"bytes,2,rep,name=senders,proto3" json:"senders,omitempty"`
+	Discarded []*InspectReply_Discarded `protobuf:"bytes,3,rep,name=discarded,proto3" json:"discarded,omitempty"` // recently discarded transactions
+}
+
+func (x *InspectReply) Reset() {
+	*x = InspectReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_txpool_txpool_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InspectReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InspectReply) ProtoMessage() {}
+
+func (x *InspectReply) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_txpool_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InspectReply.ProtoReflect.Descriptor instead.
+func (*InspectReply) Descriptor() ([]byte, []int) {
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *InspectReply) GetTxs() []*InspectReply_Tx {
+	if x != nil {
+		return x.Txs
+	}
+	return nil
+}
+
+func (x *InspectReply) GetSenders() []*InspectReply_Sender {
+	if x != nil {
+		return x.Senders
+	}
+	return nil
+}
+
+func (x *InspectReply) GetDiscarded() []*InspectReply_Discarded {
+	if x != nil {
+		return x.Discarded
+	}
+	return nil
+}
+
 type NonceRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Address *typesproto.H160 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Address *typesproto.H160 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+}
+
+func (x *NonceRequest) Reset() {
+	*x = NonceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_txpool_txpool_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NonceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NonceRequest) ProtoMessage() {}
+
+func (x *NonceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_txpool_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NonceRequest.ProtoReflect.Descriptor instead.
+func (*NonceRequest) Descriptor() ([]byte, []int) {
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *NonceRequest) GetAddress() *typesproto.H160 {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+type NonceReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Found bool   `protobuf:"varint,1,opt,name=found,proto3" json:"found,omitempty"`
+	Nonce uint64 `protobuf:"varint,2,opt,name=nonce,proto3" json:"nonce,omitempty"`
+}
+
+func (x *NonceReply) Reset() {
+	*x = NonceReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_txpool_txpool_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NonceReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NonceReply) ProtoMessage() {}
+
+func (x *NonceReply) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_txpool_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NonceReply.ProtoReflect.Descriptor instead.
+func (*NonceReply) Descriptor() ([]byte, []int) {
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *NonceReply) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+func (x *NonceReply) GetNonce() uint64 {
+	if x != nil {
+		return x.Nonce
+	}
+	return 0
+}
+
+type AddPrivateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RlpTxs         [][]byte `protobuf:"bytes,1,rep,name=rlp_txs,json=rlpTxs,proto3" json:"rlp_txs,omitempty"`
+	MaxBlockNumber uint64   `protobuf:"varint,2,opt,name=max_block_number,json=maxBlockNumber,proto3" json:"max_block_number,omitempty"` // transactions are dropped once this block is mined, 0 for the default lifetime
+}
+
+func (x *AddPrivateRequest) Reset() {
+	*x = AddPrivateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_txpool_txpool_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddPrivateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddPrivateRequest) ProtoMessage() {}
+
+func (x *AddPrivateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_txpool_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddPrivateRequest.ProtoReflect.Descriptor instead.
+func (*AddPrivateRequest) Descriptor() ([]byte, []int) {
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *AddPrivateRequest) GetRlpTxs() [][]byte {
+	if x != nil {
+		return x.RlpTxs
+	}
+	return nil
+}
+
+func (x *AddPrivateRequest) GetMaxBlockNumber() uint64 {
+	if x != nil {
+		return x.MaxBlockNumber
+	}
+	return 0
+}
+
+type AddBundleRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RlpTxs         [][]byte `protobuf:"bytes,1,rep,name=rlp_txs,json=rlpTxs,proto3" json:"rlp_txs,omitempty"`                            // included all together and in order, or not at all
+	BlockNumber    uint64   `protobuf:"varint,2,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`            // first block the bundle may be included into
+	MaxBlockNumber uint64   `protobuf:"varint,3,opt,name=max_block_number,json=maxBlockNumber,proto3" json:"max_block_number,omitempty"` // last block the bundle may be included into, 0 means block_number
+}
+
+func (x *AddBundleRequest) Reset() {
+	*x = AddBundleRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_txpool_txpool_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddBundleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddBundleRequest) ProtoMessage() {}
+
+func (x *AddBundleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_txpool_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddBundleRequest.ProtoReflect.Descriptor instead.
+func (*AddBundleRequest) Descriptor() ([]byte, []int) {
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *AddBundleRequest) GetRlpTxs() [][]byte {
+	if x != nil {
+		return x.RlpTxs
+	}
+	return nil
+}
+
+func (x *AddBundleRequest) GetBlockNumber() uint64 {
+	if x != nil {
+		return x.BlockNumber
+	}
+	return 0
+}
+
+func (x *AddBundleRequest) GetMaxBlockNumber() uint64 {
+	if x != nil {
+		return x.MaxBlockNumber
+	}
+	return 0
+}
+
+type AddBundleReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BundleHash *typesproto.H256 `protobuf:"bytes,1,opt,name=bundle_hash,json=bundleHash,proto3" json:"bundle_hash,omitempty"`
+}
+
+func (x *AddBundleReply) Reset() {
+	*x = AddBundleReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_txpool_txpool_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddBundleReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddBundleReply) ProtoMessage() {}
+
+func (x *AddBundleReply) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_txpool_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddBundleReply.ProtoReflect.Descriptor instead.
+func (*AddBundleReply) Descriptor() ([]byte, []int) {
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *AddBundleReply) GetBundleHash() *typesproto.H256 {
+	if x != nil {
+		return x.BundleHash
+	}
+	return nil
+}
+
+type GetBlobsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlobHashes []*typesproto.H256 `protobuf:"bytes,1,rep,name=blob_hashes,json=blobHashes,proto3" json:"blob_hashes,omitempty"` // versioned hashes
+}
+
+func (x *GetBlobsRequest) Reset() {
+	*x = GetBlobsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_txpool_txpool_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetBlobsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBlobsRequest) ProtoMessage() {}
+
+func (x *GetBlobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_txpool_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBlobsRequest.ProtoReflect.Descriptor instead.
+func (*GetBlobsRequest) Descriptor() ([]byte, []int) {
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetBlobsRequest) GetBlobHashes() []*typesproto.H256 {
+	if x != nil {
+		return x.BlobHashes
+	}
+	return nil
+}
+
+type GetBlobsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlobsAndProofs []*GetBlobsReply_BlobAndProof `protobuf:"bytes,1,rep,name=blobs_and_proofs,json=blobsAndProofs,proto3" json:"blobs_and_proofs,omitempty"` // empty if the blob is unknown
+}
+
+func (x *GetBlobsReply) Reset() {
+	*x = GetBlobsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_txpool_txpool_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetBlobsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBlobsReply) ProtoMessage() {}
+
+func (x *GetBlobsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_txpool_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBlobsReply.ProtoReflect.Descriptor instead.
+func (*GetBlobsReply) Descriptor() ([]byte, []int) {
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetBlobsReply) GetBlobsAndProofs() []*GetBlobsReply_BlobAndProof {
+	if x != nil {
+		return x.BlobsAndProofs
+	}
+	return nil
+}
+
+type UpdateSenderListRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Deny   bool               `protobuf:"varint,1,opt,name=deny,proto3" json:"deny,omitempty"` // update the deny list, otherwise the allow list
+	Add    []*typesproto.H160 `protobuf:"bytes,2,rep,name=add,proto3" json:"add,omitempty"`
+	Remove []*typesproto.H160 `protobuf:"bytes,3,rep,name=remove,proto3" json:"remove,omitempty"`
+}
+
+func (x *UpdateSenderListRequest) Reset() {
+	*x = UpdateSenderListRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_txpool_txpool_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateSenderListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSenderListRequest) ProtoMessage() {}
+
+func (x *UpdateSenderListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_txpool_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSenderListRequest.ProtoReflect.Descriptor instead.
+func (*UpdateSenderListRequest) Descriptor() ([]byte, []int) {
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *UpdateSenderListRequest) GetDeny() bool {
+	if x != nil {
+		return x.Deny
+	}
+	return false
+}
+
+func (x *UpdateSenderListRequest) GetAdd() []*typesproto.H160 {
+	if x != nil {
+		return x.Add
+	}
+	return nil
+}
+
+func (x *UpdateSenderListRequest) GetRemove() []*typesproto.H160 {
+	if x != nil {
+		return x.Remove
+	}
+	return nil
+}
+
+type UpdateSenderListReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Size uint64 `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"` // senders on the list after the update
+}
+
+func (x *UpdateSenderListReply) Reset() {
+	*x = UpdateSenderListReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_txpool_txpool_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateSenderListReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSenderListReply) ProtoMessage() {}
+
+func (x *UpdateSenderListReply) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_txpool_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSenderListReply.ProtoReflect.Descriptor instead.
+func (*UpdateSenderListReply) Descriptor() ([]byte, []int) {
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *UpdateSenderListReply) GetSize() uint64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type AllReply_Tx struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TxnType AllReply_TxnType `protobuf:"varint,1,opt,name=txn_type,json=txnType,proto3,enum=txpool.AllReply_TxnType" json:"txn_type,omitempty"`
+	Sender  *typesproto.H160 `protobuf:"bytes,2,opt,name=sender,proto3" json:"sender,omitempty"`
+	RlpTx   []byte           `protobuf:"bytes,3,opt,name=rlp_tx,json=rlpTx,proto3" json:"rlp_tx,omitempty"`
+}
+
+func (x *AllReply_Tx) Reset() {
+	*x = AllReply_Tx{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_txpool_txpool_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AllReply_Tx) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AllReply_Tx) ProtoMessage() {}
+
+func (x *AllReply_Tx) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_txpool_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AllReply_Tx.ProtoReflect.Descriptor instead.
+func (*AllReply_Tx) Descriptor() ([]byte, []int) {
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{8, 0}
+}
+
+func (x *AllReply_Tx) GetTxnType() AllReply_TxnType {
+	if x != nil {
+		return x.TxnType
+	}
+	return AllReply_PENDING
+}
+
+func (x *AllReply_Tx) GetSender() *typesproto.H160 {
+	if x != nil {
+		return x.Sender
+	}
+	return nil
+}
+
+func (x *AllReply_Tx) GetRlpTx() []byte {
+	if x != nil {
+		return x.RlpTx
+	}
+	return nil
+}
+
+type PendingReply_Tx struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sender  *typesproto.H160 `protobuf:"bytes,1,opt,name=sender,proto3" json:"sender,omitempty"`
+	RlpTx   []byte           `protobuf:"bytes,2,opt,name=rlp_tx,json=rlpTx,proto3" json:"rlp_tx,omitempty"`
+	IsLocal bool             `protobuf:"varint,3,opt,name=is_local,json=isLocal,proto3" json:"is_local,omitempty"`
+}
+
+func (x *PendingReply_Tx) Reset() {
+	*x = PendingReply_Tx{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_txpool_txpool_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PendingReply_Tx) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PendingReply_Tx) ProtoMessage() {}
+
+func (x *PendingReply_Tx) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_txpool_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PendingReply_Tx.ProtoReflect.Descriptor instead.
+func (*PendingReply_Tx) Descriptor() ([]byte, []int) {
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{9, 0}
+}
+
+func (x *PendingReply_Tx) GetSender() *typesproto.H160 {
+	if x != nil {
+		return x.Sender
+	}
+	return nil
+}
+
+func (x *PendingReply_Tx) GetRlpTx() []byte {
+	if x != nil {
+		return x.RlpTx
+	}
+	return nil
+}
+
+func (x *PendingReply_Tx) GetIsLocal() bool {
+	if x != nil {
+		return x.IsLocal
+	}
+	return false
+}
+
+type InspectReply_Tx struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TxnType       AllReply_TxnType `protobuf:"varint,1,opt,name=txn_type,json=txnType,proto3,enum=txpool.AllReply_TxnType" json:"txn_type,omitempty"`
+	Sender        *typesproto.H160 `protobuf:"bytes,2,opt,name=sender,proto3" json:"sender,omitempty"`
+	RlpTx         []byte           `protobuf:"bytes,3,opt,name=rlp_tx,json=rlpTx,proto3" json:"rlp_tx,omitempty"`
+	SubPoolMarker uint32           `protobuf:"varint,4,opt,name=sub_pool_marker,json=subPoolMarker,proto3" json:"sub_pool_marker,omitempty"` // bitset used to sort transactions into sub-pools
+}
+
+func (x *InspectReply_Tx) Reset() {
+	*x = InspectReply_Tx{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_txpool_txpool_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InspectReply_Tx) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InspectReply_Tx) ProtoMessage() {}
+
+func (x *InspectReply_Tx) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_txpool_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InspectReply_Tx.ProtoReflect.Descriptor instead.
+func (*InspectReply_Tx) Descriptor() ([]byte, []int) {
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{13, 0}
+}
+
+func (x *InspectReply_Tx) GetTxnType() AllReply_TxnType {
+	if x != nil {
+		return x.TxnType
+	}
+	return AllReply_PENDING
+}
+
+func (x *InspectReply_Tx) GetSender() *typesproto.H160 {
+	if x != nil {
+		return x.Sender
+	}
+	return nil
+}
+
+func (x *InspectReply_Tx) GetRlpTx() []byte {
+	if x != nil {
+		return x.RlpTx
+	}
+	return nil
+}
+
+func (x *InspectReply_Tx) GetSubPoolMarker() uint32 {
+	if x != nil {
+		return x.SubPoolMarker
+	}
+	return 0
+}
+
+type InspectReply_NonceGap struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	From uint64 `protobuf:"varint,1,opt,name=from,proto3" json:"from,omitempty"` // first missing nonce
+	To   uint64 `protobuf:"varint,2,opt,name=to,proto3" json:"to,omitempty"`     // last missing nonce
 }
 
-func (x *NonceRequest) Reset() {
-	*x = NonceRequest{}
+func (x *InspectReply_NonceGap) Reset() {
+	*x = InspectReply_NonceGap{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_txpool_txpool_proto_msgTypes[12]
+		mi := &file_txpool_txpool_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *NonceRequest) String() string {
+func (x *InspectReply_NonceGap) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*NonceRequest) ProtoMessage() {}
+func (*InspectReply_NonceGap) ProtoMessage() {}
 
-func (x *NonceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_txpool_txpool_proto_msgTypes[12]
+func (x *InspectReply_NonceGap) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_txpool_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -725,44 +1495,52 @@ func (x *NonceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use NonceRequest.ProtoReflect.Descriptor instead.
-func (*NonceRequest) Descriptor() ([]byte, []int) {
-	return file_txpool_txpool_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use InspectReply_NonceGap.ProtoReflect.Descriptor instead.
+func (*InspectReply_NonceGap) Descriptor() ([]byte, []int) {
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{13, 1}
 }
 
-func (x *NonceRequest) GetAddress() *typesproto.H160 {
+func (x *InspectReply_NonceGap) GetFrom() uint64 {
 	if x != nil {
-		return x.Address
+		return x.From
 	}
-	return nil
+	return 0
 }
 
-type NonceReply struct {
+func (x *InspectReply_NonceGap) GetTo() uint64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+type InspectReply_Sender struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Found bool   `protobuf:"varint,1,opt,name=found,proto3" json:"found,omitempty"`
-	Nonce uint64 `protobuf:"varint,2,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	Address    *typesproto.H160         `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	StateNonce uint64                   `protobuf:"varint,2,opt,name=state_nonce,json=stateNonce,proto3" json:"state_nonce,omitempty"`
+	NonceGaps  []*InspectReply_NonceGap `protobuf:"bytes,3,rep,name=nonce_gaps,json=nonceGaps,proto3" json:"nonce_gaps,omitempty"` // gaps between the state nonce and the highest nonce of the sender in the pool
 }
 
-func (x *NonceReply) Reset() {
-	*x = NonceReply{}
+func (x *InspectReply_Sender) Reset() {
+	*x = InspectReply_Sender{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_txpool_txpool_proto_msgTypes[13]
+		mi := &file_txpool_txpool_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *NonceReply) String() string {
+func (x *InspectReply_Sender) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*NonceReply) ProtoMessage() {}
+func (*InspectReply_Sender) ProtoMessage() {}
 
-func (x *NonceReply) ProtoReflect() protoreflect.Message {
-	mi := &file_txpool_txpool_proto_msgTypes[13]
+func (x *InspectReply_Sender) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_txpool_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -773,52 +1551,58 @@ func (x *NonceReply) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use NonceReply.ProtoReflect.Descriptor instead.
-func (*NonceReply) Descriptor() ([]byte, []int) {
-	return file_txpool_txpool_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use InspectReply_Sender.ProtoReflect.Descriptor instead.
+func (*InspectReply_Sender) Descriptor() ([]byte, []int) {
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{13, 2}
 }
 
-func (x *NonceReply) GetFound() bool {
+func (x *InspectReply_Sender) GetAddress() *typesproto.H160 {
 	if x != nil {
-		return x.Found
+		return x.Address
 	}
-	return false
+	return nil
 }
 
-func (x *NonceReply) GetNonce() uint64 {
+func (x *InspectReply_Sender) GetStateNonce() uint64 {
 	if x != nil {
-		return x.Nonce
+		return x.StateNonce
 	}
 	return 0
 }
 
-type AllReply_Tx struct {
+func (x *InspectReply_Sender) GetNonceGaps() []*InspectReply_NonceGap {
+	if x != nil {
+		return x.NonceGaps
+	}
+	return nil
+}
+
+type InspectReply_Discarded struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TxnType AllReply_TxnType `protobuf:"varint,1,opt,name=txn_type,json=txnType,proto3,enum=txpool.AllReply_TxnType" json:"txn_type,omitempty"`
-	Sender  *typesproto.H160 `protobuf:"bytes,2,opt,name=sender,proto3" json:"sender,omitempty"`
-	RlpTx   []byte           `protobuf:"bytes,3,opt,name=rlp_tx,json=rlpTx,proto3" json:"rlp_tx,omitempty"`
+	Hash   *typesproto.H256 `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Reason string           `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
 }
 
-func (x *AllReply_Tx) Reset() {
-	*x = AllReply_Tx{}
+func (x *InspectReply_Discarded) Reset() {
+	*x = InspectReply_Discarded{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_txpool_txpool_proto_msgTypes[14]
+		mi := &file_txpool_txpool_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *AllReply_Tx) String() string {
+func (x *InspectReply_Discarded) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AllReply_Tx) ProtoMessage() {}
+func (*InspectReply_Discarded) ProtoMessage() {}
 
-func (x *AllReply_Tx) ProtoReflect() protoreflect.Message {
-	mi := &file_txpool_txpool_proto_msgTypes[14]
+func (x *InspectReply_Discarded) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_txpool_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -829,59 +1613,52 @@ func (x *AllReply_Tx) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AllReply_Tx.ProtoReflect.Descriptor instead.
-func (*AllReply_Tx) Descriptor() ([]byte, []int) {
-	return file_txpool_txpool_proto_rawDescGZIP(), []int{8, 0}
-}
-
-func (x *AllReply_Tx) GetTxnType() AllReply_TxnType {
-	if x != nil {
-		return x.TxnType
-	}
-	return AllReply_PENDING
+// Deprecated: Use InspectReply_Discarded.ProtoReflect.Descriptor instead.
+func (*InspectReply_Discarded) Descriptor() ([]byte, []int) {
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{13, 3}
 }
 
-func (x *AllReply_Tx) GetSender() *typesproto.H160 {
+func (x *InspectReply_Discarded) GetHash() *typesproto.H256 {
 	if x != nil {
-		return x.Sender
+		return x.Hash
 	}
 	return nil
 }
 
-func (x *AllReply_Tx) GetRlpTx() []byte {
+func (x *InspectReply_Discarded) GetReason() string {
 	if x != nil {
-		return x.RlpTx
+		return x.Reason
 	}
-	return nil
+	return ""
 }
 
-type PendingReply_Tx struct {
+type GetBlobsReply_BlobAndProof struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Sender  *typesproto.H160 `protobuf:"bytes,1,opt,name=sender,proto3" json:"sender,omitempty"`
-	RlpTx   []byte           `protobuf:"bytes,2,opt,name=rlp_tx,json=rlpTx,proto3" json:"rlp_tx,omitempty"`
-	IsLocal bool             `protobuf:"varint,3,opt,name=is_local,json=isLocal,proto3" json:"is_local,omitempty"`
+	Blob       []byte `protobuf:"bytes,1,opt,name=blob,proto3" json:"blob,omitempty"`
+	Commitment []byte `protobuf:"bytes,2,opt,name=commitment,proto3" json:"commitment,omitempty"`
+	Proof      []byte `protobuf:"bytes,3,opt,name=proof,proto3" json:"proof,omitempty"`
 }
 
-func (x *PendingReply_Tx) Reset() {
-	*x = PendingReply_Tx{}
+func (x *GetBlobsReply_BlobAndProof) Reset() {
+	*x = GetBlobsReply_BlobAndProof{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_txpool_txpool_proto_msgTypes[15]
+		mi := &file_txpool_txpool_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *PendingReply_Tx) String() string {
+func (x *GetBlobsReply_BlobAndProof) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PendingReply_Tx) ProtoMessage() {}
+func (*GetBlobsReply_BlobAndProof) ProtoMessage() {}
 
-func (x *PendingReply_Tx) ProtoReflect() protoreflect.Message {
-	mi := &file_txpool_txpool_proto_msgTypes[15]
+func (x *GetBlobsReply_BlobAndProof) ProtoReflect() protoreflect.Message {
+	mi := &file_txpool_txpool_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -892,30 +1669,30 @@ func (x *PendingReply_Tx) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PendingReply_Tx.ProtoReflect.Descriptor instead.
-func (*PendingReply_Tx) Descriptor() ([]byte, []int) {
-	return file_txpool_txpool_proto_rawDescGZIP(), []int{9, 0}
+// Deprecated: Use GetBlobsReply_BlobAndProof.ProtoReflect.Descriptor instead.
+func (*GetBlobsReply_BlobAndProof) Descriptor() ([]byte, []int) {
+	return file_txpool_txpool_proto_rawDescGZIP(), []int{20, 0}
 }
 
-func (x *PendingReply_Tx) GetSender() *typesproto.H160 {
+func (x *GetBlobsReply_BlobAndProof) GetBlob() []byte {
 	if x != nil {
-		return x.Sender
+		return x.Blob
 	}
 	return nil
 }
 
-func (x *PendingReply_Tx) GetRlpTx() []byte {
+func (x *GetBlobsReply_BlobAndProof) GetCommitment() []byte {
 	if x != nil {
-		return x.RlpTx
+		return x.Commitment
 	}
 	return nil
 }
 
-func (x *PendingReply_Tx) GetIsLocal() bool {
+func (x *GetBlobsReply_BlobAndProof) GetProof() []byte {
 	if x != nil {
-		return x.IsLocal
+		return x.Proof
 	}
-	return false
+	return nil
 }
 
 var File_txpool_txpool_proto protoreflect.FileDescriptor
@@ -979,54 +1756,156 @@ var file_txpool_txpool_proto_rawDesc = []byte{
 	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x71, 0x75, 0x65,
 	0x75, 0x65, 0x64, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x24, 0x0a, 0x0e, 0x62, 0x61, 0x73, 0x65,
 	0x5f, 0x66, 0x65, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d,
-	0x52, 0x0c, 0x62, 0x61, 0x73, 0x65, 0x46, 0x65, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x35,
-	0x0a, 0x0c, 0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x25,
-	0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x0b, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x48, 0x31, 0x36, 0x30, 0x52, 0x07, 0x61, 0x64,
-	0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0x38, 0x0a, 0x0a, 0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x52, 0x65,
-	0x70, 0x6c, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x05, 0x66, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x6e,
-	0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x2a,
-	0x6c, 0x0a, 0x0c, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12,
-	0x0b, 0x0a, 0x07, 0x53, 0x55, 0x43, 0x43, 0x45, 0x53, 0x53, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e,
-	0x41, 0x4c, 0x52, 0x45, 0x41, 0x44, 0x59, 0x5f, 0x45, 0x58, 0x49, 0x53, 0x54, 0x53, 0x10, 0x01,
-	0x12, 0x0f, 0x0a, 0x0b, 0x46, 0x45, 0x45, 0x5f, 0x54, 0x4f, 0x4f, 0x5f, 0x4c, 0x4f, 0x57, 0x10,
-	0x02, 0x12, 0x09, 0x0a, 0x05, 0x53, 0x54, 0x41, 0x4c, 0x45, 0x10, 0x03, 0x12, 0x0b, 0x0a, 0x07,
-	0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x10, 0x04, 0x12, 0x12, 0x0a, 0x0e, 0x49, 0x4e, 0x54,
-	0x45, 0x52, 0x4e, 0x41, 0x4c, 0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x05, 0x32, 0xec, 0x03,
-	0x0a, 0x06, 0x54, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x12, 0x36, 0x0a, 0x07, 0x56, 0x65, 0x72, 0x73,
-	0x69, 0x6f, 0x6e, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x13, 0x2e, 0x74, 0x79,
-	0x70, 0x65, 0x73, 0x2e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79,
-	0x12, 0x31, 0x0a, 0x0b, 0x46, 0x69, 0x6e, 0x64, 0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x12,
-	0x10, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x54, 0x78, 0x48, 0x61, 0x73, 0x68, 0x65,
-	0x73, 0x1a, 0x10, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x54, 0x78, 0x48, 0x61, 0x73,
-	0x68, 0x65, 0x73, 0x12, 0x2b, 0x0a, 0x03, 0x41, 0x64, 0x64, 0x12, 0x12, 0x2e, 0x74, 0x78, 0x70,
-	0x6f, 0x6f, 0x6c, 0x2e, 0x41, 0x64, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10,
-	0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x41, 0x64, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79,
-	0x12, 0x46, 0x0a, 0x0c, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73,
-	0x12, 0x1b, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e,
-	0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x2b, 0x0a, 0x03, 0x41, 0x6c, 0x6c, 0x12,
-	0x12, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x41, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x41, 0x6c, 0x6c,
-	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x37, 0x0a, 0x07, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67,
-	0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x14, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f,
-	0x6c, 0x2e, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x33,
-	0x0a, 0x05, 0x4f, 0x6e, 0x41, 0x64, 0x64, 0x12, 0x14, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c,
-	0x2e, 0x4f, 0x6e, 0x41, 0x64, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e,
-	0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x4f, 0x6e, 0x41, 0x64, 0x64, 0x52, 0x65, 0x70, 0x6c,
-	0x79, 0x30, 0x01, 0x12, 0x34, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x15, 0x2e,
-	0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x53, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x31, 0x0a, 0x05, 0x4e, 0x6f, 0x6e,
-	0x63, 0x65, 0x12, 0x14, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x4e, 0x6f, 0x6e, 0x63,
-	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f,
-	0x6c, 0x2e, 0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x42, 0x16, 0x5a, 0x14,
-	0x2e, 0x2f, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x3b, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x52, 0x0c, 0x62, 0x61, 0x73, 0x65, 0x46, 0x65, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x10,
+	0x0a, 0x0e, 0x49, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x22, 0xd5, 0x04, 0x0a, 0x0c, 0x49, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x12, 0x29, 0x0a, 0x03, 0x74, 0x78, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17,
+	0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x49, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x2e, 0x54, 0x78, 0x52, 0x03, 0x74, 0x78, 0x73, 0x12, 0x35, 0x0a, 0x07,
+	0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
+	0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x49, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x2e, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x52, 0x07, 0x73, 0x65, 0x6e, 0x64,
+	0x65, 0x72, 0x73, 0x12, 0x3c, 0x0a, 0x09, 0x64, 0x69, 0x73, 0x63, 0x61, 0x72, 0x64, 0x65, 0x64,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e,
+	0x49, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x44, 0x69, 0x73,
+	0x63, 0x61, 0x72, 0x64, 0x65, 0x64, 0x52, 0x09, 0x64, 0x69, 0x73, 0x63, 0x61, 0x72, 0x64, 0x65,
+	0x64, 0x1a, 0x9d, 0x01, 0x0a, 0x02, 0x54, 0x78, 0x12, 0x33, 0x0a, 0x08, 0x74, 0x78, 0x6e, 0x5f,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x74, 0x78, 0x70,
+	0x6f, 0x6f, 0x6c, 0x2e, 0x41, 0x6c, 0x6c, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x54, 0x78, 0x6e,
+	0x54, 0x79, 0x70, 0x65, 0x52, 0x07, 0x74, 0x78, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x23, 0x0a,
+	0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e,
+	0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x48, 0x31, 0x36, 0x30, 0x52, 0x06, 0x73, 0x65, 0x6e, 0x64,
+	0x65, 0x72, 0x12, 0x15, 0x0a, 0x06, 0x72, 0x6c, 0x70, 0x5f, 0x74, 0x78, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x05, 0x72, 0x6c, 0x70, 0x54, 0x78, 0x12, 0x26, 0x0a, 0x0f, 0x73, 0x75, 0x62,
+	0x5f, 0x70, 0x6f, 0x6f, 0x6c, 0x5f, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0d, 0x73, 0x75, 0x62, 0x50, 0x6f, 0x6f, 0x6c, 0x4d, 0x61, 0x72, 0x6b, 0x65,
+	0x72, 0x1a, 0x2e, 0x0a, 0x08, 0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x47, 0x61, 0x70, 0x12, 0x12, 0x0a,
+	0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x66, 0x72, 0x6f,
+	0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x74,
+	0x6f, 0x1a, 0x8e, 0x01, 0x0a, 0x06, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x12, 0x25, 0x0a, 0x07,
+	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e,
+	0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x48, 0x31, 0x36, 0x30, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x74, 0x65, 0x5f, 0x6e, 0x6f, 0x6e,
+	0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x65, 0x4e,
+	0x6f, 0x6e, 0x63, 0x65, 0x12, 0x3c, 0x0a, 0x0a, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x5f, 0x67, 0x61,
+	0x70, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f,
+	0x6c, 0x2e, 0x49, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e, 0x4e,
+	0x6f, 0x6e, 0x63, 0x65, 0x47, 0x61, 0x70, 0x52, 0x09, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x47, 0x61,
+	0x70, 0x73, 0x1a, 0x44, 0x0a, 0x09, 0x44, 0x69, 0x73, 0x63, 0x61, 0x72, 0x64, 0x65, 0x64, 0x12,
+	0x1f, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e,
+	0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x48, 0x32, 0x35, 0x36, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68,
+	0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x35, 0x0a, 0x0c, 0x4e, 0x6f, 0x6e, 0x63,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x25, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x74, 0x79, 0x70, 0x65,
+	0x73, 0x2e, 0x48, 0x31, 0x36, 0x30, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22,
+	0x38, 0x0a, 0x0a, 0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x66, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x66, 0x6f,
+	0x75, 0x6e, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x22, 0x56, 0x0a, 0x11, 0x41, 0x64, 0x64,
+	0x50, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17,
+	0x0a, 0x07, 0x72, 0x6c, 0x70, 0x5f, 0x74, 0x78, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52,
+	0x06, 0x72, 0x6c, 0x70, 0x54, 0x78, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x6d, 0x61, 0x78, 0x5f, 0x62,
+	0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0e, 0x6d, 0x61, 0x78, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x22, 0x78, 0x0a, 0x10, 0x41, 0x64, 0x64, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x72, 0x6c, 0x70, 0x5f, 0x74, 0x78, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x06, 0x72, 0x6c, 0x70, 0x54, 0x78, 0x73, 0x12, 0x21,
+	0x0a, 0x0c, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x12, 0x28, 0x0a, 0x10, 0x6d, 0x61, 0x78, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x6d, 0x61, 0x78,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x3e, 0x0a, 0x0e, 0x41,
+	0x64, 0x64, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x2c, 0x0a,
+	0x0b, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x48, 0x32, 0x35, 0x36, 0x52,
+	0x0a, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x48, 0x61, 0x73, 0x68, 0x22, 0x3f, 0x0a, 0x0f, 0x47,
+	0x65, 0x74, 0x42, 0x6c, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2c,
+	0x0a, 0x0b, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x48, 0x32, 0x35, 0x36,
+	0x52, 0x0a, 0x62, 0x6c, 0x6f, 0x62, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x22, 0xb7, 0x01, 0x0a,
+	0x0d, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x4c,
+	0x0a, 0x10, 0x62, 0x6c, 0x6f, 0x62, 0x73, 0x5f, 0x61, 0x6e, 0x64, 0x5f, 0x70, 0x72, 0x6f, 0x6f,
+	0x66, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f,
+	0x6c, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x2e,
+	0x42, 0x6c, 0x6f, 0x62, 0x41, 0x6e, 0x64, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x0e, 0x62, 0x6c,
+	0x6f, 0x62, 0x73, 0x41, 0x6e, 0x64, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x73, 0x1a, 0x58, 0x0a, 0x0c,
+	0x42, 0x6c, 0x6f, 0x62, 0x41, 0x6e, 0x64, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x12, 0x0a, 0x04,
+	0x62, 0x6c, 0x6f, 0x62, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x62, 0x6c, 0x6f, 0x62,
+	0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x05, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x22, 0x71, 0x0a, 0x17, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x65, 0x6e, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x04, 0x64, 0x65, 0x6e, 0x79, 0x12, 0x1d, 0x0a, 0x03, 0x61, 0x64, 0x64, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x48, 0x31, 0x36, 0x30, 0x52,
+	0x03, 0x61, 0x64, 0x64, 0x12, 0x23, 0x0a, 0x06, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x48, 0x31, 0x36,
+	0x30, 0x52, 0x06, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x22, 0x2b, 0x0a, 0x15, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x2a, 0x6c, 0x0a, 0x0c, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x55, 0x43, 0x43, 0x45, 0x53,
+	0x53, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x41, 0x4c, 0x52, 0x45, 0x41, 0x44, 0x59, 0x5f, 0x45,
+	0x58, 0x49, 0x53, 0x54, 0x53, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x46, 0x45, 0x45, 0x5f, 0x54,
+	0x4f, 0x4f, 0x5f, 0x4c, 0x4f, 0x57, 0x10, 0x02, 0x12, 0x09, 0x0a, 0x05, 0x53, 0x54, 0x41, 0x4c,
+	0x45, 0x10, 0x03, 0x12, 0x0b, 0x0a, 0x07, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x10, 0x04,
+	0x12, 0x12, 0x0a, 0x0e, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x4e, 0x41, 0x4c, 0x5f, 0x45, 0x52, 0x52,
+	0x4f, 0x52, 0x10, 0x05, 0x32, 0xaf, 0x06, 0x0a, 0x06, 0x54, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x12,
+	0x36, 0x0a, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x1a, 0x13, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x31, 0x0a, 0x0b, 0x46, 0x69, 0x6e, 0x64, 0x55,
+	0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x12, 0x10, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e,
+	0x54, 0x78, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x1a, 0x10, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f,
+	0x6c, 0x2e, 0x54, 0x78, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x12, 0x2b, 0x0a, 0x03, 0x41, 0x64,
+	0x64, 0x12, 0x12, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x41, 0x64, 0x64, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x41,
+	0x64, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x46, 0x0a, 0x0c, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1b, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c,
+	0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
+	0x2b, 0x0a, 0x03, 0x41, 0x6c, 0x6c, 0x12, 0x12, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e,
+	0x41, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x74, 0x78, 0x70,
+	0x6f, 0x6f, 0x6c, 0x2e, 0x41, 0x6c, 0x6c, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x37, 0x0a, 0x07,
+	0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a,
+	0x14, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x33, 0x0a, 0x05, 0x4f, 0x6e, 0x41, 0x64, 0x64, 0x12, 0x14,
+	0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x4f, 0x6e, 0x41, 0x64, 0x64, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x4f, 0x6e,
+	0x41, 0x64, 0x64, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x30, 0x01, 0x12, 0x34, 0x0a, 0x06, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x15, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x74, 0x78,
+	0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x12, 0x31, 0x0a, 0x05, 0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x14, 0x2e, 0x74, 0x78, 0x70, 0x6f,
+	0x6f, 0x6c, 0x2e, 0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x12, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x12, 0x37, 0x0a, 0x07, 0x49, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x12, 0x16,
+	0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x49, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e,
+	0x49, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x3a, 0x0a, 0x08,
+	0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x62, 0x73, 0x12, 0x17, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f,
+	0x6c, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x15, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x6c,
+	0x6f, 0x62, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x39, 0x0a, 0x0a, 0x41, 0x64, 0x64, 0x50,
+	0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x12, 0x19, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e,
+	0x41, 0x64, 0x64, 0x50, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x10, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x41, 0x64, 0x64, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x12, 0x3d, 0x0a, 0x09, 0x41, 0x64, 0x64, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65,
+	0x12, 0x18, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e, 0x41, 0x64, 0x64, 0x42, 0x75, 0x6e,
+	0x64, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x74, 0x78, 0x70,
+	0x6f, 0x6f, 0x6c, 0x2e, 0x41, 0x64, 0x64, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x12, 0x52, 0x0a, 0x10, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x65, 0x6e, 0x64,
+	0x65, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x1f, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x2e,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x4c, 0x69, 0x73, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c,
+	0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x4c, 0x69, 0x73,
+	0x74, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x42, 0x16, 0x5a, 0x14, 0x2e, 0x2f, 0x74, 0x78, 0x70, 0x6f,
+	0x6f, 0x6c, 0x3b, 0x74, 0x78, 0x70, 0x6f, 0x6f, 0x6c, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1042,64 +1921,101 @@ func file_txpool_txpool_proto_rawDescGZIP() []byte {
 }
 
 var file_txpool_txpool_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_txpool_txpool_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_txpool_txpool_proto_msgTypes = make([]protoimpl.MessageInfo, 30)
 var file_txpool_txpool_proto_goTypes = []any{
-	(ImportResult)(0),               // 0: txpool.ImportResult
-	(AllReply_TxnType)(0),           // 1: txpool.AllReply.TxnType
-	(*TxHashes)(nil),                // 2: txpool.TxHashes
-	(*AddRequest)(nil),              // 3: txpool.AddRequest
-	(*AddReply)(nil),                // 4: txpool.AddReply
-	(*TransactionsRequest)(nil),     // 5: txpool.TransactionsRequest
-	(*TransactionsReply)(nil),       // 6: txpool.TransactionsReply
-	(*OnAddRequest)(nil),            // 7: txpool.OnAddRequest
-	(*OnAddReply)(nil),              // 8: txpool.OnAddReply
-	(*AllRequest)(nil),              // 9: txpool.AllRequest
-	(*AllReply)(nil),                // 10: txpool.AllReply
-	(*PendingReply)(nil),            // 11: txpool.PendingReply
-	(*StatusRequest)(nil),           // 12: txpool.StatusRequest
-	(*StatusReply)(nil),             // 13: txpool.StatusReply
-	(*NonceRequest)(nil),            // 14: txpool.NonceRequest
-	(*NonceReply)(nil),              // 15: txpool.NonceReply
-	(*AllReply_Tx)(nil),             // 16: txpool.AllReply.Tx
-	(*PendingReply_Tx)(nil),         // 17: txpool.PendingReply.Tx
-	(*typesproto.H256)(nil),         // 18: types.H256
-	(*typesproto.H160)(nil),         // 19: types.H160
-	(*emptypb.Empty)(nil),           // 20: google.protobuf.Empty
-	(*typesproto.VersionReply)(nil), // 21: types.VersionReply
+	(ImportResult)(0),                  // 0: txpool.ImportResult
+	(AllReply_TxnType)(0),              // 1: txpool.AllReply.TxnType
+	(*TxHashes)(nil),                   // 2: txpool.TxHashes
+	(*AddRequest)(nil),                 // 3: txpool.AddRequest
+	(*AddReply)(nil),                   // 4: txpool.AddReply
+	(*TransactionsRequest)(nil),        // 5: txpool.TransactionsRequest
+	(*TransactionsReply)(nil),          // 6: txpool.TransactionsReply
+	(*OnAddRequest)(nil),               // 7: txpool.OnAddRequest
+	(*OnAddReply)(nil),                 // 8: txpool.OnAddReply
+	(*AllRequest)(nil),                 // 9: txpool.AllRequest
+	(*AllReply)(nil),                   // 10: txpool.AllReply
+	(*PendingReply)(nil),               // 11: txpool.PendingReply
+	(*StatusRequest)(nil),              // 12: txpool.StatusRequest
+	(*StatusReply)(nil),                // 13: txpool.StatusReply
+	(*InspectRequest)(nil),             // 14: txpool.InspectRequest
+	(*InspectReply)(nil),               // 15: txpool.InspectReply
+	(*NonceRequest)(nil),               // 16: txpool.NonceRequest
+	(*NonceReply)(nil),                 // 17: txpool.NonceReply
+	(*AddPrivateRequest)(nil),          // 18: txpool.AddPrivateRequest
+	(*AddBundleRequest)(nil),           // 19: txpool.AddBundleRequest
+	(*AddBundleReply)(nil),             // 20: txpool.AddBundleReply
+	(*GetBlobsRequest)(nil),            // 21: txpool.GetBlobsRequest
+	(*GetBlobsReply)(nil),              // 22: txpool.GetBlobsReply
+	(*UpdateSenderListRequest)(nil),    // 23: txpool.UpdateSenderListRequest
+	(*UpdateSenderListReply)(nil),      // 24: txpool.UpdateSenderListReply
+	(*AllReply_Tx)(nil),                // 25: txpool.AllReply.Tx
+	(*PendingReply_Tx)(nil),            // 26: txpool.PendingReply.Tx
+	(*InspectReply_Tx)(nil),            // 27: txpool.InspectReply.Tx
+	(*InspectReply_NonceGap)(nil),      // 28: txpool.InspectReply.NonceGap
+	(*InspectReply_Sender)(nil),        // 29: txpool.InspectReply.Sender
+	(*InspectReply_Discarded)(nil),     // 30: txpool.InspectReply.Discarded
+	(*GetBlobsReply_BlobAndProof)(nil), // 31: txpool.GetBlobsReply.BlobAndProof
+	(*typesproto.H256)(nil),            // 32: types.H256
+	(*typesproto.H160)(nil),            // 33: types.H160
+	(*emptypb.Empty)(nil),              // 34: google.protobuf.Empty
+	(*typesproto.VersionReply)(nil),    // 35: types.VersionReply
 }
 var file_txpool_txpool_proto_depIdxs = []int32{
-	18, // 0: txpool.TxHashes.hashes:type_name -> types.H256
+	32, // 0: txpool.TxHashes.hashes:type_name -> types.H256
 	0,  // 1: txpool.AddReply.imported:type_name -> txpool.ImportResult
-	18, // 2: txpool.TransactionsRequest.hashes:type_name -> types.H256
-	16, // 3: txpool.AllReply.txs:type_name -> txpool.AllReply.Tx
-	17, // 4: txpool.PendingReply.txs:type_name -> txpool.PendingReply.Tx
-	19, // 5: txpool.NonceRequest.address:type_name -> types.H160
-	1,  // 6: txpool.AllReply.Tx.txn_type:type_name -> txpool.AllReply.TxnType
-	19, // 7: txpool.AllReply.Tx.sender:type_name -> types.H160
-	19, // 8: txpool.PendingReply.Tx.sender:type_name -> types.H160
-	20, // 9: txpool.Txpool.Version:input_type -> google.protobuf.Empty
-	2,  // 10: txpool.Txpool.FindUnknown:input_type -> txpool.TxHashes
-	3,  // 11: txpool.Txpool.Add:input_type -> txpool.AddRequest
-	5,  // 12: txpool.Txpool.Transactions:input_type -> txpool.TransactionsRequest
-	9,  // 13: txpool.Txpool.All:input_type -> txpool.AllRequest
-	20, // 14: txpool.Txpool.Pending:input_type -> google.protobuf.Empty
-	7,  // 15: txpool.Txpool.OnAdd:input_type -> txpool.OnAddRequest
-	12, // 16: txpool.Txpool.Status:input_type -> txpool.StatusRequest
-	14, // 17: txpool.Txpool.Nonce:input_type -> txpool.NonceRequest
-	21, // 18: txpool.Txpool.Version:output_type -> types.VersionReply
-	2,  // 19: txpool.Txpool.FindUnknown:output_type -> txpool.TxHashes
-	4,  // 20: txpool.Txpool.Add:output_type -> txpool.AddReply
-	6,  // 21: txpool.Txpool.Transactions:output_type -> txpool.TransactionsReply
-	10, // 22: txpool.Txpool.All:output_type -> txpool.AllReply
-	11, // 23: txpool.Txpool.Pending:output_type -> txpool.PendingReply
-	8,  // 24: txpool.Txpool.OnAdd:output_type -> txpool.OnAddReply
-	13, // 25: txpool.Txpool.Status:output_type -> txpool.StatusReply
-	15, // 26: txpool.Txpool.Nonce:output_type -> txpool.NonceReply
-	18, // [18:27] is the sub-list for method output_type
-	9,  // [9:18] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	9,  // [9:9] is the sub-list for extension extendee
-	0,  // [0:9] is the sub-list for field type_name
+	32, // 2: txpool.TransactionsRequest.hashes:type_name -> types.H256
+	25, // 3: txpool.AllReply.txs:type_name -> txpool.AllReply.Tx
+	26, // 4: txpool.PendingReply.txs:type_name -> txpool.PendingReply.Tx
+	27, // 5: txpool.InspectReply.txs:type_name -> txpool.InspectReply.Tx
+	29, // 6: txpool.InspectReply.senders:type_name -> txpool.InspectReply.Sender
+	30, // 7: txpool.InspectReply.discarded:type_name -> txpool.InspectReply.Discarded
+	33, // 8: txpool.NonceRequest.address:type_name -> types.H160
+	32, // 9: txpool.AddBundleReply.bundle_hash:type_name -> types.H256
+	32, // 10: txpool.GetBlobsRequest.blob_hashes:type_name -> types.H256
+	31, // 11: txpool.GetBlobsReply.blobs_and_proofs:type_name -> txpool.GetBlobsReply.BlobAndProof
+	33, // 12: txpool.UpdateSenderListRequest.add:type_name -> types.H160
+	33, // 13: txpool.UpdateSenderListRequest.remove:type_name -> types.H160
+	1,  // 14: txpool.AllReply.Tx.txn_type:type_name -> txpool.AllReply.TxnType
+	33, // 15: txpool.AllReply.Tx.sender:type_name -> types.H160
+	33, // 16: txpool.PendingReply.Tx.sender:type_name -> types.H160
+	1,  // 17: txpool.InspectReply.Tx.txn_type:type_name -> txpool.AllReply.TxnType
+	33, // 18: txpool.InspectReply.Tx.sender:type_name -> types.H160
+	33, // 19: txpool.InspectReply.Sender.address:type_name -> types.H160
+	28, // 20: txpool.InspectReply.Sender.nonce_gaps:type_name -> txpool.InspectReply.NonceGap
+	32, // 21: txpool.InspectReply.Discarded.hash:type_name -> types.H256
+	34, // 22: txpool.Txpool.Version:input_type -> google.protobuf.Empty
+	2,  // 23: txpool.Txpool.FindUnknown:input_type -> txpool.TxHashes
+	3,  // 24: txpool.Txpool.Add:input_type -> txpool.AddRequest
+	5,  // 25: txpool.Txpool.Transactions:input_type -> txpool.TransactionsRequest
+	9,  // 26: txpool.Txpool.All:input_type -> txpool.AllRequest
+	34, // 27: txpool.Txpool.Pending:input_type -> google.protobuf.Empty
+	7,  // 28: txpool.Txpool.OnAdd:input_type -> txpool.OnAddRequest
+	12, // 29: txpool.Txpool.Status:input_type -> txpool.StatusRequest
+	16, // 30: txpool.Txpool.Nonce:input_type -> txpool.NonceRequest
+	14, // 31: txpool.Txpool.Inspect:input_type -> txpool.InspectRequest
+	21, // 32: txpool.Txpool.GetBlobs:input_type -> txpool.GetBlobsRequest
+	18, // 33: txpool.Txpool.AddPrivate:input_type -> txpool.AddPrivateRequest
+	19, // 34: txpool.Txpool.AddBundle:input_type -> txpool.AddBundleRequest
+	23, // 35: txpool.Txpool.UpdateSenderList:input_type -> txpool.UpdateSenderListRequest
+	35, // 36: txpool.Txpool.Version:output_type -> types.VersionReply
+	2,  // 37: txpool.Txpool.FindUnknown:output_type -> txpool.TxHashes
+	4,  // 38: txpool.Txpool.Add:output_type -> txpool.AddReply
+	6,  // 39: txpool.Txpool.Transactions:output_type -> txpool.TransactionsReply
+	10, // 40: txpool.Txpool.All:output_type -> txpool.AllReply
+	11, // 41: txpool.Txpool.Pending:output_type -> txpool.PendingReply
+	8,  // 42: txpool.Txpool.OnAdd:output_type -> txpool.OnAddReply
+	13, // 43: txpool.Txpool.Status:output_type -> txpool.StatusReply
+	17, // 44: txpool.Txpool.Nonce:output_type -> txpool.NonceReply
+	15, // 45: txpool.Txpool.Inspect:output_type -> txpool.InspectReply
+	22, // 46: txpool.Txpool.GetBlobs:output_type -> txpool.GetBlobsReply
+	4,  // 47: txpool.Txpool.AddPrivate:output_type -> txpool.AddReply
+	20, // 48: txpool.Txpool.AddBundle:output_type -> txpool.AddBundleReply
+	24, // 49: txpool.Txpool.UpdateSenderList:output_type -> txpool.UpdateSenderListReply
+	36, // [36:50] is the sub-list for method output_type
+	22, // [22:36] is the sub-list for method input_type
+	22, // [22:22] is the sub-list for extension type_name
+	22, // [22:22] is the sub-list for extension extendee
+	0,  // [0:22] is the sub-list for field type_name
 }
 
 func init() { file_txpool_txpool_proto_init() }
@@ -1253,7 +2169,7 @@ func file_txpool_txpool_proto_init() {
 			}
 		}
 		file_txpool_txpool_proto_msgTypes[12].Exporter = func(v any, i int) any {
-			switch v := v.(*NonceRequest); i {
+			switch v := v.(*InspectRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1265,7 +2181,7 @@ func file_txpool_txpool_proto_init() {
 			}
 		}
 		file_txpool_txpool_proto_msgTypes[13].Exporter = func(v any, i int) any {
-			switch v := v.(*NonceReply); i {
+			switch v := v.(*InspectReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1277,7 +2193,7 @@ func file_txpool_txpool_proto_init() {
 			}
 		}
 		file_txpool_txpool_proto_msgTypes[14].Exporter = func(v any, i int) any {
-			switch v := v.(*AllReply_Tx); i {
+			switch v := v.(*NonceRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1289,6 +2205,114 @@ func file_txpool_txpool_proto_init() {
 			}
 		}
 		file_txpool_txpool_proto_msgTypes[15].Exporter = func(v any, i int) any {
+			switch v := v.(*NonceReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_txpool_txpool_proto_msgTypes[16].Exporter = func(v any, i int) any {
+			switch v := v.(*AddPrivateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_txpool_txpool_proto_msgTypes[17].Exporter = func(v any, i int) any {
+			switch v := v.(*AddBundleRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_txpool_txpool_proto_msgTypes[18].Exporter = func(v any, i int) any {
+			switch v := v.(*AddBundleReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_txpool_txpool_proto_msgTypes[19].Exporter = func(v any, i int) any {
+			switch v := v.(*GetBlobsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_txpool_txpool_proto_msgTypes[20].Exporter = func(v any, i int) any {
+			switch v := v.(*GetBlobsReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_txpool_txpool_proto_msgTypes[21].Exporter = func(v any, i int) any {
+			switch v := v.(*UpdateSenderListRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_txpool_txpool_proto_msgTypes[22].Exporter = func(v any, i int) any {
+			switch v := v.(*UpdateSenderListReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_txpool_txpool_proto_msgTypes[23].Exporter = func(v any, i int) any {
+			switch v := v.(*AllReply_Tx); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_txpool_txpool_proto_msgTypes[24].Exporter = func(v any, i int) any {
 			switch v := v.(*PendingReply_Tx); i {
 			case 0:
 				return &v.state
@@ -1300,6 +2324,66 @@ func file_txpool_txpool_proto_init() {
 				return nil
 			}
 		}
+		file_txpool_txpool_proto_msgTypes[25].Exporter = func(v any, i int) any {
+			switch v := v.(*InspectReply_Tx); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_txpool_txpool_proto_msgTypes[26].Exporter = func(v any, i int) any {
+			switch v := v.(*InspectReply_NonceGap); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_txpool_txpool_proto_msgTypes[27].Exporter = func(v any, i int) any {
+			switch v := v.(*InspectReply_Sender); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_txpool_txpool_proto_msgTypes[28].Exporter = func(v any, i int) any {
+			switch v := v.(*InspectReply_Discarded); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_txpool_txpool_proto_msgTypes[29].Exporter = func(v any, i int) any {
+			switch v := v.(*GetBlobsReply_BlobAndProof); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1307,7 +2391,7 @@ func file_txpool_txpool_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_txpool_txpool_proto_rawDesc,
 			NumEnums:      2,
-			NumMessages:   16,
+			NumMessages:   30,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -21,15 +21,20 @@ import (
 const _ = grpc.SupportPackageIsVersion8
 
 const (
-	Txpool_Version_FullMethodName      = "/txpool.Txpool/Version"
-	Txpool_FindUnknown_FullMethodName  = "/txpool.Txpool/FindUnknown"
-	Txpool_Add_FullMethodName          = "/txpool.Txpool/Add"
-	Txpool_Transactions_FullMethodName = "/txpool.Txpool/Transactions"
-	Txpool_All_FullMethodName          = "/txpool.Txpool/All"
-	Txpool_Pending_FullMethodName      = "/txpool.Txpool/Pending"
-	Txpool_OnAdd_FullMethodName        = "/txpool.Txpool/OnAdd"
-	Txpool_Status_FullMethodName       = "/txpool.Txpool/Status"
-	Txpool_Nonce_FullMethodName        = "/txpool.Txpool/Nonce"
+	Txpool_Version_FullMethodName          = "/txpool.Txpool/Version"
+	Txpool_FindUnknown_FullMethodName      = "/txpool.Txpool/FindUnknown"
+	Txpool_Add_FullMethodName              = "/txpool.Txpool/Add"
+	Txpool_Transactions_FullMethodName     = "/txpool.Txpool/Transactions"
+	Txpool_All_FullMethodName              = "/txpool.Txpool/All"
+	Txpool_Pending_FullMethodName          = "/txpool.Txpool/Pending"
+	Txpool_OnAdd_FullMethodName            = "/txpool.Txpool/OnAdd"
+	Txpool_Status_FullMethodName           = "/txpool.Txpool/Status"
+	Txpool_Nonce_FullMethodName            = "/txpool.Txpool/Nonce"
+	Txpool_Inspect_FullMethodName          = "/txpool.Txpool/Inspect"
+	Txpool_GetBlobs_FullMethodName         = "/txpool.Txpool/GetBlobs"
+	Txpool_AddPrivate_FullMethodName       = "/txpool.Txpool/AddPrivate"
+	Txpool_AddBundle_FullMethodName        = "/txpool.Txpool/AddBundle"
+	Txpool_UpdateSenderList_FullMethodName = "/txpool.Txpool/UpdateSenderList"
 )
 
 // TxpoolClient is the client API for Txpool service.
@@ -55,6 +60,17 @@ type TxpoolClient interface {
 	Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusReply, error)
 	// returns nonce for given account
 	Nonce(ctx context.Context, in *NonceRequest, opts ...grpc.CallOption) (*NonceReply, error)
+	// returns all transactions from tx pool along with the details of their sorting into sub-pools
+	Inspect(ctx context.Context, in *InspectRequest, opts ...grpc.CallOption) (*InspectReply, error)
+	// preserves incoming order and amount, returns blobs (with commitments and proofs) of pool transactions by versioned hashes
+	GetBlobs(ctx context.Context, in *GetBlobsRequest, opts ...grpc.CallOption) (*GetBlobsReply, error)
+	// Expecting signed transactions. Adds them to the private side-pool: they are never announced to peers and only
+	// used for building local blocks
+	AddPrivate(ctx context.Context, in *AddPrivateRequest, opts ...grpc.CallOption) (*AddReply, error)
+	// Expecting signed transactions. Adds them as a bundle for building local blocks
+	AddBundle(ctx context.Context, in *AddBundleRequest, opts ...grpc.CallOption) (*AddBundleReply, error)
+	// Adds senders to or removes them from the allow or deny list of the admission policy
+	UpdateSenderList(ctx context.Context, in *UpdateSenderListRequest, opts ...grpc.CallOption) (*UpdateSenderListReply, error)
 }
 
 type txpoolClient struct {
@@ -178,6 +194,56 @@ func (c *txpoolClient) Nonce(ctx context.Context, in *NonceRequest, opts ...grpc
 	return out, nil
 }
 
+func (c *txpoolClient) Inspect(ctx context.Context, in *InspectRequest, opts ...grpc.CallOption) (*InspectReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InspectReply)
+	err := c.cc.Invoke(ctx, Txpool_Inspect_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *txpoolClient) GetBlobs(ctx context.Context, in *GetBlobsRequest, opts ...grpc.CallOption) (*GetBlobsReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBlobsReply)
+	err := c.cc.Invoke(ctx, Txpool_GetBlobs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *txpoolClient) AddPrivate(ctx context.Context, in *AddPrivateRequest, opts ...grpc.CallOption) (*AddReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddReply)
+	err := c.cc.Invoke(ctx, Txpool_AddPrivate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *txpoolClient) AddBundle(ctx context.Context, in *AddBundleRequest, opts ...grpc.CallOption) (*AddBundleReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddBundleReply)
+	err := c.cc.Invoke(ctx, Txpool_AddBundle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *txpoolClient) UpdateSenderList(ctx context.Context, in *UpdateSenderListRequest, opts ...grpc.CallOption) (*UpdateSenderListReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateSenderListReply)
+	err := c.cc.Invoke(ctx, Txpool_UpdateSenderList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TxpoolServer is the server API for Txpool service.
 // All implementations must embed UnimplementedTxpoolServer
 // for forward compatibility
@@ -201,6 +267,17 @@ type TxpoolServer interface {
 	Status(context.Context, *StatusRequest) (*StatusReply, error)
 	// returns nonce for given account
 	Nonce(context.Context, *NonceRequest) (*NonceReply, error)
+	// returns all transactions from tx pool along with the details of their sorting into sub-pools
+	Inspect(context.Context, *InspectRequest) (*InspectReply, error)
+	// preserves incoming order and amount, returns blobs (with commitments and proofs) of pool transactions by versioned hashes
+	GetBlobs(context.Context, *GetBlobsRequest) (*GetBlobsReply, error)
+	// Expecting signed transactions. Adds them to the private side-pool: they are never announced to peers and only
+	// used for building local blocks
+	AddPrivate(context.Context, *AddPrivateRequest) (*AddReply, error)
+	// Expecting signed transactions. Adds them as a bundle for building local blocks
+	AddBundle(context.Context, *AddBundleRequest) (*AddBundleReply, error)
+	// Adds senders to or removes them from the allow or deny list of the admission policy
+	UpdateSenderList(context.Context, *UpdateSenderListRequest) (*UpdateSenderListReply, error)
 	mustEmbedUnimplementedTxpoolServer()
 }
 
@@ -235,6 +312,21 @@ func (UnimplementedTxpoolServer) Status(context.Context, *StatusRequest) (*Statu
 func (UnimplementedTxpoolServer) Nonce(context.Context, *NonceRequest) (*NonceReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Nonce not implemented")
 }
+func (UnimplementedTxpoolServer) Inspect(context.Context, *InspectRequest) (*InspectReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Inspect not implemented")
+}
+func (UnimplementedTxpoolServer) GetBlobs(context.Context, *GetBlobsRequest) (*GetBlobsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlobs not implemented")
+}
+func (UnimplementedTxpoolServer) AddPrivate(context.Context, *AddPrivateRequest) (*AddReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddPrivate not implemented")
+}
+func (UnimplementedTxpoolServer) AddBundle(context.Context, *AddBundleRequest) (*AddBundleReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddBundle not implemented")
+}
+func (UnimplementedTxpoolServer) UpdateSenderList(context.Context, *UpdateSenderListRequest) (*UpdateSenderListReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSenderList not implemented")
+}
 func (UnimplementedTxpoolServer) mustEmbedUnimplementedTxpoolServer() {}
 
 // UnsafeTxpoolServer may be embedded to opt out of forward compatibility for this service.
@@ -413,6 +505,96 @@ func _Txpool_Nonce_Handler(srv interface{}, ctx context.Context, dec func(interf
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Txpool_Inspect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InspectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxpoolServer).Inspect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Txpool_Inspect_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxpoolServer).Inspect(ctx, req.(*InspectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Txpool_GetBlobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxpoolServer).GetBlobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Txpool_GetBlobs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxpoolServer).GetBlobs(ctx, req.(*GetBlobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Txpool_AddPrivate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddPrivateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxpoolServer).AddPrivate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Txpool_AddPrivate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxpoolServer).AddPrivate(ctx, req.(*AddPrivateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Txpool_AddBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddBundleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxpoolServer).AddBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Txpool_AddBundle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxpoolServer).AddBundle(ctx, req.(*AddBundleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Txpool_UpdateSenderList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSenderListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxpoolServer).UpdateSenderList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Txpool_UpdateSenderList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxpoolServer).UpdateSenderList(ctx, req.(*UpdateSenderListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Txpool_ServiceDesc is the grpc.ServiceDesc for Txpool service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -452,6 +634,26 @@ var Txpool_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Nonce",
 			Handler:    _Txpool_Nonce_Handler,
 		},
+		{
+			MethodName: "Inspect",
+			Handler:    _Txpool_Inspect_Handler,
+		},
+		{
+			MethodName: "GetBlobs",
+			Handler:    _Txpool_GetBlobs_Handler,
+		},
+		{
+			MethodName: "AddPrivate",
+			Handler:    _Txpool_AddPrivate_Handler,
+		},
+		{
+			MethodName: "AddBundle",
+			Handler:    _Txpool_AddBundle_Handler,
+		},
+		{
+			MethodName: "UpdateSenderList",
+			Handler:    _Txpool_UpdateSenderList_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -40,6 +40,10 @@ import (
 )
 
 func OpenPair(from, to string, label kv.Label, targetPageSize datasize.ByteSize, logger log.Logger) (kv.RoDB, kv.RwDB) {
+	return openPair(from, to, label, targetPageSize, 4*datasize.GB, logger)
+}
+
+func openPair(from, to string, label kv.Label, targetPageSize, growthStep datasize.ByteSize, logger log.Logger) (kv.RoDB, kv.RwDB) {
 	const ThreadsHardLimit = 9_000
 	src := mdbx2.NewMDBX(logger).Path(from).
 		Label(label).
@@ -58,7 +62,7 @@ func OpenPair(from, to string, label kv.Label, targetPageSize datasize.ByteSize,
 		Label(label).
 		PageSize(targetPageSize.Bytes()).
 		MapSize(datasize.ByteSize(info.Geo.Upper)).
-		GrowthStep(4 * datasize.GB).
+		GrowthStep(growthStep).
 		Flags(func(flags uint) uint { return flags | mdbx.WriteMap }).
 		WithTableCfg(func(_ kv.TableCfg) kv.TableCfg { return kv.TablesCfgByLabel(label) }).
 		MustOpen()
@@ -71,7 +75,11 @@ func Kv2kv(ctx context.Context, src kv.RoDB, dst kv.RwDB, tables []string, readA
 		return err1
 	}
 	defer srcTx.Rollback()
+	return kv2kvTx(ctx, src, srcTx, dst, tables, readAheadThreads, logger)
+}
 
+// kv2kvTx - copies the tables as they are seen by srcTx
+func kv2kvTx(ctx context.Context, src kv.RoDB, srcTx kv.Tx, dst kv.RwDB, tables []string, readAheadThreads int, logger log.Logger) error {
 	commitEvery := time.NewTicker(5 * time.Minute)
 	defer commitEvery.Stop()
 	logEvery := time.NewTicker(20 * time.Second)
@@ -0,0 +1,518 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package backup
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/c2h5oh/datasize"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/common/dir"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/mdbx"
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+// Incremental backups. A backup directory has:
+//   - objects/ - content of the files of datadir/snapshots by sha256. Snapshot files are immutable, every file is
+//     stored once and is shared by all backups. Objects are copied. Optionally they are hard-linked from the datadir
+//     when it's on the same file system - then a backup takes no space for them, but shares the inode with the live
+//     file: it doesn't survive a failure of the disk, and a file modified in place by the node is modified in the
+//     backup as well (and fails its verification).
+//   - <name>/manifest.json - files of the backup and their hashes
+//   - <name>/<db> - copies of the databases (the mutable part, including the latest steps of the domains which are
+//     not in files yet), made from a read transaction which is opened before the snapshot files are listed.
+//
+// Files which have the same path, size and modification time as in the previous backup are not read again. Files of
+// torrents which the downloader hasn't completed yet are skipped, a restored node downloads them again.
+
+const (
+	ManifestVersion  = 1
+	ManifestFileName = "manifest.json"
+	objectsDirName   = "objects"
+	mdbxDataFileName = "mdbx.dat"
+)
+
+// dbCopyGrowthStep - the copies are hashed whole, including the preallocated tail
+var dbCopyGrowthStep = 1 * datasize.GB
+
+type ManifestFile struct {
+	Path    string    `json:"path"` // relative to the datadir, slash separated
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"modTime"`
+	Hash    string    `json:"sha256"`
+}
+
+type ManifestDB struct {
+	Label string `json:"label"`
+	ManifestFile
+}
+
+type Manifest struct {
+	Version   int            `json:"version"`
+	Name      string         `json:"name"`
+	Created   time.Time      `json:"created"`
+	Snapshots []ManifestFile `json:"snapshots"` // content is in objects/
+	DBs       []ManifestDB   `json:"dbs"`       // content is in <name>/<path>
+}
+
+type Stats struct {
+	Files, Reused, Linked, Copied int   // Reused - hash is taken from the previous backup, Linked/Copied - new objects
+	CopiedBytes                   int64 // of the new objects and the databases
+}
+
+func objectPath(root, hash string) string {
+	return filepath.Join(root, objectsDirName, hash[:2], hash)
+}
+
+func ReadManifest(root, name string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(root, name, ManifestFileName))
+	if err != nil {
+		return nil, err
+	}
+	m := &Manifest{}
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("manifest of %s: %w", name, err)
+	}
+	if m.Version != ManifestVersion {
+		return nil, fmt.Errorf("manifest of %s: unsupported version %d", name, m.Version)
+	}
+	return m, nil
+}
+
+// Backups returns names of the complete backups in root, oldest first
+func Backups(root string) ([]string, error) {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() || e.Name() == objectsDirName {
+			continue
+		}
+		if ok, _ := dir.FileExist(filepath.Join(root, e.Name(), ManifestFileName)); ok {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names) // names are timestamps
+	return names, nil
+}
+
+// Incremental makes a new backup of the datadir in root and returns its manifest. The databases of labels are
+// copied, missing databases are skipped. New snapshot files are hard-linked to the objects when hardLink is set and
+// the file system allows it. It's safe to run on the datadir of a running node.
+func Incremental(ctx context.Context, dirs datadir.Dirs, root string, labels []kv.Label, readAheadThreads int, hardLink bool, logger log.Logger) (*Manifest, Stats, error) {
+	var stats Stats
+	m := &Manifest{Version: ManifestVersion, Created: time.Now().UTC()}
+	m.Name = m.Created.Format("20060102-150405.000")
+
+	known := map[string]ManifestFile{}
+	names, err := Backups(root)
+	if err != nil {
+		return nil, stats, err
+	}
+	if len(names) > 0 {
+		prev, err := ReadManifest(root, names[len(names)-1])
+		if err != nil {
+			return nil, stats, err
+		}
+		if prev.Name == m.Name {
+			return nil, stats, fmt.Errorf("backup %s already exists", m.Name)
+		}
+		for _, f := range prev.Snapshots {
+			known[f.Path] = f
+		}
+	}
+
+	tmpDir := filepath.Join(root, m.Name+".tmp")
+	if err := os.RemoveAll(tmpDir); err != nil {
+		return nil, stats, err
+	}
+	if err := os.MkdirAll(tmpDir, 0740); err != nil {
+		return nil, stats, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	// read transactions of the databases are opened first: data which is moved from them to new snapshot files later
+	// is in the listed files or in the newer ones, which are skipped
+	type dbCopy struct {
+		label    kv.Label
+		from, to string
+		src      kv.RoDB
+		dst      kv.RwDB
+		tx       kv.Tx
+	}
+	var copies []*dbCopy
+	defer func() {
+		for _, c := range copies {
+			c.tx.Rollback()
+			c.src.Close()
+			c.dst.Close()
+		}
+	}()
+	for _, label := range labels {
+		from, rel := dbDir(dirs, label)
+		exists, err := dir.FileExist(filepath.Join(from, mdbxDataFileName))
+		if err != nil {
+			return nil, stats, err
+		}
+		if !exists {
+			continue
+		}
+		to := filepath.Join(tmpDir, rel)
+		if err := os.MkdirAll(to, 0740); err != nil {
+			return nil, stats, err
+		}
+		src, dst := openPair(from, to, label, 0, dbCopyGrowthStep, logger)
+		c := &dbCopy{label: label, from: rel, to: to, src: src, dst: dst}
+		if c.tx, err = src.BeginRo(ctx); err != nil {
+			src.Close()
+			dst.Close()
+			return nil, stats, err
+		}
+		copies = append(copies, c)
+	}
+
+	incomplete, err := incompleteDownloads(ctx, dirs, logger)
+	if err != nil {
+		return nil, stats, err
+	}
+	if m.Snapshots, err = backupSnapshots(ctx, dirs, root, known, incomplete, hardLink, &stats, logger); err != nil {
+		return nil, stats, err
+	}
+
+	for _, c := range copies {
+		logger.Info("[backup] copy db", "label", c.label)
+		if err := kv2kvTx(ctx, c.src, c.tx, c.dst, nil, readAheadThreads, logger); err != nil {
+			return nil, stats, err
+		}
+		c.tx.Rollback()
+		c.dst.Close()
+		f, err := describeFile(filepath.Join(c.to, mdbxDataFileName))
+		if err != nil {
+			return nil, stats, err
+		}
+		f.Path = filepath.ToSlash(c.from)
+		stats.CopiedBytes += f.Size
+		m.DBs = append(m.DBs, ManifestDB{Label: c.label.String(), ManifestFile: f})
+	}
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, stats, err
+	}
+	if err := dir.WriteFileWithFsync(filepath.Join(tmpDir, ManifestFileName), data, 0640); err != nil {
+		return nil, stats, err
+	}
+	if err := os.Rename(tmpDir, filepath.Join(root, m.Name)); err != nil {
+		return nil, stats, err
+	}
+	return m, stats, nil
+}
+
+// dbDir returns the directory of the database and its path relative to the datadir
+func dbDir(dirs datadir.Dirs, label kv.Label) (string, string) {
+	var path string
+	switch label {
+	case kv.ChainDB:
+		path = dirs.Chaindata
+	case kv.TxPoolDB:
+		path = dirs.TxPool
+	case kv.DownloaderDB:
+		path = filepath.Join(dirs.Snap, "db")
+	default:
+		panic(fmt.Sprintf("unexpected: %+v", label))
+	}
+	rel, err := filepath.Rel(dirs.DataDir, path)
+	if err != nil {
+		panic(err)
+	}
+	return path, rel
+}
+
+// incompleteDownloads returns names (relative to the snapshots dir) of the files of torrents which the downloader
+// hasn't completed yet
+func incompleteDownloads(ctx context.Context, dirs datadir.Dirs, logger log.Logger) (map[string]struct{}, error) {
+	path, _ := dbDir(dirs, kv.DownloaderDB)
+	if exists, err := dir.FileExist(filepath.Join(path, mdbxDataFileName)); err != nil || !exists {
+		return nil, err
+	}
+	db, err := mdbx.NewMDBX(logger).Path(path).
+		Label(kv.DownloaderDB).
+		WithTableCfg(func(_ kv.TableCfg) kv.TableCfg { return kv.TablesCfgByLabel(kv.DownloaderDB) }).
+		Accede().
+		Readonly().
+		Open(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	incomplete := map[string]struct{}{}
+	if err := db.View(ctx, func(tx kv.Tx) error {
+		return tx.ForEach(kv.BittorrentInfo, nil, func(k, v []byte) error {
+			// see downloader.torrentInfo, old records hold just the info hash
+			var info struct {
+				Completed *time.Time `json:"completed,omitempty"`
+			}
+			if string(k) == kv.BittorrentPeerID || json.Unmarshal(v, &info) != nil {
+				return nil
+			}
+			if info.Completed == nil {
+				incomplete[string(k)] = struct{}{}
+			}
+			return nil
+		})
+	}); err != nil {
+		return nil, err
+	}
+	return incomplete, nil
+}
+
+func backupSnapshots(ctx context.Context, dirs datadir.Dirs, root string, known map[string]ManifestFile, incomplete map[string]struct{}, hardLink bool, stats *Stats, logger log.Logger) ([]ManifestFile, error) {
+	logEvery := time.NewTicker(20 * time.Second)
+	defer logEvery.Stop()
+	downloaderDB, _ := dbDir(dirs, kv.DownloaderDB)
+
+	var files []ManifestFile
+	err := filepath.WalkDir(dirs.Snap, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path == downloaderDB {
+				return filepath.SkipDir // copied as a database
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() || strings.HasSuffix(path, ".tmp") {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-logEvery.C:
+			logger.Info("[backup] snapshots", "files", stats.Files, "new", stats.Linked+stats.Copied, "copied", common.ByteCount(uint64(stats.CopiedBytes)))
+		default:
+		}
+		name, err := filepath.Rel(dirs.Snap, path)
+		if err != nil {
+			return err
+		}
+		if _, ok := incomplete[filepath.ToSlash(name)]; ok {
+			logger.Debug("[backup] skip incomplete download", "file", name)
+			return nil
+		}
+		rel, err := filepath.Rel(dirs.DataDir, path)
+		if err != nil {
+			return err
+		}
+		f, err := backupSnapshot(root, path, filepath.ToSlash(rel), known, hardLink, stats)
+		if err != nil {
+			return fmt.Errorf("%s: %w", rel, err)
+		}
+		files = append(files, f)
+		return nil
+	})
+	return files, err
+}
+
+func backupSnapshot(root, path, rel string, known map[string]ManifestFile, hardLink bool, stats *Stats) (ManifestFile, error) {
+	stats.Files++
+	st, err := os.Stat(path)
+	if err != nil {
+		return ManifestFile{}, err
+	}
+	if prev, ok := known[rel]; ok && prev.Size == st.Size() && prev.ModTime.Equal(st.ModTime().UTC()) {
+		if ok, _ := dir.FileExist(objectPath(root, prev.Hash)); ok {
+			stats.Reused++
+			return prev, nil
+		}
+	}
+	f, err := describeFile(path)
+	if err != nil {
+		return ManifestFile{}, err
+	}
+	f.Path = rel
+	obj := objectPath(root, f.Hash)
+	if ok, _ := dir.FileExist(obj); ok {
+		return f, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(obj), 0740); err != nil {
+		return ManifestFile{}, err
+	}
+	if hardLink && os.Link(path, obj) == nil {
+		stats.Linked++
+		return f, nil
+	}
+	if err := copyFileAtomic(path, obj); err != nil {
+		return ManifestFile{}, err
+	}
+	stats.Copied++
+	stats.CopiedBytes += f.Size
+	return f, nil
+}
+
+// describeFile hashes the file, the path of the result is not set
+func describeFile(path string) (ManifestFile, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return ManifestFile{}, err
+	}
+	defer file.Close()
+	st, err := file.Stat()
+	if err != nil {
+		return ManifestFile{}, err
+	}
+	h := sha256.New()
+	if _, err := io.Copy(h, file); err != nil {
+		return ManifestFile{}, err
+	}
+	return ManifestFile{Size: st.Size(), ModTime: st.ModTime().UTC(), Hash: hex.EncodeToString(h.Sum(nil))}, nil
+}
+
+// copyFileAtomic copies the file, the target appears atomically
+func copyFileAtomic(from, to string) error {
+	tmp := to + ".tmp"
+	if err := copyFile(from, tmp); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, to)
+}
+
+func copyFile(from, to string) error {
+	r, err := os.Open(from)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	w, err := os.Create(to)
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+	if _, err = w.ReadFrom(r); err != nil {
+		return err
+	}
+	if err = w.Sync(); err != nil {
+		return err
+	}
+	return w.Close()
+}
+
+// Verify checks that the files of the backup exist and match the manifest
+func Verify(ctx context.Context, root string, m *Manifest, logger log.Logger) error {
+	logEvery := time.NewTicker(20 * time.Second)
+	defer logEvery.Stop()
+	check := func(path string, expected ManifestFile) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-logEvery.C:
+			logger.Info("[restore] verify", "file", expected.Path)
+		default:
+		}
+		f, err := describeFile(path)
+		if err != nil {
+			return err
+		}
+		if f.Size != expected.Size || f.Hash != expected.Hash {
+			return fmt.Errorf("%s doesn't match the manifest: size %d, sha256 %s, expected size %d, sha256 %s", expected.Path, f.Size, f.Hash, expected.Size, expected.Hash)
+		}
+		return nil
+	}
+	for _, f := range m.Snapshots {
+		if err := check(objectPath(root, f.Hash), f); err != nil {
+			return err
+		}
+	}
+	for _, db := range m.DBs {
+		if err := check(filepath.Join(root, m.Name, filepath.FromSlash(db.Path), mdbxDataFileName), db.ManifestFile); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Restore verifies the backup and copies its files to an empty datadir. The objects are never hard-linked: the node
+// would modify the backup through them.
+func Restore(ctx context.Context, root, name string, dirs datadir.Dirs, logger log.Logger) (*Manifest, error) {
+	m, err := ReadManifest(root, name)
+	if err != nil {
+		return nil, err
+	}
+	for _, db := range m.DBs {
+		target := filepath.Join(dirs.DataDir, filepath.FromSlash(db.Path), mdbxDataFileName)
+		if exists, _ := dir.FileExist(target); exists {
+			return nil, fmt.Errorf("datadir is not empty: %s exists", target)
+		}
+	}
+	if err := filepath.WalkDir(dirs.Snap, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return fmt.Errorf("datadir is not empty: %s exists", path)
+		}
+		return nil
+	}); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	logger.Info("[restore] verify", "backup", name, "snapshots", len(m.Snapshots), "dbs", len(m.DBs))
+	if err := Verify(ctx, root, m, logger); err != nil {
+		return nil, err
+	}
+	for _, f := range m.Snapshots {
+		target := filepath.Join(dirs.DataDir, filepath.FromSlash(f.Path))
+		if err := os.MkdirAll(filepath.Dir(target), 0740); err != nil {
+			return nil, err
+		}
+		if err := copyFileAtomic(objectPath(root, f.Hash), target); err != nil {
+			return nil, err
+		}
+	}
+	for _, db := range m.DBs {
+		logger.Info("[restore] copy db", "label", db.Label)
+		target := filepath.Join(dirs.DataDir, filepath.FromSlash(db.Path))
+		if err := os.MkdirAll(target, 0740); err != nil {
+			return nil, err
+		}
+		if err := copyFile(filepath.Join(root, m.Name, filepath.FromSlash(db.Path), mdbxDataFileName), filepath.Join(target, mdbxDataFileName)); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
@@ -0,0 +1,125 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package backup
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/c2h5oh/datasize"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/mdbx"
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+func TestIncrementalBackup(t *testing.T) {
+	defer func(step datasize.ByteSize) { dbCopyGrowthStep = step }(dbCopyGrowthStep)
+	dbCopyGrowthStep = 16 * datasize.MB
+	ctx, logger := context.Background(), log.New()
+	dirs := datadir.New(t.TempDir())
+	db := mdbx.NewMDBX(logger).Path(dirs.Chaindata).Label(kv.ChainDB).MustOpen()
+	require.NoError(t, db.Update(ctx, func(tx kv.RwTx) error {
+		return tx.Put(kv.Headers, []byte{1}, []byte("header"))
+	}))
+	db.Close()
+	write := func(path, content string) {
+		require.NoError(t, os.WriteFile(filepath.Join(dirs.DataDir, path), []byte(content), 0644))
+	}
+	write("snapshots/v1-000000-000500-headers.seg", "headers")
+	write("snapshots/domain/v1-accounts.0-32.kv", "accounts")
+	write("snapshots/domain/v1-accounts.0-32.kv.tmp", "unfinished")
+	write("snapshots/domain/v1-storage.0-32.kv", "downloading")
+
+	// the downloader is still downloading the storage file
+	downloaderDB := mdbx.NewMDBX(logger).Path(filepath.Join(dirs.Snap, "db")).Label(kv.DownloaderDB).
+		WithTableCfg(func(_ kv.TableCfg) kv.TableCfg { return kv.TablesCfgByLabel(kv.DownloaderDB) }).MustOpen()
+	require.NoError(t, downloaderDB.Update(ctx, func(tx kv.RwTx) error {
+		if err := tx.Put(kv.BittorrentInfo, []byte("domain/v1-storage.0-32.kv"), []byte(`{"name":"domain/v1-storage.0-32.kv","hash":"AQ=="}`)); err != nil {
+			return err
+		}
+		return tx.Put(kv.BittorrentInfo, []byte("v1-000000-000500-headers.seg"), []byte(`{"name":"v1-000000-000500-headers.seg","hash":"Ag==","completed":"2024-01-01T00:00:00Z"}`))
+	}))
+	downloaderDB.Close()
+
+	root := t.TempDir()
+	labels := []kv.Label{kv.ChainDB, kv.TxPoolDB}
+	m1, stats, err := Incremental(ctx, dirs, root, labels, 1, false, logger)
+	require.NoError(t, err)
+	require.Len(t, m1.Snapshots, 2)
+	require.Len(t, m1.DBs, 1) // no txpool db
+	require.Equal(t, 2, stats.Copied)
+	require.Zero(t, stats.Linked)
+	require.Zero(t, stats.Reused)
+
+	write("snapshots/domain/v1-accounts.32-64.kv", "accounts2")
+	m2, stats, err := Incremental(ctx, dirs, root, labels, 1, true, logger)
+	require.NoError(t, err)
+	require.Len(t, m2.Snapshots, 3)
+	require.Equal(t, 2, stats.Reused)
+	require.Equal(t, 1, stats.Linked) // same file system
+
+	names, err := Backups(root)
+	require.NoError(t, err)
+	require.Equal(t, []string{m1.Name, m2.Name}, names)
+
+	restored := datadir.New(t.TempDir())
+	_, err = Restore(ctx, root, m2.Name, restored, logger)
+	require.NoError(t, err)
+	content, err := os.ReadFile(filepath.Join(restored.SnapDomain, "v1-accounts.32-64.kv"))
+	require.NoError(t, err)
+	require.Equal(t, "accounts2", string(content))
+	// restored files are copies, even of the hard-linked objects
+	restoredSt, err := os.Stat(filepath.Join(restored.SnapDomain, "v1-accounts.32-64.kv"))
+	require.NoError(t, err)
+	objectSt, err := os.Stat(objectPath(root, m2.Snapshots[len(m2.Snapshots)-1].Hash))
+	require.NoError(t, err)
+	require.False(t, os.SameFile(restoredSt, objectSt))
+	_, err = os.Stat(filepath.Join(restored.SnapDomain, "v1-storage.0-32.kv"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+	db = mdbx.NewMDBX(logger).Path(restored.Chaindata).Label(kv.ChainDB).MustOpen()
+	require.NoError(t, db.View(ctx, func(tx kv.Tx) error {
+		v, err := tx.GetOne(kv.Headers, []byte{1})
+		require.Equal(t, "header", string(v))
+		return err
+	}))
+	db.Close()
+
+	// the datadir isn't empty
+	_, err = Restore(ctx, root, m2.Name, restored, logger)
+	require.Error(t, err)
+	withSnapshots := datadir.New(t.TempDir())
+	require.NoError(t, os.WriteFile(filepath.Join(withSnapshots.SnapDomain, "v1-accounts.0-32.kv"), []byte("local"), 0644))
+	_, err = Restore(ctx, root, m2.Name, withSnapshots, logger)
+	require.ErrorContains(t, err, "datadir is not empty")
+	content, err = os.ReadFile(filepath.Join(withSnapshots.SnapDomain, "v1-accounts.0-32.kv"))
+	require.NoError(t, err)
+	require.Equal(t, "local", string(content))
+
+	// a corrupted backup isn't restored
+	dbCopy, err := os.OpenFile(filepath.Join(root, m1.Name, "chaindata", mdbxDataFileName), os.O_WRONLY|os.O_APPEND, 0)
+	require.NoError(t, err)
+	_, err = dbCopy.Write([]byte{1})
+	require.NoError(t, err)
+	require.NoError(t, dbCopy.Close())
+	_, err = Restore(ctx, root, m1.Name, datadir.New(t.TempDir()), logger)
+	require.ErrorContains(t, err, "doesn't match the manifest")
+}
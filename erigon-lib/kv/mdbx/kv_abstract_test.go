@@ -18,6 +18,7 @@ package mdbx_test
 
 import (
 	"context"
+	"encoding/binary"
 	"fmt"
 	"net"
 	"runtime"
@@ -33,8 +34,10 @@ import (
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/kv/mdbx"
 	"github.com/erigontech/erigon-lib/kv/memdb"
+	"github.com/erigontech/erigon-lib/kv/order"
 	"github.com/erigontech/erigon-lib/kv/remotedb"
 	"github.com/erigontech/erigon-lib/kv/remotedbserver"
+	"github.com/erigontech/erigon-lib/kv/stream"
 	"github.com/erigontech/erigon-lib/log/v3"
 )
 
@@ -329,6 +332,125 @@ func TestRemoteKvRange(t *testing.T) {
 		return nil
 	})
 	require.NoError(err)
+
+	// Ranges longer than one server page must be stitched together by following page tokens
+	total := remotedbserver.PageSizeLimit*2 + 10
+	require.NoError(writeDB.Update(ctx, func(tx kv.RwTx) error {
+		for i := 0; i < total; i++ {
+			k := binary.BigEndian.AppendUint64(nil, uint64(i))
+			if err := tx.Put(kv.Headers, k, k); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+	err = db.View(ctx, func(tx kv.Tx) error {
+		collect := func(it stream.KV, err error) (keys []uint64) {
+			require.NoError(err)
+			for it.HasNext() {
+				k, v, err := it.Next()
+				require.NoError(err)
+				require.Equal(k, v)
+				keys = append(keys, binary.BigEndian.Uint64(k))
+			}
+			return keys
+		}
+
+		keys := collect(tx.Range(kv.Headers, nil, nil))
+		require.Len(keys, total)
+		for i, k := range keys {
+			require.Equal(uint64(i), k)
+		}
+
+		keys = collect(tx.RangeAscend(kv.Headers, nil, nil, remotedbserver.PageSizeLimit+5))
+		require.Len(keys, remotedbserver.PageSizeLimit+5)
+		require.Equal(uint64(remotedbserver.PageSizeLimit+4), keys[len(keys)-1])
+
+		keys = collect(tx.RangeDescend(kv.Headers, nil, nil, -1))
+		require.Len(keys, total)
+		for i, k := range keys {
+			require.Equal(uint64(total-1-i), k)
+		}
+		return nil
+	})
+	require.NoError(err)
+}
+
+func TestRemoteKvSizes(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fix me on win please")
+	}
+	logger := log.New()
+	ctx, writeDB := context.Background(), memdb.NewTestDB(t)
+	grpcServer, conn := grpc.NewServer(), bufconn.Listen(1024*1024)
+	go func() {
+		kvServer := remotedbserver.NewKvServer(ctx, writeDB, nil, nil, nil, logger)
+		remote.RegisterKVServer(grpcServer, kvServer)
+		if err := grpcServer.Serve(conn); err != nil {
+			log.Error("private RPC server fail", "err", err)
+		}
+	}()
+	defer grpcServer.Stop()
+
+	cc, err := grpc.Dial("", grpc.WithInsecure(), grpc.WithContextDialer(func(ctx context.Context, url string) (net.Conn, error) { return conn.Dial() }))
+	require.NoError(t, err)
+	db, err := remotedb.NewRemote(gointerfaces.VersionFromProto(remotedbserver.KvServiceAPIVersion), logger, remote.NewKVClient(cc)).Open()
+	require.NoError(t, err)
+
+	require := require.New(t)
+	require.NoError(writeDB.Update(ctx, func(tx kv.RwTx) error {
+		wc, err := tx.RwCursorDupSort(kv.AccountChangeSet)
+		require.NoError(err)
+		require.NoError(wc.Append([]byte{1}, []byte{1}))
+		require.NoError(wc.Append([]byte{1}, []byte{2}))
+		require.NoError(wc.Append([]byte{1}, []byte{3}))
+		require.NoError(wc.Append([]byte{2}, []byte{1}))
+		_, err = tx.IncrementSequence(kv.EthTx, 3)
+		return err
+	}))
+
+	require.Equal(writeDB.PageSize(), db.PageSize())
+	require.Nil(db.CHandle())
+	require.NoError(db.View(ctx, func(tx kv.Tx) error {
+		require.Nil(tx.CHandle())
+
+		dbSize, err := tx.DBSize()
+		require.NoError(err)
+		tableSize, err := tx.BucketSize(kv.AccountChangeSet)
+		require.NoError(err)
+		require.Positive(tableSize)
+		require.Greater(dbSize, tableSize)
+
+		count, err := tx.Count(kv.AccountChangeSet)
+		require.NoError(err)
+		require.Equal(uint64(4), count)
+
+		seq, err := tx.ReadSequence(kv.EthTx)
+		require.NoError(err)
+		require.Equal(uint64(3), seq)
+
+		tables, err := tx.(kv.BucketMigratorRO).ListBuckets()
+		require.NoError(err)
+		require.Contains(tables, kv.AccountChangeSet)
+
+		values := func(from, to []byte, asc order.By, limit int) (res []byte) {
+			it, err := tx.RangeDupSort(kv.AccountChangeSet, []byte{1}, from, to, asc, limit)
+			require.NoError(err)
+			defer it.Close()
+			for it.HasNext() {
+				k, v, err := it.Next()
+				require.NoError(err)
+				require.Equal([]byte{1}, k)
+				res = append(res, v...)
+			}
+			return res
+		}
+		require.Equal([]byte{1, 2, 3}, values(nil, nil, order.Asc, -1))
+		require.Equal([]byte{2}, values([]byte{2}, []byte{3}, order.Asc, -1))
+		require.Equal([]byte{3, 2}, values(nil, nil, order.Desc, 2))
+		require.Equal([]byte{2, 1}, values([]byte{2}, nil, order.Desc, -1))
+		return nil
+	}))
 }
 
 func setupDatabases(t *testing.T, logger log.Logger, f mdbx.TableCfgFunc) (writeDBs []kv.RwDB, readDBs []kv.RwDB) {
@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"runtime"
+	"sync/atomic"
 	"unsafe"
 
 	"golang.org/x/sync/semaphore"
@@ -55,6 +56,7 @@ type DB struct {
 	buckets      kv.TableCfg
 	roTxsLimiter *semaphore.Weighted
 	opts         remoteOpts
+	pageSize     atomic.Uint64 // fetched from server on first use
 }
 
 type tx struct {
@@ -127,7 +129,6 @@ func NewRemote(v gointerfaces.Version, logger log.Logger, remoteKV remote.KVClie
 	return remoteOpts{bucketsCfg: kv.ChaindataTablesCfg, version: v, log: logger, remoteKV: remoteKV}
 }
 
-func (db *DB) PageSize() uint64       { panic("not implemented") }
 func (db *DB) ReadOnly() bool         { return true }
 func (db *DB) AllTables() kv.TableCfg { return db.buckets }
 
@@ -149,10 +150,28 @@ func (db *DB) EnsureVersionCompatibility() bool {
 
 func (db *DB) Close() {}
 
-func (db *DB) CHandle() unsafe.Pointer {
-	panic("CHandle not implemented")
+// PageSize - page size of the server's db. Falls back to kv.DefaultPageSize if server is unavailable.
+func (db *DB) PageSize() uint64 {
+	if pageSize := db.pageSize.Load(); pageSize > 0 {
+		return pageSize
+	}
+	if err := db.View(context.Background(), func(roTx kv.Tx) error {
+		reply, err := db.remoteKV.Size(context.Background(), &remote.SizeReq{TxId: roTx.(*tx).id})
+		if err != nil {
+			return err
+		}
+		db.pageSize.Store(reply.PageSize)
+		return nil
+	}); err != nil {
+		db.log.Warn("getting PageSize", "err", err)
+		return kv.DefaultPageSize()
+	}
+	return db.pageSize.Load()
 }
 
+// CHandle - remote db has no underlying C environment handle
+func (db *DB) CHandle() unsafe.Pointer { return nil }
+
 func (db *DB) BeginRo(ctx context.Context) (txn kv.Tx, err error) {
 	select {
 	case <-ctx.Done():
@@ -234,7 +253,11 @@ func (tx *tx) IncrementSequence(bucket string, amount uint64) (uint64, error) {
 	panic("not implemented yet")
 }
 func (tx *tx) ReadSequence(bucket string) (uint64, error) {
-	panic("not implemented yet")
+	reply, err := tx.db.remoteKV.Sequence(tx.ctx, &remote.SequenceReq{TxId: tx.id, Table: bucket})
+	if err != nil {
+		return 0, err
+	}
+	return reply.Value, nil
 }
 func (tx *tx) Append(bucket string, k, v []byte) error    { panic("no write methods") }
 func (tx *tx) AppendDup(bucket string, k, v []byte) error { panic("no write methods") }
@@ -251,7 +274,7 @@ func (tx *tx) Rollback() {
 		c.Close()
 	}
 }
-func (tx *tx) DBSize() (uint64, error) { panic("not implemented") }
+func (tx *tx) DBSize() (uint64, error) { return tx.BucketSize("") }
 
 func (tx *tx) statelessCursor(bucket string) (kv.Cursor, error) {
 	if tx.statelessCursors == nil {
@@ -270,10 +293,20 @@ func (tx *tx) statelessCursor(bucket string) (kv.Cursor, error) {
 }
 
 func (tx *tx) Count(bucket string) (uint64, error) {
-	panic("not implemented")
+	reply, err := tx.db.remoteKV.Count(tx.ctx, &remote.CountReq{TxId: tx.id, Table: bucket})
+	if err != nil {
+		return 0, err
+	}
+	return reply.Count, nil
 }
 
-func (tx *tx) BucketSize(name string) (uint64, error) { panic("not implemented") }
+func (tx *tx) BucketSize(name string) (uint64, error) {
+	reply, err := tx.db.remoteKV.Size(tx.ctx, &remote.SizeReq{TxId: tx.id, Table: name})
+	if err != nil {
+		return 0, err
+	}
+	return reply.Size, nil
+}
 
 func (tx *tx) ForEach(bucket string, fromPrefix []byte, walker func(k, v []byte) error) error {
 	it, err := tx.Range(bucket, fromPrefix, nil)
@@ -360,7 +393,11 @@ func (tx *tx) Cursor(bucket string) (kv.Cursor, error) {
 }
 
 func (tx *tx) ListBuckets() ([]string, error) {
-	return nil, errors.New("function ListBuckets is not implemented for remoteTx")
+	reply, err := tx.db.remoteKV.ListTables(tx.ctx, &remote.ListTablesReq{TxId: tx.id})
+	if err != nil {
+		return nil, err
+	}
+	return reply.Tables, nil
 }
 
 // func (c *remoteCursor) Put(k []byte, v []byte) error            { panic("not supported") }
@@ -643,7 +680,7 @@ func (tx *tx) DomainGet(name kv.Domain, k, k2 []byte) (v []byte, step uint64, er
 
 func (tx *tx) DomainRange(name kv.Domain, fromKey, toKey []byte, ts uint64, asc order.By, limit int) (it stream.KV, err error) {
 	return stream.PaginateKV(func(pageToken string) (keys, vals [][]byte, nextPageToken string, err error) {
-		reply, err := tx.db.remoteKV.DomainRange(tx.ctx, &remote.DomainRangeReq{TxId: tx.id, Table: name.String(), FromKey: fromKey, ToKey: toKey, Ts: ts, OrderAscend: bool(asc), Limit: int64(limit)})
+		reply, err := tx.db.remoteKV.DomainRange(tx.ctx, &remote.DomainRangeReq{TxId: tx.id, Table: name.String(), FromKey: fromKey, ToKey: toKey, Ts: ts, OrderAscend: bool(asc), Limit: int64(limit), PageToken: pageToken})
 		if err != nil {
 			return nil, nil, "", err
 		}
@@ -659,7 +696,7 @@ func (tx *tx) HistorySeek(name kv.History, k []byte, ts uint64) (v []byte, ok bo
 }
 func (tx *tx) HistoryRange(name kv.History, fromTs, toTs int, asc order.By, limit int) (it stream.KV, err error) {
 	return stream.PaginateKV(func(pageToken string) (keys, vals [][]byte, nextPageToken string, err error) {
-		reply, err := tx.db.remoteKV.HistoryRange(tx.ctx, &remote.HistoryRangeReq{TxId: tx.id, Table: string(name), FromTs: int64(fromTs), ToTs: int64(toTs), OrderAscend: bool(asc), Limit: int64(limit)})
+		reply, err := tx.db.remoteKV.HistoryRange(tx.ctx, &remote.HistoryRangeReq{TxId: tx.id, Table: string(name), FromTs: int64(fromTs), ToTs: int64(toTs), OrderAscend: bool(asc), Limit: int64(limit), PageToken: pageToken})
 		if err != nil {
 			return nil, nil, "", err
 		}
@@ -669,7 +706,7 @@ func (tx *tx) HistoryRange(name kv.History, fromTs, toTs int, asc order.By, limi
 
 func (tx *tx) IndexRange(name kv.InvertedIdx, k []byte, fromTs, toTs int, asc order.By, limit int) (timestamps stream.U64, err error) {
 	return stream.PaginateU64(func(pageToken string) (arr []uint64, nextPageToken string, err error) {
-		req := &remote.IndexRangeReq{TxId: tx.id, Table: string(name), K: k, FromTs: int64(fromTs), ToTs: int64(toTs), OrderAscend: bool(asc), Limit: int64(limit)}
+		req := &remote.IndexRangeReq{TxId: tx.id, Table: string(name), K: k, FromTs: int64(fromTs), ToTs: int64(toTs), OrderAscend: bool(asc), Limit: int64(limit), PageToken: pageToken}
 		reply, err := tx.db.remoteKV.IndexRange(tx.ctx, req)
 		if err != nil {
 			return nil, "", err
@@ -688,7 +725,7 @@ func (tx *tx) Prefix(table string, prefix []byte) (stream.KV, error) {
 
 func (tx *tx) rangeOrderLimit(table string, fromPrefix, toPrefix []byte, asc order.By, limit int) (stream.KV, error) {
 	return stream.PaginateKV(func(pageToken string) (keys [][]byte, values [][]byte, nextPageToken string, err error) {
-		req := &remote.RangeReq{TxId: tx.id, Table: table, FromPrefix: fromPrefix, ToPrefix: toPrefix, OrderAscend: bool(asc), Limit: int64(limit)}
+		req := &remote.RangeReq{TxId: tx.id, Table: table, FromPrefix: fromPrefix, ToPrefix: toPrefix, OrderAscend: bool(asc), Limit: int64(limit), PageToken: pageToken}
 		reply, err := tx.db.remoteKV.Range(tx.ctx, req)
 		if err != nil {
 			return nil, nil, "", err
@@ -705,10 +742,132 @@ func (tx *tx) RangeAscend(table string, fromPrefix, toPrefix []byte, limit int)
 func (tx *tx) RangeDescend(table string, fromPrefix, toPrefix []byte, limit int) (stream.KV, error) {
 	return tx.rangeOrderLimit(table, fromPrefix, toPrefix, order.Desc, limit)
 }
+
+// RangeDupSort - iterates over values of `key` by remote cursor: 1 round-trip per value
 func (tx *tx) RangeDupSort(table string, key []byte, fromPrefix, toPrefix []byte, asc order.By, limit int) (stream.KV, error) {
-	panic("not implemented yet")
+	s := &cursorDup2iter{key: key, fromPrefix: fromPrefix, toPrefix: toPrefix, orderAscend: bool(asc), limit: int64(limit), ctx: tx.ctx}
+	if err := s.init(table, tx); err != nil {
+		s.Close() //it's responsibility of constructor (our) to close resource on error
+		return nil, err
+	}
+	return s, nil
+}
+
+// CHandle - remote tx has no underlying C transaction handle
+func (tx *tx) CHandle() unsafe.Pointer { return nil }
+
+type cursorDup2iter struct {
+	c                           kv.CursorDupSort
+	key                         []byte
+	fromPrefix, toPrefix, nextV []byte
+	orderAscend                 bool
+	limit                       int64
+	ctx                         context.Context
+}
+
+func (s *cursorDup2iter) init(table string, tx kv.Tx) error {
+	if s.orderAscend && s.fromPrefix != nil && s.toPrefix != nil && bytes.Compare(s.fromPrefix, s.toPrefix) >= 0 {
+		return fmt.Errorf("tx.Dual: %x must be lexicographicaly before %x", s.fromPrefix, s.toPrefix)
+	}
+	if !s.orderAscend && s.fromPrefix != nil && s.toPrefix != nil && bytes.Compare(s.fromPrefix, s.toPrefix) <= 0 {
+		return fmt.Errorf("tx.Dual: %x must be lexicographicaly before %x", s.toPrefix, s.fromPrefix)
+	}
+	c, err := tx.CursorDupSort(table)
+	if err != nil {
+		return err
+	}
+	s.c = c
+	k, _, err := c.SeekExact(s.key)
+	if err != nil {
+		return err
+	}
+	if k == nil {
+		return nil
+	}
+
+	if s.fromPrefix == nil { // no initial position
+		if s.orderAscend {
+			s.nextV, err = s.c.FirstDup()
+		} else {
+			s.nextV, err = s.c.LastDup()
+		}
+		return err
+	}
+
+	if s.orderAscend {
+		s.nextV, err = s.c.SeekBothRange(s.key, s.fromPrefix)
+		return err
+	}
+
+	// to find LAST key with given prefix:
+	nextSubtree, ok := kv.NextSubtree(s.fromPrefix)
+	if !ok {
+		_, s.nextV, err = s.c.PrevDup()
+		return err
+	}
+	s.nextV, err = s.c.SeekBothRange(s.key, nextSubtree)
+	if err != nil {
+		return err
+	}
+	if s.nextV != nil {
+		_, s.nextV, err = s.c.PrevDup()
+		return err
+	}
+	k, s.nextV, err = s.c.SeekExact(s.key)
+	if err != nil {
+		return err
+	}
+	if k == nil {
+		s.nextV = nil
+		return nil
+	}
+	s.nextV, err = s.c.LastDup()
+	return err
 }
 
-func (tx *tx) CHandle() unsafe.Pointer {
-	panic("CHandle not implemented")
+func (s *cursorDup2iter) advance() (err error) {
+	if s.orderAscend {
+		_, s.nextV, err = s.c.NextDup()
+	} else {
+		_, s.nextV, err = s.c.PrevDup()
+	}
+	return err
+}
+
+func (s *cursorDup2iter) Close() {
+	if s.c != nil {
+		s.c.Close()
+		s.c = nil
+	}
+}
+
+func (s *cursorDup2iter) HasNext() bool {
+	if s.limit == 0 { // limit reached
+		return false
+	}
+	if s.nextV == nil { // EndOfTable
+		return false
+	}
+	if s.toPrefix == nil {
+		return true
+	}
+
+	//Asc:  [from, to) AND from < to
+	//Desc: [from, to) AND from > to
+	cmp := bytes.Compare(s.nextV, s.toPrefix)
+	return (s.orderAscend && cmp < 0) || (!s.orderAscend && cmp > 0)
+}
+
+func (s *cursorDup2iter) Next() (k, v []byte, err error) {
+	select {
+	case <-s.ctx.Done():
+		return nil, nil, s.ctx.Err()
+	default:
+	}
+	s.limit--
+	v = s.nextV
+	if err = s.advance(); err != nil {
+		return nil, nil, err
+	}
+	return s.key, v, nil
 }
@@ -17,6 +17,7 @@
 package remotedbserver
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
 	"errors"
@@ -63,7 +64,8 @@ const MaxTxTTL = 60 * time.Second
 // 6.0.0 - Blocks now have system-txs - in the begin/end of block
 // 6.1.0 - Add methods Range, IndexRange, HistorySeek, HistoryRange
 // 6.2.0 - Add HistoryFiles to reply of Snapshots() method
-var KvServiceAPIVersion = &types.VersionReply{Major: 7, Minor: 0, Patch: 0}
+// 7.1.0 - Add methods Sequence, Count, Size, ListTables
+var KvServiceAPIVersion = &types.VersionReply{Major: 7, Minor: 1, Patch: 0}
 
 type KvServer struct {
 	remote.UnimplementedKVServer // must be embedded to have forward compatible implementations.
@@ -393,16 +395,10 @@ func handleOp(c kv.Cursor, stream remote.KV_TxServer, in *remote.Cursor) error {
 		k, v, err = c.(kv.CursorDupSort).NextNoDup()
 	case remote.Op_PREV:
 		k, v, err = c.Prev()
-	//case remote.Op_PREV_DUP:
-	//	k, v, err = c.(ethdb.CursorDupSort).Prev()
-	//	if err != nil {
-	//		return err
-	//	}
-	//case remote.Op_PREV_NO_DUP:
-	//	k, v, err = c.Prev()
-	//	if err != nil {
-	//		return err
-	//	}
+	case remote.Op_PREV_DUP:
+		k, v, err = c.(kv.CursorDupSort).PrevDup()
+	case remote.Op_PREV_NO_DUP:
+		k, v, err = c.(kv.CursorDupSort).PrevNoDup()
 	case remote.Op_SEEK_EXACT:
 		k, v, err = c.SeekExact(in.K)
 	case remote.Op_SEEK_BOTH_EXACT:
@@ -598,7 +594,7 @@ func (s *KvServer) IndexRange(_ context.Context, req *remote.IndexRangeReq) (*re
 			return err
 		}
 		defer it.Close()
-		for it.HasNext() {
+		for len(reply.Timestamps) < int(req.PageSize) && it.HasNext() {
 			v, err := it.Next()
 			if err != nil {
 				return err
@@ -606,7 +602,7 @@ func (s *KvServer) IndexRange(_ context.Context, req *remote.IndexRangeReq) (*re
 			reply.Timestamps = append(reply.Timestamps, v)
 			limit--
 		}
-		if len(reply.Timestamps) == int(req.PageSize) && it.HasNext() {
+		if it.HasNext() {
 			next, err := it.Next()
 			if err != nil {
 				return err
@@ -626,25 +622,56 @@ func (s *KvServer) IndexRange(_ context.Context, req *remote.IndexRangeReq) (*re
 func (s *KvServer) HistoryRange(_ context.Context, req *remote.HistoryRangeReq) (*remote.Pairs, error) {
 	reply := &remote.Pairs{}
 	fromTs, limit := int(req.FromTs), int(req.Limit)
+	var fromKey []byte
+	if req.PageToken != "" {
+		var pagination remote.PairsPagination
+		if err := unmarshalPagination(req.PageToken, &pagination); err != nil {
+			return nil, err
+		}
+		fromKey, limit = pagination.NextKey, int(pagination.Limit)
+	}
+	if req.PageSize <= 0 || req.PageSize > PageSizeLimit {
+		req.PageSize = PageSizeLimit
+	}
+	unlimited := limit <= 0
+
 	if err := s.with(req.TxId, func(tx kv.Tx) error {
 		ttx, ok := tx.(kv.TemporalTx)
 		if !ok {
 			return fmt.Errorf("server DB doesn't implement kv.Temporal interface")
 		}
-		it, err := ttx.HistoryRange(kv.History(req.Table), fromTs, int(req.ToTs), order.By(req.OrderAscend), limit)
+		// history can't be seeked to a key: the next page starts over and skips the keys of the previous pages,
+		// so the limit of the request is applied here
+		it, err := ttx.HistoryRange(kv.History(req.Table), fromTs, int(req.ToTs), order.By(req.OrderAscend), -1)
 		if err != nil {
 			return err
 		}
 		defer it.Close()
-		for it.HasNext() {
+		for len(reply.Keys) < int(req.PageSize) && (unlimited || limit > 0) && it.HasNext() {
 			k, v, err := it.Next()
 			if err != nil {
 				return err
 			}
+			if fromKey != nil && bytes.Compare(k, fromKey) < 0 {
+				continue
+			}
 			key := bytesCopy(k)
 			value := bytesCopy(v)
 			reply.Keys = append(reply.Keys, key)
 			reply.Values = append(reply.Values, value)
+			if !unlimited {
+				limit--
+			}
+		}
+		if (unlimited || limit > 0) && it.HasNext() {
+			nextK, _, err := it.Next()
+			if err != nil {
+				return err
+			}
+			reply.NextPageToken, err = marshalPagination(&remote.PairsPagination{NextKey: bytesCopy(nextK), Limit: int64(limit)})
+			if err != nil {
+				return err
+			}
 		}
 		return nil
 	}); err != nil {
@@ -681,7 +708,7 @@ func (s *KvServer) DomainRange(_ context.Context, req *remote.DomainRangeReq) (*
 			return err
 		}
 		defer it.Close()
-		for it.HasNext() {
+		for len(reply.Keys) < int(req.PageSize) && it.HasNext() {
 			k, v, err := it.Next()
 			if err != nil {
 				return err
@@ -692,7 +719,7 @@ func (s *KvServer) DomainRange(_ context.Context, req *remote.DomainRangeReq) (*
 			reply.Values = append(reply.Values, value)
 			limit--
 		}
-		if len(reply.Keys) == int(req.PageSize) && it.HasNext() {
+		if it.HasNext() {
 			nextK, _, err := it.Next()
 			if err != nil {
 				return err
@@ -737,7 +764,8 @@ func (s *KvServer) Range(_ context.Context, req *remote.RangeReq) (*remote.Pairs
 				return err
 			}
 		}
-		for it.HasNext() {
+		defer it.Close()
+		for len(reply.Keys) < int(req.PageSize) && it.HasNext() {
 			k, v, err := it.Next()
 			if err != nil {
 				return err
@@ -746,7 +774,7 @@ func (s *KvServer) Range(_ context.Context, req *remote.RangeReq) (*remote.Pairs
 			reply.Values = append(reply.Values, v)
 			limit--
 		}
-		if len(reply.Keys) == PageSizeLimit && it.HasNext() {
+		if it.HasNext() {
 			nextK, _, err := it.Next()
 			if err != nil {
 				return err
@@ -763,6 +791,59 @@ func (s *KvServer) Range(_ context.Context, req *remote.RangeReq) (*remote.Pairs
 	return reply, nil
 }
 
+func (s *KvServer) Sequence(_ context.Context, req *remote.SequenceReq) (reply *remote.SequenceReply, err error) {
+	reply = &remote.SequenceReply{}
+	if err := s.with(req.TxId, func(tx kv.Tx) error {
+		reply.Value, err = tx.ReadSequence(req.Table)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (s *KvServer) Count(_ context.Context, req *remote.CountReq) (reply *remote.CountReply, err error) {
+	reply = &remote.CountReply{}
+	if err := s.with(req.TxId, func(tx kv.Tx) error {
+		reply.Count, err = tx.Count(req.Table)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+// Size - returns size of `req.Table`, or of the whole db if `req.Table` is empty
+func (s *KvServer) Size(_ context.Context, req *remote.SizeReq) (reply *remote.SizeReply, err error) {
+	reply = &remote.SizeReply{PageSize: s.kv.PageSize()}
+	if err := s.with(req.TxId, func(tx kv.Tx) error {
+		if req.Table == "" {
+			reply.Size, err = tx.DBSize()
+		} else {
+			reply.Size, err = tx.BucketSize(req.Table)
+		}
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+func (s *KvServer) ListTables(_ context.Context, req *remote.ListTablesReq) (reply *remote.ListTablesReply, err error) {
+	reply = &remote.ListTablesReply{}
+	if err := s.with(req.TxId, func(tx kv.Tx) error {
+		migrator, ok := tx.(kv.BucketMigratorRO)
+		if !ok {
+			return errors.New("server DB doesn't implement kv.BucketMigratorRO interface")
+		}
+		reply.Tables, err = migrator.ListBuckets()
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
 // see: https://cloud.google.com/apis/design/design_patterns
 func marshalPagination(m proto.Message) (string, error) {
 	pageToken, err := proto.Marshal(m)
@@ -21,13 +21,19 @@ import (
 	"runtime"
 	"testing"
 
+	"github.com/holiman/uint256"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/erigontech/erigon-lib/common/datadir"
+	remote "github.com/erigontech/erigon-lib/gointerfaces/remoteproto"
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/kv/memdb"
+	"github.com/erigontech/erigon-lib/kv/temporal/temporaltest"
 	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/state"
+	"github.com/erigontech/erigon-lib/types"
 )
 
 func TestKvServer_renew(t *testing.T) {
@@ -139,3 +145,139 @@ func TestKVServerSnapshotsReturnsEmptyIfNoBlockSnapshots(t *testing.T) {
 	require.Empty(t, reply.BlocksFiles)
 	require.Empty(t, reply.HistoryFiles)
 }
+
+func TestKvServerSizes(t *testing.T) {
+	require, ctx, db := require.New(t), context.Background(), memdb.NewTestDB(t)
+	require.NoError(db.Update(ctx, func(tx kv.RwTx) error {
+		require.NoError(tx.Put(kv.Headers, []byte{1}, []byte{1}))
+		require.NoError(tx.Put(kv.Headers, []byte{2}, []byte{2}))
+		_, err := tx.IncrementSequence(kv.EthTx, 7)
+		return err
+	}))
+
+	s := NewKvServer(ctx, db, nil, nil, nil, log.New())
+	id, err := s.begin(ctx)
+	require.NoError(err)
+	defer s.rollback(id)
+
+	count, err := s.Count(ctx, &remote.CountReq{TxId: id, Table: kv.Headers})
+	require.NoError(err)
+	require.Equal(uint64(2), count.Count)
+
+	seq, err := s.Sequence(ctx, &remote.SequenceReq{TxId: id, Table: kv.EthTx})
+	require.NoError(err)
+	require.Equal(uint64(7), seq.Value)
+
+	dbSize, err := s.Size(ctx, &remote.SizeReq{TxId: id})
+	require.NoError(err)
+	require.Equal(db.PageSize(), dbSize.PageSize)
+	tableSize, err := s.Size(ctx, &remote.SizeReq{TxId: id, Table: kv.Headers})
+	require.NoError(err)
+	require.Positive(tableSize.Size)
+	require.Greater(dbSize.Size, tableSize.Size)
+
+	tables, err := s.ListTables(ctx, &remote.ListTablesReq{TxId: id})
+	require.NoError(err)
+	require.Contains(tables.Tables, kv.Headers)
+
+	_, err = s.Count(ctx, &remote.CountReq{TxId: id + 1, Table: kv.Headers})
+	require.Error(err) // unknown tx
+}
+
+func TestKvServerRangePages(t *testing.T) {
+	require, ctx, db := require.New(t), context.Background(), memdb.NewTestDB(t)
+	require.NoError(db.Update(ctx, func(tx kv.RwTx) error {
+		for i := byte(0); i < 10; i++ {
+			if err := tx.Put(kv.Headers, []byte{i}, []byte{i}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+
+	s := NewKvServer(ctx, db, nil, nil, nil, log.New())
+	id, err := s.begin(ctx)
+	require.NoError(err)
+	defer s.rollback(id)
+
+	readPages := func(req *remote.RangeReq) (pages []int, keys []byte) {
+		for {
+			reply, err := s.Range(ctx, req)
+			require.NoError(err)
+			pages = append(pages, len(reply.Keys))
+			for _, k := range reply.Keys {
+				keys = append(keys, k...)
+			}
+			if reply.NextPageToken == "" {
+				return pages, keys
+			}
+			req.PageToken = reply.NextPageToken
+		}
+	}
+
+	pages, keys := readPages(&remote.RangeReq{TxId: id, Table: kv.Headers, OrderAscend: true, Limit: -1, PageSize: 3})
+	require.Equal([]int{3, 3, 3, 1}, pages)
+	require.Equal([]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, keys)
+
+	pages, keys = readPages(&remote.RangeReq{TxId: id, Table: kv.Headers, OrderAscend: true, Limit: 7, PageSize: 3})
+	require.Equal([]int{3, 3, 1}, pages)
+	require.Equal([]byte{0, 1, 2, 3, 4, 5, 6}, keys)
+
+	pages, keys = readPages(&remote.RangeReq{TxId: id, Table: kv.Headers, Limit: -1, PageSize: 4})
+	require.Equal([]int{4, 4, 2}, pages)
+	require.Equal([]byte{9, 8, 7, 6, 5, 4, 3, 2, 1, 0}, keys)
+}
+
+func TestKvServerHistoryRangePages(t *testing.T) {
+	require, ctx := require.New(t), context.Background()
+	db, _ := temporaltest.NewTestDB(t, datadir.New(t.TempDir()))
+	// account i is created at txNum i
+	require.NoError(db.Update(ctx, func(tx kv.RwTx) error {
+		d, err := state.NewSharedDomains(tx, log.New())
+		if err != nil {
+			return err
+		}
+		defer d.Close()
+		for i := byte(0); i < 10; i++ {
+			d.SetTxNum(uint64(i))
+			addr := make([]byte, 20)
+			addr[19] = i
+			if err := d.DomainPut(kv.AccountsDomain, addr, nil, types.EncodeAccountBytesV3(uint64(i), uint256.NewInt(1), nil, 0), nil, 0); err != nil {
+				return err
+			}
+		}
+		return d.Flush(ctx, tx)
+	}))
+
+	s := NewKvServer(ctx, db, nil, nil, nil, log.New())
+	id, err := s.begin(ctx)
+	require.NoError(err)
+	defer s.rollback(id)
+
+	readPages := func(req *remote.HistoryRangeReq) (pages []int, keys []byte) {
+		for {
+			reply, err := s.HistoryRange(ctx, req)
+			require.NoError(err)
+			pages = append(pages, len(reply.Keys))
+			for _, k := range reply.Keys {
+				keys = append(keys, k[19])
+			}
+			if reply.NextPageToken == "" {
+				return pages, keys
+			}
+			req.PageToken = reply.NextPageToken
+		}
+	}
+
+	pages, keys := readPages(&remote.HistoryRangeReq{TxId: id, Table: string(kv.AccountsHistory), FromTs: 0, ToTs: 10, OrderAscend: true, Limit: -1, PageSize: 3})
+	require.Equal([]int{3, 3, 3, 1}, pages)
+	require.Equal([]byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, keys)
+
+	pages, keys = readPages(&remote.HistoryRangeReq{TxId: id, Table: string(kv.AccountsHistory), FromTs: 0, ToTs: 10, OrderAscend: true, Limit: 7, PageSize: 3})
+	require.Equal([]int{3, 3, 1}, pages)
+	require.Equal([]byte{0, 1, 2, 3, 4, 5, 6}, keys)
+
+	pages, keys = readPages(&remote.HistoryRangeReq{TxId: id, Table: string(kv.AccountsHistory), FromTs: 4, ToTs: 8, OrderAscend: true, Limit: -1, PageSize: 2})
+	require.Equal([]int{2, 2}, pages)
+	require.Equal([]byte{4, 5, 6, 7}, keys)
+}
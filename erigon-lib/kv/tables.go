@@ -251,6 +251,8 @@ const (
 
 	BlockBody = "BlockBody" // block_num_u64 + hash -> block body
 
+	BadBlocks = "BadBlocks" // block_num_u64 + hash -> rlp(block, reason) of recently rejected blocks
+
 	// Naming:
 	//  TxNum - Ethereum canonical transaction number - same across all nodes.
 	//  TxnID - auto-increment ID - can be differrent across all nodes
@@ -501,6 +503,7 @@ var ChaindataTables = []string{
 	ContractCode,
 	HeaderNumber,
 	BadHeaderNumber,
+	BadBlocks,
 	BlockBody,
 	Receipts,
 	TxLookup,
@@ -0,0 +1,166 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testSchema = Schema{
+	{Name: "number", Type: Int64, Logical: LogicalUint},
+	{Name: "index", Type: Int32},
+	{Name: "ok", Type: Boolean},
+	{Name: "hash", Type: FixedLenByteArray, Length: 32},
+	{Name: "data", Type: ByteArray},
+	{Name: "to", Type: FixedLenByteArray, Length: 20, Optional: true},
+	{Name: "name", Type: ByteArray, Logical: LogicalString, Optional: true},
+	{Name: "fee", Type: Int64, Optional: true},
+}
+
+func testRow(i int) []any {
+	row := []any{
+		uint64(i) << 40,
+		int32(-i),
+		i%3 == 0,
+		bytes.Repeat([]byte{byte(i)}, 32),
+		bytes.Repeat([]byte{byte(i)}, i%5),
+		nil,
+		nil,
+		nil,
+	}
+	if i%2 == 0 {
+		row[5] = bytes.Repeat([]byte{byte(i + 1)}, 20)
+	}
+	if i%7 != 0 {
+		row[6] = fmt.Sprintf("row %d", i)
+	}
+	if i > 100 {
+		row[7] = int64(i)
+	}
+	return row
+}
+
+func TestWriteRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.parquet")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	w, err := NewWriter(f, testSchema)
+	require.NoError(t, err)
+	w.RowGroupSize = 4096
+	w.SetMeta("from", "1")
+	const n = 1000
+	for i := 0; i < n; i++ {
+		require.NoError(t, w.Write(testRow(i)...))
+	}
+	require.Equal(t, int64(n), w.NumRows())
+	require.NoError(t, w.Close())
+	require.NoError(t, f.Close())
+
+	pf, err := Open(path)
+	require.NoError(t, err)
+	defer pf.Close()
+	require.Equal(t, testSchema, pf.Schema)
+	require.Equal(t, int64(n), pf.NumRows)
+	require.Equal(t, map[string]string{"from": "1"}, pf.Meta)
+	require.Greater(t, len(pf.rowGroups), 1)
+	rows, err := pf.Rows()
+	require.NoError(t, err)
+	require.Len(t, rows, n)
+	for i, row := range rows {
+		require.Equal(t, testRow(i), row, i)
+	}
+}
+
+// goldenFile was checked with a thrift compact protocol decoder written from parquet.thrift and the zstd tool:
+// the footer holds the schema (number: required INT64 UINT_64, ok: required BOOLEAN, name: optional BYTE_ARRAY UTF8),
+// 3 rows in one row group, {from: 1} metadata and created_by "erigon". The pages are PLAIN encoded and zstd
+// compressed: 1, 2, 3 little-endian; the bits 101; RLE definition levels 1, 0, 1 followed by "a" and "c".
+var goldenFile = "" +
+	"5041523115001530154a2c1506150015061506000028b52ffd0400c10000010000000000000002000000000000000300" +
+	"000000000000facdc0e515001502151c2c1506150015061506000028b52ffd0400090000050f9740131500152815422c" +
+	"1506150015061506000028b52ffd0400a100000600000002010200020101000000610100000063c53ecf771502194c48" +
+	"06736368656d611506001504250018066e756d626572251c001500250018026f6b00150c250218046e616d6525000016" +
+	"06191c193c26081c1504192500061918066e756d626572150c16061652166c2608000026741c1500192500061918026f" +
+	"6b150c16061624163e2674000026b2011c150c192500061918046e616d65150c1606164a166426b201000016c0011606" +
+	"00191c180466726f6d180131001806657269676f6e00ab00000050415231"
+
+func TestGolden(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, Schema{
+		{Name: "number", Type: Int64, Logical: LogicalUint},
+		{Name: "ok", Type: Boolean},
+		{Name: "name", Type: ByteArray, Logical: LogicalString, Optional: true},
+	})
+	require.NoError(t, err)
+	w.SetMeta("from", "1")
+	require.NoError(t, w.Write(uint64(1), true, "a"))
+	require.NoError(t, w.Write(uint64(2), false, nil))
+	require.NoError(t, w.Write(uint64(3), true, "c"))
+	require.NoError(t, w.Close())
+
+	golden, err := hex.DecodeString(goldenFile)
+	require.NoError(t, err)
+	// the footer is compared first, so that a change of the metadata is reported on its own
+	footerLen := int(binary.LittleEndian.Uint32(golden[len(golden)-8:])) + 8
+	require.Equal(t, golden[len(golden)-footerLen:], buf.Bytes()[max(buf.Len()-footerLen, 0):])
+	require.Equal(t, golden, buf.Bytes())
+}
+
+func TestWriteErrors(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, testSchema)
+	require.NoError(t, err)
+	require.Error(t, w.Write(uint64(1)))
+	row := testRow(1)
+	row[0] = nil
+	require.Error(t, w.Write(row...))
+	require.Error(t, w.Write(testRow(2)...), "writer is broken after a partial row")
+
+	w, err = NewWriter(&buf, testSchema)
+	require.NoError(t, err)
+	row = testRow(1)
+	row[3] = []byte{1}
+	require.Error(t, w.Write(row...))
+
+	_, err = NewWriter(&buf, Schema{{Name: "x", Type: FixedLenByteArray}})
+	require.Error(t, err)
+}
+
+func TestOpenIncomplete(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.parquet")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	w, err := NewWriter(f, testSchema)
+	require.NoError(t, err)
+	require.NoError(t, w.Write(testRow(1)...))
+	require.NoError(t, w.Close())
+	require.NoError(t, f.Close())
+
+	st, err := os.Stat(path)
+	require.NoError(t, err)
+	require.NoError(t, os.Truncate(path, st.Size()-1))
+	_, err = Open(path)
+	require.Error(t, err)
+}
@@ -0,0 +1,264 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// File is a parquet file written by Writer
+type File struct {
+	f         *os.File
+	Schema    Schema
+	NumRows   int64
+	Meta      map[string]string
+	rowGroups []thriftFields
+}
+
+// Open reads the footer of the file, it fails on files which are not complete
+func Open(path string) (*File, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	pf, err := readFooter(f)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	pf.f = f
+	return pf, nil
+}
+
+func (f *File) Close() error { return f.f.Close() }
+
+func readFooter(f *os.File) (*File, error) {
+	st, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if st.Size() < int64(2*len(magic)+4) {
+		return nil, fmt.Errorf("parquet: file too short")
+	}
+	tail := make([]byte, len(magic)+4)
+	if _, err := f.ReadAt(tail, st.Size()-int64(len(tail))); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(tail[4:], magic) {
+		return nil, fmt.Errorf("parquet: no footer")
+	}
+	footerSize := int64(binary.LittleEndian.Uint32(tail))
+	if footerSize > st.Size()-int64(2*len(magic)+4) {
+		return nil, fmt.Errorf("parquet: footer size %d out of file", footerSize)
+	}
+	footer := make([]byte, footerSize)
+	if _, err := f.ReadAt(footer, st.Size()-int64(len(tail))-footerSize); err != nil {
+		return nil, err
+	}
+	r := thriftReader{buf: footer}
+	m, err := r.readStruct()
+	if err != nil {
+		return nil, err
+	}
+
+	pf := &File{NumRows: m.i64(3), Meta: map[string]string{}}
+	elements := m.list(2)
+	if len(elements) == 0 {
+		return nil, errThriftCorrupted
+	}
+	for _, e := range elements[1:] { // the first one is the root
+		el, ok := e.(thriftFields)
+		if !ok {
+			return nil, errThriftCorrupted
+		}
+		col := Column{
+			Name:     string(el.binary(4)),
+			Type:     Type(el.i64(1)),
+			Length:   int(el.i64(2)),
+			Optional: el.i64(3) == repetitionOptional,
+		}
+		if el.has(6) {
+			switch el.i64(6) {
+			case convertedUTF8:
+				col.Logical = LogicalString
+			case convertedUint32, convertedUint64:
+				col.Logical = LogicalUint
+			}
+		}
+		pf.Schema = append(pf.Schema, col)
+	}
+	for _, rg := range m.list(4) {
+		rgs, ok := rg.(thriftFields)
+		if !ok || len(rgs.list(1)) != len(pf.Schema) {
+			return nil, errThriftCorrupted
+		}
+		pf.rowGroups = append(pf.rowGroups, rgs)
+	}
+	for _, kv := range m.list(5) {
+		if kvs, ok := kv.(thriftFields); ok {
+			pf.Meta[string(kvs.binary(1))] = string(kvs.binary(2))
+		}
+	}
+	return pf, nil
+}
+
+// Rows reads all rows of the file. Values have the types accepted by Writer.Write: bool, int32, int64, []byte, nil
+// - with uint32, uint64 for LogicalUint and string for LogicalString columns.
+func (f *File) Rows() ([][]any, error) {
+	dec, err := zstd.NewReader(nil, zstd.WithDecoderConcurrency(1))
+	if err != nil {
+		return nil, err
+	}
+	defer dec.Close()
+	rows := make([][]any, 0, f.NumRows)
+	for _, rg := range f.rowGroups {
+		numRows := int(rg.i64(3))
+		groupRows := make([][]any, numRows)
+		for i := range groupRows {
+			groupRows[i] = make([]any, len(f.Schema))
+		}
+		for i, chunk := range rg.list(1) {
+			cs, ok := chunk.(thriftFields)
+			if !ok {
+				return nil, errThriftCorrupted
+			}
+			values, err := f.readColumnChunk(dec, &f.Schema[i], cs.structField(3), numRows)
+			if err != nil {
+				return nil, fmt.Errorf("parquet: column %s: %w", f.Schema[i].Name, err)
+			}
+			for j, v := range values {
+				groupRows[j][i] = v
+			}
+		}
+		rows = append(rows, groupRows...)
+	}
+	return rows, nil
+}
+
+func (f *File) readColumnChunk(dec *zstd.Decoder, col *Column, meta thriftFields, numRows int) ([]any, error) {
+	chunk := make([]byte, meta.i64(7))
+	if _, err := f.f.ReadAt(chunk, meta.i64(9)); err != nil && err != io.EOF {
+		return nil, err
+	}
+	r := thriftReader{buf: chunk}
+	header, err := r.readStruct()
+	if err != nil {
+		return nil, err
+	}
+	if header.i64(1) != pageTypeData || int(header.structField(5).i64(1)) != numRows {
+		return nil, errThriftCorrupted
+	}
+	compressedSize := int(header.i64(3))
+	if compressedSize > len(chunk)-r.pos {
+		return nil, errThriftCorrupted
+	}
+	page, err := dec.DecodeAll(chunk[r.pos:r.pos+compressedSize], nil)
+	if err != nil {
+		return nil, err
+	}
+
+	present := make([]bool, numRows)
+	if col.Optional {
+		if len(page) < 4 {
+			return nil, errThriftCorrupted
+		}
+		levelsLen := int(binary.LittleEndian.Uint32(page))
+		if levelsLen > len(page)-4 {
+			return nil, errThriftCorrupted
+		}
+		levels, n := page[4:4+levelsLen], 0
+		for len(levels) > 0 {
+			h, l := binary.Uvarint(levels)
+			if l <= 0 || h&1 != 0 || len(levels) <= l || n+int(h>>1) > numRows {
+				return nil, fmt.Errorf("unsupported definition levels")
+			}
+			for i := 0; i < int(h>>1); i++ {
+				present[n+i] = levels[l] == 1
+			}
+			n += int(h >> 1)
+			levels = levels[l+1:]
+		}
+		page = page[4+levelsLen:]
+	} else {
+		for i := range present {
+			present[i] = true
+		}
+	}
+
+	values := make([]any, numRows)
+	var bools int
+	for i := range values {
+		if !present[i] {
+			continue
+		}
+		switch col.Type {
+		case Boolean:
+			if bools/8 >= len(page) {
+				return nil, errThriftCorrupted
+			}
+			values[i] = page[bools/8]&(1<<(bools%8)) != 0
+			bools++
+			continue
+		case Int32:
+			if len(page) < 4 {
+				return nil, errThriftCorrupted
+			}
+			if v := binary.LittleEndian.Uint32(page); col.Logical == LogicalUint {
+				values[i] = v
+			} else {
+				values[i] = int32(v)
+			}
+			page = page[4:]
+		case Int64:
+			if len(page) < 8 {
+				return nil, errThriftCorrupted
+			}
+			if v := binary.LittleEndian.Uint64(page); col.Logical == LogicalUint {
+				values[i] = v
+			} else {
+				values[i] = int64(v)
+			}
+			page = page[8:]
+		case ByteArray, FixedLenByteArray:
+			l := col.Length
+			if col.Type == ByteArray {
+				if len(page) < 4 {
+					return nil, errThriftCorrupted
+				}
+				l, page = int(binary.LittleEndian.Uint32(page)), page[4:]
+			}
+			if l > len(page) {
+				return nil, errThriftCorrupted
+			}
+			if col.Logical == LogicalString {
+				values[i] = string(page[:l])
+			} else {
+				values[i] = bytes.Clone(page[:l])
+			}
+			page = page[l:]
+		default:
+			return nil, fmt.Errorf("unsupported type %d", col.Type)
+		}
+	}
+	return values, nil
+}
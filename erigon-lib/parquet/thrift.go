@@ -0,0 +1,257 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package parquet
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// Parquet metadata is serialized with the Thrift compact protocol, only the subset used by the parquet structures
+// is implemented: structs, lists, i32, i64 and binary fields.
+
+const (
+	thriftStop   = 0
+	thriftTrue   = 1
+	thriftFalse  = 2
+	thriftByte   = 3
+	thriftI16    = 4
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftDouble = 7
+	thriftBinary = 8
+	thriftList   = 9
+	thriftSet    = 10
+	thriftMap    = 11
+	thriftStruct = 12
+)
+
+type thriftWriter struct {
+	buf     []byte
+	lastIDs []int16 // field ids of the enclosing structs
+	lastID  int16
+}
+
+func (w *thriftWriter) structBegin() {
+	w.lastIDs = append(w.lastIDs, w.lastID)
+	w.lastID = 0
+}
+
+func (w *thriftWriter) structEnd() {
+	w.buf = append(w.buf, thriftStop)
+	w.lastID = w.lastIDs[len(w.lastIDs)-1]
+	w.lastIDs = w.lastIDs[:len(w.lastIDs)-1]
+}
+
+func (w *thriftWriter) field(id int16, typ byte) {
+	if delta := id - w.lastID; delta > 0 && delta <= 15 {
+		w.buf = append(w.buf, byte(delta)<<4|typ)
+	} else {
+		w.buf = append(w.buf, typ)
+		w.buf = binary.AppendVarint(w.buf, int64(id))
+	}
+	w.lastID = id
+}
+
+func (w *thriftWriter) i32(id int16, v int32) {
+	w.field(id, thriftI32)
+	w.buf = binary.AppendVarint(w.buf, int64(v))
+}
+
+func (w *thriftWriter) i64(id int16, v int64) {
+	w.field(id, thriftI64)
+	w.buf = binary.AppendVarint(w.buf, v)
+}
+
+func (w *thriftWriter) binary(id int16, v []byte) {
+	w.field(id, thriftBinary)
+	w.buf = binary.AppendUvarint(w.buf, uint64(len(v)))
+	w.buf = append(w.buf, v...)
+}
+
+func (w *thriftWriter) structField(id int16) {
+	w.field(id, thriftStruct)
+	w.structBegin()
+}
+
+func (w *thriftWriter) list(id int16, elemType byte, size int) {
+	w.field(id, thriftList)
+	if size < 15 {
+		w.buf = append(w.buf, byte(size)<<4|elemType)
+	} else {
+		w.buf = append(w.buf, 0xf0|elemType)
+		w.buf = binary.AppendUvarint(w.buf, uint64(size))
+	}
+}
+
+func (w *thriftWriter) listI32(v int32) { w.buf = binary.AppendVarint(w.buf, int64(v)) }
+
+func (w *thriftWriter) listBinary(v []byte) {
+	w.buf = binary.AppendUvarint(w.buf, uint64(len(v)))
+	w.buf = append(w.buf, v...)
+}
+
+// listStruct starts an element of a list of structs
+func (w *thriftWriter) listStruct() { w.structBegin() }
+
+// thriftFields is a decoded struct: values by field id. Integers are decoded to int64, binaries to []byte, lists
+// to []any, structs to thriftFields.
+type thriftFields map[int16]any
+
+func (s thriftFields) i64(id int16) int64 {
+	v, _ := s[id].(int64)
+	return v
+}
+
+func (s thriftFields) has(id int16) bool {
+	_, ok := s[id]
+	return ok
+}
+
+func (s thriftFields) binary(id int16) []byte {
+	v, _ := s[id].([]byte)
+	return v
+}
+
+func (s thriftFields) list(id int16) []any {
+	v, _ := s[id].([]any)
+	return v
+}
+
+func (s thriftFields) structField(id int16) thriftFields {
+	v, _ := s[id].(thriftFields)
+	return v
+}
+
+var errThriftCorrupted = errors.New("parquet: corrupted thrift metadata")
+
+type thriftReader struct {
+	buf []byte
+	pos int
+}
+
+func (r *thriftReader) byte() (byte, error) {
+	if r.pos >= len(r.buf) {
+		return 0, errThriftCorrupted
+	}
+	r.pos++
+	return r.buf[r.pos-1], nil
+}
+
+func (r *thriftReader) uvarint() (uint64, error) {
+	v, n := binary.Uvarint(r.buf[r.pos:])
+	if n <= 0 {
+		return 0, errThriftCorrupted
+	}
+	r.pos += n
+	return v, nil
+}
+
+func (r *thriftReader) varint() (int64, error) {
+	v, n := binary.Varint(r.buf[r.pos:])
+	if n <= 0 {
+		return 0, errThriftCorrupted
+	}
+	r.pos += n
+	return v, nil
+}
+
+func (r *thriftReader) readStruct() (thriftFields, error) {
+	s := thriftFields{}
+	var lastID int16
+	for {
+		h, err := r.byte()
+		if err != nil {
+			return nil, err
+		}
+		if h == thriftStop {
+			return s, nil
+		}
+		typ := h & 0x0f
+		if delta := int16(h >> 4); delta != 0 {
+			lastID += delta
+		} else {
+			id, err := r.varint()
+			if err != nil {
+				return nil, err
+			}
+			lastID = int16(id)
+		}
+		if typ == thriftTrue || typ == thriftFalse {
+			s[lastID] = typ == thriftTrue
+			continue
+		}
+		if s[lastID], err = r.readValue(typ); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (r *thriftReader) readValue(typ byte) (any, error) {
+	switch typ {
+	case thriftTrue, thriftFalse: // list elements
+		b, err := r.byte()
+		return b == 1, err
+	case thriftByte:
+		b, err := r.byte()
+		return int64(int8(b)), err
+	case thriftI16, thriftI32, thriftI64:
+		return r.varint()
+	case thriftDouble:
+		if r.pos+8 > len(r.buf) {
+			return nil, errThriftCorrupted
+		}
+		r.pos += 8
+		return nil, nil
+	case thriftBinary:
+		l, err := r.uvarint()
+		if err != nil {
+			return nil, err
+		}
+		if l > uint64(len(r.buf)-r.pos) {
+			return nil, errThriftCorrupted
+		}
+		r.pos += int(l)
+		return r.buf[r.pos-int(l) : r.pos], nil
+	case thriftList, thriftSet:
+		h, err := r.byte()
+		if err != nil {
+			return nil, err
+		}
+		size := uint64(h >> 4)
+		if size == 15 {
+			if size, err = r.uvarint(); err != nil {
+				return nil, err
+			}
+		}
+		if size > uint64(len(r.buf)-r.pos) {
+			return nil, errThriftCorrupted
+		}
+		list := make([]any, size)
+		for i := range list {
+			if list[i], err = r.readValue(h & 0x0f); err != nil {
+				return nil, err
+			}
+		}
+		return list, nil
+	case thriftStruct:
+		return r.readStruct()
+	default:
+		return nil, fmt.Errorf("parquet: unsupported thrift type %d", typ)
+	}
+}
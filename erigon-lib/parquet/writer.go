@@ -0,0 +1,387 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+// Package parquet writes flat Apache Parquet files: one level of required or optional columns, PLAIN encoded values
+// and zstd compressed pages - the subset every parquet reader supports.
+package parquet
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+var (
+	magic     = []byte("PAR1")
+	errClosed = errors.New("parquet: writer is closed")
+)
+
+// Type is the physical type of a column
+type Type int32
+
+const (
+	Boolean           Type = 0
+	Int32             Type = 1
+	Int64             Type = 2
+	ByteArray         Type = 6
+	FixedLenByteArray Type = 7
+)
+
+// Logical annotates how the physical values are interpreted
+type Logical int
+
+const (
+	LogicalNone   Logical = iota
+	LogicalString         // ByteArray holding utf8
+	LogicalUint           // unsigned Int32 or Int64
+)
+
+// converted types of the parquet format
+const (
+	convertedUTF8   = 0
+	convertedUint32 = 13
+	convertedUint64 = 14
+)
+
+const (
+	repetitionRequired = 0
+	repetitionOptional = 1
+
+	encodingPlain = 0
+	encodingRLE   = 3
+	codecZstd     = 6
+	pageTypeData  = 0
+)
+
+type Column struct {
+	Name     string
+	Type     Type
+	Length   int // of FixedLenByteArray values
+	Logical  Logical
+	Optional bool // nil values are allowed
+}
+
+type Schema []Column
+
+// DefaultRowGroupSize - amount of encoded bytes buffered before a row group is flushed
+const DefaultRowGroupSize = 64 * 1024 * 1024
+
+type columnBuf struct {
+	defLevels []byte // 1 for present values, only for optional columns
+	values    []byte // PLAIN encoded present values
+	bools     int    // amount of bit-packed booleans in values
+	count     int    // values including nils
+}
+
+// Writer writes rows to a parquet file. Rows are buffered in memory and flushed as a row group when they reach
+// RowGroupSize. Close writes the footer, the file is not valid before it.
+type Writer struct {
+	w            io.Writer
+	schema       Schema
+	meta         map[string]string
+	RowGroupSize int
+
+	cols      []columnBuf
+	rows      int
+	rowGroups []thriftWriter // encoded RowGroup structs, appended to the footer as is
+	offset    int64
+	numRows   int64
+	enc       *zstd.Encoder
+	err       error
+}
+
+func NewWriter(w io.Writer, schema Schema) (*Writer, error) {
+	for _, c := range schema {
+		switch c.Type {
+		case Boolean, Int32, Int64, ByteArray:
+		case FixedLenByteArray:
+			if c.Length <= 0 {
+				return nil, fmt.Errorf("parquet: column %s: fixed length must be positive", c.Name)
+			}
+		default:
+			return nil, fmt.Errorf("parquet: column %s: unsupported type %d", c.Name, c.Type)
+		}
+	}
+	enc, err := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedDefault), zstd.WithEncoderConcurrency(1))
+	if err != nil {
+		return nil, err
+	}
+	pw := &Writer{w: w, schema: schema, meta: map[string]string{}, RowGroupSize: DefaultRowGroupSize, cols: make([]columnBuf, len(schema)), enc: enc}
+	pw.write(magic)
+	return pw, pw.err
+}
+
+// SetMeta sets a key-value pair of the file metadata
+func (w *Writer) SetMeta(key, value string) { w.meta[key] = value }
+
+// NumRows returns the amount of rows written so far
+func (w *Writer) NumRows() int64 { return w.numRows + int64(w.rows) }
+
+// Write appends a row, its values follow the schema. Accepted values: bool for Boolean, int32/uint32 for Int32,
+// int64/uint64 for Int64, []byte or string for ByteArray and FixedLenByteArray, nil for optional columns.
+func (w *Writer) Write(row ...any) error {
+	if w.err != nil {
+		return w.err
+	}
+	if len(row) != len(w.schema) {
+		return fmt.Errorf("parquet: row has %d values, schema has %d columns", len(row), len(w.schema))
+	}
+	for i, v := range row {
+		if err := w.cols[i].append(&w.schema[i], v); err != nil {
+			// the buffered values of the row are partial now
+			w.err = err
+			return err
+		}
+	}
+	w.rows++
+	size := 0
+	for i := range w.cols {
+		size += len(w.cols[i].values) + len(w.cols[i].defLevels)
+	}
+	if size >= w.RowGroupSize {
+		w.flushRowGroup()
+	}
+	return w.err
+}
+
+func (c *columnBuf) append(col *Column, v any) error {
+	c.count++
+	if v == nil {
+		if !col.Optional {
+			return fmt.Errorf("parquet: column %s is required", col.Name)
+		}
+		c.defLevels = append(c.defLevels, 0)
+		return nil
+	}
+	if col.Optional {
+		c.defLevels = append(c.defLevels, 1)
+	}
+	switch col.Type {
+	case Boolean:
+		b, ok := v.(bool)
+		if !ok {
+			return fmt.Errorf("parquet: column %s: unexpected %T", col.Name, v)
+		}
+		if c.bools%8 == 0 {
+			c.values = append(c.values, 0)
+		}
+		if b {
+			c.values[len(c.values)-1] |= 1 << (c.bools % 8)
+		}
+		c.bools++
+	case Int32:
+		switch n := v.(type) {
+		case int32:
+			c.values = binary.LittleEndian.AppendUint32(c.values, uint32(n))
+		case uint32:
+			c.values = binary.LittleEndian.AppendUint32(c.values, n)
+		default:
+			return fmt.Errorf("parquet: column %s: unexpected %T", col.Name, v)
+		}
+	case Int64:
+		switch n := v.(type) {
+		case int64:
+			c.values = binary.LittleEndian.AppendUint64(c.values, uint64(n))
+		case uint64:
+			c.values = binary.LittleEndian.AppendUint64(c.values, n)
+		default:
+			return fmt.Errorf("parquet: column %s: unexpected %T", col.Name, v)
+		}
+	case ByteArray, FixedLenByteArray:
+		var b []byte
+		switch s := v.(type) {
+		case []byte:
+			b = s
+		case string:
+			b = []byte(s)
+		default:
+			return fmt.Errorf("parquet: column %s: unexpected %T", col.Name, v)
+		}
+		if col.Type == FixedLenByteArray {
+			if len(b) != col.Length {
+				return fmt.Errorf("parquet: column %s: value of length %d, expected %d", col.Name, len(b), col.Length)
+			}
+		} else {
+			c.values = binary.LittleEndian.AppendUint32(c.values, uint32(len(b)))
+		}
+		c.values = append(c.values, b...)
+	}
+	return nil
+}
+
+func (w *Writer) write(b []byte) {
+	if w.err != nil {
+		return
+	}
+	n, err := w.w.Write(b)
+	w.offset += int64(n)
+	w.err = err
+}
+
+// flushRowGroup writes every column of the buffered rows as a single data page
+func (w *Writer) flushRowGroup() {
+	if w.rows == 0 || w.err != nil {
+		return
+	}
+	rg := thriftWriter{}
+	rg.structBegin()
+	rg.list(1, thriftStruct, len(w.schema))
+	var totalSize int64
+	for i := range w.cols {
+		col, buf := &w.schema[i], &w.cols[i]
+		var page []byte
+		if col.Optional {
+			levels := appendLevels(nil, buf.defLevels)
+			page = binary.LittleEndian.AppendUint32(page, uint32(len(levels)))
+			page = append(page, levels...)
+		}
+		page = append(page, buf.values...)
+		compressed := w.enc.EncodeAll(page, nil)
+
+		header := thriftWriter{}
+		header.structBegin()
+		header.i32(1, pageTypeData)
+		header.i32(2, int32(len(page)))
+		header.i32(3, int32(len(compressed)))
+		header.structField(5)
+		header.i32(1, int32(buf.count))
+		header.i32(2, encodingPlain)
+		header.i32(3, encodingRLE)
+		header.i32(4, encodingRLE)
+		header.structEnd()
+		header.structEnd()
+
+		pageOffset := w.offset
+		w.write(header.buf)
+		w.write(compressed)
+		uncompressedSize := int64(len(header.buf) + len(page))
+		compressedSize := int64(len(header.buf) + len(compressed))
+		totalSize += uncompressedSize
+
+		rg.listStruct() // ColumnChunk
+		rg.i64(2, pageOffset)
+		rg.structField(3) // ColumnMetaData
+		rg.i32(1, int32(col.Type))
+		rg.list(2, thriftI32, 2)
+		rg.listI32(encodingPlain)
+		rg.listI32(encodingRLE)
+		rg.list(3, thriftBinary, 1)
+		rg.listBinary([]byte(col.Name))
+		rg.i32(4, codecZstd)
+		rg.i64(5, int64(buf.count))
+		rg.i64(6, uncompressedSize)
+		rg.i64(7, compressedSize)
+		rg.i64(9, pageOffset)
+		rg.structEnd()
+		rg.structEnd()
+
+		*buf = columnBuf{defLevels: buf.defLevels[:0], values: buf.values[:0]}
+	}
+	rg.i64(2, totalSize)
+	rg.i64(3, int64(w.rows))
+	rg.structEnd()
+	w.rowGroups = append(w.rowGroups, rg)
+	w.numRows += int64(w.rows)
+	w.rows = 0
+}
+
+// appendLevels encodes definition levels of bit width 1 as RLE runs
+func appendLevels(buf []byte, levels []byte) []byte {
+	for i := 0; i < len(levels); {
+		j := i + 1
+		for j < len(levels) && levels[j] == levels[i] {
+			j++
+		}
+		buf = binary.AppendUvarint(buf, uint64(j-i)<<1)
+		buf = append(buf, levels[i])
+		i = j
+	}
+	return buf
+}
+
+// Close flushes the buffered rows and writes the footer, the underlying writer is not closed
+func (w *Writer) Close() error {
+	defer w.enc.Close()
+	w.flushRowGroup()
+	if w.err != nil {
+		return w.err
+	}
+
+	m := thriftWriter{}
+	m.structBegin()
+	m.i32(1, 1)
+	m.list(2, thriftStruct, len(w.schema)+1)
+	m.listStruct()
+	m.binary(4, []byte("schema"))
+	m.i32(5, int32(len(w.schema)))
+	m.structEnd()
+	for _, col := range w.schema {
+		m.listStruct()
+		m.i32(1, int32(col.Type))
+		if col.Type == FixedLenByteArray {
+			m.i32(2, int32(col.Length))
+		}
+		if col.Optional {
+			m.i32(3, repetitionOptional)
+		} else {
+			m.i32(3, repetitionRequired)
+		}
+		m.binary(4, []byte(col.Name))
+		switch {
+		case col.Logical == LogicalString:
+			m.i32(6, convertedUTF8)
+		case col.Logical == LogicalUint && col.Type == Int32:
+			m.i32(6, convertedUint32)
+		case col.Logical == LogicalUint && col.Type == Int64:
+			m.i32(6, convertedUint64)
+		}
+		m.structEnd()
+	}
+	m.i64(3, w.numRows)
+	m.list(4, thriftStruct, len(w.rowGroups))
+	for _, rg := range w.rowGroups {
+		m.buf = append(m.buf, rg.buf...)
+	}
+	if len(w.meta) > 0 {
+		keys := make([]string, 0, len(w.meta))
+		for k := range w.meta {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		m.list(5, thriftStruct, len(keys))
+		for _, k := range keys {
+			m.listStruct()
+			m.binary(1, []byte(k))
+			m.binary(2, []byte(w.meta[k]))
+			m.structEnd()
+		}
+	}
+	m.binary(6, []byte("erigon"))
+	m.structEnd()
+
+	w.write(m.buf)
+	w.write(binary.LittleEndian.AppendUint32(nil, uint32(len(m.buf))))
+	w.write(magic)
+	if w.err != nil {
+		return w.err
+	}
+	w.err = errClosed
+	return nil
+}
@@ -286,6 +286,11 @@ func (idx *Index) KeyCount() uint64 {
 	return idx.keyCount
 }
 
+// Enums - if true, the index maps keys to ordinal numbers, which are mapped to offsets by OrdinalLookup
+func (idx *Index) Enums() bool {
+	return idx.enums
+}
+
 // Lookup is not thread-safe because it used id.hasher
 func (idx *Index) Lookup(bucketHash, fingerprint uint64) (uint64, bool) {
 	if idx.keyCount == 0 {
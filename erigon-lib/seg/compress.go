@@ -73,6 +73,14 @@ type Cfg struct {
 	SamplingFactor uint64
 
 	Workers int
+
+	// Codec - CodecHuffman uses all the fields above, CodecZstd only the ones below
+	Codec Codec
+	// ZstdBlockSize - minimal size of uncompressed words in a block. Smaller blocks are faster to access randomly
+	// and compress worse. DefaultZstdBlockSize if 0
+	ZstdBlockSize int
+	// ZstdDictSize - max size of the dictionary trained on the blocks. DefaultZstdDictSize if 0
+	ZstdDictSize int
 }
 
 var DefaultCfg = Cfg{
@@ -198,6 +206,9 @@ func (c *Compressor) AddWord(word []byte) error {
 	}
 
 	c.wordsCount++
+	if c.Codec == CodecZstd { // no patterns to look for
+		return c.uncompressedFile.Append(word)
+	}
 	l := 2*len(word) + 2
 	if c.superstringLen+l > superstringLimit {
 		if c.superstringCount%c.SamplingFactor == 0 {
@@ -245,14 +256,17 @@ func (c *Compressor) Compress() error {
 	c.wg.Wait()
 	runtime.GC()
 
-	if c.lvl < log.LvlTrace {
-		c.logger.Log(c.lvl, fmt.Sprintf("[%s] BuildDict start", c.logPrefix), "workers", c.Workers)
-	}
-	db, err := DictionaryBuilderFromCollectors(c.ctx, c.Cfg, c.logPrefix, c.tmpDir, c.suffixCollectors, c.lvl, c.logger)
-	if err != nil {
-		return err
+	var db *DictionaryBuilder
+	var err error
+	if c.Codec != CodecZstd {
+		if c.lvl < log.LvlTrace {
+			c.logger.Log(c.lvl, fmt.Sprintf("[%s] BuildDict start", c.logPrefix), "workers", c.Workers)
+		}
+		if db, err = DictionaryBuilderFromCollectors(c.ctx, c.Cfg, c.logPrefix, c.tmpDir, c.suffixCollectors, c.lvl, c.logger); err != nil {
+			return err
+		}
 	}
-	if c.trace {
+	if c.trace && db != nil {
 		_, fileName := filepath.Split(c.outputFile)
 		if err := PersistDictionary(filepath.Join(c.tmpDir, fileName)+".dictionary.txt", db); err != nil {
 			return err
@@ -266,7 +280,12 @@ func (c *Compressor) Compress() error {
 	}
 	defer cf.Close()
 	t := time.Now()
-	if err := compressWithPatternCandidates(c.ctx, c.trace, c.Cfg, c.logPrefix, c.tmpOutFilePath, cf, c.uncompressedFile, db, c.lvl, c.logger); err != nil {
+	if c.Codec == CodecZstd {
+		err = compressZstdBlocks(c.ctx, c.Cfg, c.logPrefix, cf, c.uncompressedFile, c.lvl, c.logger)
+	} else {
+		err = compressWithPatternCandidates(c.ctx, c.trace, c.Cfg, c.logPrefix, c.tmpOutFilePath, cf, c.uncompressedFile, db, c.lvl, c.logger)
+	}
+	if err != nil {
 		return err
 	}
 	if err = c.fsync(cf); err != nil {
@@ -286,7 +305,7 @@ func (c *Compressor) Compress() error {
 
 	_, fName := filepath.Split(c.outputFile)
 	if c.lvl < log.LvlTrace {
-		c.logger.Log(c.lvl, fmt.Sprintf("[%s] Compress", c.logPrefix), "took", time.Since(t), "ratio", c.Ratio, "file", fName)
+		c.logger.Log(c.lvl, fmt.Sprintf("[%s] Compress", c.logPrefix), "took", time.Since(t), "ratio", c.Ratio, "codec", c.Codec, "file", fName)
 	}
 	return nil
 }
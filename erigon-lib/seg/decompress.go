@@ -136,6 +136,8 @@ type Decompressor struct {
 	serializedDictSize uint64
 	dictWords          int
 
+	zstd *zstdBlocks // only for CodecZstd files
+
 	filePath, FileName1 string
 
 	readAheadRefcnt atomic.Int32 // ref-counter: allow enable/disable read-ahead from goroutines. only when refcnt=0 - disable read-ahead once
@@ -222,6 +224,14 @@ func NewDecompressor(compressedFilePath string) (*Decompressor, error) {
 	d.data = d.mmapHandle1[:d.size]
 	defer d.EnableMadvNormal().DisableReadAhead() //speedup opening on slow drives
 
+	if isZstdFile(d.data) {
+		if err = d.openZstdBlocks(); err != nil {
+			return nil, err
+		}
+		closeDecompressor = false
+		return d, nil
+	}
+
 	d.wordsCount = binary.BigEndian.Uint64(d.data[:8])
 	d.emptyWordsCount = binary.BigEndian.Uint64(d.data[8:16])
 
@@ -467,6 +477,10 @@ func (d *Decompressor) Close() {
 		d.data = nil
 		d.posDict = nil
 		d.dict = nil
+		if d.zstd != nil {
+			d.zstd.dec.Close()
+			d.zstd = nil
+		}
 	}
 }
 
@@ -560,6 +574,14 @@ type Getter struct {
 	dataP       uint64
 	dataBit     int // Value 0..7 - position of the bit
 	trace       bool
+
+	// CodecZstd files: the current word is the wordNum-th of block blockNum, at blockP of the decoded block
+	zstd        *zstdBlocks
+	block       []byte
+	loadedBlock uint64 // number+1 of the block decoded into `block`, 0 if none
+	blockNum    uint64
+	wordNum     uint64
+	blockP      int // -1 if not known yet
 }
 
 func (g *Getter) Trace(t bool)     { g.trace = t }
@@ -652,6 +674,15 @@ func (g *Getter) Size() int {
 	return len(g.data)
 }
 
+// OffsetsLimit - offsets of the words are below it. It's Size for CodecHuffman files, but the offsets of
+// CodecZstd files are block and word numbers, see zstdPos
+func (g *Getter) OffsetsLimit() uint64 {
+	if g.zstd != nil {
+		return g.zstd.count << zstdWordBits
+	}
+	return uint64(len(g.data))
+}
+
 func (d *Decompressor) Count() int           { return int(d.wordsCount) }
 func (d *Decompressor) EmptyWordsCount() int { return int(d.emptyWordsCount) }
 
@@ -664,15 +695,23 @@ func (d *Decompressor) MakeGetter() *Getter {
 		data:        d.data[d.wordsStart:],
 		patternDict: d.dict,
 		fName:       d.FileName1,
+		zstd:        d.zstd,
 	}
 }
 
 func (g *Getter) Reset(offset uint64) {
+	if g.zstd != nil {
+		g.zstdReset(offset)
+		return
+	}
 	g.dataP = offset
 	g.dataBit = 0
 }
 
 func (g *Getter) HasNext() bool {
+	if g.zstd != nil {
+		return g.blockNum < g.zstd.count
+	}
 	return g.dataP < uint64(len(g.data))
 }
 
@@ -680,6 +719,9 @@ func (g *Getter) HasNext() bool {
 // and appends it to the given buf, returning the result of appending
 // After extracting next word, it moves to the beginning of the next one
 func (g *Getter) Next(buf []byte) ([]byte, uint64) {
+	if g.zstd != nil {
+		return g.zstdNext(buf)
+	}
 	defer func() {
 		if rec := recover(); rec != nil {
 			panic(fmt.Sprintf("file: %s, %s, %s", g.fName, rec, dbg.Stack()))
@@ -751,6 +793,9 @@ func (g *Getter) Next(buf []byte) ([]byte, uint64) {
 }
 
 func (g *Getter) NextUncompressed() ([]byte, uint64) {
+	if g.zstd != nil {
+		return g.zstdNext(nil)
+	}
 	defer func() {
 		if rec := recover(); rec != nil {
 			panic(fmt.Sprintf("file: %s, %s, %s", g.fName, rec, dbg.Stack()))
@@ -777,6 +822,9 @@ func (g *Getter) NextUncompressed() ([]byte, uint64) {
 
 // Skip moves offset to the next word and returns the new offset and the length of the word.
 func (g *Getter) Skip() (uint64, int) {
+	if g.zstd != nil {
+		return g.zstdSkip()
+	}
 	l := g.nextPos(true)
 	l-- // because when create huffman tree we do ++ , because 0 is terminator
 	if l == 0 {
@@ -814,6 +862,9 @@ func (g *Getter) Skip() (uint64, int) {
 }
 
 func (g *Getter) SkipUncompressed() (uint64, int) {
+	if g.zstd != nil {
+		return g.zstdSkip()
+	}
 	wordLen := g.nextPos(true)
 	wordLen-- // because when create huffman tree we do ++ , because 0 is terminator
 	if wordLen == 0 {
@@ -834,6 +885,9 @@ func (g *Getter) SkipUncompressed() (uint64, int) {
 
 // MatchPrefix only checks if the word at the current offset has a buf prefix. Does not move offset to the next word.
 func (g *Getter) MatchPrefix(prefix []byte) bool {
+	if g.zstd != nil {
+		return g.zstdMatchPrefix(prefix)
+	}
 	savePos := g.dataP
 	defer func() {
 		g.dataP, g.dataBit = savePos, 0
@@ -917,6 +971,9 @@ func (g *Getter) MatchPrefix(prefix []byte) bool {
 // MatchCmp lexicographically compares given buf with the word at the current offset in the file.
 // returns 0 if buf == word, -1 if buf < word, 1 if buf > word
 func (g *Getter) MatchCmp(buf []byte) int {
+	if g.zstd != nil {
+		return g.zstdMatchCmp(buf)
+	}
 	savePos := g.dataP
 	wordLen := g.nextPos(true)
 	wordLen-- // because when create huffman tree we do ++ , because 0 is terminator
@@ -977,6 +1034,9 @@ func (g *Getter) MatchCmp(buf []byte) int {
 }
 
 func (g *Getter) MatchPrefixUncompressed(prefix []byte) bool {
+	if g.zstd != nil {
+		return g.zstdMatchPrefix(prefix)
+	}
 	savePos := g.dataP
 	defer func() {
 		g.dataP, g.dataBit = savePos, 0
@@ -998,6 +1058,10 @@ func (g *Getter) MatchPrefixUncompressed(prefix []byte) bool {
 }
 
 func (g *Getter) MatchCmpUncompressed(buf []byte) int {
+	if g.zstd != nil {
+		word, _ := g.zstdWord()
+		return bytes.Compare(buf, word)
+	}
 	savePos := g.dataP
 	defer func() {
 		g.dataP, g.dataBit = savePos, 0
@@ -1023,6 +1087,9 @@ func (g *Getter) MatchCmpUncompressed(buf []byte) int {
 // It is important to allocate enough buf size. Could throw an error if word in file is larger then the buf size.
 // After extracting next word, it moves to the beginning of the next one
 func (g *Getter) FastNext(buf []byte) ([]byte, uint64) {
+	if g.zstd != nil {
+		return g.zstdFastNext(buf)
+	}
 	defer func() {
 		if rec := recover(); rec != nil {
 			panic(fmt.Sprintf("file: %s, %s, %s", g.fName, rec, dbg.Stack()))
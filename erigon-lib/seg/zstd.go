@@ -0,0 +1,368 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package seg
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/c2h5oh/datasize"
+	"github.com/klauspost/compress/dict"
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/etl"
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+// Codec selects how the words of a file are encoded
+type Codec uint8
+
+const (
+	// CodecHuffman replaces frequent patterns of the words with Huffman codes, see Compressor
+	CodecHuffman Codec = iota
+	// CodecZstd packs the words into blocks compressed by zstd with a dictionary trained on the blocks of the file.
+	// Keys and values are compressed alike, so FileCompression makes no difference for such files.
+	CodecZstd
+)
+
+func (c Codec) String() string {
+	switch c {
+	case CodecHuffman:
+		return "huffman"
+	case CodecZstd:
+		return "zstd"
+	default:
+		return fmt.Sprintf("unknown codec %d", uint8(c))
+	}
+}
+
+func ParseCodec(s string) (Codec, error) {
+	switch strings.ToLower(s) {
+	case "huffman", "":
+		return CodecHuffman, nil
+	case "zstd":
+		return CodecZstd, nil
+	default:
+		return CodecHuffman, fmt.Errorf("unknown seg codec: %s", s)
+	}
+}
+
+/*
+CodecZstd file layout:
+
+	| magic | words | empty words | blocks | dict size | dict | block 0 | ... | block N-1 | N+1 offsets |
+
+all numbers are big-endian uint64, offsets point to the beginning of each block relative to the beginning of
+block 0 (the last one points to the end of block N-1). A block decompresses into a sequence of
+`uvarint(len(word)) word`. The offset of a word - as returned by Getter.Next and accepted by Getter.Reset - is
+`block<<zstdWordBits | word number in the block`, so it grows monotonically over the file like the offsets of
+CodecHuffman files do.
+*/
+
+// zstdMagic can't be the beginning of a CodecHuffman file: that one starts with the amount of words
+var zstdMagic = []byte{0xff, 'z', 's', 't', 'd', 's', 'e', 'g'}
+
+const (
+	zstdWordBits      = 16
+	zstdMaxBlockWords = 1 << zstdWordBits
+	zstdHeaderSize    = 5 * 8
+
+	DefaultZstdBlockSize = 16 * 1024
+	DefaultZstdDictSize  = 112 * 1024
+	// zstd recommends ~100 times more samples than the size of the dictionary
+	zstdSamplesPerDictByte = 100
+)
+
+func isZstdFile(data []byte) bool { return bytes.HasPrefix(data, zstdMagic) }
+
+// forEachZstdBlock groups the words of uncompressedFile into blocks of at least blockSize bytes
+func forEachZstdBlock(ctx context.Context, uncompressedFile *RawWordsFile, blockSize int, walker func(block []byte, words, emptyWords int) error) error {
+	var block []byte
+	var words, emptyWords int
+	if err := uncompressedFile.ForEach(func(v []byte, _ bool) error {
+		block = binary.AppendUvarint(block, uint64(len(v)))
+		block = append(block, v...)
+		words++
+		if len(v) == 0 {
+			emptyWords++
+		}
+		if len(block) < blockSize && words < zstdMaxBlockWords {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		if err := walker(block, words, emptyWords); err != nil {
+			return err
+		}
+		block, words, emptyWords = block[:0], 0, 0
+		return nil
+	}); err != nil {
+		return err
+	}
+	if words == 0 {
+		return nil
+	}
+	return walker(block, words, emptyWords)
+}
+
+// zstdSamples keeps every stride-th block of the file for the dictionary training. The stride doubles whenever
+// the samples outgrow the limit, so the samples are spread evenly over the whole file and not just its beginning.
+type zstdSamples struct {
+	blocks [][]byte
+	size   int
+	limit  int
+	stride uint64
+}
+
+func (s *zstdSamples) add(blockNum uint64, block []byte) {
+	if blockNum%s.stride != 0 {
+		return
+	}
+	s.blocks = append(s.blocks, common.Copy(block))
+	s.size += len(block)
+	for s.size > s.limit && len(s.blocks) > 1 {
+		kept, size := s.blocks[:0], 0
+		for i := 0; i < len(s.blocks); i += 2 {
+			kept = append(kept, s.blocks[i])
+			size += len(s.blocks[i])
+		}
+		clear(s.blocks[len(kept):])
+		s.blocks, s.size, s.stride = kept, size, s.stride*2
+	}
+}
+
+func compressZstdBlocks(ctx context.Context, cfg Cfg, logPrefix string, cf *os.File, uncompressedFile *RawWordsFile, lvl log.Lvl, logger log.Logger) error {
+	blockSize, dictSize := cfg.ZstdBlockSize, cfg.ZstdDictSize
+	if blockSize <= 0 {
+		blockSize = DefaultZstdBlockSize
+	}
+	if dictSize <= 0 {
+		dictSize = DefaultZstdDictSize
+	}
+
+	// 1-st pass: count words and blocks, sample the blocks for the dictionary
+	var wordsCount, emptyWordsCount, blocksCount uint64
+	samples := zstdSamples{limit: zstdSamplesPerDictByte * dictSize, stride: 1}
+	if err := forEachZstdBlock(ctx, uncompressedFile, blockSize, func(block []byte, words, emptyWords int) error {
+		samples.add(blocksCount, block)
+		wordsCount += uint64(words)
+		emptyWordsCount += uint64(emptyWords)
+		blocksCount++
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	var zdict []byte
+	samplesSize := samples.size
+	if samplesSize > dictSize {
+		var err error
+		if zdict, err = dict.BuildZstdDict(samples.blocks, dict.Options{MaxDictSize: dictSize, HashBytes: 6, ZstdLevel: zstd.SpeedDefault}); err != nil {
+			// too few repetitions to build a dictionary - blocks are compressed without it
+			logger.Debug(fmt.Sprintf("[%s] zstd dictionary skipped", logPrefix), "err", err)
+			zdict = nil
+		}
+	}
+	samples.blocks = nil
+	if lvl < log.LvlTrace {
+		logger.Log(lvl, fmt.Sprintf("[%s] zstd dictionary", logPrefix), "size", datasize.ByteSize(len(zdict)).HR(), "samples", datasize.ByteSize(samplesSize).HR(), "blocks", blocksCount)
+	}
+
+	opts := []zstd.EOption{zstd.WithEncoderLevel(zstd.SpeedDefault), zstd.WithEncoderCRC(false), zstd.WithEncoderConcurrency(1)}
+	if zdict != nil {
+		opts = append(opts, zstd.WithEncoderDict(zdict))
+	}
+	enc, err := zstd.NewWriter(nil, opts...)
+	if err != nil {
+		return err
+	}
+	defer enc.Close()
+
+	cw := bufio.NewWriterSize(cf, 2*etl.BufIOSize)
+	header := make([]byte, 0, zstdHeaderSize)
+	header = append(header, zstdMagic...)
+	header = binary.BigEndian.AppendUint64(header, wordsCount)
+	header = binary.BigEndian.AppendUint64(header, emptyWordsCount)
+	header = binary.BigEndian.AppendUint64(header, blocksCount)
+	header = binary.BigEndian.AppendUint64(header, uint64(len(zdict)))
+	if _, err = cw.Write(header); err != nil {
+		return err
+	}
+	if _, err = cw.Write(zdict); err != nil {
+		return err
+	}
+
+	// 2-nd pass: compress the blocks
+	logEvery := time.NewTicker(20 * time.Second)
+	defer logEvery.Stop()
+	offsets := make([]byte, 0, 8*(blocksCount+1))
+	var offset uint64
+	var compressed []byte
+	var blockNum uint64
+	if err = forEachZstdBlock(ctx, uncompressedFile, blockSize, func(block []byte, _, _ int) error {
+		compressed = enc.EncodeAll(block, compressed[:0])
+		offsets = binary.BigEndian.AppendUint64(offsets, offset)
+		offset += uint64(len(compressed))
+		blockNum++
+		select {
+		case <-logEvery.C:
+			logger.Info(fmt.Sprintf("[%s] Compressed", logPrefix), "processed", fmt.Sprintf("%.2f%%", 100*float64(blockNum)/float64(blocksCount)))
+		default:
+		}
+		_, err := cw.Write(compressed)
+		return err
+	}); err != nil {
+		return err
+	}
+	offsets = binary.BigEndian.AppendUint64(offsets, offset)
+	if _, err = cw.Write(offsets); err != nil {
+		return err
+	}
+	return cw.Flush()
+}
+
+// zstdBlocks is the part of Decompressor which reads CodecZstd files
+type zstdBlocks struct {
+	dec     *zstd.Decoder // safe for concurrent use by getters
+	offsets []byte        // blocksCount+1 offsets of the blocks in Getter.data
+	count   uint64
+}
+
+func (d *Decompressor) openZstdBlocks() error {
+	if d.size < zstdHeaderSize {
+		return &ErrCompressedFileCorrupted{FileName: d.FileName1, Reason: fmt.Sprintf("invalid file size %s", datasize.ByteSize(d.size).HR())}
+	}
+	d.wordsCount = binary.BigEndian.Uint64(d.data[8:16])
+	d.emptyWordsCount = binary.BigEndian.Uint64(d.data[16:24])
+	blocksCount := binary.BigEndian.Uint64(d.data[24:32])
+	dictSize := binary.BigEndian.Uint64(d.data[32:zstdHeaderSize])
+	d.serializedDictSize = dictSize
+
+	wordsStart := zstdHeaderSize + dictSize
+	offsetsSize := 8 * (blocksCount + 1)
+	if wordsStart+offsetsSize > uint64(d.size) || blocksCount > uint64(d.size) {
+		return &ErrCompressedFileCorrupted{FileName: d.FileName1,
+			Reason: fmt.Sprintf("invalid dictSize=%d blocks=%d while file size is just %s", dictSize, blocksCount, datasize.ByteSize(d.size).HR())}
+	}
+	offsetsStart := uint64(d.size) - offsetsSize
+	if binary.BigEndian.Uint64(d.data[uint64(d.size)-8:]) != offsetsStart-wordsStart {
+		return &ErrCompressedFileCorrupted{FileName: d.FileName1, Reason: "last block offset doesn't match the file size"}
+	}
+
+	opts := []zstd.DOption{zstd.WithDecoderConcurrency(0)}
+	if dictSize > 0 {
+		opts = append(opts, zstd.WithDecoderDicts(d.data[zstdHeaderSize:wordsStart]))
+	}
+	dec, err := zstd.NewReader(nil, opts...)
+	if err != nil {
+		return &ErrCompressedFileCorrupted{FileName: d.FileName1, Reason: err.Error()}
+	}
+	d.zstd = &zstdBlocks{dec: dec, offsets: d.data[offsetsStart:], count: blocksCount}
+	d.wordsStart = wordsStart
+	d.data = d.data[:offsetsStart]
+	return nil
+}
+
+func (g *Getter) zstdPos() uint64 { return g.blockNum<<zstdWordBits | g.wordNum }
+
+func (g *Getter) zstdReset(offset uint64) {
+	g.blockNum, g.wordNum, g.blockP = offset>>zstdWordBits, offset&(zstdMaxBlockWords-1), -1
+}
+
+// zstdWord returns the word at the current offset and the position in the decoded block where the next word starts.
+// The word is only valid until the getter moves to another block.
+func (g *Getter) zstdWord() (word []byte, next int) {
+	if g.loadedBlock != g.blockNum+1 {
+		from := binary.BigEndian.Uint64(g.zstd.offsets[8*g.blockNum:])
+		to := binary.BigEndian.Uint64(g.zstd.offsets[8*g.blockNum+8:])
+		var err error
+		if g.block, err = g.zstd.dec.DecodeAll(g.data[from:to], g.block[:0]); err != nil {
+			panic(fmt.Sprintf("file: %s, block: %d, %s", g.fName, g.blockNum, err))
+		}
+		g.loadedBlock, g.blockP = g.blockNum+1, -1
+	}
+	if g.blockP < 0 {
+		g.blockP = 0
+		for i := uint64(0); i < g.wordNum; i++ {
+			l, n := binary.Uvarint(g.block[g.blockP:])
+			if n <= 0 {
+				panic(fmt.Sprintf("file: %s, block: %d, likely .idx is invalid", g.fName, g.blockNum))
+			}
+			g.blockP += n + int(l)
+		}
+	}
+	l, n := binary.Uvarint(g.block[g.blockP:])
+	if n <= 0 {
+		panic(fmt.Sprintf("file: %s, block: %d, word: %d not found", g.fName, g.blockNum, g.wordNum))
+	}
+	start := g.blockP + n
+	return g.block[start : start+int(l)], start + int(l)
+}
+
+func (g *Getter) zstdAdvance(next int) uint64 {
+	if next >= len(g.block) {
+		g.blockNum, g.wordNum, g.blockP = g.blockNum+1, 0, -1
+	} else {
+		g.wordNum, g.blockP = g.wordNum+1, next
+	}
+	return g.zstdPos()
+}
+
+func (g *Getter) zstdNext(buf []byte) ([]byte, uint64) {
+	word, next := g.zstdWord()
+	if buf == nil { // nil - is the marker of "something not found"
+		buf = []byte{}
+	}
+	buf = append(buf, word...)
+	return buf, g.zstdAdvance(next)
+}
+
+func (g *Getter) zstdFastNext(buf []byte) ([]byte, uint64) {
+	word, next := g.zstdWord()
+	buf = buf[:len(word)]
+	copy(buf, word)
+	return buf, g.zstdAdvance(next)
+}
+
+func (g *Getter) zstdSkip() (uint64, int) {
+	word, next := g.zstdWord()
+	return g.zstdAdvance(next), len(word)
+}
+
+func (g *Getter) zstdMatchPrefix(prefix []byte) bool {
+	word, _ := g.zstdWord()
+	return bytes.HasPrefix(word, prefix)
+}
+
+func (g *Getter) zstdMatchCmp(buf []byte) int {
+	word, next := g.zstdWord()
+	cmp := bytes.Compare(buf, word)
+	if cmp == 0 {
+		g.zstdAdvance(next)
+	}
+	return cmp
+}
@@ -0,0 +1,158 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package seg
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+func prepareZstd(t *testing.T, words [][]byte, blockSize int) *Decompressor {
+	t.Helper()
+	tmpDir := t.TempDir()
+	file := filepath.Join(tmpDir, "compressed")
+	cfg := DefaultCfg
+	cfg.Codec = CodecZstd
+	cfg.ZstdBlockSize = blockSize
+	cfg.ZstdDictSize = 1024
+	c, err := NewCompressor(context.Background(), t.Name(), file, tmpDir, cfg, log.LvlDebug, log.New())
+	require.NoError(t, err)
+	defer c.Close()
+	for i, w := range words {
+		if i%2 == 0 {
+			err = c.AddWord(w)
+		} else {
+			err = c.AddUncompressedWord(w)
+		}
+		require.NoError(t, err)
+	}
+	require.NoError(t, c.Compress())
+	d, err := NewDecompressor(file)
+	require.NoError(t, err)
+	return d
+}
+
+func TestZstdCodec(t *testing.T) {
+	var words [][]byte
+	for k := 0; k < 20; k++ {
+		for i, w := range loremStrings {
+			if i%7 == 0 {
+				words = append(words, []byte{})
+				continue
+			}
+			words = append(words, []byte(fmt.Sprintf("%s %d", w, k*len(loremStrings)+i)))
+		}
+	}
+	d := prepareZstd(t, words, 256)
+	defer d.Close()
+	require.Equal(t, len(words), d.Count())
+	require.Equal(t, 20*((len(loremStrings)+6)/7), d.EmptyWordsCount())
+	require.Positive(t, d.SerializedDictSize())
+	require.Greater(t, d.zstd.count, uint64(1))
+
+	g := d.MakeGetter()
+	offsets := make([]uint64, 0, len(words))
+	var offset uint64
+	var w []byte
+	for i := 0; g.HasNext(); i++ {
+		offsets = append(offsets, offset)
+		if i%3 == 0 {
+			var l int
+			offset, l = g.Skip()
+			require.Equal(t, len(words[i]), l)
+			continue
+		}
+		require.True(t, g.MatchPrefix(words[i][:len(words[i])/2]))
+		w, offset = g.Next(w[:0])
+		require.Equal(t, words[i], w)
+	}
+	require.Equal(t, len(words), len(offsets))
+	require.Less(t, offsets[len(offsets)-1], g.OffsetsLimit())
+	require.Equal(t, int(d.Size())-zstdHeaderSize-int(d.SerializedDictSize())-8*int(d.zstd.count+1), g.Size())
+
+	// random access by the offsets, like the indices do
+	for i := len(words) - 1; i >= 0; i -= 5 {
+		g.Reset(offsets[i])
+		require.Equal(t, 0, g.MatchCmp(words[i]))
+		if i+1 < len(words) {
+			w, _ = g.Next(nil)
+			require.Equal(t, words[i+1], w)
+		} else {
+			require.False(t, g.HasNext())
+		}
+	}
+	g.Reset(offsets[1])
+	require.Equal(t, 1, g.MatchCmp(append(words[1], 0)))
+	w, _ = g.NextUncompressed()
+	require.Equal(t, words[1], w)
+
+	r := NewReader(d.MakeGetter(), CompressKeys)
+	for i := 0; r.HasNext(); i++ {
+		w, _ = r.Next(w[:0])
+		require.Equal(t, words[i], w)
+	}
+}
+
+func TestZstdCodecEmpty(t *testing.T) {
+	d := prepareZstd(t, nil, 0)
+	defer d.Close()
+	require.Zero(t, d.Count())
+	require.False(t, d.MakeGetter().HasNext())
+
+	d = prepareZstd(t, [][]byte{{}, {}}, 0)
+	defer d.Close()
+	g := d.MakeGetter()
+	w, _ := g.Next(nil)
+	require.NotNil(t, w)
+	require.Empty(t, w)
+	w, _ = g.Next(nil)
+	require.Empty(t, w)
+	require.False(t, g.HasNext())
+}
+
+func TestParseCodec(t *testing.T) {
+	for _, c := range []Codec{CodecHuffman, CodecZstd} {
+		parsed, err := ParseCodec(c.String())
+		require.NoError(t, err)
+		require.Equal(t, c, parsed)
+	}
+	_, err := ParseCodec("lz4")
+	require.Error(t, err)
+}
+
+func TestZstdSamples(t *testing.T) {
+	s := zstdSamples{limit: 1000, stride: 1}
+	block := make([]byte, 10)
+	for i := uint64(0); i < 1000; i++ {
+		block[0] = byte(i / 100) // tag the samples with the part of the file they come from
+		s.add(i, block)
+	}
+	require.LessOrEqual(t, s.size, s.limit)
+	require.Equal(t, uint64(16), s.stride)
+	require.Len(t, s.blocks, 1000/16+1)
+	parts := map[byte]int{}
+	for _, b := range s.blocks {
+		parts[b[0]]++
+	}
+	require.Len(t, parts, 10) // every part of the file is sampled, not only the beginning
+}
@@ -528,6 +528,23 @@ func sendDiagnostics(startIndexingTime time.Time, indexPercent map[string]int, a
 	})
 }
 
+// RebuildAccessors removes the given accessor files and builds them again. Like BuildMissedIndicesInBackground it
+// doesn't run while other files are being built: then nothing is removed and false is returned.
+func (a *Aggregator) RebuildAccessors(ctx context.Context, files []string, workers int) (bool, error) {
+	if ok := a.buildingFiles.CompareAndSwap(false, true); !ok {
+		return false, nil
+	}
+	defer a.buildingFiles.Store(false)
+	aggTx := a.BeginFilesRo()
+	defer aggTx.Close()
+	for _, fPath := range files {
+		if err := os.Remove(fPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return true, err
+		}
+	}
+	return true, a.BuildMissedIndices(ctx, workers)
+}
+
 func (a *Aggregator) BuildMissedIndicesInBackground(ctx context.Context, workers int) {
 	if ok := a.buildingFiles.CompareAndSwap(false, true); !ok {
 		return
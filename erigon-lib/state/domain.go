@@ -111,6 +111,7 @@ type Domain struct {
 type domainCfg struct {
 	hist     histCfg
 	compress seg.FileCompression
+	codec    seg.Codec // of new .kv files, existing files are read with the codec they were built with
 
 	largeVals                   bool
 	replaceKeysInValues         bool
@@ -155,6 +156,7 @@ func NewDomain(cfg domainCfg, aggregationStep uint64, name kv.Domain, valsTable,
 		integrityCheck:              integrityCheck,
 	}
 
+	d.compressCfg.Codec = cfg.codec
 	d._visible = newDomainVisible(d.name, []visibleFile{})
 
 	var err error
@@ -224,8 +224,8 @@ func (dt *DomainRoTx) lookupByShortenedKey(shortKey []byte, getter *seg.Reader)
 	//getter := NewArchiveGetter(item.decompressor.MakeGetter(), dt.d.compression)
 	getter.Reset(offset)
 	n := getter.HasNext()
-	if !n || uint64(getter.Size()) <= offset {
-		dt.d.logger.Warn("lookupByShortenedKey failed", "file", getter.FileName(), "short", fmt.Sprintf("%x", shortKey), "offset", offset, "hasNext", n, "size", getter.Size(), "offsetBigger", uint64(getter.Size()) <= offset)
+	if !n || getter.OffsetsLimit() <= offset {
+		dt.d.logger.Warn("lookupByShortenedKey failed", "file", getter.FileName(), "short", fmt.Sprintf("%x", shortKey), "offset", offset, "hasNext", n, "size", getter.Size(), "offsetBigger", getter.OffsetsLimit() <= offset)
 		return nil, false
 	}
 
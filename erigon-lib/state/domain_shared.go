@@ -119,7 +119,9 @@ func NewSharedDomains(tx kv.Tx, logger log.Logger) (*SharedDomains, error) {
 	}
 	sd.SetTx(tx)
 
-	sd.aggTx.a.DiscardHistory(kv.CommitmentDomain)
+	if !dbg.KeepCommitmentHistory() {
+		sd.aggTx.a.DiscardHistory(kv.CommitmentDomain)
+	}
 
 	for id, ii := range sd.aggTx.iis {
 		sd.iiWriters[id] = ii.NewWriter()
@@ -241,6 +243,59 @@ func (sd *SharedDomains) rebuildCommitment(ctx context.Context, roTx kv.Tx, bloc
 	return sd.ComputeCommitment(ctx, true, blockNum, "rebuild commit")
 }
 
+// GetCommitmentContext returns commitment context which trie is kept in sync with the domains
+func (sd *SharedDomains) GetCommitmentContext() *SharedDomainsCommitmentContext { return sd.sdCtx }
+
+// SeekCommitmentAsOf restores commitment state stored in commitment history for the end of blockNum
+// (txNum is the first txNum of the next block) and switches all further commitment reads to be done as of txNum.
+// Works only while commitment history is kept (see dbg.KeepCommitmentHistory) and not pruned yet,
+// caller is expected to check returned root hash against the block header.
+func (sd *SharedDomains) SeekCommitmentAsOf(blockNum, txNum uint64) (rootHash []byte, err error) {
+	sd.sdCtx.limitReadAsOfTxNum, sd.sdCtx.branchesAsOf = txNum, true
+	sd.sdCtx.ResetBranchCache()
+	defer sd.sdCtx.ResetBranchCache()
+
+	_, _, state, err := sd.sdCtx.LatestCommitmentState()
+	if err != nil {
+		return nil, err
+	}
+	bn, _, err := sd.sdCtx.restorePatriciaState(state)
+	if err != nil {
+		return nil, err
+	}
+	if bn != blockNum {
+		return nil, fmt.Errorf("commitment history has no state for block %d (found %d)", blockNum, bn)
+	}
+	return sd.sdCtx.patriciaTrie.RootHash()
+}
+
+// RewindCommitment recalculates commitment in memory to represent state for the end of blockNum
+// (txNum is the first txNum of the next block): all keys changed since txNum are re-read as of txNum.
+// Nothing is written into db, so domains should be closed without flush after use.
+func (sd *SharedDomains) RewindCommitment(ctx context.Context, blockNum, txNum uint64) (rootHash []byte, err error) {
+	for _, h := range []kv.History{kv.AccountsHistory, kv.CodeHistory, kv.StorageHistory} {
+		it, err := sd.aggTx.HistoryRange(h, int(txNum), math.MaxInt64, order.Asc, -1, sd.roTx)
+		if err != nil {
+			return nil, err
+		}
+		for it.HasNext() {
+			k, _, err := it.Next()
+			if err != nil {
+				it.Close()
+				return nil, err
+			}
+			if h == kv.StorageHistory {
+				sd.sdCtx.TouchKey(kv.StorageDomain, string(k), nil)
+			} else {
+				sd.sdCtx.TouchKey(kv.AccountsDomain, string(k), nil)
+			}
+		}
+		it.Close()
+	}
+	sd.sdCtx.limitReadAsOfTxNum, sd.sdCtx.branchesAsOf = txNum, false
+	return sd.sdCtx.ComputeCommitment(ctx, false, blockNum, "rewind commitment")
+}
+
 // SeekCommitment lookups latest available commitment and sets it as current
 func (sd *SharedDomains) SeekCommitment(ctx context.Context, tx kv.Tx) (txsFromBlockBeginning uint64, err error) {
 	bn, txn, ok, err := sd.sdCtx.SeekCommitment(tx, sd.aggTx.d[kv.CommitmentDomain], 0, math.MaxUint64)
@@ -886,7 +941,7 @@ func (sd *SharedDomains) DomainPut(domain kv.Domain, k1, k2 []byte, val, prevVal
 	}
 	if prevVal == nil {
 		var err error
-		prevVal, prevStep, err = sd.DomainGet(domain, k1, k2)
+		prevVal, prevStep, err = sd.prevValue(domain, k1, k2)
 		if err != nil {
 			return err
 		}
@@ -914,6 +969,55 @@ func (sd *SharedDomains) DomainPut(domain kv.Domain, k1, k2 []byte, val, prevVal
 	}
 }
 
+// prevValue reads the value which is going to be replaced by DomainPut/DomainDel. Once commitment is rewound
+// (see RewindCommitment) the latest value belongs to the future, so the value as of rewound txNum is read instead.
+func (sd *SharedDomains) prevValue(domain kv.Domain, k1, k2 []byte) (v []byte, step uint64, err error) {
+	if sd.sdCtx.limitReadAsOfTxNum == 0 || domain == kv.CommitmentDomain {
+		return sd.DomainGet(domain, k1, k2)
+	}
+	if len(k2) > 0 {
+		k1 = append(common.Copy(k1), k2...)
+	}
+	v, err = sd.sdCtx.readDomain(domain, k1)
+	return v, sd.sdCtx.limitReadAsOfTxNum / sd.StepSize(), err
+}
+
+// iterateStoragePrefixAsOf is IterateStoragePrefix for rewound domains (see RewindCommitment): storage is read
+// as of rewound txNum with own updates of SharedDomains on top of it.
+func (sd *SharedDomains) iterateStoragePrefixAsOf(prefix []byte, it func(k []byte, v []byte, step uint64) error) error {
+	step := sd.sdCtx.limitReadAsOfTxNum / sd.StepSize()
+	seen := make(map[string]struct{})
+	iter := sd.storage.Iter()
+	for ok := iter.Seek(string(prefix)); ok && bytes.HasPrefix([]byte(iter.Key()), prefix); ok = iter.Next() {
+		seen[iter.Key()] = struct{}{}
+		if v := iter.Value(); len(v.data) > 0 {
+			if err := it([]byte(iter.Key()), v.data, v.prevStep); err != nil {
+				return err
+			}
+		}
+	}
+
+	to, _ := kv.NextSubtree(prefix)
+	r, err := sd.aggTx.DomainRange(context.Background(), sd.roTx, kv.StorageDomain, prefix, to, sd.sdCtx.limitReadAsOfTxNum, order.Asc, -1)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	for r.HasNext() {
+		k, v, err := r.Next()
+		if err != nil {
+			return err
+		}
+		if _, ok := seen[string(k)]; ok || len(v) == 0 {
+			continue
+		}
+		if err := it(k, v, step); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // DomainDel
 // Optimizations:
 //   - user can prvide `prevVal != nil` - then it will not read prev value from storage
@@ -922,7 +1026,7 @@ func (sd *SharedDomains) DomainPut(domain kv.Domain, k1, k2 []byte, val, prevVal
 func (sd *SharedDomains) DomainDel(domain kv.Domain, k1, k2 []byte, prevVal []byte, prevStep uint64) error {
 	if prevVal == nil {
 		var err error
-		prevVal, prevStep, err = sd.DomainGet(domain, k1, k2)
+		prevVal, prevStep, err = sd.prevValue(domain, k1, k2)
 		if err != nil {
 			return err
 		}
@@ -956,7 +1060,11 @@ func (sd *SharedDomains) DomainDelPrefix(domain kv.Domain, prefix []byte) error
 		step uint64
 	}
 	tombs := make([]tuple, 0, 8)
-	if err := sd.IterateStoragePrefix(prefix, func(k, v []byte, step uint64) error {
+	iterate := sd.IterateStoragePrefix
+	if sd.sdCtx.limitReadAsOfTxNum > 0 {
+		iterate = sd.iterateStoragePrefixAsOf
+	}
+	if err := iterate(prefix, func(k, v []byte, step uint64) error {
 		tombs = append(tombs, tuple{k, v, step})
 		return nil
 	}); err != nil {
@@ -968,7 +1076,7 @@ func (sd *SharedDomains) DomainDelPrefix(domain kv.Domain, prefix []byte) error
 		}
 	}
 
-	if assert.Enable {
+	if assert.Enable && sd.sdCtx.limitReadAsOfTxNum == 0 {
 		forgotten := 0
 		if err := sd.IterateStoragePrefix(prefix, func(k, v []byte, step uint64) error {
 			forgotten++
@@ -992,6 +1100,9 @@ type SharedDomainsCommitmentContext struct {
 	updates       *commitment.Updates
 	patriciaTrie  commitment.Trie
 	justRestored  atomic.Bool
+
+	limitReadAsOfTxNum uint64 // if set, state is read as of given txNum instead of latest
+	branchesAsOf       bool   // if set, branches are read from commitment history as of limitReadAsOfTxNum
 }
 
 func NewSharedDomainsCommitmentContext(sd *SharedDomains, mode commitment.Mode, trieVariant commitment.TrieVariant) *SharedDomainsCommitmentContext {
@@ -1029,7 +1140,7 @@ func (sdc *SharedDomainsCommitmentContext) Branch(pref []byte) ([]byte, uint64,
 		return cached.data, cached.step, nil
 	}
 
-	v, step, err := sdc.sharedDomains.LatestCommitment(pref)
+	v, step, err := sdc.branchAsOf(pref)
 	if err != nil {
 		return nil, 0, fmt.Errorf("Branch failed: %w", err)
 	}
@@ -1046,6 +1157,37 @@ func (sdc *SharedDomainsCommitmentContext) Branch(pref []byte) ([]byte, uint64,
 	return v, step, nil
 }
 
+// branchAsOf reads branch from commitment history if branchesAsOf is set and history has value for given prefix.
+// Otherwise latest branch is returned.
+func (sdc *SharedDomainsCommitmentContext) branchAsOf(pref []byte) ([]byte, uint64, error) {
+	if sdc.branchesAsOf {
+		if v, prevStep, ok := sdc.sharedDomains.get(kv.CommitmentDomain, pref); ok {
+			return v, prevStep, nil
+		}
+		aggTx := sdc.sharedDomains.aggTx
+		v, ok, err := aggTx.d[kv.CommitmentDomain].ht.HistorySeek(pref, sdc.limitReadAsOfTxNum, sdc.sharedDomains.roTx)
+		if err != nil {
+			return nil, 0, err
+		}
+		if ok {
+			return v, sdc.limitReadAsOfTxNum / aggTx.a.StepSize(), nil
+		}
+	}
+	return sdc.sharedDomains.LatestCommitment(pref)
+}
+
+func (sdc *SharedDomainsCommitmentContext) readDomain(d kv.Domain, plainKey []byte) ([]byte, error) {
+	if sdc.limitReadAsOfTxNum > 0 {
+		if v, _, ok := sdc.sharedDomains.get(d, plainKey); ok {
+			return v, nil
+		}
+		v, _, err := sdc.sharedDomains.aggTx.DomainGetAsOf(sdc.sharedDomains.roTx, d, plainKey, sdc.limitReadAsOfTxNum)
+		return v, err
+	}
+	v, _, err := sdc.sharedDomains.DomainGet(d, plainKey, nil)
+	return v, err
+}
+
 func (sdc *SharedDomainsCommitmentContext) PutBranch(prefix []byte, data []byte, prevData []byte, prevStep uint64) error {
 	if sdc.sharedDomains.trace {
 		fmt.Printf("[SDC] PutBranch: %x: %x\n", prefix, data)
@@ -1056,7 +1198,7 @@ func (sdc *SharedDomainsCommitmentContext) PutBranch(prefix []byte, data []byte,
 }
 
 func (sdc *SharedDomainsCommitmentContext) Account(plainKey []byte) (*commitment.Update, error) {
-	encAccount, _, err := sdc.sharedDomains.DomainGet(kv.AccountsDomain, plainKey, nil)
+	encAccount, err := sdc.readDomain(kv.AccountsDomain, plainKey)
 	if err != nil {
 		return nil, fmt.Errorf("GetAccount failed: %w", err)
 	}
@@ -1081,7 +1223,7 @@ func (sdc *SharedDomainsCommitmentContext) Account(plainKey []byte) (*commitment
 		return u, nil
 	}
 
-	code, _, err := sdc.sharedDomains.DomainGet(kv.CodeDomain, plainKey, nil)
+	code, err := sdc.readDomain(kv.CodeDomain, plainKey)
 	if err != nil {
 		return nil, fmt.Errorf("GetAccount/Code: failed to read latest code: %w", err)
 	}
@@ -1103,7 +1245,7 @@ func (sdc *SharedDomainsCommitmentContext) Account(plainKey []byte) (*commitment
 
 func (sdc *SharedDomainsCommitmentContext) Storage(plainKey []byte) (*commitment.Update, error) {
 	// Look in the summary table first
-	enc, _, err := sdc.sharedDomains.DomainGet(kv.StorageDomain, plainKey, nil)
+	enc, err := sdc.readDomain(kv.StorageDomain, plainKey)
 	if err != nil {
 		return nil, err
 	}
@@ -1118,6 +1260,27 @@ func (sdc *SharedDomainsCommitmentContext) Storage(plainKey []byte) (*commitment
 	return u, nil
 }
 
+// ProveAccount returns merkle proof of the account from the current commitment trie,
+// storageRoot is nil if account is absent.
+func (sdc *SharedDomainsCommitmentContext) ProveAccount(plainKey []byte) (proof [][]byte, storageRoot []byte, err error) {
+	hph, ok := sdc.patriciaTrie.(*commitment.HexPatriciaHashed)
+	if !ok {
+		return nil, nil, fmt.Errorf("proofs are not supported for trie variant %s", sdc.patriciaTrie.Variant())
+	}
+	defer sdc.ResetBranchCache()
+	return hph.ProveAccount(plainKey)
+}
+
+// ProveStorage returns merkle proof of the storage slot (account address followed by location) from the current commitment trie.
+func (sdc *SharedDomainsCommitmentContext) ProveStorage(plainKey []byte) (proof [][]byte, err error) {
+	hph, ok := sdc.patriciaTrie.(*commitment.HexPatriciaHashed)
+	if !ok {
+		return nil, fmt.Errorf("proofs are not supported for trie variant %s", sdc.patriciaTrie.Variant())
+	}
+	defer sdc.ResetBranchCache()
+	return hph.ProveStorage(plainKey)
+}
+
 func (sdc *SharedDomainsCommitmentContext) Reset() {
 	if !sdc.justRestored.Load() {
 		sdc.patriciaTrie.Reset()
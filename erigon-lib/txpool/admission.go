@@ -0,0 +1,231 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/txpool/txpoolcfg"
+	"github.com/erigontech/erigon-lib/types"
+)
+
+// senderListReloadInterval is how often the sender list files are checked for changes
+const senderListReloadInterval = 5 * time.Second
+
+var ErrSenderListDisabled = errors.New("sender list is not configured")
+
+// AdmissionPolicy decides whether a txn may enter the pool. It is consulted before any other validation, so
+// the txns it refuses never take pool slots. Txns already in the pool are not re-checked when a policy changes.
+type AdmissionPolicy interface {
+	Admit(txn *types.TxSlot, sender common.Address, isLocal bool) bool
+}
+
+// AddAdmissionPolicy installs a policy on top of the sender lists of the config, it must be called before the
+// pool is started
+func (p *TxPool) AddAdmissionPolicy(policy AdmissionPolicy) {
+	p.admissionPolicies = append(p.admissionPolicies, policy)
+}
+
+func (p *TxPool) admit(txn *types.TxSlot, sender common.Address, isLocal bool) txpoolcfg.DiscardReason {
+	for _, policy := range p.admissionPolicies {
+		if !policy.Admit(txn, sender, isLocal) {
+			return txpoolcfg.NotAdmitted
+		}
+	}
+	return txpoolcfg.Success
+}
+
+// UpdateSenderList adds senders to and removes them from the allow or deny list and returns the size of the
+// updated list. The change is written to the list file, so it survives reloads and restarts.
+func (p *TxPool) UpdateSenderList(deny bool, add, remove []common.Address) (int, error) {
+	list := p.senderLists.Allow
+	if deny {
+		list = p.senderLists.Deny
+	}
+	if list == nil {
+		return 0, ErrSenderListDisabled
+	}
+	return list.Update(add, remove)
+}
+
+// SenderListPolicy admits the senders which are on the allow list, when there is one, and not on the deny list
+type SenderListPolicy struct {
+	Allow *SenderList // nil when there is no allow list
+	Deny  *SenderList // nil when there is no deny list
+}
+
+func (p *SenderListPolicy) Admit(_ *types.TxSlot, sender common.Address, _ bool) bool {
+	// an allow list which was never read is empty and refuses everybody
+	if p.Allow != nil && !p.Allow.Contains(sender) {
+		return false
+	}
+	return p.Deny == nil || !p.Deny.Contains(sender)
+}
+
+// reload re-reads the list files which changed since they were last read
+func (p *SenderListPolicy) reload() error {
+	var errs []error
+	for _, list := range []*SenderList{p.Allow, p.Deny} {
+		if list != nil {
+			if _, err := list.Reload(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// SenderList is a set of addresses backed by a file with one hex address per line. Empty lines and lines
+// starting with # are ignored. When the file goes missing the last list read from it is kept, a file which
+// never existed is an empty list which was never loaded.
+type SenderList struct {
+	path    string
+	lock    sync.RWMutex
+	senders map[common.Address]struct{}
+	modTime time.Time
+	size    int64
+	loaded  bool // the file was read at least once
+	missing bool // the file is missing, reported once
+}
+
+func NewSenderList(path string) (*SenderList, error) {
+	l := &SenderList{path: path, senders: map[common.Address]struct{}{}}
+	if _, err := l.Reload(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func (l *SenderList) Contains(addr common.Address) bool {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	_, ok := l.senders[addr]
+	return ok
+}
+
+// Loaded reports whether the list was ever read from its file or written to it
+func (l *SenderList) Loaded() bool {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	return l.loaded
+}
+
+func (l *SenderList) Len() int {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	return len(l.senders)
+}
+
+// Reload re-reads the file if its modification time or size changed, it reports whether the list was re-read.
+// A file which went missing since the last reload is reported as an error once, the list is kept as it was.
+func (l *SenderList) Reload() (bool, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	info, err := os.Stat(l.path)
+	if errors.Is(err, os.ErrNotExist) {
+		if l.missing {
+			return false, nil
+		}
+		l.missing = true
+		if !l.loaded {
+			return false, nil
+		}
+		return false, fmt.Errorf("sender list %s is missing, keeping the last %d senders read from it", l.path, len(l.senders))
+	}
+	if err != nil {
+		return false, err
+	}
+	l.missing = false
+	if info.ModTime().Equal(l.modTime) && info.Size() == l.size {
+		return false, nil
+	}
+	data, err := os.ReadFile(l.path)
+	if err != nil {
+		return false, err
+	}
+	senders, err := parseSenderList(data)
+	if err != nil {
+		return false, fmt.Errorf("sender list %s: %w", l.path, err)
+	}
+	l.senders, l.modTime, l.size, l.loaded = senders, info.ModTime(), info.Size(), true
+	return true, nil
+}
+
+// Update changes the list and rewrites its file, comments of the file are not preserved
+func (l *SenderList) Update(add, remove []common.Address) (int, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	senders := make(map[common.Address]struct{}, len(l.senders)+len(add))
+	for addr := range l.senders {
+		senders[addr] = struct{}{}
+	}
+	for _, addr := range add {
+		senders[addr] = struct{}{}
+	}
+	for _, addr := range remove {
+		delete(senders, addr)
+	}
+
+	sorted := make([]common.Address, 0, len(senders))
+	for addr := range senders {
+		sorted = append(sorted, addr)
+	}
+	slices.SortFunc(sorted, func(a, b common.Address) int { return bytes.Compare(a[:], b[:]) })
+	var buf bytes.Buffer
+	for _, addr := range sorted {
+		buf.WriteString(addr.Hex())
+		buf.WriteByte('\n')
+	}
+	tmpPath := l.path + ".new"
+	if err := os.WriteFile(tmpPath, buf.Bytes(), 0644); err != nil {
+		return 0, err
+	}
+	if err := os.Rename(tmpPath, l.path); err != nil {
+		return 0, err
+	}
+	info, err := os.Stat(l.path)
+	if err != nil {
+		return 0, err
+	}
+	l.senders, l.modTime, l.size, l.loaded, l.missing = senders, info.ModTime(), info.Size(), true, false
+	return len(l.senders), nil
+}
+
+func parseSenderList(data []byte) (map[common.Address]struct{}, error) {
+	senders := map[common.Address]struct{}{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		if !common.IsHexAddress(text) {
+			return nil, fmt.Errorf("line %d: invalid address %q", line, text)
+		}
+		senders[common.HexToAddress(text)] = struct{}{}
+	}
+	return senders, scanner.Err()
+}
@@ -0,0 +1,159 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/common/fixedgas"
+	"github.com/erigontech/erigon-lib/common/u256"
+	"github.com/erigontech/erigon-lib/gointerfaces"
+	remote "github.com/erigontech/erigon-lib/gointerfaces/remoteproto"
+	"github.com/erigontech/erigon-lib/kv/kvcache"
+	"github.com/erigontech/erigon-lib/kv/memdb"
+	"github.com/erigontech/erigon-lib/kv/temporal/temporaltest"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/txpool/txpoolcfg"
+	"github.com/erigontech/erigon-lib/types"
+)
+
+type remoteOnlyPolicy struct{ sender common.Address }
+
+func (p remoteOnlyPolicy) Admit(_ *types.TxSlot, sender common.Address, isLocal bool) bool {
+	return sender != p.sender || !isLocal
+}
+
+func TestSenderLists(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	var allowed, denied, unknown, local common.Address
+	allowed[0], denied[0], unknown[0], local[0] = 1, 2, 3, 4
+
+	dir := t.TempDir()
+	cfg := txpoolcfg.DefaultConfig
+	cfg.AllowList = filepath.Join(dir, "allow.txt")
+	cfg.DenyList = filepath.Join(dir, "deny.txt")
+	require.NoError(os.WriteFile(cfg.AllowList, []byte("# registered senders\n"+allowed.Hex()+"\n\n"+denied.Hex()+"\n"+local.Hex()+"\n"), 0644))
+	require.NoError(os.WriteFile(cfg.DenyList, []byte(denied.Hex()+"\n"), 0644))
+
+	ch := make(chan types.Announcements, 100)
+	coreDB, _ := temporaltest.NewTestDB(t, datadir.New(t.TempDir()))
+	db := memdb.NewTestPoolDB(t)
+	sendersCache := kvcache.New(kvcache.DefaultCoherentConfig)
+	pool, err := New(ch, coreDB, cfg, sendersCache, *u256.N1, common.Big0 /* shanghaiTime */, nil, /* agraBlock */
+		common.Big0 /* cancunTime */, common.Big0 /* pragueTime */, fixedgas.DefaultMaxBlobsPerBlock, nil, log.New())
+	require.NoError(err)
+	pool.AddAdmissionPolicy(remoteOnlyPolicy{sender: local})
+	ctx := context.Background()
+
+	change := &remote.StateChangeBatch{
+		PendingBlockBaseFee: 200000,
+		BlockGasLimit:       1000000,
+		ChangeBatch: []*remote.StateChange{
+			{BlockHeight: 0, BlockHash: gointerfaces.ConvertHashToH256([32]byte{})},
+		},
+	}
+	for _, addr := range []common.Address{allowed, denied, unknown, local} {
+		change.ChangeBatch[0].Changes = append(change.ChangeBatch[0].Changes, &remote.AccountChange{
+			Action:  remote.Action_UPSERT,
+			Address: gointerfaces.ConvertAddressToH160(addr),
+			Data:    types.EncodeAccountBytesV3(0, uint256.NewInt(1*common.Ether), nil, 1),
+		})
+	}
+	tx, err := db.BeginRw(ctx)
+	require.NoError(err)
+	defer tx.Rollback()
+	require.NoError(pool.OnNewBlock(ctx, change, types.TxSlots{}, types.TxSlots{}, types.TxSlots{}, tx))
+
+	var id byte
+	add := func(sender common.Address, nonce uint64, expected txpoolcfg.DiscardReason) {
+		id++
+		txSlot := &types.TxSlot{
+			Tip:    *uint256.NewInt(300000),
+			FeeCap: *uint256.NewInt(300000),
+			Gas:    100000,
+			Nonce:  nonce,
+			Rlp:    []byte{id},
+		}
+		txSlot.IDHash[0] = id
+		var txSlots types.TxSlots
+		txSlots.Append(txSlot, sender[:], true)
+		reasons, err := pool.AddLocalTxs(ctx, txSlots, tx)
+		require.NoError(err)
+		assert.Equal([]txpoolcfg.DiscardReason{expected}, reasons, "txn %d", id)
+	}
+
+	add(allowed, 0, txpoolcfg.Success)
+	add(denied, 0, txpoolcfg.NotAdmitted)
+	add(unknown, 0, txpoolcfg.NotAdmitted)
+	add(local, 0, txpoolcfg.NotAdmitted) // by the custom policy
+
+	// runtime updates are written to the list file
+	size, err := pool.UpdateSenderList(false /* deny */, []common.Address{unknown}, []common.Address{allowed})
+	require.NoError(err)
+	assert.Equal(3, size)
+	add(unknown, 0, txpoolcfg.Success)
+	add(allowed, 1, txpoolcfg.NotAdmitted)
+	list, err := NewSenderList(cfg.AllowList)
+	require.NoError(err)
+	assert.Equal(3, list.Len())
+	assert.True(list.Contains(unknown))
+	assert.False(list.Contains(allowed))
+
+	// changed files are reloaded
+	require.NoError(os.WriteFile(cfg.DenyList, []byte("# nobody is denied\n"), 0644))
+	require.NoError(pool.senderLists.reload())
+	add(denied, 0, txpoolcfg.Success)
+
+	// a file which went missing is reported once and the last list read is kept, a malformed one is refused
+	require.NoError(os.Remove(cfg.AllowList))
+	assert.ErrorContains(pool.senderLists.reload(), "missing")
+	require.NoError(pool.senderLists.reload())
+	add(unknown, 1, txpoolcfg.Success)
+	add(allowed, 1, txpoolcfg.NotAdmitted)
+	require.NoError(os.WriteFile(cfg.AllowList, []byte("0x01\n"), 0644))
+	assert.Error(pool.senderLists.reload())
+	add(unknown, 2, txpoolcfg.Success)
+
+	// an allow list whose file never existed refuses everybody until the file is created, the pool doesn't start
+	// with one
+	list, err = NewSenderList(filepath.Join(dir, "none.txt"))
+	require.NoError(err)
+	assert.False(list.Loaded())
+	policy := &SenderListPolicy{Allow: list}
+	assert.False(policy.Admit(nil, unknown, false))
+	missingCfg := cfg
+	missingCfg.AllowList = filepath.Join(dir, "none.txt")
+	_, err = New(ch, coreDB, missingCfg, sendersCache, *u256.N1, common.Big0, nil, common.Big0, common.Big0, fixedgas.DefaultMaxBlobsPerBlock, nil, log.New())
+	assert.ErrorContains(err, "does not exist")
+	require.NoError(os.WriteFile(filepath.Join(dir, "none.txt"), []byte(allowed.Hex()+"\n"), 0644))
+	require.NoError(policy.reload())
+	assert.True(list.Loaded())
+	assert.True(policy.Admit(nil, allowed, false))
+	assert.False(policy.Admit(nil, unknown, false))
+
+	_, err = (&TxPool{}).UpdateSenderList(true /* deny */, []common.Address{denied}, nil)
+	assert.ErrorIs(err, ErrSenderListDisabled)
+}
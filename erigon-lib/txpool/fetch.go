@@ -17,6 +17,7 @@
 package txpool
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -27,10 +28,12 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/protobuf/types/known/emptypb"
 
+	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/dbg"
 	"github.com/erigontech/erigon-lib/gointerfaces/grpcutil"
 	remote "github.com/erigontech/erigon-lib/gointerfaces/remoteproto"
 	sentry "github.com/erigontech/erigon-lib/gointerfaces/sentryproto"
+	"github.com/erigontech/erigon-lib/gointerfaces/typesproto"
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/log/v3"
 	"github.com/erigontech/erigon-lib/rlp"
@@ -53,6 +56,7 @@ type Fetch struct {
 	sentryClients            []sentry.SentryClient // sentry clients that will be used for accessing the network
 	stateChangesParseCtxLock sync.Mutex
 	pooledTxsParseCtxLock    sync.Mutex
+	scheduler                *fetchScheduler // decides which peer is asked for which announced txn
 	logger                   log.Logger
 }
 
@@ -74,6 +78,7 @@ func NewFetch(ctx context.Context, sentryClients []sentry.SentryClient, pool Poo
 		stateChangesClient:   stateChangesClient,
 		stateChangesParseCtx: types2.NewTxParseContext(chainID).ChainIDRequired(), //TODO: change ctx if rules changed
 		pooledTxsParseCtx:    types2.NewTxParseContext(chainID).ChainIDRequired(),
+		scheduler:            newFetchScheduler(),
 		logger:               logger,
 	}
 	f.pooledTxsParseCtx.ValidateRLP(f.pool.ValidateSerializedTxn)
@@ -108,6 +113,43 @@ func (f *Fetch) ConnectSentries() {
 			f.receivePeerLoop(f.sentryClients[i])
 		}(i)
 	}
+	go f.fetchLoop()
+}
+
+// fetchLoop sends the GetPooledTransactions requests decided by the scheduler
+func (f *Fetch) fetchLoop() {
+	ticker := time.NewTicker(txFetchInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-f.ctx.Done():
+			return
+		case <-ticker.C:
+		case <-f.scheduler.wake:
+		}
+		for _, fetch := range f.scheduler.schedule() {
+			encodedRequest, err := types2.EncodeGetPooledTransactions66(fetch.hashes, fetch.requestID, nil)
+			if err != nil {
+				f.logger.Debug("[txpool.fetch] encoding GetPooledTransactions", "err", err)
+				continue
+			}
+			// a failed request is retried with another peer once it times out
+			if _, err = fetch.sentryClient.SendMessageById(f.ctx, &sentry.SendMessageByIdRequest{
+				Data:   &sentry.OutboundMessageData{Id: sentry.MessageId_GET_POOLED_TRANSACTIONS_66, Data: encodedRequest},
+				PeerId: fetch.peerID,
+			}, &grpc.EmptyCallOption{}); err != nil {
+				f.logger.Debug("[txpool.fetch] sending GetPooledTransactions", "err", err)
+			}
+		}
+	}
+}
+
+// penalize kicks a peer which delivered txns not matching its announcements
+func (f *Fetch) penalize(sentryClient sentry.SentryClient, peerID *typesproto.H512) {
+	f.scheduler.dropPeer(peerID)
+	if _, err := sentryClient.PenalizePeer(f.ctx, &sentry.PenalizePeerRequest{PeerId: peerID, Penalty: sentry.PenaltyKind_Kick}); err != nil {
+		f.logger.Debug("[txpool.fetch] penalizing peer", "err", err)
+	}
 }
 
 func (f *Fetch) ConnectCore() {
@@ -232,42 +274,30 @@ func (f *Fetch) handleInboundMessage(ctx context.Context, req *sentry.InboundMes
 			return err
 		}
 		if len(unknownHashes) > 0 {
-			var encodedRequest []byte
-			var messageID sentry.MessageId
-			if encodedRequest, err = types2.EncodeGetPooledTransactions66(unknownHashes, uint64(1), nil); err != nil {
-				return err
-			}
-			messageID = sentry.MessageId_GET_POOLED_TRANSACTIONS_66
-			if _, err = sentryClient.SendMessageById(f.ctx, &sentry.SendMessageByIdRequest{
-				Data:   &sentry.OutboundMessageData{Id: messageID, Data: encodedRequest},
-				PeerId: req.PeerId,
-			}, &grpc.EmptyCallOption{}); err != nil {
-				return err
-			}
+			f.scheduler.announce(sentryClient, req.PeerId, nil, nil, unknownHashes)
 		}
 	case sentry.MessageId_NEW_POOLED_TRANSACTION_HASHES_68:
-		_, _, hashes, _, err := rlp.ParseAnnouncements(req.Data, 0)
+		txTypes, sizes, hashes, _, err := rlp.ParseAnnouncements(req.Data, 0)
 		if err != nil {
 			return fmt.Errorf("parsing NewPooledTransactionHashes88: %w", err)
 		}
+		if len(txTypes) != len(hashes)/32 {
+			return fmt.Errorf("parsing NewPooledTransactionHashes88: %d types for %d hashes", len(txTypes), len(hashes)/32)
+		}
 		unknownHashes, err := f.pool.FilterKnownIdHashes(tx, hashes)
 		if err != nil {
 			return err
 		}
-
 		if len(unknownHashes) > 0 {
-			var encodedRequest []byte
-			var messageID sentry.MessageId
-			if encodedRequest, err = types2.EncodeGetPooledTransactions66(unknownHashes, uint64(1), nil); err != nil {
-				return err
-			}
-			messageID = sentry.MessageId_GET_POOLED_TRANSACTIONS_66
-			if _, err = sentryClient.SendMessageById(f.ctx, &sentry.SendMessageByIdRequest{
-				Data:   &sentry.OutboundMessageData{Id: messageID, Data: encodedRequest},
-				PeerId: req.PeerId,
-			}, &grpc.EmptyCallOption{}); err != nil {
-				return err
+			// keep types and sizes aligned with the unknown hashes
+			unknownTypes, unknownSizes := make([]byte, 0, unknownHashes.Len()), make([]uint32, 0, unknownHashes.Len())
+			for i, j := 0, 0; i < len(txTypes) && j < unknownHashes.Len(); i++ {
+				if bytes.Equal(hashes[i*32:(i+1)*32], unknownHashes.At(j)) {
+					unknownTypes, unknownSizes = append(unknownTypes, txTypes[i]), append(unknownSizes, sizes[i])
+					j++
+				}
 			}
+			f.scheduler.announce(sentryClient, req.PeerId, unknownTypes, unknownSizes, unknownHashes)
 		}
 	case sentry.MessageId_GET_POOLED_TRANSACTIONS_66:
 		//TODO: handleInboundMessage is single-threaded - means it can accept as argument couple buffers (or analog of txParseContext). Protobuf encoding will copy data anyway, but DirectClient doesn't
@@ -320,6 +350,7 @@ func (f *Fetch) handleInboundMessage(ctx context.Context, req *sentry.InboundMes
 		}
 	case sentry.MessageId_POOLED_TRANSACTIONS_66, sentry.MessageId_TRANSACTIONS_66:
 		txs := types2.TxSlots{}
+		var delivered [][]byte // hashes of all the txns in the message, including the rejected ones
 		if err := f.threadSafeParsePooledTxn(func(parseContext *types2.TxParseContext) error {
 			return nil
 		}); err != nil {
@@ -330,6 +361,7 @@ func (f *Fetch) handleInboundMessage(ctx context.Context, req *sentry.InboundMes
 		case sentry.MessageId_TRANSACTIONS_66:
 			if err := f.threadSafeParsePooledTxn(func(parseContext *types2.TxParseContext) error {
 				if _, err := types2.ParseTransactions(req.Data, 0, parseContext, &txs, func(hash []byte) error {
+					delivered = append(delivered, common.Copy(hash))
 					known, err := f.pool.IdHashKnown(tx, hash)
 					if err != nil {
 						return err
@@ -341,13 +373,15 @@ func (f *Fetch) handleInboundMessage(ctx context.Context, req *sentry.InboundMes
 				}); err != nil {
 					return err
 				}
+				f.scheduler.broadcast(delivered)
 				return nil
 			}); err != nil {
 				return err
 			}
 		case sentry.MessageId_POOLED_TRANSACTIONS_66:
 			if err := f.threadSafeParsePooledTxn(func(parseContext *types2.TxParseContext) error {
-				if _, _, err := types2.ParsePooledTransactions66(req.Data, 0, parseContext, &txs, func(hash []byte) error {
+				requestID, _, err := types2.ParsePooledTransactions66(req.Data, 0, parseContext, &txs, func(hash []byte) error {
+					delivered = append(delivered, common.Copy(hash))
 					known, err := f.pool.IdHashKnown(tx, hash)
 					if err != nil {
 						return err
@@ -356,9 +390,13 @@ func (f *Fetch) handleInboundMessage(ctx context.Context, req *sentry.InboundMes
 						return types2.ErrRejected
 					}
 					return nil
-				}); err != nil {
+				})
+				if err != nil {
 					return err
 				}
+				if f.scheduler.delivered(req.PeerId, requestID, delivered, txs.Txs) {
+					f.penalize(sentryClient, req.PeerId)
+				}
 				return nil
 			}); err != nil {
 				return err
@@ -443,6 +481,8 @@ func (f *Fetch) handleNewPeer(req *sentry.PeerEvent) error {
 	switch req.EventId {
 	case sentry.PeerEvent_Connect:
 		f.pool.AddNewGoodPeer(req.PeerId)
+	case sentry.PeerEvent_Disconnect:
+		f.scheduler.dropPeer(req.PeerId)
 	}
 
 	return nil
@@ -0,0 +1,322 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"sync"
+	"time"
+
+	"github.com/erigontech/erigon-lib/gointerfaces"
+	sentry "github.com/erigontech/erigon-lib/gointerfaces/sentryproto"
+	"github.com/erigontech/erigon-lib/gointerfaces/typesproto"
+	"github.com/erigontech/erigon-lib/types"
+)
+
+const (
+	// txArriveTimeout is the time an announced txn waits for a direct broadcast before it is requested, blob txns
+	// are never broadcast and are requested right away
+	txArriveTimeout = 500 * time.Millisecond
+	// txFetchTimeout is the time a peer has to reply to a GetPooledTransactions request
+	txFetchTimeout = 5 * time.Second
+	// txFetchInterval is how often waiting and timed out txns are rescheduled, newly announced blob txns are
+	// scheduled right away
+	txFetchInterval = 100 * time.Millisecond
+	// txBlobSizeTolerance is how much the size of a delivered blob txn may differ from the announced one, peers
+	// don't agree on whether the size of the network wrapper is announced
+	txBlobSizeTolerance = 8
+
+	maxTxFetchesPerPeer   = 2          // in-flight GetPooledTransactions requests per peer
+	maxTxFetchHashes      = 256        // hashes per GetPooledTransactions request
+	maxTxFetchSize        = 128 * 1024 // announced bytes per GetPooledTransactions request
+	maxTxAnnouncesPerPeer = 4096       // tracked announcements per peer, the rest are ignored
+)
+
+type peerKey = [64]byte
+
+// txAnnounce is what a peer told about a txn hash
+type txAnnounce struct {
+	txType byte
+	size   uint32
+	typed  bool // announced via eth/68, type and size are known
+}
+
+// matches reports whether the delivered txn is what was announced
+func (a txAnnounce) matches(txn *types.TxSlot) bool {
+	if a.txType != txn.Type {
+		return false
+	}
+	if a.txType == types.BlobTxType {
+		return max(a.size, txn.Size)-min(a.size, txn.Size) <= txBlobSizeTolerance
+	}
+	return a.size == txn.Size
+}
+
+type fetchRequest struct {
+	hashes []string
+	sent   time.Time
+}
+
+type fetchPeer struct {
+	sentryClient sentry.SentryClient
+	id           *typesproto.H512
+	announces    map[string]txAnnounce
+	requests     map[uint64]*fetchRequest // by request id
+}
+
+// txFetch is a GetPooledTransactions request to be sent
+type txFetch struct {
+	sentryClient sentry.SentryClient
+	peerID       *typesproto.H512
+	requestID    uint64
+	hashes       types.Hashes
+}
+
+// fetchScheduler decides which peer is asked for which announced txn. Every tracked hash is in exactly one of
+// the states: waiting for a direct broadcast, queued for a request or being fetched from a peer. Hashes which
+// were not delivered in time are retried with the other peers which announced them.
+type fetchScheduler struct {
+	lock       sync.Mutex
+	now        func() time.Time
+	peers      map[peerKey]*fetchPeer
+	announcers map[string]map[peerKey]struct{}
+	waiting    map[string]time.Time // hash -> time of the first announcement
+	queued     map[string]struct{}
+	fetching   map[string]peerKey
+	requestID  uint64
+	wake       chan struct{} // signalled when announced hashes are queued without waiting
+}
+
+func newFetchScheduler() *fetchScheduler {
+	return &fetchScheduler{
+		now:        time.Now,
+		peers:      map[peerKey]*fetchPeer{},
+		announcers: map[string]map[peerKey]struct{}{},
+		waiting:    map[string]time.Time{},
+		queued:     map[string]struct{}{},
+		fetching:   map[string]peerKey{},
+		wake:       make(chan struct{}, 1),
+	}
+}
+
+// announce records hashes announced by a peer. txTypes and sizes are nil for eth/66 announcements.
+func (s *fetchScheduler) announce(sentryClient sentry.SentryClient, peerID *typesproto.H512, txTypes []byte, sizes []uint32, hashes types.Hashes) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	key := gointerfaces.ConvertH512ToHash(peerID)
+	peer, ok := s.peers[key]
+	if !ok {
+		peer = &fetchPeer{sentryClient: sentryClient, id: peerID, announces: map[string]txAnnounce{}, requests: map[uint64]*fetchRequest{}}
+		s.peers[key] = peer
+	}
+	now := s.now()
+	queued := false
+	defer func() {
+		if queued {
+			select {
+			case s.wake <- struct{}{}:
+			default:
+			}
+		}
+	}()
+	for i := 0; i < hashes.Len(); i++ {
+		if len(peer.announces) >= maxTxAnnouncesPerPeer {
+			return
+		}
+		hash := string(hashes.At(i))
+		if _, ok := peer.announces[hash]; ok {
+			continue
+		}
+		var announce txAnnounce
+		if i < len(txTypes) && i < len(sizes) {
+			announce = txAnnounce{txType: txTypes[i], size: sizes[i], typed: true}
+		}
+		peer.announces[hash] = announce
+		if announcers, ok := s.announcers[hash]; ok {
+			announcers[key] = struct{}{}
+			continue
+		}
+		s.announcers[hash] = map[peerKey]struct{}{key: {}}
+		if announce.typed && announce.txType == types.BlobTxType {
+			s.queued[hash] = struct{}{} // blob txns are never broadcast
+			queued = true
+		} else {
+			s.waiting[hash] = now
+		}
+	}
+}
+
+// schedule returns the requests to send: blob txns, txns which waited long enough for a broadcast and those whose
+// fetch timed out are assigned to the peers which announced them and have free request slots
+func (s *fetchScheduler) schedule() []txFetch {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	now := s.now()
+	for hash, arrived := range s.waiting {
+		if now.Sub(arrived) >= txArriveTimeout {
+			delete(s.waiting, hash)
+			s.queued[hash] = struct{}{}
+		}
+	}
+	for key, peer := range s.peers {
+		for requestID, req := range peer.requests {
+			if now.Sub(req.sent) >= txFetchTimeout {
+				delete(peer.requests, requestID)
+				s.requeue(key, req.hashes, nil)
+			}
+		}
+	}
+	if len(s.queued) == 0 {
+		return nil
+	}
+
+	// only the queued hashes are visited, not every announcement of every peer
+	type batch struct {
+		hashes []string
+		size   uint64
+	}
+	var fetches []txFetch
+	batches := map[peerKey]*batch{}
+	for hash := range s.queued {
+		for key := range s.announcers[hash] {
+			peer := s.peers[key]
+			if len(peer.requests) >= maxTxFetchesPerPeer {
+				continue
+			}
+			b, ok := batches[key]
+			if !ok {
+				b = &batch{}
+				batches[key] = b
+			}
+			b.hashes = append(b.hashes, hash)
+			b.size += uint64(peer.announces[hash].size)
+			if len(b.hashes) >= maxTxFetchHashes || b.size >= maxTxFetchSize {
+				fetches = append(fetches, s.request(key, b.hashes, now))
+				delete(batches, key)
+			}
+			break
+		}
+	}
+	for key, b := range batches {
+		fetches = append(fetches, s.request(key, b.hashes, now))
+	}
+	return fetches
+}
+
+// request moves the hashes from the queue to a new in-flight request to the peer
+func (s *fetchScheduler) request(key peerKey, hashes []string, now time.Time) txFetch {
+	peer := s.peers[key]
+	s.requestID++
+	peer.requests[s.requestID] = &fetchRequest{hashes: hashes, sent: now}
+	encoded := make(types.Hashes, 0, len(hashes)*32)
+	for _, hash := range hashes {
+		delete(s.queued, hash)
+		s.fetching[hash] = key
+		encoded = append(encoded, hash...)
+	}
+	return txFetch{sentryClient: peer.sentryClient, peerID: peer.id, requestID: s.requestID, hashes: encoded}
+}
+
+// delivered handles a PooledTransactions reply with the hashes of all the txns it carried. It reports whether
+// any of the parsed txns contradicts the type or size the peer announced for it.
+func (s *fetchScheduler) delivered(peerID *typesproto.H512, requestID uint64, hashes [][]byte, txs []*types.TxSlot) (mismatch bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	key := gointerfaces.ConvertH512ToHash(peerID)
+	peer, ok := s.peers[key]
+	if ok {
+		for _, txn := range txs {
+			announce, ok := peer.announces[string(txn.IDHash[:])]
+			if ok && announce.typed && !announce.matches(txn) {
+				mismatch = true
+			}
+		}
+	}
+	delivered := make(map[string]struct{}, len(hashes))
+	for _, hash := range hashes {
+		delivered[string(hash)] = struct{}{}
+		s.forget(string(hash))
+	}
+	if !ok {
+		return mismatch
+	}
+	if req, ok := peer.requests[requestID]; ok {
+		delete(peer.requests, requestID)
+		s.requeue(key, req.hashes, delivered)
+	}
+	return mismatch
+}
+
+// broadcast stops tracking the hashes of txns which arrived without being requested
+func (s *fetchScheduler) broadcast(hashes [][]byte) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for _, hash := range hashes {
+		s.forget(string(hash))
+	}
+}
+
+// dropPeer forgets the announcements of a peer, the txns it was asked for are retried with other announcers
+func (s *fetchScheduler) dropPeer(peerID *typesproto.H512) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	key := gointerfaces.ConvertH512ToHash(peerID)
+	peer, ok := s.peers[key]
+	if !ok {
+		return
+	}
+	hashes := make([]string, 0, len(peer.announces))
+	for hash := range peer.announces {
+		hashes = append(hashes, hash)
+	}
+	s.requeue(key, hashes, nil)
+	delete(s.peers, key)
+}
+
+// requeue puts back to the queue the hashes which were not delivered by the peer, without that peer as their
+// announcer. Hashes nobody else announced are forgotten.
+func (s *fetchScheduler) requeue(key peerKey, hashes []string, delivered map[string]struct{}) {
+	peer := s.peers[key]
+	for _, hash := range hashes {
+		if _, ok := delivered[hash]; ok {
+			continue
+		}
+		announcers, ok := s.announcers[hash]
+		if !ok {
+			continue
+		}
+		delete(peer.announces, hash)
+		delete(announcers, key)
+		if len(announcers) == 0 {
+			s.forget(hash)
+			continue
+		}
+		if fetcher, ok := s.fetching[hash]; ok && fetcher == key {
+			delete(s.fetching, hash)
+			s.queued[hash] = struct{}{}
+		}
+	}
+}
+
+func (s *fetchScheduler) forget(hash string) {
+	for key := range s.announcers[hash] {
+		delete(s.peers[key].announces, hash)
+	}
+	delete(s.announcers, hash)
+	delete(s.waiting, hash)
+	delete(s.queued, hash)
+	delete(s.fetching, hash)
+}
@@ -0,0 +1,152 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/gointerfaces"
+	"github.com/erigontech/erigon-lib/gointerfaces/typesproto"
+	"github.com/erigontech/erigon-lib/types"
+)
+
+func TestFetchScheduler(t *testing.T) {
+	now := time.Unix(0, 0)
+	s := newFetchScheduler()
+	s.now = func() time.Time { return now }
+
+	peerA := gointerfaces.ConvertHashToH512([64]byte{1})
+	peerB := gointerfaces.ConvertHashToH512([64]byte{2})
+	hash := func(b byte) []byte {
+		h := make([]byte, 32)
+		h[0] = b
+		return h
+	}
+	hashes := func(bs ...byte) types.Hashes {
+		var hs types.Hashes
+		for _, b := range bs {
+			hs = append(hs, hash(b)...)
+		}
+		return hs
+	}
+	fetchedFrom := func(fetches []txFetch, peerID *typesproto.H512) []txFetch {
+		var res []txFetch
+		for _, fetch := range fetches {
+			if fetch.peerID == peerID {
+				res = append(res, fetch)
+			}
+		}
+		return res
+	}
+
+	// announced by both peers, the txn waits for a broadcast, then it is requested from a single announcer only
+	s.announce(nil, peerA, []byte{types.DynamicFeeTxType}, []uint32{100}, hashes(1))
+	s.announce(nil, peerB, []byte{types.DynamicFeeTxType}, []uint32{100}, hashes(1))
+	s.announce(nil, peerA, nil, nil, hashes(1)) // duplicate announcement
+	assert.Empty(t, s.wake)
+	assert.Empty(t, s.schedule())
+	now = now.Add(txArriveTimeout)
+	fetches := s.schedule()
+	require.Len(t, fetches, 1)
+	assert.Equal(t, hashes(1), fetches[0].hashes)
+	first, second := peerA, peerB
+	if fetches[0].peerID == peerB {
+		first, second = peerB, peerA
+	}
+	assert.Empty(t, s.schedule())
+
+	// blob txns are never broadcast, they are requested right away
+	s.announce(nil, peerB, []byte{types.BlobTxType}, []uint32{200_000}, hashes(2))
+	require.Len(t, s.wake, 1)
+	<-s.wake
+	fetches = s.schedule()
+	require.Len(t, fetches, 1)
+	assert.Equal(t, peerB, fetches[0].peerID)
+	assert.Equal(t, hashes(2), fetches[0].hashes)
+
+	// a txn broadcast while it waits is never requested, whether announced via eth/66 or eth/68
+	s.announce(nil, peerA, nil, nil, hashes(3))
+	s.announce(nil, peerA, []byte{types.LegacyTxType}, []uint32{100}, hashes(4))
+	assert.Empty(t, s.wake)
+	s.broadcast([][]byte{hash(3), hash(4)})
+	assert.Empty(t, s.waiting)
+	assert.NotContains(t, s.announcers, string(hash(3)))
+	assert.NotContains(t, s.announcers, string(hash(4)))
+
+	// the request times out, the txn is retried with the other announcer
+	now = now.Add(txFetchTimeout)
+	fetches = s.schedule()
+	retries := fetchedFrom(fetches, second)
+	require.Len(t, retries, 1)
+	assert.Equal(t, hashes(1), retries[0].hashes)
+	assert.Empty(t, fetchedFrom(fetches, first))
+	assert.False(t, s.delivered(second, retries[0].requestID, [][]byte{hash(1)}, []*types.TxSlot{{IDHash: [32]byte{1}, Type: types.DynamicFeeTxType, Size: 100}}))
+	assert.NotContains(t, s.announcers, string(hash(1)))
+
+	// the blob fetch timed out too and nobody else announced the txn
+	assert.NotContains(t, s.announcers, string(hash(2)))
+
+	// a limited number of requests are in flight per peer
+	for i := 0; i < maxTxFetchesPerPeer+1; i++ {
+		s.announce(nil, peerA, []byte{types.BlobTxType}, []uint32{maxTxFetchSize}, hashes(byte(10+i)))
+	}
+	fetches = s.schedule()
+	require.Len(t, fetches, maxTxFetchesPerPeer)
+	assert.Empty(t, s.schedule())
+
+	// a delivery which does not match the announcement is reported, undelivered txns go back to the queue
+	mismatch := s.delivered(peerA, fetches[0].requestID, nil, []*types.TxSlot{{IDHash: [32]byte(fetches[0].hashes), Type: types.DynamicFeeTxType, Size: 100}})
+	assert.True(t, mismatch)
+	assert.NotContains(t, s.announcers, string(fetches[0].hashes)) // peerA was the only announcer
+	s.dropPeer(peerA)
+	assert.Empty(t, s.queued)
+	assert.Empty(t, s.fetching)
+
+	// the txns of a dropped peer are retried with the other announcers
+	s.announce(nil, peerA, nil, nil, hashes(20))
+	s.announce(nil, peerB, nil, nil, hashes(20))
+	now = now.Add(txArriveTimeout)
+	s.schedule()
+	fetcher := s.fetching[string(hash(20))]
+	other := peerB
+	if fetcher == gointerfaces.ConvertH512ToHash(peerB) {
+		other = peerA
+	}
+	s.dropPeer(gointerfaces.ConvertHashToH512(fetcher))
+	fetches = s.schedule()
+	require.Len(t, fetchedFrom(fetches, other), 1)
+	assert.Equal(t, hashes(20), fetchedFrom(fetches, other)[0].hashes)
+}
+
+func TestTxAnnounceMatches(t *testing.T) {
+	announced := func(txType byte, size uint32) txAnnounce {
+		return txAnnounce{txType: txType, size: size, typed: true}
+	}
+	assert.True(t, announced(types.DynamicFeeTxType, 100).matches(&types.TxSlot{Type: types.DynamicFeeTxType, Size: 100}))
+	assert.False(t, announced(types.DynamicFeeTxType, 100).matches(&types.TxSlot{Type: types.DynamicFeeTxType, Size: 101}))
+	assert.False(t, announced(types.DynamicFeeTxType, 100).matches(&types.TxSlot{Type: types.LegacyTxType, Size: 100}))
+
+	// blob txn sizes may be a few bytes off
+	assert.True(t, announced(types.BlobTxType, 131_300).matches(&types.TxSlot{Type: types.BlobTxType, Size: 131_300 - txBlobSizeTolerance}))
+	assert.True(t, announced(types.BlobTxType, 131_300).matches(&types.TxSlot{Type: types.BlobTxType, Size: 131_300 + txBlobSizeTolerance}))
+	assert.False(t, announced(types.BlobTxType, 131_300).matches(&types.TxSlot{Type: types.BlobTxType, Size: 131_300 + txBlobSizeTolerance + 1}))
+	assert.False(t, announced(types.BlobTxType, 131_300).matches(&types.TxSlot{Type: types.DynamicFeeTxType, Size: 131_300}))
+}
@@ -0,0 +1,230 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/length"
+	"github.com/erigontech/erigon-lib/rlp"
+)
+
+const (
+	// journalBatchSize is the number of journal entries replayed at once
+	journalBatchSize = 1024
+	// maxJournalEntrySize bounds what a corrupted length prefix can make the reader allocate, far above the size
+	// of any transaction wrapped with its blobs
+	maxJournalEntrySize = 64 * 1024 * 1024
+)
+
+var errNoActiveJournal = errors.New("no active journal")
+
+// JournalEntry is a transaction of the journal along with its sender
+type JournalEntry struct {
+	Sender common.Address
+	Rlp    []byte // as the pool keeps it: without network envelope, blob txs wrapped with their blobs
+}
+
+// WriteJournal appends the entries to w, each of them is an RLP list of the sender and the transaction RLP
+func WriteJournal(w io.Writer, entries ...JournalEntry) error {
+	var buf []byte
+	for _, entry := range entries {
+		payloadLen := rlp.StringLen(entry.Sender[:]) + rlp.StringLen(entry.Rlp)
+		size := rlp.ListPrefixLen(payloadLen) + payloadLen
+		if cap(buf) < size {
+			buf = make([]byte, size)
+		}
+		buf = buf[:size]
+		pos := rlp.EncodeListPrefix(payloadLen, buf)
+		pos += rlp.EncodeString(entry.Sender[:], buf[pos:])
+		rlp.EncodeString(entry.Rlp, buf[pos:])
+		if _, err := w.Write(buf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadJournal calls f for every entry written by WriteJournal. The journal is streamed, f gets the entries as
+// they are read, so those before a malformed one, e.g. the last entry cut by a crash in the middle of a write,
+// are applied before the error about it is returned.
+func ReadJournal(r io.Reader, f func(entry JournalEntry) error) error {
+	br := bufio.NewReader(r)
+	for pos := 0; ; {
+		data, err := readJournalEntry(br)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("journal entry at %d: %w", pos, err)
+		}
+		dataPos, dataLen, err := rlp.List(data, 0)
+		if err != nil {
+			return fmt.Errorf("journal entry at %d: %w", pos, err)
+		}
+		senderPos, err := rlp.StringOfLen(data, dataPos, length.Addr)
+		if err != nil {
+			return fmt.Errorf("journal entry at %d: sender: %w", pos, err)
+		}
+		rlpPos, rlpLen, err := rlp.String(data, senderPos+length.Addr)
+		if err != nil {
+			return fmt.Errorf("journal entry at %d: txn: %w", pos, err)
+		}
+		if rlpPos+rlpLen != dataPos+dataLen {
+			return fmt.Errorf("journal entry at %d: unexpected list items", pos)
+		}
+		if err := f(JournalEntry{
+			Sender: common.BytesToAddress(data[senderPos : senderPos+length.Addr]),
+			Rlp:    data[rlpPos : rlpPos+rlpLen],
+		}); err != nil {
+			return err
+		}
+		pos += len(data)
+	}
+}
+
+// readJournalEntry reads the next RLP list of the journal, io.EOF means there are no more entries
+func readJournalEntry(r *bufio.Reader) ([]byte, error) {
+	prefix, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	header := []byte{prefix}
+	var payloadLen uint64
+	switch {
+	case prefix < 0xc0:
+		return nil, errors.New("not a list")
+	case prefix <= 0xf7:
+		payloadLen = uint64(prefix - 0xc0)
+	default:
+		header = append(header, make([]byte, prefix-0xf7)...)
+		if _, err := io.ReadFull(r, header[1:]); err != nil {
+			return nil, noEOF(err)
+		}
+		for _, b := range header[1:] {
+			payloadLen = payloadLen<<8 | uint64(b)
+		}
+	}
+	if payloadLen > maxJournalEntrySize {
+		return nil, fmt.Errorf("entry of %d bytes is too large", payloadLen)
+	}
+	data := make([]byte, len(header)+int(payloadLen))
+	copy(data, header)
+	if _, err := io.ReadFull(r, data[len(header):]); err != nil {
+		return nil, noEOF(err)
+	}
+	return data, nil
+}
+
+// noEOF turns the end of the input in the middle of an entry into an error of its own
+func noEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// txJournal keeps the local transactions in a file of its own, so that they outlive the pool db
+type txJournal struct {
+	path   string
+	writer *os.File // append-only handle, open between rotations
+}
+
+func newTxJournal(path string) *txJournal {
+	return &txJournal{path: path}
+}
+
+// load replays the journal in batches, a missing journal is not an error
+func (j *txJournal) load(add func(entries []JournalEntry) error) error {
+	file, err := os.Open(j.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	batch := make([]JournalEntry, 0, journalBatchSize)
+	err = ReadJournal(bufio.NewReader(file), func(entry JournalEntry) error {
+		batch = append(batch, entry)
+		if len(batch) < journalBatchSize {
+			return nil
+		}
+		err := add(batch)
+		batch = batch[:0]
+		return err
+	})
+	if len(batch) > 0 {
+		if addErr := add(batch); addErr != nil && err == nil {
+			err = addErr
+		}
+	}
+	return err
+}
+
+// insert appends the entries to the journal
+func (j *txJournal) insert(entries ...JournalEntry) error {
+	if j.writer == nil {
+		return errNoActiveJournal
+	}
+	return WriteJournal(j.writer, entries...)
+}
+
+// rotate regenerates the journal from the given entries and reopens it for appending
+func (j *txJournal) rotate(entries []JournalEntry) error {
+	if j.writer != nil {
+		if err := j.writer.Close(); err != nil {
+			return err
+		}
+		j.writer = nil
+	}
+	tmpPath := j.path + ".new"
+	replacement, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(replacement)
+	if err = WriteJournal(w, entries...); err == nil {
+		err = w.Flush()
+	}
+	if closeErr := replacement.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if err = os.Rename(tmpPath, j.path); err != nil {
+		return err
+	}
+	j.writer, err = os.OpenFile(j.path, os.O_WRONLY|os.O_APPEND, 0644)
+	return err
+}
+
+func (j *txJournal) close() error {
+	if j.writer == nil {
+		return nil
+	}
+	err := j.writer.Close()
+	j.writer = nil
+	return err
+}
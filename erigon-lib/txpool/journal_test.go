@@ -0,0 +1,178 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/iotest"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/common/fixedgas"
+	"github.com/erigontech/erigon-lib/common/hexutility"
+	"github.com/erigontech/erigon-lib/common/u256"
+	"github.com/erigontech/erigon-lib/gointerfaces"
+	remote "github.com/erigontech/erigon-lib/gointerfaces/remoteproto"
+	"github.com/erigontech/erigon-lib/kv/kvcache"
+	"github.com/erigontech/erigon-lib/kv/memdb"
+	"github.com/erigontech/erigon-lib/kv/temporal/temporaltest"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/txpool/txpoolcfg"
+	"github.com/erigontech/erigon-lib/types"
+)
+
+func readJournalFile(t *testing.T, path string) []JournalEntry {
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var entries []JournalEntry
+	require.NoError(t, ReadJournal(bytes.NewReader(data), func(entry JournalEntry) error {
+		entries = append(entries, entry)
+		return nil
+	}))
+	return entries
+}
+
+func TestJournal(t *testing.T) {
+	entries := []JournalEntry{
+		{Sender: common.HexToAddress("0x01"), Rlp: []byte{0x02}},
+		{Sender: common.HexToAddress("0x03"), Rlp: bytes.Repeat([]byte{0x04}, 1000)},
+		{Sender: common.HexToAddress("0x05"), Rlp: []byte{}},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, WriteJournal(&buf, entries...))
+
+	var read []JournalEntry
+	require.NoError(t, ReadJournal(bytes.NewReader(buf.Bytes()), func(entry JournalEntry) error {
+		read = append(read, entry)
+		return nil
+	}))
+	assert.Equal(t, entries, read)
+
+	// entries before a truncated one are still read
+	read = nil
+	err := ReadJournal(bytes.NewReader(buf.Bytes()[:buf.Len()-3]), func(entry JournalEntry) error {
+		read = append(read, entry)
+		return nil
+	})
+	assert.Error(t, err)
+	assert.Equal(t, entries[:2], read)
+
+	// the journal is streamed: the entries are applied as they are read, before a read error further on
+	read = nil
+	readErr := errors.New("disk failure")
+	err = ReadJournal(io.MultiReader(bytes.NewReader(buf.Bytes()), iotest.ErrReader(readErr)), func(entry JournalEntry) error {
+		read = append(read, entry)
+		return nil
+	})
+	assert.ErrorIs(t, err, readErr)
+	assert.Equal(t, entries, read)
+
+	// a corrupted length prefix doesn't make the reader allocate it
+	err = ReadJournal(bytes.NewReader([]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}), func(JournalEntry) error {
+		t.Fatal("unexpected entry")
+		return nil
+	})
+	assert.ErrorContains(t, err, "too large")
+
+	// file journal: nothing to load at first, appends only after a rotation
+	path := filepath.Join(t.TempDir(), "transactions.rlp")
+	journal := newTxJournal(path)
+	require.NoError(t, journal.load(func([]JournalEntry) error {
+		t.Fatal("unexpected entries")
+		return nil
+	}))
+	assert.ErrorIs(t, journal.insert(entries[0]), errNoActiveJournal)
+	require.NoError(t, journal.rotate(entries[:1]))
+	require.NoError(t, journal.insert(entries[1:]...))
+	require.NoError(t, journal.close())
+	var loaded []JournalEntry
+	require.NoError(t, journal.load(func(batch []JournalEntry) error {
+		loaded = append(loaded, batch...)
+		return nil
+	}))
+	assert.Equal(t, entries, loaded)
+
+	require.NoError(t, journal.rotate(entries[2:]))
+	require.NoError(t, journal.close())
+	assert.Equal(t, entries[2:], readJournalFile(t, path))
+}
+
+func TestJournalReplay(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ch := make(chan types.Announcements, 100)
+	coreDB, _ := temporaltest.NewTestDB(t, datadir.New(t.TempDir()))
+	db := memdb.NewTestPoolDB(t)
+	cfg := txpoolcfg.DefaultConfig
+	cfg.Journal = filepath.Join(t.TempDir(), "transactions.rlp")
+	sendersCache := kvcache.New(kvcache.DefaultCoherentConfig)
+	pool, err := New(ch, coreDB, cfg, sendersCache, *u256.N1, common.Big0 /* shanghaiTime */, nil, /* agraBlock */
+		common.Big0 /* cancunTime */, common.Big0 /* pragueTime */, fixedgas.DefaultMaxBlobsPerBlock, nil, log.New())
+	require.NoError(err)
+	ctx := context.Background()
+
+	// set code txn with nonce 3 signed by 0x0D3ab14BBaD3D99F4203bd7a11aCB94882050E7e for chain 1
+	txnRlp := hexutility.MustDecodeHex("0x04f9017a0103010a830186a09400000000000000000000000000000000000000bb8080c0f90114f85a019400000000000000000000000000000000000000aa0701a00dbbfb04544dabed7dcb8d262aed0501d887803bd3df26e3c83ca8f70eb193b0a043478b7b77256657480520d5b8a880f65bd62b252a1795c1bc05aadd0d1c1391f85a809400000000000000000000000000000000000000aa8001a0acac15d2c6b6e9f2c2963aba520ec44ecc298e9d044e995ff4daf2912552a262a02afc0119b702390dede2963133fcacbee4e8b0cda92867fee8970314508c9628f85a059400000000000000000000000000000000000000aa0180a08272352be35d1b5a6124f24e1145324d50676370b4bbe9e2f1e365b40f0ca9d0a001fd9132c9720d96b06d3fda656ae16f4ab942edb162083100acef637629ecb901a08d41c1aaeaddf271656332c92dfed9c5ece55a76b8949bd36231caf9239b09b8a01e440eb9f28555555ba02f2fa0ccddeb4975ce98eaab388376cb6aeb087284a9")
+	sender := common.HexToAddress("0x0D3ab14BBaD3D99F4203bd7a11aCB94882050E7e")
+	change := &remote.StateChangeBatch{
+		PendingBlockBaseFee: 1,
+		BlockGasLimit:       1000000,
+		ChangeBatch: []*remote.StateChange{
+			{BlockHeight: 0, BlockHash: gointerfaces.ConvertHashToH256([32]byte{})},
+		},
+	}
+	change.ChangeBatch[0].Changes = append(change.ChangeBatch[0].Changes, &remote.AccountChange{
+		Action:  remote.Action_UPSERT,
+		Address: gointerfaces.ConvertAddressToH160(sender),
+		Data:    types.EncodeAccountBytesV3(3, uint256.NewInt(1*common.Ether), nil, 1),
+	})
+	tx, err := db.BeginRw(ctx)
+	require.NoError(err)
+	defer tx.Rollback()
+	require.NoError(pool.OnNewBlock(ctx, change, types.TxSlots{}, types.TxSlots{}, types.TxSlots{}, tx))
+
+	// the entry with a wrong sender is dropped
+	f, err := os.Create(cfg.Journal)
+	require.NoError(err)
+	require.NoError(WriteJournal(f,
+		JournalEntry{Sender: common.HexToAddress("0x01"), Rlp: txnRlp},
+		JournalEntry{Sender: sender, Rlp: txnRlp},
+	))
+	require.NoError(f.Close())
+
+	require.NoError(pool.loadJournal(ctx, tx))
+	require.Len(pool.byHash, 1)
+	for hash, mt := range pool.byHash {
+		assert.True(pool.IsLocal([]byte(hash)))
+		assert.Equal(types.SetCodeTxType, mt.Tx.Type)
+		assert.Len(mt.Tx.Authorities, 3)
+	}
+
+	// the journal is regenerated from the local txs of the pool
+	require.NoError(pool.rotateJournal(tx))
+	assert.Equal([]JournalEntry{{Sender: sender, Rlp: txnRlp}}, readJournalFile(t, cfg.Journal))
+	require.NoError(pool.journal.close())
+}
@@ -0,0 +1,184 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/erigontech/erigon-lib/common"
+)
+
+const (
+	PriceTxOrdering      = "price"
+	FifoTxOrdering       = "fifo"
+	RoundRobinTxOrdering = "roundrobin"
+)
+
+// TxCandidate is a pending transaction which YieldBest can offer for the block being built
+type TxCandidate struct {
+	Sender  common.Address
+	Nonce   uint64
+	Arrival uint64 // order in which the transaction arrived to the pool, lower is earlier
+	IsLocal bool
+
+	mt *metaTx
+}
+
+// TxOrdering decides in which order YieldBest offers the pending transactions. Candidates come sorted by effective
+// tip. Implementations don't need to care about nonces: whatever positions they give to transactions of a sender,
+// those are refilled with the sender's transactions in nonce order.
+type TxOrdering interface {
+	// Order sorts candidates in place
+	Order(candidates []*TxCandidate)
+}
+
+// NewTxOrdering creates one of the built-in orderings by name, effective tip by default. If priority senders are
+// given, their transactions go ahead of any other.
+func NewTxOrdering(name string, prioritySenders []common.Address) (TxOrdering, error) {
+	var ordering TxOrdering
+	switch name {
+	case PriceTxOrdering, "":
+		ordering = priceTxOrdering{}
+	case FifoTxOrdering:
+		ordering = fifoTxOrdering{}
+	case RoundRobinTxOrdering:
+		ordering = roundRobinTxOrdering{}
+	default:
+		return nil, fmt.Errorf("unknown transaction ordering: %s", name)
+	}
+	if len(prioritySenders) > 0 {
+		senders := make(map[common.Address]struct{}, len(prioritySenders))
+		for _, sender := range prioritySenders {
+			senders[sender] = struct{}{}
+		}
+		ordering = NewPriorityTxOrdering(func(c *TxCandidate) int {
+			if _, ok := senders[c.Sender]; ok {
+				return 1
+			}
+			return 0
+		}, ordering)
+	}
+	return ordering, nil
+}
+
+// SetTxOrdering replaces the ordering configured by txpoolcfg.Config.TxOrdering and PrioritySenders
+func (p *TxPool) SetTxOrdering(ordering TxOrdering) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.ordering = ordering
+	p.ordered = nil
+}
+
+// NewPriorityTxOrdering puts candidates with higher priority first, candidates of the same priority keep the order
+// of the base ordering
+func NewPriorityTxOrdering(priority func(c *TxCandidate) int, base TxOrdering) TxOrdering {
+	return &priorityTxOrdering{priority: priority, base: base}
+}
+
+type priorityTxOrdering struct {
+	priority func(c *TxCandidate) int
+	base     TxOrdering
+}
+
+func (o *priorityTxOrdering) Order(candidates []*TxCandidate) {
+	o.base.Order(candidates)
+	priorities := make(map[*TxCandidate]int, len(candidates))
+	for _, c := range candidates {
+		priorities[c] = o.priority(c)
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return priorities[candidates[i]] > priorities[candidates[j]] })
+}
+
+// priceTxOrdering keeps the order of the pending sub-pool, by effective tip
+type priceTxOrdering struct{}
+
+func (priceTxOrdering) Order([]*TxCandidate) {}
+
+// fifoTxOrdering offers transactions in order of their arrival to the pool
+type fifoTxOrdering struct{}
+
+func (fifoTxOrdering) Order(candidates []*TxCandidate) {
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].Arrival < candidates[j].Arrival })
+}
+
+// roundRobinTxOrdering takes one transaction of every sender in turn, so that a single sender can't fill the block.
+// Senders take turns in order of their best transaction.
+type roundRobinTxOrdering struct{}
+
+func (roundRobinTxOrdering) Order(candidates []*TxCandidate) {
+	var senders []common.Address
+	bySender := map[common.Address][]*TxCandidate{}
+	for _, c := range candidates {
+		if _, ok := bySender[c.Sender]; !ok {
+			senders = append(senders, c.Sender)
+		}
+		bySender[c.Sender] = append(bySender[c.Sender], c)
+	}
+	ordered := candidates[:0]
+	for round := 0; len(ordered) < len(candidates); round++ {
+		for _, sender := range senders {
+			if txs := bySender[sender]; round < len(txs) {
+				ordered = append(ordered, txs[round])
+			}
+		}
+	}
+}
+
+// orderedPending returns the pending transactions in the configured order. Every sender's transactions are then
+// put back into nonce order within the positions the ordering gave them. The order is kept until the pending
+// sub-pool changes, so that the batches of one block don't sort the whole sub-pool again.
+func (p *TxPool) orderedPending() []*metaTx {
+	best := p.pending.best.ms
+	if _, ok := p.ordering.(priceTxOrdering); ok || p.ordering == nil {
+		return best
+	}
+	if p.ordered != nil && p.orderedVersion == p.pending.version {
+		return p.ordered
+	}
+
+	candidates := make([]*TxCandidate, len(best))
+	for i, mt := range best {
+		candidates[i] = &TxCandidate{Sender: p.senders.senderID2Addr[mt.Tx.SenderID], Nonce: mt.Tx.Nonce, Arrival: mt.arrival, IsLocal: mt.subPool&IsLocal > 0, mt: mt}
+	}
+	p.ordering.Order(candidates)
+
+	positions := map[common.Address][]int{}
+	for i, c := range candidates {
+		positions[c.Sender] = append(positions[c.Sender], i)
+	}
+	for _, idx := range positions {
+		if len(idx) < 2 {
+			continue
+		}
+		txs := make([]*TxCandidate, len(idx))
+		for i, pos := range idx {
+			txs[i] = candidates[pos]
+		}
+		sort.SliceStable(txs, func(i, j int) bool { return txs[i].Nonce < txs[j].Nonce })
+		for i, pos := range idx {
+			candidates[pos] = txs[i]
+		}
+	}
+
+	ordered := make([]*metaTx, len(candidates))
+	for i, c := range candidates {
+		ordered[i] = c.mt
+	}
+	p.ordered, p.orderedVersion = ordered, p.pending.version
+	return ordered
+}
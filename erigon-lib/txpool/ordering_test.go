@@ -0,0 +1,157 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"context"
+	"testing"
+
+	mapset "github.com/deckarep/golang-set/v2"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/common/fixedgas"
+	"github.com/erigontech/erigon-lib/common/u256"
+	"github.com/erigontech/erigon-lib/gointerfaces"
+	remote "github.com/erigontech/erigon-lib/gointerfaces/remoteproto"
+	"github.com/erigontech/erigon-lib/kv/kvcache"
+	"github.com/erigontech/erigon-lib/kv/memdb"
+	"github.com/erigontech/erigon-lib/kv/temporal/temporaltest"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/txpool/txpoolcfg"
+	"github.com/erigontech/erigon-lib/types"
+)
+
+func TestTxOrdering(t *testing.T) {
+	addrA, addrB, addrC := common.Address{1}, common.Address{2}, common.Address{3}
+	const a0, a1, a2, b0, c0 = 1, 2, 3, 4, 5
+
+	// newPool fills a pool in which A's transactions pay the best tip and C's the worst. They arrive in the order
+	// C0, B0, A1, A0, A2 (A1 waits for A0 in the queued sub-pool)
+	newPool := func(t *testing.T, cfg txpoolcfg.Config) *TxPool {
+		ctx := context.Background()
+		coreDB, _ := temporaltest.NewTestDB(t, datadir.New(t.TempDir()))
+		pool, err := New(make(chan types.Announcements, 10), coreDB, cfg, kvcache.New(kvcache.DefaultCoherentConfig), *u256.N1, nil, nil, nil, nil, fixedgas.DefaultMaxBlobsPerBlock, nil, log.New())
+		require.NoError(t, err)
+
+		change := &remote.StateChangeBatch{
+			PendingBlockBaseFee: 1,
+			BlockGasLimit:       1_000_000,
+			ChangeBatch:         []*remote.StateChange{{BlockHeight: 0, BlockHash: gointerfaces.ConvertHashToH256([32]byte{})}},
+		}
+		for _, addr := range []common.Address{addrA, addrB, addrC} {
+			change.ChangeBatch[0].Changes = append(change.ChangeBatch[0].Changes, &remote.AccountChange{
+				Action:  remote.Action_UPSERT,
+				Address: gointerfaces.ConvertAddressToH160(addr),
+				Data:    types.EncodeAccountBytesV3(0, uint256.NewInt(common.Ether), make([]byte, 32), 1),
+			})
+		}
+		tx, err := memdb.NewTestPoolDB(t).BeginRw(ctx)
+		require.NoError(t, err)
+		t.Cleanup(tx.Rollback)
+		require.NoError(t, pool.OnNewBlock(ctx, change, types.TxSlots{}, types.TxSlots{}, types.TxSlots{}, tx))
+
+		for _, txn := range []struct {
+			id     byte
+			sender common.Address
+			nonce  uint64
+			tip    uint64
+		}{{c0, addrC, 0, 10}, {b0, addrB, 0, 20}, {a1, addrA, 1, 30}, {a0, addrA, 0, 30}, {a2, addrA, 2, 30}} {
+			slot := &types.TxSlot{Tip: *uint256.NewInt(txn.tip), FeeCap: *uint256.NewInt(txn.tip), Gas: fixedgas.TxGas, Nonce: txn.nonce, Rlp: []byte{txn.id}}
+			slot.IDHash[0] = txn.id
+			var slots types.TxSlots
+			slots.Append(slot, txn.sender[:], true)
+			reasons, err := pool.AddLocalTxs(ctx, slots, tx)
+			require.NoError(t, err)
+			require.Equal(t, []txpoolcfg.DiscardReason{txpoolcfg.Success}, reasons)
+		}
+		return pool
+	}
+	// yield takes the transactions in small batches, like the mining stage does
+	yield := func(t *testing.T, pool *TxPool) (ids []byte) {
+		yielded := mapset.NewThreadUnsafeSet[[32]byte]()
+		for {
+			txs := types.TxsRlp{}
+			_, count, err := pool.YieldBest(2, &txs, nil, 0, 30_000_000, 0, yielded)
+			require.NoError(t, err)
+			if count == 0 {
+				return ids
+			}
+			for _, rlp := range txs.Txs {
+				ids = append(ids, rlp[0])
+			}
+		}
+	}
+	withOrdering := func(name string, prioritySenders ...common.Address) txpoolcfg.Config {
+		cfg := txpoolcfg.DefaultConfig
+		cfg.TxOrdering, cfg.PrioritySenders = name, prioritySenders
+		return cfg
+	}
+
+	t.Run("price", func(t *testing.T) {
+		ids := yield(t, newPool(t, txpoolcfg.DefaultConfig))
+		require.ElementsMatch(t, []byte{a0, a1, a2}, ids[:3])
+		require.Equal(t, []byte{b0, c0}, ids[3:])
+	})
+	t.Run("fifo", func(t *testing.T) {
+		// A1 arrived before A0, but can't go before it
+		require.Equal(t, []byte{c0, b0, a0, a1, a2}, yield(t, newPool(t, withOrdering(FifoTxOrdering))))
+	})
+	t.Run("roundrobin", func(t *testing.T) {
+		require.Equal(t, []byte{a0, b0, c0, a1, a2}, yield(t, newPool(t, withOrdering(RoundRobinTxOrdering))))
+	})
+	t.Run("cached", func(t *testing.T) {
+		pool := newPool(t, withOrdering(FifoTxOrdering))
+		ordered := pool.orderedPending()
+		require.Len(t, ordered, 5)
+		require.Same(t, &ordered[0], &pool.orderedPending()[0])
+
+		// the order is built again once the pending sub-pool changes
+		pool.pending.Remove(ordered[0], "test", log.New())
+		reordered := pool.orderedPending()
+		require.Len(t, reordered, 4)
+		require.Equal(t, ordered[1:], reordered)
+		pool.SetTxOrdering(roundRobinTxOrdering{})
+		require.NotSame(t, &reordered[0], &pool.orderedPending()[0])
+	})
+	t.Run("priority senders", func(t *testing.T) {
+		require.Equal(t, []byte{c0, a0, a1, a2, b0}, yield(t, newPool(t, withOrdering("", addrC))))
+		require.Equal(t, []byte{b0, a0, c0, a1, a2}, yield(t, newPool(t, withOrdering(RoundRobinTxOrdering, addrB))))
+	})
+	t.Run("custom", func(t *testing.T) {
+		// B is a system sender and goes first, A is deprioritised and goes last
+		pool := newPool(t, txpoolcfg.DefaultConfig)
+		pool.SetTxOrdering(NewPriorityTxOrdering(func(c *TxCandidate) int {
+			switch c.Sender {
+			case addrB:
+				return 1
+			case addrA:
+				return -1
+			}
+			return 0
+		}, priceTxOrdering{}))
+		require.Equal(t, []byte{b0, c0, a0, a1, a2}, yield(t, pool))
+	})
+	t.Run("unknown", func(t *testing.T) {
+		_, err := NewTxOrdering("lifo", nil)
+		require.ErrorContains(t, err, "unknown transaction ordering")
+		_, err = New(nil, nil, withOrdering("lifo"), nil, *u256.N1, nil, nil, nil, nil, fixedgas.DefaultMaxBlobsPerBlock, nil, log.New())
+		require.ErrorContains(t, err, "unknown transaction ordering")
+	})
+}
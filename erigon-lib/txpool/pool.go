@@ -28,6 +28,7 @@ import (
 	"math"
 	"math/big"
 	"runtime"
+	"slices"
 	"sort"
 	"sync"
 	"sync/atomic"
@@ -46,6 +47,7 @@ import (
 	"github.com/erigontech/erigon-lib/common/dbg"
 	"github.com/erigontech/erigon-lib/common/fixedgas"
 	"github.com/erigontech/erigon-lib/common/hexutility"
+	"github.com/erigontech/erigon-lib/common/length"
 	"github.com/erigontech/erigon-lib/common/u256"
 	libkzg "github.com/erigontech/erigon-lib/crypto/kzg"
 	"github.com/erigontech/erigon-lib/gointerfaces"
@@ -129,6 +131,7 @@ type metaTx struct {
 	bestIndex                 int
 	worstIndex                int
 	timestamp                 uint64 // when it was added to pool
+	arrival                   uint64 // order in which it was added to pool, lower is earlier
 	subPool                   SubPoolMarker
 	currentSubPool            SubPoolType
 	minedBlockNum             uint64
@@ -207,6 +210,16 @@ type TxPool struct {
 	queued                  *SubPool
 	minedBlobTxsByBlock     map[uint64][]*metaTx             // (blockNum => slice): cache of recently mined blobs
 	minedBlobTxsByHash      map[string]*metaTx               // (hash => mt): map of recently mined blobs
+	blobTxs                 map[common.Hash][]*metaTx        // (blob versioned hash => mts): pool and recently mined txs carrying the blob
+	private                 *privatePool                     // private transactions and bundles, never announced to peers
+	ordering                TxOrdering                       // order in which YieldBest offers pending transactions
+	ordered                 []*metaTx                        // pending transactions in the configured order, nil when stale
+	orderedVersion          uint64                           // version of the pending sub-pool ordered was built from
+	arrivalSeq              uint64                           // sequence number of the last transaction added to the pool
+	authorities             map[common.Address]int           // EIP-7702 authority => number of pooled set code txs carrying its authorization
+	journal                 *txJournal                       // local transactions kept out of the db, nil if disabled
+	senderLists             SenderListPolicy                 // allow and deny lists of the config
+	admissionPolicies       []AdmissionPolicy                // consulted before any other validation of a txn
 	isLocalLRU              *simplelru.LRU[string, struct{}] // tx_hash => is_local : to restore isLocal flag of unwinded transactions
 	newPendingTxs           chan types.Announcements         // notifications about new txs in Pending sub-pool
 	all                     *BySenderAndNonce                // senderID => (sorted map of txn nonce => *metaTx)
@@ -286,11 +299,37 @@ func New(newTxs chan types.Announcements, coreDB kv.RoDB, cfg txpoolcfg.Config,
 		unprocessedRemoteByHash: map[string]int{},
 		minedBlobTxsByBlock:     map[uint64][]*metaTx{},
 		minedBlobTxsByHash:      map[string]*metaTx{},
+		blobTxs:                 map[common.Hash][]*metaTx{},
+		private:                 newPrivatePool(),
+		authorities:             map[common.Address]int{},
 		maxBlobsPerBlock:        maxBlobsPerBlock,
 		feeCalculator:           feeCalculator,
 		logger:                  logger,
 	}
 
+	if res.ordering, err = NewTxOrdering(cfg.TxOrdering, cfg.PrioritySenders); err != nil {
+		return nil, err
+	}
+	if cfg.Journal != "" {
+		res.journal = newTxJournal(cfg.Journal)
+	}
+	if cfg.AllowList != "" {
+		if res.senderLists.Allow, err = NewSenderList(cfg.AllowList); err != nil {
+			return nil, err
+		}
+		if !res.senderLists.Allow.Loaded() {
+			return nil, fmt.Errorf("allow list %s does not exist", cfg.AllowList)
+		}
+	}
+	if cfg.DenyList != "" {
+		if res.senderLists.Deny, err = NewSenderList(cfg.DenyList); err != nil {
+			return nil, err
+		}
+	}
+	if res.senderLists.Allow != nil || res.senderLists.Deny != nil {
+		res.admissionPolicies = append(res.admissionPolicies, &res.senderLists)
+	}
+
 	if shanghaiTime != nil {
 		if !shanghaiTime.IsUint64() {
 			return nil, errors.New("shanghaiTime overflow")
@@ -342,6 +381,15 @@ func (p *TxPool) Start(ctx context.Context, db kv.RwDB) error {
 			return fmt.Errorf("loading pool from DB: %w", err)
 		}
 
+		if p.journal != nil {
+			if err := p.loadJournal(ctx, tx); err != nil {
+				p.logger.Warn("[txpool] Failed to load local transaction journal", "err", err)
+			}
+			if err := p.rotateJournal(tx); err != nil {
+				p.logger.Warn("[txpool] Failed to rotate local transaction journal", "err", err)
+			}
+		}
+
 		if p.started.CompareAndSwap(false, true) {
 			p.logger.Info("[txpool] Started")
 		}
@@ -455,6 +503,8 @@ func (p *TxPool) OnNewBlock(ctx context.Context, stateChanges *remote.StateChang
 			}
 		}
 	}
+	p.private.onNewBlock(block, minedTxs)
+
 	if err = p.senders.onNewBlock(stateChanges, unwindTxs, minedTxs, p.logger); err != nil {
 		return err
 	}
@@ -495,6 +545,8 @@ func (p *TxPool) OnNewBlock(ctx context.Context, stateChanges *remote.StateChang
 		return err
 	}
 
+	p.limitDelegatedSenders(minedTxs.Txs)
+
 	p.pending.EnforceWorstInvariants()
 	p.baseFee.EnforceInvariants()
 	p.queued.EnforceInvariants()
@@ -598,6 +650,62 @@ func (p *TxPool) GetRlp(tx kv.Tx, hash []byte) ([]byte, error) {
 	rlpTx, _, _, err := p.getRlpLocked(tx, hash)
 	return common.Copy(rlpTx), err
 }
+
+// BlobBundle is a blob of a pool transaction together with its KZG commitment and proof
+type BlobBundle struct {
+	Blob       []byte
+	Commitment gokzg4844.KZGCommitment
+	Proof      gokzg4844.KZGProof
+}
+
+// GetBlobs looks up blobs by their versioned hashes among pool and recently mined blob transactions. Result is in
+// the order of blobHashes, with nil for blobs the pool doesn't have.
+func (p *TxPool) GetBlobs(blobHashes []common.Hash) []*BlobBundle {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	bundles := make([]*BlobBundle, len(blobHashes))
+	for i, h := range blobHashes {
+		txs := p.blobTxs[h]
+		if len(txs) == 0 {
+			continue
+		}
+		mt := txs[0]
+		for j, blobHash := range mt.Tx.BlobHashes {
+			if blobHash == h {
+				bundles[i] = &BlobBundle{Blob: common.Copy(mt.Tx.Blobs[j]), Commitment: mt.Tx.Commitments[j], Proof: mt.Tx.Proofs[j]}
+				break
+			}
+		}
+	}
+	return bundles
+}
+
+// indexBlobs makes the blobs of a pool or recently mined transaction available to GetBlobs. Transactions without the
+// blobs themselves - e.g. the mined ones, blocks don't carry blobs - are skipped.
+func (p *TxPool) indexBlobs(mt *metaTx) {
+	if mt.Tx.Type != types.BlobTxType || len(mt.Tx.Blobs) != len(mt.Tx.BlobHashes) {
+		return
+	}
+	for _, h := range mt.Tx.BlobHashes {
+		p.blobTxs[h] = append(p.blobTxs[h], mt)
+	}
+}
+
+func (p *TxPool) unindexBlobs(mt *metaTx) {
+	if mt.Tx.Type != types.BlobTxType {
+		return
+	}
+	for _, h := range mt.Tx.BlobHashes {
+		txs := slices.DeleteFunc(p.blobTxs[h], func(indexed *metaTx) bool { return indexed == mt })
+		if len(txs) == 0 {
+			delete(p.blobTxs, h)
+		} else {
+			p.blobTxs[h] = txs
+		}
+	}
+}
+
 func (p *TxPool) AppendLocalAnnouncements(types []byte, sizes []uint32, hashes []byte) ([]byte, []uint32, []byte) {
 	p.lock.Lock()
 	defer p.lock.Unlock()
@@ -705,6 +813,77 @@ func (p *TxPool) getCachedBlobTxnLocked(tx kv.Tx, hash []byte) (*metaTx, error)
 	return newMetaTx(txSlot, false, 0), nil
 }
 
+// loadJournal re-adds the journaled local transactions, the ones which got into the pool db
+// or became invalid meanwhile are skipped
+func (p *TxPool) loadJournal(ctx context.Context, tx kv.Tx) error {
+	parseCtx := types.NewTxParseContext(p.chainID)
+	sender := make([]byte, length.Addr)
+	var total, added int
+	err := p.journal.load(func(entries []JournalEntry) error {
+		var txs types.TxSlots
+		for _, entry := range entries {
+			total++
+			txn := &types.TxSlot{}
+			if _, err := parseCtx.ParseTransaction(entry.Rlp, 0, txn, sender, false /* hasEnvelope */, true /* wrappedWithBlobs */, nil); err != nil {
+				p.logger.Warn("[txpool] journal: parseTransaction", "err", err)
+				continue
+			}
+			if !bytes.Equal(sender, entry.Sender[:]) {
+				p.logger.Warn("[txpool] journal: unexpected sender", "expected", entry.Sender, "recovered", common.BytesToAddress(sender))
+				continue
+			}
+			txn.Rlp = common.Copy(entry.Rlp)
+			txs.Append(txn, sender, true)
+		}
+		if len(txs.Txs) == 0 {
+			return nil
+		}
+		reasons, err := p.AddLocalTxs(ctx, txs, tx)
+		if err != nil {
+			return err
+		}
+		for _, reason := range reasons {
+			if reason == txpoolcfg.Success {
+				added++
+			}
+		}
+		return nil
+	})
+	p.logger.Info("[txpool] Loaded local transaction journal", "transactions", total, "added", added)
+	return err
+}
+
+// rotateJournal regenerates the journal from the local transactions of the pool
+func (p *TxPool) rotateJournal(tx kv.Tx) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	var entries []JournalEntry
+	var err error
+	p.all.ascendAll(func(mt *metaTx) bool {
+		if mt.subPool&IsLocal == 0 {
+			return true
+		}
+		var rlpTxn []byte
+		var sender common.Address
+		if rlpTxn, sender, _, err = p.getRlpLocked(tx, mt.Tx.IDHash[:]); err != nil {
+			return false
+		}
+		if len(rlpTxn) > 0 {
+			entries = append(entries, JournalEntry{Sender: sender, Rlp: common.Copy(rlpTxn)})
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	if err = p.journal.rotate(entries); err != nil {
+		return err
+	}
+	p.logger.Debug("[txpool] Regenerated local transaction journal", "transactions", len(entries))
+	return nil
+}
+
 func (p *TxPool) IsLocal(idHash []byte) bool {
 	hashS := string(idHash)
 	p.lock.Lock()
@@ -714,35 +893,94 @@ func (p *TxPool) IsLocal(idHash []byte) bool {
 func (p *TxPool) AddNewGoodPeer(peerID types.PeerID) { p.recentlyConnectedPeers.AddPeer(peerID) }
 func (p *TxPool) Started() bool                      { return p.started.Load() }
 
-func (p *TxPool) best(n uint16, txs *types.TxsRlp, tx kv.Tx, onTopOf, availableGas, availableBlobGas uint64, yielded mapset.Set[[32]byte]) (bool, int, error) {
+func (p *TxPool) best(n uint16, txs *types.TxsRlp, tx kv.Tx, onTopOf, availableGas, availableBlobGas uint64, yielded mapset.Set[[32]byte], withPrivate bool) (bool, int, error) {
 	p.lock.Lock()
-	defer p.lock.Unlock()
-
 	for last := p.lastSeenBlock.Load(); last < onTopOf; last = p.lastSeenBlock.Load() {
 		p.logger.Debug("[txpool] Waiting for block", "expecting", onTopOf, "lastSeen", last, "txRequested", n, "pending", p.pending.Len(), "baseFee", p.baseFee.Len(), "queued", p.queued.Len())
 		p.lastSeenCond.Wait()
 	}
 
-	best := p.pending.best
+	// the state of the senders of private transactions is read from the chain db, which is not done under the lock
+	var private []*privateTxn
+	var senders map[common.Address]sender
+	if withPrivate {
+		private = p.private.yieldableTxs(onTopOf + 1)
+	}
+	if len(private) > 0 {
+		p.lock.Unlock()
+		var err error
+		if senders, err = p.privateSendersState(private); err != nil {
+			return false, 0, err
+		}
+		p.lock.Lock()
+		private = p.private.yieldableTxs(onTopOf + 1)
+	}
+	defer p.lock.Unlock()
+
+	best := p.orderedPending()
 
 	isShanghai := p.isShanghai() || p.isAgra()
 
-	txs.Resize(uint(min(int(n), len(best.ms))))
+	txs.Resize(uint(min(int(n), len(private)+len(best))))
 	var toRemove []*metaTx
 	count := 0
 	i := 0
 
+	// private transactions were submitted to this node for its own blocks, so they go first. They skipped the
+	// stateful checks of the sub-pools: only the ones with the next nonce of the sender and enough balance are yielded.
+	// Transactions yielded by earlier calls for the same block count as included, so that the following nonces of
+	// their senders can be yielded too.
+	for _, txn := range private {
+		if count >= int(n) || availableGas < fixedgas.TxGas {
+			break
+		}
+		state, ok := senders[txn.sender]
+		if !ok {
+			continue // added after the states of the senders were read
+		}
+		needBalance := requiredBalance(txn.slot)
+		if txn.slot.Nonce != state.nonce || needBalance.Gt(&state.balance) {
+			continue
+		}
+		if yielded.Contains(txn.slot.IDHash) {
+			state.nonce++
+			state.balance.Sub(&state.balance, needBalance)
+			senders[txn.sender] = state
+			continue
+		}
+		blobCount := uint64(len(txn.slot.BlobHashes))
+		if blobCount*fixedgas.BlobGasPerBlob > availableBlobGas {
+			continue
+		}
+		intrinsicGas, _ := txpoolcfg.CalcIntrinsicGas(uint64(txn.slot.DataLen), uint64(txn.slot.DataNonZeroLen), uint64(len(txn.slot.Authorizations)), nil, txn.slot.Creation, true, true, isShanghai)
+		if intrinsicGas > availableGas {
+			continue
+		}
+		availableBlobGas -= blobCount * fixedgas.BlobGasPerBlob
+		availableGas -= intrinsicGas
+
+		state.nonce++
+		state.balance.Sub(&state.balance, needBalance)
+		senders[txn.sender] = state
+
+		txs.Txs[count] = txn.rlp
+		copy(txs.Senders.At(count), txn.sender.Bytes())
+		txs.IsLocal[count] = true
+		yielded.Add(txn.slot.IDHash)
+		count++
+	}
+
 	defer func() {
-		p.logger.Debug("[txpool] Processing best request", "last", onTopOf, "txRequested", n, "txAvailable", len(best.ms), "txProcessed", i, "txReturned", count)
+		p.logger.Debug("[txpool] Processing best request", "last", onTopOf, "txRequested", n, "txAvailable", len(best), "txProcessed", i, "txReturned", count)
 	}()
 
-	for ; count < int(n) && i < len(best.ms); i++ {
+	for ; count < int(n) && i < len(best); i++ {
 		// if we wouldn't have enough gas for a standard transaction then quit out early
 		if availableGas < fixedgas.TxGas {
 			break
 		}
 
-		mt := best.ms[i]
+		mt := best[i]
 
 		if yielded.Contains(mt.Tx.IDHash) {
 			continue
@@ -797,7 +1035,12 @@ func (p *TxPool) best(n uint16, txs *types.TxsRlp, tx kv.Tx, onTopOf, availableG
 }
 
 func (p *TxPool) YieldBest(n uint16, txs *types.TxsRlp, tx kv.Tx, onTopOf, availableGas, availableBlobGas uint64, toSkip mapset.Set[[32]byte]) (bool, int, error) {
-	return p.best(n, txs, tx, onTopOf, availableGas, availableBlobGas, toSkip)
+	return p.best(n, txs, tx, onTopOf, availableGas, availableBlobGas, toSkip, true)
+}
+
+// YieldBestPublic is like YieldBest, but leaves out private transactions
+func (p *TxPool) YieldBestPublic(n uint16, txs *types.TxsRlp, tx kv.Tx, onTopOf, availableGas, availableBlobGas uint64, toSkip mapset.Set[[32]byte]) (bool, int, error) {
+	return p.best(n, txs, tx, onTopOf, availableGas, availableBlobGas, toSkip, false)
 }
 
 func (p *TxPool) PeekBest(n uint16, txs *types.TxsRlp, tx kv.Tx, onTopOf, availableGas, availableBlobGas uint64) (bool, error) {
@@ -844,6 +1087,14 @@ func toBlobs(_blobs [][]byte) []gokzg4844.Blob {
 }
 
 func (p *TxPool) validateTx(txn *types.TxSlot, isLocal bool, stateCache kvcache.CacheView) txpoolcfg.DiscardReason {
+	if len(p.admissionPolicies) > 0 {
+		if reason := p.admit(txn, p.senders.senderID2Addr[txn.SenderID], isLocal); reason != txpoolcfg.Success {
+			if txn.Traced {
+				p.logger.Info(fmt.Sprintf("TX TRACING: validateTx not admitted idHash=%x local=%t", txn.IDHash, isLocal))
+			}
+			return reason
+		}
+	}
 	isShanghai := p.isShanghai() || p.isAgra()
 	if isShanghai && txn.Creation && txn.DataLen > fixedgas.MaxInitCodeSize {
 		return txpoolcfg.InitCodeTooLarge // EIP-3860
@@ -939,6 +1190,12 @@ func (p *TxPool) validateTx(txn *types.TxSlot, isLocal bool, stateCache kvcache.
 		}
 		return txpoolcfg.Spammer
 	}
+	if reason := p.validateDelegation(txn, stateCache); reason != txpoolcfg.Success {
+		if txn.Traced {
+			p.logger.Info(fmt.Sprintf("TX TRACING: validateTx delegation idHash=%x slots=%d, limit=%d, reason=%s", txn.IDHash, p.all.count(txn.SenderID), p.cfg.DelegatedSlots, reason))
+		}
+		return reason
+	}
 
 	// Check nonce and balance
 	senderNonce, senderBalance, _ := p.senders.info(stateCache, txn.SenderID)
@@ -959,6 +1216,84 @@ func (p *TxPool) validateTx(txn *types.TxSlot, isLocal bool, stateCache kvcache.
 	return txpoolcfg.Success
 }
 
+// validateDelegation protects the pool from EIP-7702 invalidation spam: the code of a delegated account can bump its
+// nonce or spend its balance at any time, invalidating all its pooled txs at once. So such accounts only get
+// DelegatedSlots in-flight txs, and authorities which already have more pooled txs than that can't be delegated.
+func (p *TxPool) validateDelegation(txn *types.TxSlot, stateCache kvcache.CacheView) txpoolcfg.DiscardReason {
+	senderAddr, ok := p.senders.senderID2Addr[txn.SenderID]
+	if !ok {
+		return txpoolcfg.Success
+	}
+	delegated := p.authorities[senderAddr] > 0
+	if !delegated {
+		delegated, _ = p.senders.hasCode(stateCache, txn.SenderID)
+	}
+	if delegated && p.all.get(txn.SenderID, txn.Nonce) == nil && uint64(p.all.count(txn.SenderID)) >= p.cfg.DelegatedSlots {
+		return txpoolcfg.InflightTxLimit
+	}
+	for _, authority := range txn.Authorities {
+		if authority == nil || *authority == senderAddr {
+			continue
+		}
+		if id, ok := p.senders.getID(*authority); ok && uint64(p.all.count(id)) > p.cfg.DelegatedSlots {
+			return txpoolcfg.AuthorityReserved
+		}
+	}
+	return txpoolcfg.Success
+}
+
+// limitDelegatedSenders drops the txs of the authorities delegated by the mined set code txs, keeping only
+// the DelegatedSlots ones with the lowest nonces
+func (p *TxPool) limitDelegatedSenders(minedTxs []*types.TxSlot) {
+	seen := map[uint64]struct{}{}
+	var toDel []*metaTx // can't delete items while iterate them
+	for _, txn := range minedTxs {
+		for _, authority := range txn.Authorities {
+			if authority == nil {
+				continue
+			}
+			id, ok := p.senders.getID(*authority)
+			if !ok {
+				continue
+			}
+			if _, ok := seen[id]; ok {
+				continue
+			}
+			seen[id] = struct{}{}
+
+			var kept uint64
+			p.all.ascend(id, func(mt *metaTx) bool {
+				if kept < p.cfg.DelegatedSlots {
+					kept++
+					return true
+				}
+				toDel = append(toDel, mt)
+				return true
+			})
+		}
+	}
+
+	for _, mt := range toDel {
+		if mt.Tx.Traced {
+			p.logger.Info("TX TRACING: limitDelegatedSenders", "idHash", fmt.Sprintf("%x", mt.Tx.IDHash), "senderId", mt.Tx.SenderID, "nonce", mt.Tx.Nonce, "currentSubPool", mt.currentSubPool)
+		}
+		switch mt.currentSubPool {
+		case PendingSubPool:
+			p.pending.Remove(mt, "limitDelegatedSenders", p.logger)
+		case BaseFeeSubPool:
+			p.baseFee.Remove(mt, "limitDelegatedSenders", p.logger)
+		case QueuedSubPool:
+			p.queued.Remove(mt, "limitDelegatedSenders", p.logger)
+		default:
+			//already removed
+		}
+		p.discardLocked(mt, txpoolcfg.InflightTxLimit)
+	}
+	if len(toDel) > 0 {
+		p.logger.Debug("[txpool] Discarded transactions of delegated accounts", "count", len(toDel))
+	}
+}
+
 var maxUint256 = new(uint256.Int).SetAllOne()
 
 // Sender should have enough balance for: gasLimit x feeCap + blobGas x blobFeeCap + transferred_value
@@ -1225,6 +1560,18 @@ func (p *TxPool) AddLocalTxs(ctx context.Context, newTransactions types.TxSlots,
 			p.promoted.Append(txn.Type, txn.Size, txn.IDHash[:])
 		}
 	}
+	if p.journal != nil {
+		var entries []JournalEntry
+		for i, reason := range reasons {
+			if reason == txpoolcfg.Success {
+				entries = append(entries, JournalEntry{Sender: newTransactions.Senders.AddressAt(i), Rlp: newTransactions.Txs[i].Rlp})
+			}
+		}
+		// the journal isn't open yet while it's being replayed
+		if err := p.journal.insert(entries...); err != nil && !errors.Is(err, errNoActiveJournal) {
+			p.logger.Warn("[txpool] Failed to journal local transactions", "err", err)
+		}
+	}
 	if p.promoted.Len() > 0 {
 		select {
 		case p.newPendingTxs <- p.promoted.Copy():
@@ -1437,6 +1784,14 @@ func (p *TxPool) addLocked(mt *metaTx, announcements *types.Announcements) txpoo
 
 	hashStr := string(mt.Tx.IDHash[:])
 	p.byHash[hashStr] = mt
+	p.indexBlobs(mt)
+	p.arrivalSeq++
+	mt.arrival = p.arrivalSeq
+	for _, authority := range mt.Tx.Authorities {
+		if authority != nil {
+			p.authorities[*authority]++
+		}
+	}
 
 	if replaced := p.all.replaceOrInsert(mt, p.logger); replaced != nil {
 		if assert.Enable {
@@ -1464,6 +1819,7 @@ func (p *TxPool) addLocked(mt *metaTx, announcements *types.Announcements) txpoo
 func (p *TxPool) discardLocked(mt *metaTx, reason txpoolcfg.DiscardReason) {
 	hashStr := string(mt.Tx.IDHash[:])
 	delete(p.byHash, hashStr)
+	p.unindexBlobs(mt)
 	p.deletedTxs = append(p.deletedTxs, mt)
 	p.all.delete(mt, reason, p.logger)
 	p.discardReasonsLRU.Add(hashStr, reason)
@@ -1471,21 +1827,31 @@ func (p *TxPool) discardLocked(mt *metaTx, reason txpoolcfg.DiscardReason) {
 		t := p.totalBlobsInPool.Load()
 		p.totalBlobsInPool.Store(t - uint64(len(mt.Tx.BlobHashes)))
 	}
+	for _, authority := range mt.Tx.Authorities {
+		if authority == nil {
+			continue
+		}
+		if p.authorities[*authority]--; p.authorities[*authority] <= 0 {
+			delete(p.authorities, *authority)
+		}
+	}
 }
 
 // Cache recently mined blobs in anticipation of reorg, delete finalized ones
 func (p *TxPool) processMinedFinalizedBlobs(coreTx kv.Tx, minedTxs []*types.TxSlot, finalizedBlock uint64) error {
 	p.lastFinalizedBlock.Store(finalizedBlock)
 	// Remove blobs in the finalized block and older, loop through all entries
-	for l := len(p.minedBlobTxsByBlock); l > 0 && finalizedBlock > 0; l-- {
+	for blockNum, mts := range p.minedBlobTxsByBlock {
+		if finalizedBlock == 0 || blockNum > finalizedBlock {
+			continue
+		}
 		// delete individual hashes
-		for _, mt := range p.minedBlobTxsByBlock[finalizedBlock] {
+		for _, mt := range mts {
 			delete(p.minedBlobTxsByHash, string(mt.Tx.IDHash[:]))
+			p.unindexBlobs(mt)
 		}
 		// delete the map entry for this block num
-		delete(p.minedBlobTxsByBlock, finalizedBlock)
-		// move on to older blocks, if present
-		finalizedBlock--
+		delete(p.minedBlobTxsByBlock, blockNum)
 	}
 
 	// Add mined blobs
@@ -1497,6 +1863,7 @@ func (p *TxPool) processMinedFinalizedBlobs(coreTx kv.Tx, minedTxs []*types.TxSl
 			p.minedBlobTxsByBlock[minedBlock] = append(p.minedBlobTxsByBlock[minedBlock], mt)
 			mt.bestIndex = len(p.minedBlobTxsByBlock[minedBlock]) - 1
 			p.minedBlobTxsByHash[string(txn.IDHash[:])] = mt
+			p.indexBlobs(mt)
 		}
 	}
 	return nil
@@ -1514,6 +1881,7 @@ func (p *TxPool) deleteMinedBlobTxn(hash string) {
 	}
 	p.minedBlobTxsByBlock[mt.minedBlockNum] = p.minedBlobTxsByBlock[mt.minedBlockNum][:l-1]
 	delete(p.minedBlobTxsByHash, hash)
+	p.unindexBlobs(mt)
 }
 
 func (p *TxPool) NonceFromAddress(addr [20]byte) (nonce uint64, inPool bool) {
@@ -1783,6 +2151,18 @@ func MainLoop(ctx context.Context, db kv.RwDB, p *TxPool, newTxs chan types.Anno
 	defer commitEvery.Stop()
 	logEvery := time.NewTicker(p.cfg.LogEvery)
 	defer logEvery.Stop()
+	var rejournalEvery <-chan time.Time
+	if p.journal != nil && p.cfg.Rejournal > 0 {
+		rejournal := time.NewTicker(p.cfg.Rejournal)
+		defer rejournal.Stop()
+		rejournalEvery = rejournal.C
+	}
+	var reloadSenderListsEvery <-chan time.Time
+	if p.senderLists.Allow != nil || p.senderLists.Deny != nil {
+		reloadSenderLists := time.NewTicker(senderListReloadInterval)
+		defer reloadSenderLists.Stop()
+		reloadSenderListsEvery = reloadSenderLists.C
+	}
 
 	err := p.Start(ctx, db)
 
@@ -1795,9 +2175,22 @@ func MainLoop(ctx context.Context, db kv.RwDB, p *TxPool, newTxs chan types.Anno
 		select {
 		case <-ctx.Done():
 			_, _ = p.flush(ctx, db)
+			if p.journal != nil {
+				p.lock.Lock()
+				_ = p.journal.close()
+				p.lock.Unlock()
+			}
 			return
 		case <-logEvery.C:
 			p.logStats()
+		case <-rejournalEvery:
+			if err := db.View(ctx, p.rotateJournal); err != nil {
+				p.logger.Warn("[txpool] Failed to rotate local transaction journal", "err", err)
+			}
+		case <-reloadSenderListsEvery:
+			if err := p.senderLists.reload(); err != nil {
+				p.logger.Warn("[txpool] Failed to reload sender lists", "err", err)
+			}
 		case <-processRemoteTxsEvery.C:
 			if !p.Started() {
 				continue
@@ -2123,6 +2516,7 @@ func (p *TxPool) fromDB(ctx context.Context, tx kv.Tx, coreTx kv.Tx) error {
 	txs := types.TxSlots{}
 	parseCtx := types.NewTxParseContext(p.chainID)
 	parseCtx.WithSender(false)
+	authCtx := types.NewTxParseContext(p.chainID) // EIP-7702 authorities are recovered like senders, but aren't stored
 
 	i := 0
 	it, err = tx.Range(kv.PoolTransaction, nil, nil)
@@ -2145,6 +2539,12 @@ func (p *TxPool) fromDB(ctx context.Context, tx kv.Tx, coreTx kv.Tx) error {
 			continue
 		}
 		txn.Rlp = nil // means that we don't need store it in db anymore
+		if txn.Type == types.SetCodeTxType {
+			authTxn, sender := &types.TxSlot{}, make([]byte, length.Addr)
+			if _, err = authCtx.ParseTransaction(txRlp, 0, authTxn, sender, false /* hasEnvelope */, true /*wrappedWithBlobs*/, nil); err == nil {
+				txn.Authorities = authTxn.Authorities
+			}
+		}
 
 		txn.SenderID, txn.Traced = p.senders.getOrCreateID(addr, p.logger)
 		binary.BigEndian.Uint64(v) // TODO - unnecessary line, remove
@@ -2325,27 +2725,105 @@ func (p *TxPool) deprecatedForEach(_ context.Context, f func(rlp []byte, sender
 	p.lock.Lock()
 	defer p.lock.Unlock()
 	p.all.ascendAll(func(mt *metaTx) bool {
-		slot := mt.Tx
-		slotRlp := slot.Rlp
-		if slot.Rlp == nil {
-			v, err := tx.GetOne(kv.PoolTransaction, slot.IDHash[:])
-			if err != nil {
-				p.logger.Warn("[txpool] foreach: get txn from db", "err", err)
-				return true
-			}
-			if v == nil {
-				p.logger.Warn("[txpool] foreach: txn not found in db")
-				return true
-			}
-			slotRlp = v[20:]
+		slotRlp, ok := p.slotRlpLocked(mt.Tx, tx)
+		if !ok {
+			return true
 		}
-		if sender, found := p.senders.senderID2Addr[slot.SenderID]; found {
+		if sender, found := p.senders.senderID2Addr[mt.Tx.SenderID]; found {
 			f(slotRlp, sender, mt.currentSubPool)
 		}
 		return true
 	})
 }
 
+// slotRlpLocked returns the RLP of the transaction, reading it from the db if it was already committed
+func (p *TxPool) slotRlpLocked(slot *types.TxSlot, tx kv.Tx) ([]byte, bool) {
+	if slot.Rlp != nil {
+		return slot.Rlp, true
+	}
+	v, err := tx.GetOne(kv.PoolTransaction, slot.IDHash[:])
+	if err != nil {
+		p.logger.Warn("[txpool] foreach: get txn from db", "err", err)
+		return nil, false
+	}
+	if v == nil {
+		p.logger.Warn("[txpool] foreach: txn not found in db")
+		return nil, false
+	}
+	return v[20:], true
+}
+
+// inspectedSender is the sender of pool transactions as reported by inspect
+type inspectedSender struct {
+	addr       common.Address
+	stateNonce uint64
+	nonceGaps  [][2]uint64 // inclusive ranges of nonces missing between the state nonce and the highest nonce in the pool
+}
+
+// inspect walks all transactions like deprecatedForEach, but also reports their sub-pool markers. Returns the senders
+// of the transactions along with their nonce gaps, and the reasons of recently discarded transactions.
+func (p *TxPool) inspect(ctx context.Context, f func(rlp []byte, sender common.Address, t SubPoolType, marker SubPoolMarker), tx kv.Tx) ([]inspectedSender, map[common.Hash]txpoolcfg.DiscardReason, error) {
+	coreDb, cache := p.coreDBWithCache()
+	coreTx, err := coreDb.BeginRo(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer coreTx.Rollback()
+
+	cacheView, err := cache.View(ctx, coreTx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	var (
+		senders     []inspectedSender
+		senderID    uint64
+		noGapsNonce uint64
+	)
+	p.all.ascendAll(func(mt *metaTx) bool {
+		addr, found := p.senders.senderID2Addr[mt.Tx.SenderID]
+		if !found {
+			return true
+		}
+		if len(senders) == 0 || senderID != mt.Tx.SenderID {
+			var stateNonce uint64
+			if stateNonce, _, err = p.senders.info(cacheView, mt.Tx.SenderID); err != nil {
+				return false
+			}
+			senders = append(senders, inspectedSender{addr: addr, stateNonce: stateNonce})
+			senderID, noGapsNonce = mt.Tx.SenderID, stateNonce
+		}
+		if sender := &senders[len(senders)-1]; mt.Tx.Nonce > noGapsNonce {
+			sender.nonceGaps = append(sender.nonceGaps, [2]uint64{noGapsNonce, mt.Tx.Nonce - 1})
+		}
+		noGapsNonce = max(noGapsNonce, mt.Tx.Nonce+1)
+
+		// fee cap bit is not persisted in the marker, it is checked against the base fee of the moment
+		marker := mt.subPool
+		if mt.minFeeCap.CmpUint64(p.pendingBaseFee.Load()) >= 0 {
+			marker |= EnoughFeeCapBlock
+		}
+		if slotRlp, ok := p.slotRlpLocked(mt.Tx, tx); ok {
+			f(slotRlp, addr, mt.currentSubPool, marker)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	discarded := make(map[common.Hash]txpoolcfg.DiscardReason, p.discardReasonsLRU.Len())
+	for _, hash := range p.discardReasonsLRU.Keys() {
+		if reason, ok := p.discardReasonsLRU.Peek(hash); ok {
+			discarded[common.BytesToHash([]byte(hash))] = reason
+		}
+	}
+	return senders, discarded, nil
+}
+
 var PoolChainConfigKey = []byte("chain_config")
 var PoolLastSeenBlockKey = []byte("last_seen_block")
 var PoolPendingBaseFeeKey = []byte("pending_base_fee")
@@ -2425,6 +2903,10 @@ func (sc *sendersBatch) info(cacheView kvcache.CacheView, id uint64) (nonce uint
 	if !ok {
 		panic("must not happen")
 	}
+	return senderInfo(cacheView, addr)
+}
+
+func senderInfo(cacheView kvcache.CacheView, addr common.Address) (nonce uint64, balance uint256.Int, err error) {
 	encoded, err := cacheView.Get(addr.Bytes())
 	if err != nil {
 		return 0, emptySender.balance, err
@@ -2445,6 +2927,24 @@ func (sc *sendersBatch) info(cacheView kvcache.CacheView, id uint64) (nonce uint
 	return nonce, balance, nil
 }
 
+// hasCode tells whether the account has code, which for a sender means an EIP-7702 delegation
+func (sc *sendersBatch) hasCode(cacheView kvcache.CacheView, id uint64) (bool, error) {
+	addr, ok := sc.senderID2Addr[id]
+	if !ok {
+		panic("must not happen")
+	}
+	encoded, err := cacheView.Get(addr.Bytes())
+	if err != nil || len(encoded) == 0 {
+		return false, err
+	}
+	if cacheView.StateV3() {
+		// the empty code hash isn't stored, and a zero one doesn't point to any code either
+		_, _, codeHash := types.DecodeAccountBytesV3(encoded)
+		return len(codeHash) == length.Hash && common.BytesToHash(codeHash) != (common.Hash{}), nil
+	}
+	return encoded[0]&8 > 0, nil // the code hash field is set
+}
+
 func (sc *sendersBatch) registerNewSenders(newTxs *types.TxSlots, logger log.Logger) (err error) {
 	for i, txn := range newTxs.Txs {
 		txn.SenderID, txn.Traced = sc.getOrCreateID(newTxs.Senders.AddressAt(i), logger)
@@ -2611,10 +3111,11 @@ func (b *BySenderAndNonce) replaceOrInsert(mt *metaTx, logger log.Logger) *metaT
 // It's more expensive to maintain "slice sort" invariant, but it allow do cheap copy of
 // pending.best slice for mining (because we consider txs and metaTx are immutable)
 type PendingPool struct {
-	best  *bestSlice
-	worst *WorstQueue
-	limit int
-	t     SubPoolType
+	best    *bestSlice
+	worst   *WorstQueue
+	limit   int
+	t       SubPoolType
+	version uint64 // changes whenever transactions join, leave or get reordered in best
 }
 
 func NewPendingSubPool(t SubPoolType, limit int) *PendingPool {
@@ -2652,6 +3153,7 @@ func (p *PendingPool) EnforceWorstInvariants() {
 }
 func (p *PendingPool) EnforceBestInvariants() {
 	sort.Sort(p.best)
+	p.version++
 }
 
 func (p *PendingPool) Best() *metaTx { //nolint
@@ -2670,6 +3172,7 @@ func (p *PendingPool) PopWorst() *metaTx { //nolint
 	i := heap.Pop(p.worst).(*metaTx)
 	if i.bestIndex >= 0 {
 		p.best.UnsafeRemove(i)
+		p.version++
 	}
 	return i
 }
@@ -2687,6 +3190,7 @@ func (p *PendingPool) Remove(i *metaTx, reason string, logger log.Logger) {
 	}
 	if i.bestIndex >= 0 {
 		p.best.UnsafeRemove(i)
+		p.version++
 	}
 	i.currentSubPool = 0
 }
@@ -2698,6 +3202,7 @@ func (p *PendingPool) Add(i *metaTx, logger log.Logger) {
 	i.currentSubPool = p.t
 	heap.Push(p.worst, i)
 	p.best.UnsafeAdd(i)
+	p.version++
 }
 func (p *PendingPool) DebugPrint(prefix string) {
 	for i, it := range p.best.ms {
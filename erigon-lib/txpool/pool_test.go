@@ -25,6 +25,7 @@ import (
 	"testing"
 
 	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	mapset "github.com/deckarep/golang-set/v2"
 	"github.com/holiman/uint256"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -799,6 +800,114 @@ func TestSetCodeTxValidationWithLargeAuthorizationValues(t *testing.T) {
 }
 
 // Blob gas price bump + other requirements to replace existing txns in the pool
+func TestDelegatedAccounts(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ch := make(chan types.Announcements, 100)
+
+	coreDB, _ := temporaltest.NewTestDB(t, datadir.New(t.TempDir()))
+	db := memdb.NewTestPoolDB(t)
+	cfg := txpoolcfg.DefaultConfig
+	sendersCache := kvcache.New(kvcache.DefaultCoherentConfig)
+	pool, err := New(ch, coreDB, cfg, sendersCache, *u256.N1, common.Big0 /* shanghaiTime */, nil, /* agraBlock */
+		common.Big0 /* cancunTime */, common.Big0 /* pragueTime */, fixedgas.DefaultMaxBlobsPerBlock, nil, log.New())
+	assert.NoError(err)
+	require.True(pool != nil)
+	ctx := context.Background()
+	h1 := gointerfaces.ConvertHashToH256([32]byte{})
+	change := &remote.StateChangeBatch{
+		StateVersionId:      0,
+		PendingBlockBaseFee: 200000,
+		BlockGasLimit:       1000000,
+		ChangeBatch: []*remote.StateChange{
+			{BlockHeight: 0, BlockHash: h1},
+		},
+	}
+	// authority with pooled txs, set code txs sender, delegated account, fresh authority, miner of a set code txn
+	var authority, setCoder, delegated, freshAuthority, miner common.Address
+	authority[0], setCoder[0], delegated[0], freshAuthority[0], miner[0] = 1, 2, 3, 4, 5
+	for _, addr := range []common.Address{authority, setCoder, delegated, freshAuthority, miner} {
+		var codeHash []byte
+		if addr == delegated {
+			codeHash = common.HexToHash("0x01").Bytes()
+		}
+		change.ChangeBatch[0].Changes = append(change.ChangeBatch[0].Changes, &remote.AccountChange{
+			Action:  remote.Action_UPSERT,
+			Address: gointerfaces.ConvertAddressToH160(addr),
+			Data:    types.EncodeAccountBytesV3(0, uint256.NewInt(1*common.Ether), codeHash, 1),
+		})
+	}
+	tx, err := db.BeginRw(ctx)
+	require.NoError(err)
+	defer tx.Rollback()
+	err = pool.OnNewBlock(ctx, change, types.TxSlots{}, types.TxSlots{}, types.TxSlots{}, tx)
+	assert.NoError(err)
+
+	newTxSlot := func(nonce, feeCap uint64, id byte, authorities ...common.Address) *types.TxSlot {
+		txSlot := &types.TxSlot{
+			Tip:    *uint256.NewInt(feeCap),
+			FeeCap: *uint256.NewInt(feeCap),
+			Gas:    100000,
+			Nonce:  nonce,
+			Rlp:    []byte{id},
+		}
+		txSlot.IDHash[0] = id
+		if len(authorities) > 0 {
+			txSlot.Type = types.SetCodeTxType
+			for i := range authorities {
+				txSlot.Authorizations = append(txSlot.Authorizations, types.Signature{})
+				txSlot.Authorities = append(txSlot.Authorities, &authorities[i])
+			}
+		}
+		return txSlot
+	}
+	add := func(txSlot *types.TxSlot, sender common.Address, expected txpoolcfg.DiscardReason) {
+		var txSlots types.TxSlots
+		txSlots.Append(txSlot, sender[:], true)
+		reasons, err := pool.AddLocalTxs(ctx, txSlots, tx)
+		require.NoError(err)
+		assert.Equal([]txpoolcfg.DiscardReason{expected}, reasons, "txn %d", txSlot.IDHash[0])
+	}
+
+	// accounts without code aren't limited
+	add(newTxSlot(0, 300000, 1), authority, txpoolcfg.Success)
+	add(newTxSlot(1, 300000, 2), authority, txpoolcfg.Success)
+
+	// accounts with code may only replace their in-flight txn
+	add(newTxSlot(0, 300000, 3), delegated, txpoolcfg.Success)
+	add(newTxSlot(1, 300000, 4), delegated, txpoolcfg.InflightTxLimit)
+	add(newTxSlot(0, 400000, 5), delegated, txpoolcfg.Success)
+
+	// an authority can't have more pooled txs than a delegated account
+	add(newTxSlot(0, 300000, 6, authority), setCoder, txpoolcfg.AuthorityReserved)
+	add(newTxSlot(0, 300000, 7, freshAuthority), setCoder, txpoolcfg.Success)
+	assert.Equal(1, pool.authorities[freshAuthority])
+
+	// pooled authorizations make the authority delegated
+	add(newTxSlot(0, 300000, 8), freshAuthority, txpoolcfg.Success)
+	add(newTxSlot(1, 300000, 9), freshAuthority, txpoolcfg.InflightTxLimit)
+
+	// once an authorization is mined, the authority only keeps its first txs
+	var minedTxs types.TxSlots
+	minedTxs.Append(newTxSlot(0, 300000, 10, authority), miner[:], false)
+	change.ChangeBatch[0].BlockHeight = 1
+	change.ChangeBatch[0].Changes = nil
+	err = pool.OnNewBlock(ctx, change, types.TxSlots{}, types.TxSlots{}, minedTxs, tx)
+	assert.NoError(err)
+	authorityID, ok := pool.senders.getID(authority)
+	require.True(ok)
+	assert.Equal(1, pool.all.count(authorityID))
+	assert.Contains(pool.byHash, string(newTxSlot(0, 0, 1).IDHash[:]))
+	reason, ok := pool.discardReasonsLRU.Get(string(newTxSlot(0, 0, 2).IDHash[:]))
+	assert.True(ok)
+	assert.Equal(txpoolcfg.InflightTxLimit, reason)
+
+	// authorizations are released along with their txs
+	pool.lock.Lock()
+	pool.discardLocked(pool.byHash[string(newTxSlot(0, 0, 7).IDHash[:])], txpoolcfg.Mined)
+	pool.lock.Unlock()
+	assert.NotContains(pool.authorities, freshAuthority)
+}
+
 func TestBlobTxReplacement(t *testing.T) {
 	t.Skip("TODO")
 	assert, require := assert.New(t), require.New(t)
@@ -1276,3 +1385,314 @@ func TestGasLimitChanged(t *testing.T) {
 
 	assert.Zero(mtx.subPool&NotTooMuchGas, "Should now have block space (again) for the tx")
 }
+
+func TestInspect(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ch := make(chan types.Announcements, 100)
+
+	coreDB, _ := temporaltest.NewTestDB(t, datadir.New(t.TempDir()))
+	db := memdb.NewTestPoolDB(t)
+	cfg := txpoolcfg.DefaultConfig
+	sendersCache := kvcache.New(kvcache.DefaultCoherentConfig)
+	pool, err := New(ch, coreDB, cfg, sendersCache, *u256.N1, nil, nil, nil, nil, fixedgas.DefaultMaxBlobsPerBlock, nil, log.New())
+	assert.NoError(err)
+	require.True(pool != nil)
+	ctx := context.Background()
+	pendingBaseFee := uint64(200000)
+	h1 := gointerfaces.ConvertHashToH256([32]byte{})
+	change := &remote.StateChangeBatch{
+		StateVersionId:      0,
+		PendingBlockBaseFee: pendingBaseFee,
+		BlockGasLimit:       1000000,
+		ChangeBatch: []*remote.StateChange{
+			{BlockHeight: 0, BlockHash: h1},
+		},
+	}
+	var addr [20]byte
+	addr[0] = 1
+	v := types.EncodeAccountBytesV3(2, uint256.NewInt(1*common.Ether), make([]byte, 32), 1)
+	change.ChangeBatch[0].Changes = append(change.ChangeBatch[0].Changes, &remote.AccountChange{
+		Action:  remote.Action_UPSERT,
+		Address: gointerfaces.ConvertAddressToH160(addr),
+		Data:    v,
+	})
+	tx, err := db.BeginRw(ctx)
+	require.NoError(err)
+	defer tx.Rollback()
+	err = pool.OnNewBlock(ctx, change, types.TxSlots{}, types.TxSlots{}, types.TxSlots{}, tx)
+	assert.NoError(err)
+
+	newTxSlot := func(nonce, feeCap uint64, id byte) *types.TxSlot {
+		txSlot := &types.TxSlot{
+			Tip:    *uint256.NewInt(feeCap),
+			FeeCap: *uint256.NewInt(feeCap),
+			Gas:    100000,
+			Nonce:  nonce,
+			Rlp:    []byte{id},
+		}
+		txSlot.IDHash[0] = id
+		return txSlot
+	}
+	// state nonce is 2: nonce 2 is pending, 3 and 4 are missing, 5 can't pay the base fee (nor 8 after it), 6 and 7 are missing
+	var txSlots types.TxSlots
+	txSlots.Append(newTxSlot(2, 300000, 1), addr[:], true)
+	txSlots.Append(newTxSlot(5, 100000, 2), addr[:], true)
+	txSlots.Append(newTxSlot(8, 300000, 3), addr[:], true)
+	reasons, err := pool.AddLocalTxs(ctx, txSlots, tx)
+	assert.NoError(err)
+	for _, reason := range reasons {
+		assert.Equal(txpoolcfg.Success, reason, reason.String())
+	}
+	// replace nonce 2, the replaced txn must be reported as discarded
+	txSlots = types.TxSlots{}
+	txSlots.Append(newTxSlot(2, 400000, 4), addr[:], true)
+	reasons, err = pool.AddLocalTxs(ctx, txSlots, tx)
+	assert.NoError(err)
+	assert.Equal(txpoolcfg.Success, reasons[0], reasons[0].String())
+
+	type inspectedTxn struct {
+		rlp     []byte
+		subPool SubPoolType
+		marker  SubPoolMarker
+	}
+	var txns []inspectedTxn
+	senders, discarded, err := pool.inspect(ctx, func(rlp []byte, sender common.Address, t SubPoolType, marker SubPoolMarker) {
+		assert.Equal(common.Address(addr), sender)
+		txns = append(txns, inspectedTxn{rlp: common.Copy(rlp), subPool: t, marker: marker})
+	}, tx)
+	require.NoError(err)
+
+	require.Len(txns, 3)
+	assert.Equal(inspectedTxn{rlp: []byte{4}, subPool: PendingSubPool, marker: NoNonceGaps | EnoughBalance | NotTooMuchGas | EnoughFeeCapBlock | IsLocal}, txns[0])
+	assert.Equal(inspectedTxn{rlp: []byte{2}, subPool: QueuedSubPool, marker: EnoughBalance | NotTooMuchGas | IsLocal}, txns[1])
+	assert.Equal(inspectedTxn{rlp: []byte{3}, subPool: QueuedSubPool, marker: EnoughBalance | NotTooMuchGas | IsLocal}, txns[2])
+
+	require.Len(senders, 1)
+	assert.Equal(inspectedSender{addr: addr, stateNonce: 2, nonceGaps: [][2]uint64{{3, 4}, {6, 7}}}, senders[0])
+
+	var replacedHash common.Hash
+	replacedHash[0] = 1
+	assert.Equal(map[common.Hash]txpoolcfg.DiscardReason{replacedHash: txpoolcfg.ReplacedByHigherTip}, discarded)
+}
+
+func TestGetBlobs(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ch := make(chan types.Announcements, 5)
+	coreDB, _ := temporaltest.NewTestDB(t, datadir.New(t.TempDir()))
+	cfg := txpoolcfg.DefaultConfig
+	sendersCache := kvcache.New(kvcache.DefaultCoherentConfig)
+	pool, err := New(ch, coreDB, cfg, sendersCache, *u256.N1, common.Big0, nil, common.Big0, nil, fixedgas.DefaultMaxBlobsPerBlock, nil, log.New())
+	assert.NoError(err)
+	require.True(pool != nil)
+
+	blobTxn := makeBlobTx()
+	unknown := common.Hash{0x01, 0x02}
+	request := []common.Hash{blobTxn.BlobHashes[1], unknown, blobTxn.BlobHashes[0]}
+	check := func() {
+		bundles := pool.GetBlobs(request)
+		require.Len(bundles, len(request))
+		assert.Nil(bundles[1])
+		for i, j := range map[int]int{0: 1, 2: 0} {
+			require.NotNil(bundles[i])
+			assert.Equal(blobTxn.Blobs[j], bundles[i].Blob)
+			assert.Equal(blobTxn.Commitments[j], bundles[i].Commitment)
+			assert.Equal(blobTxn.Proofs[j], bundles[i].Proof)
+			assert.Equal(request[i], common.Hash(kzg.KZGToVersionedHash(bundles[i].Commitment)))
+		}
+	}
+
+	assert.Equal([]*BlobBundle{nil, nil, nil}, pool.GetBlobs(request))
+
+	var addr [20]byte
+	addr[0] = 1
+	ctx := context.Background()
+	h1 := gointerfaces.ConvertHashToH256([32]byte{})
+	change := &remote.StateChangeBatch{
+		PendingBlockBaseFee:  200_000,
+		BlockGasLimit:        1000000,
+		PendingBlobFeePerGas: 100_000,
+		ChangeBatch: []*remote.StateChange{{BlockHeight: 0, BlockHash: h1, Changes: []*remote.AccountChange{{
+			Action:  remote.Action_UPSERT,
+			Address: gointerfaces.ConvertAddressToH160(addr),
+			Data:    types.EncodeAccountBytesV3(blobTxn.Nonce, uint256.NewInt(common.Ether), make([]byte, 32), 1),
+		}}}},
+	}
+	tx, err := memdb.NewTestPoolDB(t).BeginRw(ctx)
+	require.NoError(err)
+	defer tx.Rollback()
+	require.NoError(pool.OnNewBlock(ctx, change, types.TxSlots{}, types.TxSlots{}, types.TxSlots{}, tx))
+
+	// pending blob transaction
+	txSlots := types.TxSlots{}
+	txSlots.Append(&blobTxn, addr[:], true)
+	reasons, err := pool.AddLocalTxs(ctx, txSlots, tx)
+	require.NoError(err)
+	require.Equal([]txpoolcfg.DiscardReason{txpoolcfg.Success}, reasons)
+	check()
+
+	// recently mined blob transaction: it leaves the pool, but is kept until its block is finalized
+	change.ChangeBatch[0] = &remote.StateChange{BlockHeight: 1, BlockHash: h1}
+	require.NoError(pool.OnNewBlock(ctx, change, types.TxSlots{}, types.TxSlots{}, txSlots, tx))
+	require.NotContains(pool.byHash, string(blobTxn.IDHash[:]))
+	check()
+
+	change.ChangeBatch[0] = &remote.StateChange{BlockHeight: 2, BlockHash: h1}
+	change.FinalizedBlock = 1
+	require.NoError(pool.OnNewBlock(ctx, change, types.TxSlots{}, types.TxSlots{}, types.TxSlots{}, tx))
+	assert.Equal([]*BlobBundle{nil, nil, nil}, pool.GetBlobs(request))
+	assert.Empty(pool.blobTxs)
+}
+
+func TestPrivatePool(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ch := make(chan types.Announcements, 5)
+	coreDB, _ := temporaltest.NewTestDB(t, datadir.New(t.TempDir()))
+	cfg := txpoolcfg.DefaultConfig
+	sendersCache := kvcache.New(kvcache.DefaultCoherentConfig)
+	pool, err := New(ch, coreDB, cfg, sendersCache, *u256.N1, nil, nil, nil, nil, fixedgas.DefaultMaxBlobsPerBlock, nil, log.New())
+	assert.NoError(err)
+	require.True(pool != nil)
+
+	var addr1, addr2 [20]byte
+	addr1[0], addr2[0] = 1, 2
+	ctx := context.Background()
+	h1 := gointerfaces.ConvertHashToH256([32]byte{})
+	change := &remote.StateChangeBatch{
+		PendingBlockBaseFee: 1,
+		BlockGasLimit:       1000000,
+		ChangeBatch: []*remote.StateChange{
+			{BlockHeight: 0, BlockHash: h1},
+		},
+	}
+	// addr2 can pay for a single transaction only
+	for _, acc := range []struct {
+		addr    [20]byte
+		nonce   uint64
+		balance uint64
+	}{{addr1, 0, common.Ether}, {addr2, 1, 21_000}} {
+		change.ChangeBatch[0].Changes = append(change.ChangeBatch[0].Changes, &remote.AccountChange{
+			Action:  remote.Action_UPSERT,
+			Address: gointerfaces.ConvertAddressToH160(acc.addr),
+			Data:    types.EncodeAccountBytesV3(acc.nonce, uint256.NewInt(acc.balance), make([]byte, 32), 1),
+		})
+	}
+	db := memdb.NewTestPoolDB(t)
+	tx, err := db.BeginRw(ctx)
+	require.NoError(err)
+	defer tx.Rollback()
+	require.NoError(pool.OnNewBlock(ctx, change, types.TxSlots{}, types.TxSlots{}, types.TxSlots{}, tx))
+	newTx := func(id byte, nonce uint64, gas uint64) *types.TxSlot {
+		txn := &types.TxSlot{Tip: *uint256.NewInt(1), FeeCap: *uint256.NewInt(1), Gas: gas, Nonce: nonce, Rlp: []byte{id}}
+		txn.IDHash[0] = id
+		return txn
+	}
+
+	var txSlots types.TxSlots
+	txSlots.Append(newTx(1, 1, 21_000), addr2[:], true)
+	txSlots.Append(newTx(2, 0, 21_000), addr1[:], true)
+	txSlots.Append(newTx(3, 1, 21_000), addr1[:], true)
+	txSlots.Append(newTx(4, 0, 20_000), addr1[:], true)
+	txSlots.Append(newTx(7, 2, 21_000), addr2[:], true)
+	txSlots.Append(newTx(8, 3, 21_000), addr1[:], true)
+	reasons, err := pool.AddPrivateTxs(txSlots, 0)
+	require.NoError(err)
+	assert.Equal([]txpoolcfg.DiscardReason{txpoolcfg.Success, txpoolcfg.Success, txpoolcfg.Success, txpoolcfg.IntrinsicGas, txpoolcfg.Success, txpoolcfg.Success}, reasons)
+	reasons, err = pool.AddPrivateTxs(types.TxSlots{Txs: txSlots.Txs[:1], Senders: txSlots.Senders.At(0)}, 3)
+	require.NoError(err)
+	assert.Equal([]txpoolcfg.DiscardReason{txpoolcfg.DuplicateHash}, reasons)
+
+	// block previews are served to anyone, they only see public transactions
+	txs := types.TxsRlp{}
+	yielded := mapset.NewThreadUnsafeSet[[32]byte]()
+	_, count, err := pool.YieldBestPublic(10, &txs, nil, 0, 30_000_000, 0, yielded)
+	require.NoError(err)
+	assert.Zero(count)
+	assert.Zero(yielded.Cardinality())
+
+	// private transactions are yielded first, ordered by sender and nonce, and only once. Transactions with a nonce
+	// gap or which the sender can't pay for are held back
+	_, count, err = pool.YieldBest(10, &txs, nil, 0, 30_000_000, 0, yielded)
+	require.NoError(err)
+	require.Equal(3, count)
+	assert.Equal([][]byte{{2}, {3}, {1}}, txs.Txs)
+	assert.Equal(addr1[:], txs.Senders.At(0))
+	assert.Equal(addr2[:], txs.Senders.At(2))
+	_, count, err = pool.YieldBest(10, &txs, nil, 0, 30_000_000, 0, yielded)
+	require.NoError(err)
+	assert.Zero(count)
+
+	// when the block is filled in batches, the nonces yielded by earlier batches count as included
+	batched := mapset.NewThreadUnsafeSet[[32]byte]()
+	var order [][]byte
+	for i := 0; i < 4; i++ {
+		batch := types.TxsRlp{}
+		_, count, err = pool.YieldBest(1, &batch, nil, 0, 30_000_000, 0, batched)
+		require.NoError(err)
+		order = append(order, batch.Txs[:count]...)
+	}
+	assert.Equal([][]byte{{2}, {3}, {1}}, order)
+
+	// bundles
+	bundleTxs := types.TxSlots{}
+	bundleTxs.Append(newTx(5, 0, 21_000), addr1[:], true)
+	bundleTxs.Append(newTx(6, 0, 21_000), addr2[:], true)
+	_, err = pool.AddBundle(types.TxSlots{}, 1, 0)
+	assert.ErrorIs(err, ErrEmptyBundle)
+	_, err = pool.AddBundle(bundleTxs, 3, 2)
+	assert.ErrorIs(err, ErrInvalidBundleSpan)
+	hash1, err := pool.AddBundle(bundleTxs, 1, 2)
+	require.NoError(err)
+	_, err = pool.AddBundle(bundleTxs, 1, 0)
+	assert.ErrorIs(err, ErrBundleKnown)
+	hash2, err := pool.AddBundle(types.TxSlots{Txs: bundleTxs.Txs[1:], Senders: bundleTxs.Senders.At(1)}, 2, 0)
+	require.NoError(err)
+	assert.NotEqual(hash1, hash2)
+
+	assert.Empty(pool.YieldBundles(0))
+	bundles := pool.YieldBundles(1)
+	require.Len(bundles, 1)
+	assert.Equal(hash1, bundles[0].Hash)
+	assert.Equal([][]byte{{5}, {6}}, bundles[0].Txs)
+	assert.Equal([]common.Address{addr1, addr2}, bundles[0].Senders)
+	bundles = pool.YieldBundles(2)
+	require.Len(bundles, 2)
+	assert.Equal(hash1, bundles[0].Hash)
+	assert.Equal(hash2, bundles[1].Hash)
+
+	txCount, bundleCount := pool.CountPrivate()
+	assert.Equal(5, txCount)
+	assert.Equal(2, bundleCount)
+
+	// block 1 mines a transaction of the first bundle and of the private pool
+	var minedTxs types.TxSlots
+	minedTxs.Append(newTx(2, 0, 21_000), addr1[:], true)
+	minedTxs.Append(newTx(5, 0, 21_000), addr1[:], true)
+	pool.lock.Lock()
+	pool.private.onNewBlock(1, minedTxs)
+	pool.lock.Unlock()
+	pool.lastSeenBlock.Store(1)
+	txCount, bundleCount = pool.CountPrivate()
+	assert.Equal(4, txCount)
+	assert.Equal(1, bundleCount)
+	bundles = pool.YieldBundles(2)
+	require.Len(bundles, 1)
+	assert.Equal(hash2, bundles[0].Hash)
+
+	_, err = pool.AddBundle(bundleTxs, 1, 0)
+	assert.ErrorIs(err, ErrBundleExpired)
+	_, err = pool.AddPrivateTxs(bundleTxs, 1)
+	assert.Error(err)
+
+	// expiry
+	pool.lock.Lock()
+	pool.private.onNewBlock(2, types.TxSlots{})
+	pool.lock.Unlock()
+	txCount, bundleCount = pool.CountPrivate()
+	assert.Equal(4, txCount)
+	assert.Zero(bundleCount)
+	pool.lock.Lock()
+	pool.private.onNewBlock(PrivateTxLifetime, types.TxSlots{})
+	pool.lock.Unlock()
+	txCount, _ = pool.CountPrivate()
+	assert.Zero(txCount)
+}
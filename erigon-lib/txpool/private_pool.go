@@ -0,0 +1,322 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/fixedgas"
+	"github.com/erigontech/erigon-lib/common/length"
+	"github.com/erigontech/erigon-lib/txpool/txpoolcfg"
+	"github.com/erigontech/erigon-lib/types"
+)
+
+const (
+	// PrivateTxLifetime is the number of blocks a private transaction stays in the pool by default
+	PrivateTxLifetime = 25
+	// privateTxsLimit and bundlesLimit bound the private side-pool, it only holds transactions submitted to this node
+	privateTxsLimit = 1024
+	bundlesLimit    = 256
+	// bundleTxsLimit caps the number of transactions in a single bundle
+	bundleTxsLimit = 64
+)
+
+var (
+	ErrEmptyBundle       = errors.New("bundle has no transactions")
+	ErrBundleTooLarge    = fmt.Errorf("bundle has more than %d transactions", bundleTxsLimit)
+	ErrBundleExpired     = errors.New("bundle target block is already mined")
+	ErrBundleKnown       = errors.New("bundle already known")
+	ErrBundlePoolFull    = errors.New("bundle pool is full")
+	ErrInvalidBundleSpan = errors.New("bundle max block number is lower than its block number")
+)
+
+// Bundle is an ordered list of transactions which is included into a block as a whole - all transactions in order,
+// none of them failing - or not at all
+type Bundle struct {
+	Hash           common.Hash // keccak256 of the concatenated transaction hashes
+	Txs            [][]byte    // rlp of transactions, blob transactions are wrapped with blobs
+	TxHashes       []common.Hash
+	Senders        []common.Address
+	BlockNumber    uint64 // first block the bundle may be included into
+	MaxBlockNumber uint64 // last block the bundle may be included into, the bundle is dropped once it is mined
+	seq            uint64 // submission order
+}
+
+// privateTxn is a transaction which is never announced to peers and only used for local block building
+type privateTxn struct {
+	slot           *types.TxSlot
+	rlp            []byte
+	sender         common.Address
+	maxBlockNumber uint64 // the transaction is dropped once this block is mined
+}
+
+// privatePool is a side-pool of transactions and bundles submitted to this node for local block building only.
+// It is guarded by the lock of the TxPool it belongs to.
+type privatePool struct {
+	txs     map[string]*privateTxn // tx_hash => txn
+	bundles map[common.Hash]*Bundle
+	seq     uint64
+}
+
+func newPrivatePool() *privatePool {
+	return &privatePool{
+		txs:     map[string]*privateTxn{},
+		bundles: map[common.Hash]*Bundle{},
+	}
+}
+
+func (pp *privatePool) addTx(slot *types.TxSlot, rlp []byte, sender common.Address, maxBlockNumber uint64) txpoolcfg.DiscardReason {
+	hashS := string(slot.IDHash[:])
+	if _, ok := pp.txs[hashS]; ok {
+		return txpoolcfg.DuplicateHash
+	}
+	if len(pp.txs) >= privateTxsLimit {
+		return txpoolcfg.PrivatePoolOverflow
+	}
+	pp.txs[hashS] = &privateTxn{slot: slot, rlp: rlp, sender: sender, maxBlockNumber: maxBlockNumber}
+	return txpoolcfg.Success
+}
+
+func (pp *privatePool) addBundle(bundle *Bundle) error {
+	if _, ok := pp.bundles[bundle.Hash]; ok {
+		return ErrBundleKnown
+	}
+	if len(pp.bundles) >= bundlesLimit {
+		return ErrBundlePoolFull
+	}
+	pp.seq++
+	bundle.seq = pp.seq
+	pp.bundles[bundle.Hash] = bundle
+	return nil
+}
+
+// yieldableTxs returns private transactions which may still be included into the given block, ordered by sender and nonce
+func (pp *privatePool) yieldableTxs(blockNumber uint64) []*privateTxn {
+	txs := make([]*privateTxn, 0, len(pp.txs))
+	for _, txn := range pp.txs {
+		if txn.maxBlockNumber >= blockNumber {
+			txs = append(txs, txn)
+		}
+	}
+	sort.Slice(txs, func(i, j int) bool {
+		if c := bytes.Compare(txs[i].sender[:], txs[j].sender[:]); c != 0 {
+			return c < 0
+		}
+		return txs[i].slot.Nonce < txs[j].slot.Nonce
+	})
+	return txs
+}
+
+// yieldableBundles returns bundles targeting the given block, in submission order
+func (pp *privatePool) yieldableBundles(blockNumber uint64) []*Bundle {
+	bundles := make([]*Bundle, 0, len(pp.bundles))
+	for _, bundle := range pp.bundles {
+		if bundle.BlockNumber <= blockNumber && blockNumber <= bundle.MaxBlockNumber {
+			bundles = append(bundles, bundle)
+		}
+	}
+	sort.Slice(bundles, func(i, j int) bool { return bundles[i].seq < bundles[j].seq })
+	return bundles
+}
+
+// onNewBlock drops mined and expired transactions and bundles. A bundle is dropped as soon as any of its transactions
+// is mined, it can't be included as a whole anymore.
+func (pp *privatePool) onNewBlock(blockNumber uint64, minedTxs types.TxSlots) {
+	mined := make(map[common.Hash]struct{}, len(minedTxs.Txs))
+	for _, txn := range minedTxs.Txs {
+		mined[txn.IDHash] = struct{}{}
+		delete(pp.txs, string(txn.IDHash[:]))
+	}
+	for hashS, txn := range pp.txs {
+		if txn.maxBlockNumber <= blockNumber {
+			delete(pp.txs, hashS)
+		}
+	}
+	for hash, bundle := range pp.bundles {
+		if bundle.MaxBlockNumber <= blockNumber {
+			delete(pp.bundles, hash)
+			continue
+		}
+		for _, txHash := range bundle.TxHashes {
+			if _, ok := mined[txHash]; ok {
+				delete(pp.bundles, hash)
+				break
+			}
+		}
+	}
+}
+
+func (pp *privatePool) count() (txs int, bundles int) {
+	return len(pp.txs), len(pp.bundles)
+}
+
+// bundleHash is keccak256 of the concatenated transaction hashes, same as in the Flashbots bundle API
+func bundleHash(txHashes []common.Hash) (common.Hash, error) {
+	buf := make([]byte, 0, len(txHashes)*length.Hash)
+	for _, h := range txHashes {
+		buf = append(buf, h[:]...)
+	}
+	return common.HashData(buf)
+}
+
+// AddPrivateTxs adds transactions to the private side-pool. They are never announced to peers and are dropped once
+// maxBlockNumber is mined, 0 means PrivateTxLifetime blocks on top of the last seen block.
+func (p *TxPool) AddPrivateTxs(newTxs types.TxSlots, maxBlockNumber uint64) ([]txpoolcfg.DiscardReason, error) {
+	lastSeenBlock := p.lastSeenBlock.Load()
+	if maxBlockNumber == 0 {
+		maxBlockNumber = lastSeenBlock + PrivateTxLifetime
+	}
+	if maxBlockNumber <= lastSeenBlock {
+		return nil, fmt.Errorf("max block number %d is already mined", maxBlockNumber)
+	}
+	isShanghai := p.isShanghai() || p.isAgra()
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	reasons := make([]txpoolcfg.DiscardReason, len(newTxs.Txs))
+	for i, txn := range newTxs.Txs {
+		if reasons[i] = p.admit(txn, newTxs.Senders.AddressAt(i), true); reasons[i] != txpoolcfg.Success {
+			continue
+		}
+		if reasons[i] = p.validatePrivateTx(txn, isShanghai); reasons[i] != txpoolcfg.Success {
+			continue
+		}
+		reasons[i] = p.private.addTx(txn, common.Copy(txn.Rlp), newTxs.Senders.AddressAt(i), maxBlockNumber)
+		if txn.Traced {
+			p.logger.Info("TX TRACING: AddPrivateTxs", "idHash", fmt.Sprintf("%x", txn.IDHash), "reason", reasons[i])
+		}
+	}
+	return reasons, nil
+}
+
+// AddBundle adds a bundle to the private side-pool. The bundle may be included into blocks from blockNumber to
+// maxBlockNumber, 0 means blockNumber only.
+func (p *TxPool) AddBundle(newTxs types.TxSlots, blockNumber, maxBlockNumber uint64) (common.Hash, error) {
+	if len(newTxs.Txs) == 0 {
+		return common.Hash{}, ErrEmptyBundle
+	}
+	if len(newTxs.Txs) > bundleTxsLimit {
+		return common.Hash{}, ErrBundleTooLarge
+	}
+	if maxBlockNumber == 0 {
+		maxBlockNumber = blockNumber
+	}
+	if maxBlockNumber < blockNumber {
+		return common.Hash{}, ErrInvalidBundleSpan
+	}
+	if maxBlockNumber <= p.lastSeenBlock.Load() {
+		return common.Hash{}, ErrBundleExpired
+	}
+	isShanghai := p.isShanghai() || p.isAgra()
+
+	bundle := &Bundle{
+		Txs:            make([][]byte, len(newTxs.Txs)),
+		TxHashes:       make([]common.Hash, len(newTxs.Txs)),
+		Senders:        make([]common.Address, len(newTxs.Txs)),
+		BlockNumber:    blockNumber,
+		MaxBlockNumber: maxBlockNumber,
+	}
+	for i, txn := range newTxs.Txs {
+		reason := p.admit(txn, newTxs.Senders.AddressAt(i), true)
+		if reason == txpoolcfg.Success {
+			reason = p.validatePrivateTx(txn, isShanghai)
+		}
+		if reason != txpoolcfg.Success {
+			return common.Hash{}, fmt.Errorf("bundle transaction %x: %s", txn.IDHash, reason)
+		}
+		bundle.Txs[i] = common.Copy(txn.Rlp)
+		bundle.TxHashes[i] = txn.IDHash
+		bundle.Senders[i] = newTxs.Senders.AddressAt(i)
+	}
+	var err error
+	if bundle.Hash, err = bundleHash(bundle.TxHashes); err != nil {
+		return common.Hash{}, err
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if err := p.private.addBundle(bundle); err != nil {
+		return common.Hash{}, err
+	}
+	return bundle.Hash, nil
+}
+
+// privateSendersState reads the latest nonce and balance of the senders of private transactions
+func (p *TxPool) privateSendersState(txs []*privateTxn) (map[common.Address]sender, error) {
+	ctx := context.Background()
+	coreTx, err := p._chainDB.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer coreTx.Rollback()
+	cacheView, err := p._stateCache.View(ctx, coreTx)
+	if err != nil {
+		return nil, err
+	}
+	senders := make(map[common.Address]sender, len(txs))
+	for _, txn := range txs {
+		if _, ok := senders[txn.sender]; ok {
+			continue
+		}
+		nonce, balance, err := senderInfo(cacheView, txn.sender)
+		if err != nil {
+			return nil, err
+		}
+		senders[txn.sender] = sender{nonce: nonce, balance: balance}
+	}
+	return senders, nil
+}
+
+// YieldBundles returns bundles which may be included into the given block, in submission order
+func (p *TxPool) YieldBundles(blockNumber uint64) []*Bundle {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.private.yieldableBundles(blockNumber)
+}
+
+// CountPrivate returns the number of private transactions and bundles in the side-pool
+func (p *TxPool) CountPrivate() (txs int, bundles int) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.private.count()
+}
+
+// validatePrivateTx does stateless checks of a private transaction, stateful ones are left to block building
+func (p *TxPool) validatePrivateTx(txn *types.TxSlot, isShanghai bool) txpoolcfg.DiscardReason {
+	if isShanghai && txn.Creation && txn.DataLen > fixedgas.MaxInitCodeSize {
+		return txpoolcfg.InitCodeTooLarge // EIP-3860
+	}
+	if txn.Type == types.BlobTxType && !p.isCancun() {
+		return txpoolcfg.TypeNotActivated
+	}
+	if txn.Type == types.SetCodeTxType && !p.isPrague() {
+		return txpoolcfg.TypeNotActivated
+	}
+	intrinsicGas, reason := txpoolcfg.CalcIntrinsicGas(uint64(txn.DataLen), uint64(txn.DataNonZeroLen), uint64(len(txn.Authorizations)), nil, txn.Creation, true, true, isShanghai)
+	if reason != txpoolcfg.Success {
+		return reason
+	}
+	if intrinsicGas > txn.Gas {
+		return txpoolcfg.IntrinsicGas
+	}
+	return txpoolcfg.Success
+}
@@ -48,7 +48,7 @@ import (
 )
 
 // TxPoolAPIVersion
-var TxPoolAPIVersion = &types2.VersionReply{Major: 1, Minor: 0, Patch: 0}
+var TxPoolAPIVersion = &types2.VersionReply{Major: 1, Minor: 4, Patch: 0}
 
 type txPool interface {
 	ValidateSerializedTxn(serializedTxn []byte) error
@@ -57,9 +57,14 @@ type txPool interface {
 	GetRlp(tx kv.Tx, hash []byte) ([]byte, error)
 	AddLocalTxs(ctx context.Context, newTxs types.TxSlots, tx kv.Tx) ([]txpoolcfg.DiscardReason, error)
 	deprecatedForEach(_ context.Context, f func(rlp []byte, sender common.Address, t SubPoolType), tx kv.Tx)
+	inspect(ctx context.Context, f func(rlp []byte, sender common.Address, t SubPoolType, marker SubPoolMarker), tx kv.Tx) ([]inspectedSender, map[common.Hash]txpoolcfg.DiscardReason, error)
 	CountContent() (int, int, int)
 	IdHashKnown(tx kv.Tx, hash []byte) (bool, error)
 	NonceFromAddress(addr [20]byte) (nonce uint64, inPool bool)
+	GetBlobs(blobHashes []common.Hash) []*BlobBundle
+	AddPrivateTxs(newTxs types.TxSlots, maxBlockNumber uint64) ([]txpoolcfg.DiscardReason, error)
+	AddBundle(newTxs types.TxSlots, blockNumber, maxBlockNumber uint64) (common.Hash, error)
+	UpdateSenderList(deny bool, add, remove []common.Address) (int, error)
 }
 
 var _ txpool_proto.TxpoolServer = (*GrpcServer)(nil)   // compile-time interface check
@@ -98,6 +103,21 @@ func (*GrpcDisabled) Status(ctx context.Context, request *txpool_proto.StatusReq
 func (*GrpcDisabled) Nonce(ctx context.Context, request *txpool_proto.NonceRequest) (*txpool_proto.NonceReply, error) {
 	return nil, ErrPoolDisabled
 }
+func (*GrpcDisabled) Inspect(ctx context.Context, request *txpool_proto.InspectRequest) (*txpool_proto.InspectReply, error) {
+	return nil, ErrPoolDisabled
+}
+func (*GrpcDisabled) AddPrivate(ctx context.Context, request *txpool_proto.AddPrivateRequest) (*txpool_proto.AddReply, error) {
+	return nil, ErrPoolDisabled
+}
+func (*GrpcDisabled) AddBundle(ctx context.Context, request *txpool_proto.AddBundleRequest) (*txpool_proto.AddBundleReply, error) {
+	return nil, ErrPoolDisabled
+}
+func (*GrpcDisabled) UpdateSenderList(ctx context.Context, request *txpool_proto.UpdateSenderListRequest) (*txpool_proto.UpdateSenderListReply, error) {
+	return nil, ErrPoolDisabled
+}
+func (*GrpcDisabled) GetBlobs(ctx context.Context, request *txpool_proto.GetBlobsRequest) (*txpool_proto.GetBlobsReply, error) {
+	return nil, ErrPoolDisabled
+}
 
 type GrpcServer struct {
 	txpool_proto.UnimplementedTxpoolServer
@@ -147,6 +167,68 @@ func (s *GrpcServer) All(ctx context.Context, _ *txpool_proto.AllRequest) (*txpo
 	return reply, nil
 }
 
+func (s *GrpcServer) Inspect(ctx context.Context, _ *txpool_proto.InspectRequest) (*txpool_proto.InspectReply, error) {
+	tx, err := s.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	reply := &txpool_proto.InspectReply{}
+	reply.Txs = make([]*txpool_proto.InspectReply_Tx, 0, 32)
+	senders, discarded, err := s.txPool.inspect(ctx, func(rlp []byte, sender common.Address, t SubPoolType, marker SubPoolMarker) {
+		reply.Txs = append(reply.Txs, &txpool_proto.InspectReply_Tx{
+			Sender:        gointerfaces.ConvertAddressToH160(sender),
+			TxnType:       convertSubPoolType(t),
+			RlpTx:         common.Copy(rlp),
+			SubPoolMarker: uint32(marker),
+		})
+	}, tx)
+	if err != nil {
+		return nil, err
+	}
+	reply.Senders = make([]*txpool_proto.InspectReply_Sender, 0, len(senders))
+	for _, sender := range senders {
+		gaps := make([]*txpool_proto.InspectReply_NonceGap, 0, len(sender.nonceGaps))
+		for _, gap := range sender.nonceGaps {
+			gaps = append(gaps, &txpool_proto.InspectReply_NonceGap{From: gap[0], To: gap[1]})
+		}
+		reply.Senders = append(reply.Senders, &txpool_proto.InspectReply_Sender{
+			Address:    gointerfaces.ConvertAddressToH160(sender.addr),
+			StateNonce: sender.stateNonce,
+			NonceGaps:  gaps,
+		})
+	}
+	reply.Discarded = make([]*txpool_proto.InspectReply_Discarded, 0, len(discarded))
+	for hash, reason := range discarded {
+		reply.Discarded = append(reply.Discarded, &txpool_proto.InspectReply_Discarded{
+			Hash:   gointerfaces.ConvertHashToH256(hash),
+			Reason: reason.String(),
+		})
+	}
+	return reply, nil
+}
+
+func (s *GrpcServer) GetBlobs(ctx context.Context, in *txpool_proto.GetBlobsRequest) (*txpool_proto.GetBlobsReply, error) {
+	hashes := make([]common.Hash, len(in.BlobHashes))
+	for i := range in.BlobHashes {
+		hashes[i] = gointerfaces.ConvertH256ToHash(in.BlobHashes[i])
+	}
+	bundles := s.txPool.GetBlobs(hashes)
+	reply := &txpool_proto.GetBlobsReply{BlobsAndProofs: make([]*txpool_proto.GetBlobsReply_BlobAndProof, len(bundles))}
+	for i, bundle := range bundles {
+		if bundle == nil {
+			reply.BlobsAndProofs[i] = &txpool_proto.GetBlobsReply_BlobAndProof{}
+			continue
+		}
+		reply.BlobsAndProofs[i] = &txpool_proto.GetBlobsReply_BlobAndProof{
+			Blob:       bundle.Blob,
+			Commitment: bundle.Commitment[:],
+			Proof:      bundle.Proof[:],
+		}
+	}
+	return reply, nil
+}
+
 func (s *GrpcServer) Pending(ctx context.Context, _ *emptypb.Empty) (*txpool_proto.PendingReply, error) {
 	tx, err := s.db.BeginRo(ctx)
 	if err != nil {
@@ -182,23 +264,81 @@ func (s *GrpcServer) Add(ctx context.Context, in *txpool_proto.AddRequest) (*txp
 	}
 	defer tx.Rollback()
 
+	reply := &txpool_proto.AddReply{Imported: make([]txpool_proto.ImportResult, len(in.RlpTxs)), Errors: make([]string, len(in.RlpTxs))}
+	slots := s.parseLocalTxs(in.RlpTxs, reply, func(hash []byte) error {
+		if known, _ := s.txPool.IdHashKnown(tx, hash); known {
+			return types.ErrAlreadyKnown
+		}
+		return nil
+	})
+
+	discardReasons, err := s.txPool.AddLocalTxs(ctx, slots, tx)
+	if err != nil {
+		return nil, err
+	}
+	fillAddReply(reply, discardReasons)
+	return reply, nil
+}
+
+func (s *GrpcServer) AddPrivate(ctx context.Context, in *txpool_proto.AddPrivateRequest) (*txpool_proto.AddReply, error) {
+	reply := &txpool_proto.AddReply{Imported: make([]txpool_proto.ImportResult, len(in.RlpTxs)), Errors: make([]string, len(in.RlpTxs))}
+	slots := s.parseLocalTxs(in.RlpTxs, reply, nil)
+
+	discardReasons, err := s.txPool.AddPrivateTxs(slots, in.MaxBlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	fillAddReply(reply, discardReasons)
+	return reply, nil
+}
+
+func (s *GrpcServer) AddBundle(ctx context.Context, in *txpool_proto.AddBundleRequest) (*txpool_proto.AddBundleReply, error) {
 	var slots types.TxSlots
 	parseCtx := types.NewTxParseContext(s.chainID).ChainIDRequired()
 	parseCtx.ValidateRLP(s.txPool.ValidateSerializedTxn)
+	slots.Resize(uint(len(in.RlpTxs)))
+	for i := range in.RlpTxs {
+		slots.Txs[i] = &types.TxSlot{}
+		slots.IsLocal[i] = true
+		if _, err := parseCtx.ParseTransaction(in.RlpTxs[i], 0, slots.Txs[i], slots.Senders.At(i), false /* hasEnvelope */, true /* wrappedWithBlobs */, nil); err != nil {
+			return nil, fmt.Errorf("bundle transaction %d: %w", i, err)
+		}
+	}
+	hash, err := s.txPool.AddBundle(slots, in.BlockNumber, in.MaxBlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	return &txpool_proto.AddBundleReply{BundleHash: gointerfaces.ConvertHashToH256(hash)}, nil
+}
 
-	reply := &txpool_proto.AddReply{Imported: make([]txpool_proto.ImportResult, len(in.RlpTxs)), Errors: make([]string, len(in.RlpTxs))}
+func (s *GrpcServer) UpdateSenderList(ctx context.Context, in *txpool_proto.UpdateSenderListRequest) (*txpool_proto.UpdateSenderListReply, error) {
+	add := make([]common.Address, len(in.Add))
+	for i, addr := range in.Add {
+		add[i] = gointerfaces.ConvertH160toAddress(addr)
+	}
+	remove := make([]common.Address, len(in.Remove))
+	for i, addr := range in.Remove {
+		remove[i] = gointerfaces.ConvertH160toAddress(addr)
+	}
+	size, err := s.txPool.UpdateSenderList(in.Deny, add, remove)
+	if err != nil {
+		return nil, err
+	}
+	return &txpool_proto.UpdateSenderListReply{Size: uint64(size)}, nil
+}
 
-	for i := 0; i < len(in.RlpTxs); i++ {
+// parseLocalTxs parses transactions submitted by RPC, erroneous ones are reported in the reply and skipped
+func (s *GrpcServer) parseLocalTxs(rlpTxs [][]byte, reply *txpool_proto.AddReply, validateHash func([]byte) error) types.TxSlots {
+	var slots types.TxSlots
+	parseCtx := types.NewTxParseContext(s.chainID).ChainIDRequired()
+	parseCtx.ValidateRLP(s.txPool.ValidateSerializedTxn)
+
+	for i := 0; i < len(rlpTxs); i++ {
 		j := len(slots.Txs) // some incoming txs may be rejected, so - need second index
 		slots.Resize(uint(j + 1))
 		slots.Txs[j] = &types.TxSlot{}
 		slots.IsLocal[j] = true
-		if _, err := parseCtx.ParseTransaction(in.RlpTxs[i], 0, slots.Txs[j], slots.Senders.At(j), false /* hasEnvelope */, true /* wrappedWithBlobs */, func(hash []byte) error {
-			if known, _ := s.txPool.IdHashKnown(tx, hash); known {
-				return types.ErrAlreadyKnown
-			}
-			return nil
-		}); err != nil {
+		if _, err := parseCtx.ParseTransaction(rlpTxs[i], 0, slots.Txs[j], slots.Senders.At(j), false /* hasEnvelope */, true /* wrappedWithBlobs */, validateHash); err != nil {
 			slots.Resize(uint(j))                      // remove erroneous transaction
 			if errors.Is(err, types.ErrAlreadyKnown) { // Noop, but need to handle to not count these
 				reply.Errors[i] = txpoolcfg.AlreadyKnown.String()
@@ -212,12 +352,11 @@ func (s *GrpcServer) Add(ctx context.Context, in *txpool_proto.AddRequest) (*txp
 			}
 		}
 	}
+	return slots
+}
 
-	discardReasons, err := s.txPool.AddLocalTxs(ctx, slots, tx)
-	if err != nil {
-		return nil, err
-	}
-
+// fillAddReply sets results of the parsed transactions, which are the ones still marked as successfully imported
+func fillAddReply(reply *txpool_proto.AddReply, discardReasons []txpoolcfg.DiscardReason) {
 	j := 0
 	for i := range reply.Imported {
 		if reply.Imported[i] != txpool_proto.ImportResult_SUCCESS {
@@ -229,7 +368,6 @@ func (s *GrpcServer) Add(ctx context.Context, in *txpool_proto.AddRequest) (*txp
 		reply.Errors[i] = discardReasons[j].String()
 		j++
 	}
-	return reply, nil
 }
 
 func mapDiscardReasonToProto(reason txpoolcfg.DiscardReason) txpool_proto.ImportResult {
@@ -43,9 +43,16 @@ type Config struct {
 	AccountSlots        uint64 // Number of executable transaction slots guaranteed per account
 	BlobSlots           uint64 // Total number of blobs (not txs) allowed per account
 	TotalBlobPoolLimit  uint64 // Total number of blobs (not txs) allowed within the txpool
+	DelegatedSlots      uint64 // Number of in-flight transactions allowed for EIP-7702 delegated accounts
 	PriceBump           uint64 // Price bump percentage to replace an already existing transaction
 	BlobPriceBump       uint64 //Price bump percentage to replace an existing 4844 blob txn (type-3)
 	OverridePragueTime  *big.Int
+	Journal             string           // Journal of local transactions to survive pool db resets, empty disables it
+	Rejournal           time.Duration    // Time interval to regenerate the local transaction journal
+	AllowList           string           // File of the only senders admitted to the pool, empty disables it
+	DenyList            string           // File of the senders never admitted to the pool, empty disables it
+	TxOrdering          string           // Order in which pending transactions are offered for new blocks, effective tip by default
+	PrioritySenders     []common.Address // Transactions of these senders are offered for new blocks ahead of any other
 
 	// regular batch tasks processing
 	SyncToNewPeersEvery   time.Duration
@@ -67,6 +74,7 @@ var DefaultConfig = Config{
 	ProcessRemoteTxsEvery: 100 * time.Millisecond,
 	CommitEvery:           15 * time.Second,
 	LogEvery:              30 * time.Second,
+	Rejournal:             time.Hour,
 
 	PendingSubPoolLimit: 10_000,
 	BaseFeeSubPoolLimit: 10_000,
@@ -76,6 +84,7 @@ var DefaultConfig = Config{
 	AccountSlots:       16,  //TODO: to choose right value (16 to be compatible with Geth)
 	BlobSlots:          48,  // Default for a total of 8 txs for 6 blobs each - for hive tests
 	TotalBlobPoolLimit: 480, // Default for a total of 10 different accounts hitting the above limit
+	DelegatedSlots:     1,   // Delegated code may bump the nonce at any time, invalidating every queued txn but the next one
 	PriceBump:          10,  // Price bump percentage to replace an already existing transaction
 	BlobPriceBump:      100,
 
@@ -119,6 +128,10 @@ const (
 	BlobTxReplace       DiscardReason = 30 // Cannot replace type-3 blob txn with another type of txn
 	BlobPoolOverflow    DiscardReason = 31 // The total number of blobs (through blob txs) in the pool has reached its limit
 	NoAuthorizations    DiscardReason = 32 // EIP-7702 transactions with an empty authorization list are invalid
+	PrivatePoolOverflow DiscardReason = 33 // The side-pool of private transactions has reached its limit
+	InflightTxLimit     DiscardReason = 34 // EIP-7702 delegated accounts may only have a few transactions in the pool
+	AuthorityReserved   DiscardReason = 35 // EIP-7702 authority has more pooled transactions than a delegated account may have
+	NotAdmitted         DiscardReason = 36 // rejected by an admission policy, e.g. the sender is not on the allow list
 )
 
 func (r DiscardReason) String() string {
@@ -183,6 +196,14 @@ func (r DiscardReason) String() string {
 		return "blobs limit in txpool is full"
 	case NoAuthorizations:
 		return "EIP-7702 transactions with an empty authorization list are invalid"
+	case PrivatePoolOverflow:
+		return "private transactions pool is full"
+	case InflightTxLimit:
+		return "in-flight transaction limit reached for delegated accounts"
+	case AuthorityReserved:
+		return "authority already has too many transactions in the pool"
+	case NotAdmitted:
+		return "sender is not admitted to the pool"
 	default:
 		panic(fmt.Sprintf("discard reason: %d", r))
 	}
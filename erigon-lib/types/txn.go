@@ -63,6 +63,7 @@ type TxParseContext struct {
 	buf             [65]byte // buffer needs to be enough for hashes (32 bytes) and for public key (65 bytes)
 	Sig             [65]byte
 	Sighash         [32]byte
+	keccakAuth      hash.Hash // EIP-7702 authorizations are hashed while the transaction hashes are still being computed
 	withSender      bool
 	allowPreEip2s   bool // Allow s > secp256k1n/2; see EIP-2
 	chainIDRequired bool
@@ -112,6 +113,7 @@ type TxSlot struct {
 
 	// EIP-7702: set code tx
 	Authorizations []Signature
+	Authorities    []*common.Address // Recovered signers of Authorizations, nil for authorizations which can't be applied on this chain
 }
 
 const (
@@ -522,11 +524,16 @@ func (ctx *TxParseContext) parseTransactionBody(payload []byte, pos, p0 int, slo
 			if err != nil {
 				return 0, fmt.Errorf("%w: authorization nonce: %s", ErrParseTxn, err) //nolint
 			}
-			p2, _, err = parseSignature(payload, p2, false /* legacy */, nil /* cfgChainId */, &sig)
+			sigPos := p2
+			var yParity byte
+			p2, yParity, err = parseSignature(payload, p2, false /* legacy */, nil /* cfgChainId */, &sig)
 			if err != nil {
 				return 0, fmt.Errorf("%w: authorization signature: %s", ErrParseTxn, err) //nolint
 			}
 			slot.Authorizations = append(slot.Authorizations, sig)
+			if ctx.withSender {
+				slot.Authorities = append(slot.Authorities, ctx.recoverAuthority(payload[authPos:sigPos], &sig, yParity))
+			}
 			authPos += authLen
 			if authPos != p2 {
 				return 0, fmt.Errorf("%w: authorization: unexpected list items", ErrParseTxn)
@@ -685,6 +692,50 @@ func (ctx *TxParseContext) parseTransactionBody(payload []byte, pos, p0 int, slo
 	return p, nil
 }
 
+// authorizationMagic prefixes the signed EIP-7702 authorization tuple
+const authorizationMagic = 0x05
+
+// recoverAuthority returns the signer of an EIP-7702 authorization, given the encoded [chain_id, address, nonce] items.
+// Authorizations for other chains or with invalid signatures are skipped during execution, so they yield nil.
+func (ctx *TxParseContext) recoverAuthority(items []byte, sig *Signature, yParity byte) *common.Address {
+	if !sig.ChainID.IsZero() && !sig.ChainID.Eq(&ctx.cfg.ChainID) {
+		return nil
+	}
+	if sig.V.GtUint64(1) || !crypto.TransactionSignatureIsValid(yParity, &sig.R, &sig.S, false /* allowPreEip2s */) {
+		return nil
+	}
+	if ctx.keccakAuth == nil {
+		ctx.keccakAuth = sha3.NewLegacyKeccak256()
+	}
+	ctx.keccakAuth.Reset()
+	ctx.buf[0] = authorizationMagic
+	prefixLen := rlp.EncodeListPrefix(len(items), ctx.buf[1:])
+	if _, err := ctx.keccakAuth.Write(ctx.buf[:1+prefixLen]); err != nil {
+		return nil
+	}
+	if _, err := ctx.keccakAuth.Write(items); err != nil {
+		return nil
+	}
+	var authSig [65]byte
+	var hash [32]byte
+	_, _ = ctx.keccakAuth.(io.Reader).Read(hash[:])
+	sig.R.WriteToSlice(authSig[0:32])
+	sig.S.WriteToSlice(authSig[32:64])
+	authSig[64] = yParity
+	pubkey, err := secp256k1.RecoverPubkeyWithContext(secp256k1.DefaultContext, hash[:], authSig[:], ctx.buf[:0])
+	if err != nil {
+		return nil
+	}
+	ctx.keccakAuth.Reset()
+	if _, err = ctx.keccakAuth.Write(pubkey[1:65]); err != nil {
+		return nil
+	}
+	_, _ = ctx.keccakAuth.(io.Reader).Read(hash[:])
+	var authority common.Address
+	copy(authority[:], hash[12:])
+	return &authority
+}
+
 type PeerID *types.H512
 
 type Hashes []byte // flatten list of 32-byte hashes
@@ -27,6 +27,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/fixedgas"
 	"github.com/erigontech/erigon-lib/common/hexutility"
 )
@@ -415,3 +416,30 @@ func TestSetCodeTxParsingWithLargeAuthorizationValues(t *testing.T) {
 	assert.True(t, tx.Authorizations[0].R.Eq(maxUint256))
 	assert.True(t, tx.Authorizations[0].S.Eq(maxUint256))
 }
+
+func TestSetCodeTxAuthorities(t *testing.T) {
+	// signed by 0x0D3ab14BBaD3D99F4203bd7a11aCB94882050E7e with authorizations for chain 1 (0x71562b71999873DB5b286dF957af199Ec94617F7),
+	// for any chain (0x703c4b2bD70c169f5717101CaeE543299Fc946C7) and for chain 5 (0x71562b71999873DB5b286dF957af199Ec94617F7)
+	txnRlp := hexutility.MustDecodeHex("0x04f9017a0103010a830186a09400000000000000000000000000000000000000bb8080c0f90114f85a019400000000000000000000000000000000000000aa0701a00dbbfb04544dabed7dcb8d262aed0501d887803bd3df26e3c83ca8f70eb193b0a043478b7b77256657480520d5b8a880f65bd62b252a1795c1bc05aadd0d1c1391f85a809400000000000000000000000000000000000000aa8001a0acac15d2c6b6e9f2c2963aba520ec44ecc298e9d044e995ff4daf2912552a262a02afc0119b702390dede2963133fcacbee4e8b0cda92867fee8970314508c9628f85a059400000000000000000000000000000000000000aa0180a08272352be35d1b5a6124f24e1145324d50676370b4bbe9e2f1e365b40f0ca9d0a001fd9132c9720d96b06d3fda656ae16f4ab942edb162083100acef637629ecb901a08d41c1aaeaddf271656332c92dfed9c5ece55a76b8949bd36231caf9239b09b8a01e440eb9f28555555ba02f2fa0ccddeb4975ce98eaab388376cb6aeb087284a9")
+	ctx := NewTxParseContext(*uint256.NewInt(1))
+	var txn TxSlot
+	sender := make([]byte, 20)
+	_, err := ctx.ParseTransaction(txnRlp, 0, &txn, sender, false /* hasEnvelope */, false /* wrappedWithBlobs */, nil)
+	require.NoError(t, err)
+	assert.Equal(t, common.HexToAddress("0x0D3ab14BBaD3D99F4203bd7a11aCB94882050E7e"), common.BytesToAddress(sender))
+	require.Len(t, txn.Authorities, 3)
+	require.NotNil(t, txn.Authorities[0])
+	assert.Equal(t, common.HexToAddress("0x71562b71999873DB5b286dF957af199Ec94617F7"), *txn.Authorities[0])
+	require.NotNil(t, txn.Authorities[1])
+	assert.Equal(t, common.HexToAddress("0x703c4b2bD70c169f5717101CaeE543299Fc946C7"), *txn.Authorities[1])
+	assert.Nil(t, txn.Authorities[2])
+
+	// authorities are recovered only along with the sender
+	ctx = NewTxParseContext(*uint256.NewInt(1))
+	ctx.WithSender(false)
+	var txn2 TxSlot
+	_, err = ctx.ParseTransaction(txnRlp, 0, &txn2, nil, false /* hasEnvelope */, false /* wrappedWithBlobs */, nil)
+	require.NoError(t, err)
+	assert.Len(t, txn2.Authorizations, 3)
+	assert.Empty(t, txn2.Authorities)
+}
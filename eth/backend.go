@@ -22,6 +22,7 @@ package eth
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/fs"
@@ -62,6 +63,7 @@ import (
 	"github.com/erigontech/erigon-lib/downloader/downloadercfg"
 	"github.com/erigontech/erigon-lib/downloader/downloadergrpc"
 	"github.com/erigontech/erigon-lib/downloader/snaptype"
+	"github.com/erigontech/erigon-lib/gointerfaces"
 	protodownloader "github.com/erigontech/erigon-lib/gointerfaces/downloaderproto"
 	"github.com/erigontech/erigon-lib/gointerfaces/grpcutil"
 	remote "github.com/erigontech/erigon-lib/gointerfaces/remoteproto"
@@ -96,6 +98,7 @@ import (
 	"github.com/erigontech/erigon/core/rawdb"
 	"github.com/erigontech/erigon/core/rawdb/blockio"
 	snaptype2 "github.com/erigontech/erigon/core/snaptype"
+	"github.com/erigontech/erigon/core/tracing"
 	"github.com/erigontech/erigon/core/types"
 	"github.com/erigontech/erigon/core/vm"
 	"github.com/erigontech/erigon/crypto"
@@ -103,9 +106,12 @@ import (
 	"github.com/erigontech/erigon/eth/ethconfig"
 	"github.com/erigontech/erigon/eth/ethconsensusconfig"
 	"github.com/erigontech/erigon/eth/ethutils"
+	"github.com/erigontech/erigon/eth/integrity"
 	"github.com/erigontech/erigon/eth/protocols/eth"
 	"github.com/erigontech/erigon/eth/stagedsync"
 	"github.com/erigontech/erigon/eth/stagedsync/stages"
+	"github.com/erigontech/erigon/eth/tracers"
+	_ "github.com/erigontech/erigon/eth/tracers/live"
 	"github.com/erigontech/erigon/ethdb/privateapi"
 	"github.com/erigontech/erigon/ethdb/prune"
 	"github.com/erigontech/erigon/ethstats"
@@ -173,6 +179,7 @@ type Ethereum struct {
 	miningSealingQuit chan struct{}
 	pendingBlocks     chan *types.Block
 	minedBlocks       chan *types.Block
+	previewBlock      func(ctx context.Context, param *core.BlockBuilderParameters, etherbase libcommon.Address) (*stagedsync.MiningBlock, error)
 
 	sentryCtx      context.Context
 	sentryCancel   context.CancelFunc
@@ -186,6 +193,7 @@ type Ethereum struct {
 	syncPruneOrder     stagedsync.PruneOrder
 
 	downloaderClient protodownloader.DownloaderClient
+	scrubber         *integrity.Scrubber
 
 	notifications *shards.Notifications
 
@@ -333,6 +341,22 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 		os.Exit(1)
 	}
 
+	var tracer *tracing.Hooks
+	if config.VMTrace != "" {
+		var traceConfig json.RawMessage
+		if config.VMTraceJsonConfig != "" {
+			traceConfig = json.RawMessage(config.VMTraceJsonConfig)
+		}
+		tracer, err = tracers.LiveDirectory.New(config.VMTrace, traceConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create live tracer %s: %w", config.VMTrace, err)
+		}
+		if tracer.OnBlockchainInit != nil {
+			tracer.OnBlockchainInit(chainConfig)
+		}
+		logger.Info("Live tracing enabled", "tracer", config.VMTrace)
+	}
+
 	// Check if we have an already initialized chain and fall back to
 	// that if so. Otherwise we need to generate a new genesis spec.
 	blockReader, blockWriter, allSnapshots, allBorSnapshots, agg, err := setUpBlockReader(ctx, chainKv, config.Dirs, config, chainConfig.Bor != nil, logger)
@@ -589,7 +613,7 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 		terseLogger.SetHandler(log.LvlFilterHandler(log.LvlWarn, log.StderrHandler))
 		// Needs its own notifications to not update RPC daemon and txpool about pending blocks
 		stateSync := stages2.NewInMemoryExecution(backend.sentryCtx, backend.chainDB, config, backend.sentriesClient,
-			dirs, notifications, blockReader, blockWriter, backend.agg, backend.silkworm, terseLogger)
+			dirs, notifications, blockReader, blockWriter, backend.agg, backend.silkworm, terseLogger, tracer)
 		chainReader := consensuschain.NewReader(chainConfig, txc.Tx, blockReader, logger)
 		// We start the mining step
 		if err := stages2.StateStep(ctx, chainReader, backend.engine, txc, stateSync, header, body, unwindPoint, headersChain, bodiesChain, config.ImportMode); err != nil {
@@ -716,6 +740,7 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 				config.Genesis,
 				config.Sync,
 				stages2.SilkwormForExecutionStage(backend.silkworm, config),
+				nil,
 			),
 			stagedsync.StageSendersCfg(backend.chainDB, chainConfig, config.Sync, false, dirs.Tmp, config.Prune, blockReader, backend.sentriesClient.Hd),
 			stagedsync.StageMiningExecCfg(backend.chainDB, miner, backend.notifications.Events, *backend.chainConfig, backend.engine, &vm.Config{}, tmpdir, nil, 0, backend.txPool, backend.txPoolDB, blockReader),
@@ -754,6 +779,7 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 					config.Genesis,
 					config.Sync,
 					stages2.SilkwormForExecutionStage(backend.silkworm, config),
+					nil,
 				),
 				stagedsync.StageSendersCfg(backend.chainDB, chainConfig, config.Sync, false, dirs.Tmp, config.Prune, blockReader, backend.sentriesClient.Hd),
 				stagedsync.StageMiningExecCfg(backend.chainDB, miningStatePos, backend.notifications.Events, *backend.chainConfig, backend.engine, &vm.Config{}, tmpdir, interrupt, param.PayloadId, backend.txPool, backend.txPoolDB, blockReader),
@@ -766,6 +792,46 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 		return block, nil
 	}
 
+	// block preview, builds the block like the mining step does, but neither seals nor announces it
+	backend.previewBlock = func(ctx context.Context, param *core.BlockBuilderParameters, etherbase libcommon.Address) (*stagedsync.MiningBlock, error) {
+		minerCfg := config.Miner
+		minerCfg.Etherbase = etherbase
+		miningStatePreview := stagedsync.NewMiningState(&minerCfg)
+		previewSync := stagedsync.New(
+			config.Sync,
+			stagedsync.MiningPreviewStages(ctx,
+				stagedsync.StageMiningCreateBlockCfg(backend.chainDB, miningStatePreview, *backend.chainConfig, backend.engine, backend.txPoolDB, param, tmpdir, backend.blockReader),
+				stagedsync.StageBorHeimdallCfg(backend.chainDB, snapDb, miningStatePreview, *backend.chainConfig, heimdallClient, backend.blockReader, nil, nil, recents, signatures, false, nil),
+				stagedsync.StageExecuteBlocksCfg(
+					backend.chainDB,
+					config.Prune,
+					config.BatchSize,
+					chainConfig,
+					backend.engine,
+					&vm.Config{},
+					backend.notifications,
+					config.StateStream,
+					/*stateStream=*/ false,
+					/*alwaysGenerateChangesets=*/ false,
+					dirs,
+					blockReader,
+					backend.sentriesClient.Hd,
+					config.Genesis,
+					config.Sync,
+					stages2.SilkwormForExecutionStage(backend.silkworm, config),
+					nil,
+				),
+				stagedsync.StageSendersCfg(backend.chainDB, chainConfig, config.Sync, false, dirs.Tmp, config.Prune, blockReader, backend.sentriesClient.Hd),
+				// no notifier: pending logs of a preview are not announced. Private transactions and bundles are
+				// left out, they were submitted for this node's blocks only and the preview is served over RPC
+				stagedsync.StageMiningExecCfg(backend.chainDB, miningStatePreview, nil, *backend.chainConfig, backend.engine, &vm.Config{}, tmpdir, nil, 0, stagedsync.PublicTxPoolForMining(backend.txPool), backend.txPoolDB, blockReader),
+			), stagedsync.MiningUnwindOrder, stagedsync.MiningPruneOrder, logger)
+		if err := stages2.MiningStep(ctx, backend.chainDB, previewSync, tmpdir, logger); err != nil {
+			return nil, err
+		}
+		return miningStatePreview.MiningBlock, nil
+	}
+
 	// Initialize ethbackend
 	ethBackendRPC := privateapi.NewEthBackendServer(ctx, backend, backend.chainDB, backend.notifications.Events, blockReader, logger, latestBlockBuiltStore)
 	// initialize engine backend
@@ -774,6 +840,13 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 
 	agg.SetSnapshotBuildSema(blockSnapBuildSema)
 	blockRetire := freezeblocks.NewBlockRetire(1, dirs, blockReader, blockWriter, backend.chainDB, backend.chainConfig, backend.notifications.Events, blockSnapBuildSema, logger)
+	if config.Snapshot.ScrubInterval > 0 {
+		buildMissedBlockIndices := func(ctx context.Context) error {
+			return blockRetire.BuildMissedIndicesIfNeed(ctx, "[scrub]", backend.notifications.Events, backend.chainConfig)
+		}
+		scrubCfg := integrity.ScrubberCfg{Interval: config.Snapshot.ScrubInterval, Rate: config.Snapshot.ScrubRate}
+		backend.scrubber = integrity.NewScrubber(scrubCfg, dirs, agg, backend.downloaderClient, buildMissedBlockIndices, logger)
+	}
 
 	miningRPC = privateapi.NewMiningServer(ctx, backend, ethashApi, logger)
 
@@ -900,12 +973,13 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 			p2pConfig.MaxPeers,
 			statusDataProvider,
 			backend.stopNode,
+			tracer,
 		)
 		backend.syncUnwindOrder = stagedsync.PolygonSyncUnwindOrder
 		backend.syncPruneOrder = stagedsync.PolygonSyncPruneOrder
 	} else {
 		backend.syncStages = stages2.NewDefaultStages(backend.sentryCtx, backend.chainDB, snapDb, p2pConfig, config, backend.sentriesClient, backend.notifications, backend.downloaderClient,
-			blockReader, blockRetire, backend.agg, backend.silkworm, backend.forkValidator, heimdallClient, recents, signatures, logger)
+			blockReader, blockRetire, backend.agg, backend.silkworm, backend.forkValidator, heimdallClient, recents, signatures, logger, tracer)
 		backend.syncUnwindOrder = stagedsync.DefaultUnwindOrder
 		backend.syncPruneOrder = stagedsync.DefaultPruneOrder
 	}
@@ -937,14 +1011,14 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 	}
 
 	checkStateRoot := true
-	pipelineStages := stages2.NewPipelineStages(ctx, backend.chainDB, config, p2pConfig, backend.sentriesClient, backend.notifications, backend.downloaderClient, blockReader, blockRetire, backend.agg, backend.silkworm, backend.forkValidator, logger, checkStateRoot)
+	pipelineStages := stages2.NewPipelineStages(ctx, backend.chainDB, config, p2pConfig, backend.sentriesClient, backend.notifications, backend.downloaderClient, blockReader, blockRetire, backend.agg, backend.silkworm, backend.forkValidator, logger, checkStateRoot, tracer)
 	backend.pipelineStagedSync = stagedsync.New(config.Sync, pipelineStages, stagedsync.PipelineUnwindOrder, stagedsync.PipelinePruneOrder, logger)
 	backend.eth1ExecutionServer = eth1.NewEthereumExecutionModule(blockReader, backend.chainDB, backend.pipelineStagedSync, backend.forkValidator, chainConfig, assembleBlockPOS, hook, backend.notifications.Accumulator, backend.notifications.StateChangesConsumer, logger, backend.engine, config.Sync, ctx)
 	executionRpc := direct.NewExecutionClientDirect(backend.eth1ExecutionServer)
 
 	var executionEngine executionclient.ExecutionEngine
 
-	executionEngine, err = executionclient.NewExecutionClientDirect(eth1_chain_reader.NewChainReaderEth1(chainConfig, executionRpc, 1000))
+	executionEngine, err = executionclient.NewExecutionClientDirect(eth1_chain_reader.NewChainReaderEth1(chainConfig, executionRpc, 1000), direct.NewTxPoolClient(backend.txPoolGrpcServer))
 	if err != nil {
 		return nil, err
 	}
@@ -953,6 +1027,7 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 		logger,
 		chainConfig,
 		executionRpc,
+		backend.chainDB,
 		backend.sentriesClient.Hd,
 		engine_block_downloader.NewEngineBlockDownloader(ctx,
 			logger, backend.sentriesClient.Hd, executionRpc,
@@ -1333,6 +1408,91 @@ func (s *Ethereum) StartMining(ctx context.Context, db kv.RwDB, stateDiffClient
 
 func (s *Ethereum) IsMining() bool { return s.config.Miner.Enabled }
 
+// PreviewBlock builds the block this node would produce on top of its head with the public txns of the pool, without
+// sealing or announcing it. Private txns and bundles are left out. On proof-of-stake the block has no withdrawals and
+// a zero prev randao, the consensus layer provides them only when a payload is actually requested. The fee recipient
+// defaults to the etherbase.
+func (s *Ethereum) PreviewBlock(ctx context.Context, feeRecipient libcommon.Address) (*txpoolproto.PreviewBlockReply, error) {
+	if feeRecipient == (libcommon.Address{}) {
+		feeRecipient = s.config.Miner.Etherbase
+	}
+	var param *core.BlockBuilderParameters
+	if err := s.chainDB.View(ctx, func(tx kv.Tx) error {
+		executionAt, err := stages.GetStageProgress(tx, stages.Execution)
+		if err != nil {
+			return err
+		}
+		parent := rawdb.ReadHeaderByNumber(tx, executionAt)
+		if parent == nil {
+			return fmt.Errorf("empty block %d", executionAt)
+		}
+		if parent.Difficulty.Sign() != 0 {
+			return nil // proof-of-work, the block is built like the miner does
+		}
+		if feeRecipient == (libcommon.Address{}) {
+			return errors.New("fee recipient is required, the node has no etherbase")
+		}
+		timestamp := max(uint64(time.Now().Unix()), parent.Time+1)
+		param = &core.BlockBuilderParameters{ParentHash: parent.Hash(), Timestamp: timestamp, SuggestedFeeRecipient: feeRecipient}
+		if s.chainConfig.IsShanghai(timestamp) {
+			param.Withdrawals = []*types.Withdrawal{}
+		}
+		if s.chainConfig.IsCancun(timestamp) {
+			param.ParentBeaconBlockRoot = &libcommon.Hash{}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	block, err := s.previewBlock(ctx, param, feeRecipient)
+	if err != nil {
+		return nil, err
+	}
+	header := block.Header
+	reply := &txpoolproto.PreviewBlockReply{
+		BlockNumber: header.Number.Uint64(),
+		ParentHash:  gointerfaces.ConvertHashToH256(header.ParentHash),
+		Timestamp:   header.Time,
+		GasLimit:    header.GasLimit,
+		GasUsed:     header.GasUsed,
+	}
+	var baseFee uint256.Int
+	if header.BaseFee != nil {
+		baseFee.SetFromBig(header.BaseFee)
+		reply.BaseFee = gointerfaces.ConvertUint256IntToH256(&baseFee)
+	}
+	if header.BlobGasUsed != nil {
+		reply.BlobGasUsed = *header.BlobGasUsed
+	}
+	var priorityFees uint256.Int
+	for i, txn := range block.Txs {
+		sender, _ := txn.GetSender()
+		gasUsed := block.Receipts[i].GasUsed
+		priorityFee := new(uint256.Int).Mul(txn.GetEffectiveGasTip(&baseFee), uint256.NewInt(gasUsed))
+		priorityFees.Add(&priorityFees, priorityFee)
+		blobCount := uint64(len(txn.GetBlobHashes()))
+		reply.BlobCount += blobCount
+		reply.Txs = append(reply.Txs, &txpoolproto.PreviewBlockReply_Txn{
+			Hash:        gointerfaces.ConvertHashToH256(txn.Hash()),
+			Sender:      gointerfaces.ConvertAddressToH160(sender),
+			Nonce:       txn.GetNonce(),
+			GasUsed:     gasUsed,
+			PriorityFee: gointerfaces.ConvertUint256IntToH256(priorityFee),
+			BlobCount:   blobCount,
+		})
+	}
+	reply.PriorityFees = gointerfaces.ConvertUint256IntToH256(&priorityFees)
+	for _, skipped := range block.Skipped {
+		reply.Skipped = append(reply.Skipped, &txpoolproto.PreviewBlockReply_Skipped{
+			Hash:   gointerfaces.ConvertHashToH256(skipped.Hash),
+			Sender: gointerfaces.ConvertAddressToH160(skipped.Sender),
+			Reason: skipped.Reason,
+		})
+	}
+	return reply, nil
+}
+
 func (s *Ethereum) ChainKV() kv.RwDB            { return s.chainDB }
 func (s *Ethereum) NetVersion() (uint64, error) { return s.networkID, nil }
 func (s *Ethereum) NetPeerCount() (uint64, error) {
@@ -1548,6 +1708,10 @@ func (s *Ethereum) Start() error {
 		s.engine.(*bor.Bor).Start(s.chainDB)
 	}
 
+	if s.scrubber != nil {
+		go s.scrubber.Run(s.sentryCtx)
+	}
+
 	if s.silkwormRPCDaemonService != nil {
 		if err := s.silkwormRPCDaemonService.Start(); err != nil {
 			s.logger.Error("silkworm.StartRpcDaemon error", "err", err)
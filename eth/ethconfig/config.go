@@ -142,6 +142,9 @@ type BlocksFreezing struct {
 	Verify         bool // verify snapshots on startup
 	DownloaderAddr string
 	ChainName      string
+
+	ScrubInterval time.Duration     // pause between passes of the snapshot files scrubber, 0 - disabled
+	ScrubRate     datasize.ByteSize // disk read rate of the scrubber
 }
 
 func (s BlocksFreezing) String() string {
@@ -161,6 +164,12 @@ var (
 	FlagSnapStateStop  = "snap.state.stop"
 )
 
+type Export struct {
+	Dir           string   // export is disabled if empty
+	BlocksPerFile uint64   // size of the partitions
+	Tables        []string // all tables if empty
+}
+
 func NewSnapCfg(keepBlocks, produceE2, produceE3 bool, chainName string) BlocksFreezing {
 	return BlocksFreezing{KeepBlocks: keepBlocks, ProduceE2: produceE2, ProduceE3: produceE3, ChainName: chainName}
 }
@@ -244,6 +253,13 @@ type Config struct {
 
 	OverridePragueTime *big.Int `toml:",omitempty"`
 
+	// Live tracer (see eth/tracers/live) attached to the Execution stage and its JSON config
+	VMTrace           string
+	VMTraceJsonConfig string
+
+	// Export stage: writes block ranges to Parquet files after Execution
+	Export Export
+
 	// Embedded Silkworm support
 	SilkwormExecution            bool
 	SilkwormRpcDaemon            bool
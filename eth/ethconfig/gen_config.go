@@ -61,6 +61,9 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		SentinelAddr                   string
 		SentinelPort                   uint64
 		OverridePragueTime             *big.Int `toml:",omitempty"`
+		VMTrace                        string
+		VMTraceJsonConfig              string
+		Export                         Export
 		SilkwormExecution              bool
 		SilkwormRpcDaemon              bool
 		SilkwormSentry                 bool
@@ -114,6 +117,9 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.CaplinConfig.SentinelAddr = c.CaplinConfig.SentinelAddr
 	enc.CaplinConfig.SentinelPort = c.CaplinConfig.SentinelPort
 	enc.OverridePragueTime = c.OverridePragueTime
+	enc.VMTrace = c.VMTrace
+	enc.VMTraceJsonConfig = c.VMTraceJsonConfig
+	enc.Export = c.Export
 	enc.SilkwormExecution = c.SilkwormExecution
 	enc.SilkwormRpcDaemon = c.SilkwormRpcDaemon
 	enc.SilkwormSentry = c.SilkwormSentry
@@ -171,6 +177,9 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		SentinelAddr                   *string
 		SentinelPort                   *uint64
 		OverridePragueTime             *big.Int `toml:",omitempty"`
+		VMTrace                        *string
+		VMTraceJsonConfig              *string
+		Export                         *Export
 		SilkwormExecution              *bool
 		SilkwormRpcDaemon              *bool
 		SilkwormSentry                 *bool
@@ -303,6 +312,15 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.OverridePragueTime != nil {
 		c.OverridePragueTime = dec.OverridePragueTime
 	}
+	if dec.VMTrace != nil {
+		c.VMTrace = *dec.VMTrace
+	}
+	if dec.VMTraceJsonConfig != nil {
+		c.VMTraceJsonConfig = *dec.VMTraceJsonConfig
+	}
+	if dec.Export != nil {
+		c.Export = *dec.Export
+	}
 	if dec.SilkwormExecution != nil {
 		c.SilkwormExecution = *dec.SilkwormExecution
 	}
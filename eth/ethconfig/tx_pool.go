@@ -75,6 +75,10 @@ var DefaultTxPool2Config = func(fullCfg *Config) txpoolcfg.Config {
 	cfg.AccountSlots = pool1Cfg.AccountSlots
 	cfg.BlobSlots = fullCfg.TxPool.BlobSlots
 	cfg.TotalBlobPoolLimit = fullCfg.TxPool.TotalBlobPoolLimit
+	cfg.DelegatedSlots = fullCfg.TxPool.DelegatedSlots
+	cfg.Rejournal = fullCfg.TxPool.Rejournal
+	cfg.AllowList = fullCfg.TxPool.AllowList
+	cfg.DenyList = fullCfg.TxPool.DenyList
 	cfg.LogEvery = 3 * time.Minute
 	cfg.CommitEvery = 5 * time.Minute
 	cfg.TracedSenders = pool1Cfg.TracedSenders
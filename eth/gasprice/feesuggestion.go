@@ -0,0 +1,233 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package gasprice
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"slices"
+
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon-lib/chain"
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon/consensus/misc"
+	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/rpc"
+)
+
+const (
+	// BlobFeeForecastBlocks is the number of upcoming blocks the blob base fee is forecast for
+	BlobFeeForecastBlocks = 6
+	// minTypeSamples is the number of tips of a txn type below which the tips of all types are used instead
+	minTypeSamples = 3
+)
+
+var (
+	// feeTierPercentiles are the percentiles of recent tips behind the slow, standard and fast tiers
+	feeTierPercentiles = [3]float64{25, 50, 90}
+	// blobFeeTierHorizons are the numbers of blocks the slow, standard and fast tiers expect to wait for inclusion,
+	// the blob fee cap of a tier covers the forecast over its horizon
+	blobFeeTierHorizons = [3]int{BlobFeeForecastBlocks, 3, 1}
+)
+
+// FeeTier is a fee suggestion for one txn type, only the fields the type uses are set
+type FeeTier struct {
+	GasPrice             *big.Int // legacy txns
+	MaxPriorityFeePerGas *big.Int
+	MaxFeePerGas         *big.Int
+	MaxFeePerBlobGas     *big.Int // blob txns
+}
+
+type FeeTiers struct {
+	Slow, Standard, Fast FeeTier
+}
+
+// FeeSuggestions are fee tiers per txn type for the block following BlockNumber
+type FeeSuggestions struct {
+	BlockNumber         uint64   // head the suggestions are based on
+	BaseFee             *big.Int // of the next block, nil before London
+	BlobBaseFee         *big.Int // of the next block, nil before Cancun
+	BlobBaseFeeForecast []*big.Int
+	// PoolPressure is the number of pending txns of the pool per txn included in the sampled blocks, above 1 the
+	// pool holds more than one block can take and the tiers move up the tip distribution
+	PoolPressure float64
+
+	Legacy     FeeTiers
+	DynamicFee *FeeTiers // nil before London
+	Blob       *FeeTiers // nil before Cancun
+}
+
+// SuggestFees returns slow, standard and fast fee tiers for legacy, dynamic fee and blob txns. The tips of every
+// tier are percentiles of the effective tips paid by the txns of the same type in recent blocks, raised when
+// pendingTxs, the number of executable txns waiting in the pool, exceeds what recent blocks included.
+func (oracle *Oracle) SuggestFees(ctx context.Context, pendingTxs int) (*FeeSuggestions, error) {
+	head, err := oracle.backend.HeaderByNumber(ctx, rpc.LatestBlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	if head == nil {
+		return nil, errors.New("head header not found")
+	}
+	chainConfig := oracle.backend.ChainConfig()
+	ignoreUnder, overflow := uint256.FromBig(oracle.ignorePrice)
+	if overflow {
+		return nil, errors.New("overflow in SuggestFees: ignorePrice too large")
+	}
+
+	var (
+		tips        = map[byte][]*uint256.Int{}
+		allTips     []*uint256.Int
+		txCount     int
+		blobGasUsed uint64
+		sampled     int
+	)
+	for number := head.Number.Uint64(); number > 0 && sampled < oracle.checkBlocks; number-- {
+		if err := libcommon.Stopped(ctx.Done()); err != nil {
+			return nil, err
+		}
+		block, err := oracle.backend.BlockByNumber(ctx, rpc.BlockNumber(number))
+		if err != nil {
+			return nil, err
+		}
+		if block == nil {
+			break
+		}
+		sampled++
+		txCount += block.Transactions().Len()
+		if used := block.HeaderNoCopy().BlobGasUsed; used != nil {
+			blobGasUsed += *used
+		}
+		var baseFee *uint256.Int
+		if block.BaseFee() != nil {
+			if baseFee, overflow = uint256.FromBig(block.BaseFee()); overflow {
+				return nil, errors.New("overflow in SuggestFees: baseFee > 2^256-1")
+			}
+		}
+		for _, txn := range block.Transactions() {
+			if sender, ok := txn.GetSender(); ok && sender == block.Coinbase() {
+				continue
+			}
+			tip := txn.GetEffectiveGasTip(baseFee)
+			if tip.Lt(ignoreUnder) {
+				continue
+			}
+			tips[txn.Type()] = append(tips[txn.Type()], tip)
+			allTips = append(allTips, tip)
+		}
+	}
+
+	res := &FeeSuggestions{BlockNumber: head.Number.Uint64()}
+	if sampled > 0 {
+		res.PoolPressure = float64(pendingTxs) / max(float64(txCount)/float64(sampled), 1)
+	}
+	var percentiles [3]float64
+	for i, p := range feeTierPercentiles {
+		if res.PoolPressure > 1 {
+			p += (100 - p) * (1 - 1/res.PoolPressure)
+		}
+		percentiles[i] = p
+	}
+	tierTips := func(txTypes ...byte) [3]*big.Int {
+		var samples []*uint256.Int
+		for _, txType := range txTypes {
+			samples = append(samples, tips[txType]...)
+		}
+		if len(samples) < minTypeSamples {
+			samples = allTips
+		}
+		return oracle.tipPercentiles(samples, percentiles)
+	}
+
+	baseFee := new(big.Int)
+	if chainConfig.IsLondon(head.Number.Uint64() + 1) {
+		res.BaseFee = misc.CalcBaseFee(chainConfig, head)
+		baseFee = res.BaseFee
+	}
+	// the fee cap leaves room for the base fee to double, like the usual wallet default
+	maxFee := func(tip *big.Int) *big.Int {
+		return new(big.Int).Add(new(big.Int).Mul(baseFee, libcommon.Big2), tip)
+	}
+
+	legacyTips := tierTips(types.LegacyTxType, types.AccessListTxType)
+	legacy := []*FeeTier{&res.Legacy.Slow, &res.Legacy.Standard, &res.Legacy.Fast}
+	for i, tier := range legacy {
+		tier.GasPrice = new(big.Int).Add(baseFee, legacyTips[i])
+	}
+	if res.BaseFee == nil {
+		return res, nil
+	}
+
+	res.DynamicFee = &FeeTiers{}
+	dynamicTips := tierTips(types.DynamicFeeTxType, types.SetCodeTxType)
+	for i, tier := range []*FeeTier{&res.DynamicFee.Slow, &res.DynamicFee.Standard, &res.DynamicFee.Fast} {
+		tier.MaxPriorityFeePerGas, tier.MaxFeePerGas = dynamicTips[i], maxFee(dynamicTips[i])
+	}
+	if head.ExcessBlobGas == nil || !chainConfig.IsCancun(head.Time) {
+		return res, nil
+	}
+
+	if res.BlobBaseFeeForecast, err = forecastBlobBaseFee(chainConfig, head, blobGasUsed/uint64(max(sampled, 1))); err != nil {
+		return nil, err
+	}
+	res.BlobBaseFee = res.BlobBaseFeeForecast[0]
+	res.Blob = &FeeTiers{}
+	blobTips := tierTips(types.BlobTxType)
+	for i, tier := range []*FeeTier{&res.Blob.Slow, &res.Blob.Standard, &res.Blob.Fast} {
+		tier.MaxPriorityFeePerGas, tier.MaxFeePerGas = blobTips[i], maxFee(blobTips[i])
+		highest := slices.MaxFunc(res.BlobBaseFeeForecast[:blobFeeTierHorizons[i]], func(a, b *big.Int) int { return a.Cmp(b) })
+		tier.MaxFeePerBlobGas = new(big.Int).Mul(highest, libcommon.Big2)
+	}
+	return res, nil
+}
+
+// tipPercentiles picks the tips at the given percentiles, the default price is used when there are no samples
+func (oracle *Oracle) tipPercentiles(samples []*uint256.Int, percentiles [3]float64) [3]*big.Int {
+	var res [3]*big.Int
+	sorted := slices.Clone(samples)
+	slices.SortFunc(sorted, func(a, b *uint256.Int) int { return a.Cmp(b) })
+	for i, p := range percentiles {
+		tip := new(big.Int)
+		if len(sorted) > 0 {
+			tip = sorted[int(float64(len(sorted)-1)*p/100)].ToBig()
+		} else if oracle.lastPrice != nil {
+			tip.Set(oracle.lastPrice)
+		}
+		if tip.Cmp(oracle.maxPrice) > 0 {
+			tip.Set(oracle.maxPrice)
+		}
+		res[i] = tip
+	}
+	return res
+}
+
+// forecastBlobBaseFee returns the blob base fees of the blocks following head, assuming that each of them uses
+// blobGasUsed
+func forecastBlobBaseFee(chainConfig *chain.Config, head *types.Header, blobGasUsed uint64) ([]*big.Int, error) {
+	forecast := make([]*big.Int, BlobFeeForecastBlocks)
+	excessBlobGas := misc.CalcExcessBlobGas(chainConfig, head)
+	for i := range forecast {
+		fee, err := misc.GetBlobGasPrice(chainConfig, excessBlobGas)
+		if err != nil {
+			return nil, err
+		}
+		forecast[i] = fee.ToBig()
+		excessBlobGas = misc.CalcExcessBlobGas(chainConfig, &types.Header{ExcessBlobGas: &excessBlobGas, BlobGasUsed: &blobGasUsed})
+	}
+	return forecast, nil
+}
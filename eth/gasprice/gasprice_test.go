@@ -27,6 +27,8 @@ import (
 
 	"github.com/holiman/uint256"
 
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/crypto/kzg"
 	"github.com/erigontech/erigon-lib/kv/kvcache"
 	"github.com/erigontech/erigon/rpc/rpccfg"
 
@@ -36,11 +38,14 @@ import (
 	"github.com/erigontech/erigon/turbo/jsonrpc"
 	"github.com/erigontech/erigon/turbo/stages/mock"
 
+	"github.com/erigontech/erigon/consensus/misc"
 	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/core/rawdb"
 	"github.com/erigontech/erigon/core/types"
 	"github.com/erigontech/erigon/crypto"
 	"github.com/erigontech/erigon/eth/gasprice"
 	"github.com/erigontech/erigon/params"
+	"github.com/erigontech/erigon/rpc"
 )
 
 func newTestBackend(t *testing.T) *mock.MockSentry {
@@ -75,6 +80,125 @@ func newTestBackend(t *testing.T) *mock.MockSentry {
 	return m
 }
 
+// newLondonTestBackend makes a post-London chain on which every block includes a dynamic fee txn with a tip of i+1 GWei
+func newLondonTestBackend(t *testing.T) *mock.MockSentry {
+	config := *params.TestChainConfig
+	config.LondonBlock = big.NewInt(0)
+	var (
+		key, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+		gspec  = &types.Genesis{
+			Config: &config,
+			Alloc:  types.GenesisAlloc{addr: {Balance: big.NewInt(math.MaxInt64)}},
+		}
+		signer = types.LatestSigner(gspec.Config)
+		to     = libcommon.HexToAddress("deadbeef")
+	)
+	m := mock.MockWithGenesis(t, gspec, key, false)
+
+	chain, err := core.GenerateChain(m.ChainConfig, m.Genesis, m.Engine, m.DB, 32, func(i int, b *core.BlockGen) {
+		b.SetCoinbase(libcommon.Address{1})
+		tx, txErr := types.SignTx(&types.DynamicFeeTransaction{
+			CommonTx: types.CommonTx{Nonce: b.TxNonce(addr), Gas: 21000, To: &to, Value: uint256.NewInt(100)},
+			ChainID:  uint256.MustFromBig(config.ChainID),
+			Tip:      uint256.NewInt(uint64(int64(i+1) * params.GWei)),
+			FeeCap:   uint256.NewInt(uint64(100 * params.GWei)),
+		}, *signer, key)
+		if txErr != nil {
+			t.Fatalf("failed to create tx: %v", txErr)
+		}
+		b.AddTx(tx)
+	})
+	if err != nil {
+		t.Error(err)
+	}
+	if err = m.InsertChain(chain); err != nil {
+		t.Error(err)
+	}
+	return m
+}
+
+// blocksBackend serves the oracle a fixed chain of blocks, the head is the last one
+type blocksBackend struct {
+	config *chain.Config
+	blocks []*types.Block
+}
+
+func (b *blocksBackend) HeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Header, error) {
+	block, err := b.BlockByNumber(ctx, number)
+	if block == nil {
+		return nil, err
+	}
+	return block.Header(), err
+}
+
+func (b *blocksBackend) BlockByNumber(_ context.Context, number rpc.BlockNumber) (*types.Block, error) {
+	if number == rpc.LatestBlockNumber {
+		return b.blocks[len(b.blocks)-1], nil
+	}
+	if number < 0 || int(number) >= len(b.blocks) {
+		return nil, nil
+	}
+	return b.blocks[number], nil
+}
+
+func (b *blocksBackend) ChainConfig() *chain.Config { return b.config }
+
+func (b *blocksBackend) GetReceipts(context.Context, *types.Block) (types.Receipts, error) {
+	return nil, nil
+}
+
+func (b *blocksBackend) PendingBlockAndReceipts() (*types.Block, types.Receipts) { return nil, nil }
+
+// newCancunTestBackend makes a post-Cancun chain on which every block i includes a dynamic fee txn with a tip of i+1 GWei
+// and a blob txn with a tip of 2*(i+1) GWei using all the blobs a block can take
+func newCancunTestBackend(config *chain.Config) *blocksBackend {
+	blobHashes := make([]libcommon.Hash, config.GetMaxBlobsPerBlock())
+	for i := range blobHashes {
+		blobHashes[i] = libcommon.Hash{kzg.BlobCommitmentVersionKZG, byte(i)}
+	}
+	chainID, to := uint256.MustFromBig(config.ChainID), libcommon.HexToAddress("deadbeef")
+	blobGasUsed := config.GetMaxBlobGasPerBlock()
+	var excessBlobGas uint64
+	genesis := types.NewBlock(&types.Header{Number: big.NewInt(0), GasLimit: 30_000_000, BaseFee: big.NewInt(params.GWei), BlobGasUsed: new(uint64), ExcessBlobGas: &excessBlobGas}, nil, nil, nil, nil, nil)
+	backend := &blocksBackend{config: config, blocks: []*types.Block{genesis}}
+	for i := 0; i < 32; i++ {
+		parent := backend.blocks[i].Header()
+		excessBlobGas := misc.CalcExcessBlobGas(config, parent)
+		header := &types.Header{
+			ParentHash:    parent.Hash(),
+			Number:        big.NewInt(int64(i + 1)),
+			GasLimit:      parent.GasLimit,
+			GasUsed:       2 * 21000,
+			Time:          uint64(i+1) * 12,
+			BaseFee:       misc.CalcBaseFee(config, parent),
+			BlobGasUsed:   &blobGasUsed,
+			ExcessBlobGas: &excessBlobGas,
+		}
+		tip := uint256.NewInt(uint64(int64(i+1) * params.GWei))
+		txns := []types.Transaction{
+			&types.DynamicFeeTransaction{
+				CommonTx: types.CommonTx{Nonce: uint64(2 * i), Gas: 21000, To: &to, Value: new(uint256.Int)},
+				ChainID:  chainID,
+				Tip:      tip,
+				FeeCap:   uint256.NewInt(uint64(100 * params.GWei)),
+			},
+			&types.BlobTx{
+				DynamicFeeTransaction: types.DynamicFeeTransaction{
+					CommonTx: types.CommonTx{Nonce: uint64(2*i + 1), Gas: 21000, To: &to, Value: new(uint256.Int)},
+					ChainID:  chainID,
+					Tip:      new(uint256.Int).Mul(tip, uint256.NewInt(2)),
+					FeeCap:   uint256.NewInt(uint64(100 * params.GWei)),
+				},
+				MaxFeePerBlobGas:    uint256.NewInt(uint64(params.GWei)),
+				BlobVersionedHashes: blobHashes,
+			},
+		}
+		backend.blocks = append(backend.blocks, types.NewBlock(header, txns, nil, nil, nil, nil))
+	}
+	return backend
+}
+
 func TestSuggestPrice(t *testing.T) {
 	config := gaspricecfg.Config{
 		Blocks:     2,
@@ -101,3 +225,136 @@ func TestSuggestPrice(t *testing.T) {
 		t.Fatalf("Gas price mismatch, want %d, got %d", expect, got)
 	}
 }
+
+func TestSuggestFees(t *testing.T) {
+	config := gaspricecfg.Config{
+		Blocks:     4,
+		Percentile: 60,
+		Default:    big.NewInt(params.GWei),
+	}
+
+	m := newTestBackend(t)
+	baseApi := jsonrpc.NewBaseApi(nil, kvcache.NewDummy(), m.BlockReader, false, rpccfg.DefaultEvmCallTimeout, m.Engine, m.Dirs, nil)
+
+	tx, _ := m.DB.BeginRo(m.Ctx)
+	defer tx.Rollback()
+
+	oracle := gasprice.NewOracle(jsonrpc.NewGasPriceOracleBackend(tx, baseApi), config, jsonrpc.NewGasPriceCache(), log.New())
+	gwei := func(n int64) *big.Int { return big.NewInt(n * params.GWei) }
+
+	// The gas prices sampled are 29G, 30G, 31G and 32G, one txn per block
+	fees, err := oracle.SuggestFees(context.Background(), 0)
+	if err != nil {
+		t.Fatalf("Failed to retrieve fee suggestions: %v", err)
+	}
+	if fees.BlockNumber != 32 || fees.PoolPressure != 0 {
+		t.Fatalf("Unexpected head %d or pool pressure %f", fees.BlockNumber, fees.PoolPressure)
+	}
+	for i, c := range []struct{ got, want *big.Int }{
+		{fees.Legacy.Slow.GasPrice, gwei(29)},
+		{fees.Legacy.Standard.GasPrice, gwei(30)},
+		{fees.Legacy.Fast.GasPrice, gwei(31)},
+	} {
+		if c.got.Cmp(c.want) != 0 {
+			t.Fatalf("Gas price mismatch of tier %d, want %d, got %d", i, c.want, c.got)
+		}
+	}
+	// pre-London chain
+	if fees.BaseFee != nil || fees.DynamicFee != nil || fees.Blob != nil {
+		t.Fatalf("Unexpected fee suggestions for inactive txn types")
+	}
+
+	// two blocks worth of pending txns move the tiers up
+	fees, err = oracle.SuggestFees(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("Failed to retrieve fee suggestions: %v", err)
+	}
+	if fees.PoolPressure != 2 {
+		t.Fatalf("Pool pressure mismatch, want 2, got %f", fees.PoolPressure)
+	}
+	if fees.Legacy.Slow.GasPrice.Cmp(gwei(30)) != 0 || fees.Legacy.Fast.GasPrice.Cmp(gwei(31)) != 0 {
+		t.Fatalf("Unexpected gas prices under pressure, slow %d, fast %d", fees.Legacy.Slow.GasPrice, fees.Legacy.Fast.GasPrice)
+	}
+
+	checkTiers := func(name string, got [3]*big.Int, want [3]*big.Int) {
+		for i := range got {
+			if got[i].Cmp(want[i]) != 0 {
+				t.Fatalf("%s mismatch of tier %d, want %d, got %d", name, i, want[i], got[i])
+			}
+		}
+	}
+	maxFee := func(baseFee, tip *big.Int) *big.Int {
+		return new(big.Int).Add(new(big.Int).Mul(baseFee, big.NewInt(2)), tip)
+	}
+
+	// post-London chain, the tips sampled are 29G, 30G, 31G and 32G of dynamic fee txns only
+	m = newLondonTestBackend(t)
+	baseApi = jsonrpc.NewBaseApi(nil, kvcache.NewDummy(), m.BlockReader, false, rpccfg.DefaultEvmCallTimeout, m.Engine, m.Dirs, nil)
+	londonTx, _ := m.DB.BeginRo(m.Ctx)
+	defer londonTx.Rollback()
+	oracle = gasprice.NewOracle(jsonrpc.NewGasPriceOracleBackend(londonTx, baseApi), config, jsonrpc.NewGasPriceCache(), log.New())
+	fees, err = oracle.SuggestFees(context.Background(), 0)
+	if err != nil {
+		t.Fatalf("Failed to retrieve fee suggestions: %v", err)
+	}
+	head := rawdb.ReadCurrentHeader(londonTx)
+	if fees.BaseFee == nil || fees.BaseFee.Cmp(misc.CalcBaseFee(m.ChainConfig, head)) != 0 {
+		t.Fatalf("Base fee mismatch, want %d, got %d", misc.CalcBaseFee(m.ChainConfig, head), fees.BaseFee)
+	}
+	if fees.DynamicFee == nil || fees.Blob != nil || fees.BlobBaseFee != nil {
+		t.Fatalf("Unexpected fee suggestions for the txn types of a post-London chain")
+	}
+	checkTiers("Priority fee", [3]*big.Int{fees.DynamicFee.Slow.MaxPriorityFeePerGas, fees.DynamicFee.Standard.MaxPriorityFeePerGas, fees.DynamicFee.Fast.MaxPriorityFeePerGas},
+		[3]*big.Int{gwei(29), gwei(30), gwei(31)})
+	checkTiers("Fee cap", [3]*big.Int{fees.DynamicFee.Slow.MaxFeePerGas, fees.DynamicFee.Standard.MaxFeePerGas, fees.DynamicFee.Fast.MaxFeePerGas},
+		[3]*big.Int{maxFee(fees.BaseFee, gwei(29)), maxFee(fees.BaseFee, gwei(30)), maxFee(fees.BaseFee, gwei(31))})
+	// without legacy txns the legacy tiers fall back to the tips of all txns on top of the base fee
+	checkTiers("Gas price", [3]*big.Int{fees.Legacy.Slow.GasPrice, fees.Legacy.Standard.GasPrice, fees.Legacy.Fast.GasPrice},
+		[3]*big.Int{new(big.Int).Add(fees.BaseFee, gwei(29)), new(big.Int).Add(fees.BaseFee, gwei(30)), new(big.Int).Add(fees.BaseFee, gwei(31))})
+
+	// post-Cancun chain, every block also includes a blob txn with twice the tip using all blobs a block can take
+	cancunBackend := newCancunTestBackend(params.AllProtocolChanges)
+	oracle = gasprice.NewOracle(cancunBackend, config, jsonrpc.NewGasPriceCache(), log.New())
+	fees, err = oracle.SuggestFees(context.Background(), 0)
+	if err != nil {
+		t.Fatalf("Failed to retrieve fee suggestions: %v", err)
+	}
+	head, _ = cancunBackend.HeaderByNumber(context.Background(), rpc.LatestBlockNumber)
+	if fees.DynamicFee == nil || fees.Blob == nil {
+		t.Fatalf("Missing fee suggestions for the txn types of a post-Cancun chain")
+	}
+	checkTiers("Priority fee", [3]*big.Int{fees.DynamicFee.Slow.MaxPriorityFeePerGas, fees.DynamicFee.Standard.MaxPriorityFeePerGas, fees.DynamicFee.Fast.MaxPriorityFeePerGas},
+		[3]*big.Int{gwei(29), gwei(30), gwei(31)})
+	checkTiers("Blob priority fee", [3]*big.Int{fees.Blob.Slow.MaxPriorityFeePerGas, fees.Blob.Standard.MaxPriorityFeePerGas, fees.Blob.Fast.MaxPriorityFeePerGas},
+		[3]*big.Int{gwei(58), gwei(60), gwei(62)})
+	checkTiers("Blob fee cap", [3]*big.Int{fees.Blob.Slow.MaxFeePerGas, fees.Blob.Standard.MaxFeePerGas, fees.Blob.Fast.MaxFeePerGas},
+		[3]*big.Int{maxFee(fees.BaseFee, gwei(58)), maxFee(fees.BaseFee, gwei(60)), maxFee(fees.BaseFee, gwei(62))})
+	// the legacy tiers sample 29G-32G and 58G-64G
+	checkTiers("Gas price", [3]*big.Int{fees.Legacy.Slow.GasPrice, fees.Legacy.Standard.GasPrice, fees.Legacy.Fast.GasPrice},
+		[3]*big.Int{new(big.Int).Add(fees.BaseFee, gwei(30)), new(big.Int).Add(fees.BaseFee, gwei(32)), new(big.Int).Add(fees.BaseFee, gwei(62))})
+
+	// full blob blocks keep raising the excess blob gas, so the forecast keeps rising from the fee of the next block
+	excessBlobGas := misc.CalcExcessBlobGas(params.AllProtocolChanges, head)
+	blobBaseFee, err := misc.GetBlobGasPrice(params.AllProtocolChanges, excessBlobGas)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fees.BlobBaseFee == nil || fees.BlobBaseFee.Cmp(blobBaseFee.ToBig()) != 0 {
+		t.Fatalf("Blob base fee mismatch, want %d, got %d", blobBaseFee, fees.BlobBaseFee)
+	}
+	if len(fees.BlobBaseFeeForecast) != gasprice.BlobFeeForecastBlocks || fees.BlobBaseFeeForecast[0].Cmp(fees.BlobBaseFee) != 0 {
+		t.Fatalf("Unexpected blob base fee forecast %v", fees.BlobBaseFeeForecast)
+	}
+	for i := 1; i < len(fees.BlobBaseFeeForecast); i++ {
+		if fees.BlobBaseFeeForecast[i].Cmp(fees.BlobBaseFeeForecast[i-1]) <= 0 {
+			t.Fatalf("Blob base fee forecast doesn't rise %v", fees.BlobBaseFeeForecast)
+		}
+	}
+	// the slow tier covers the whole forecast, the standard tier half of it and the fast tier the next block only
+	checkTiers("Blob gas fee cap", [3]*big.Int{fees.Blob.Slow.MaxFeePerBlobGas, fees.Blob.Standard.MaxFeePerBlobGas, fees.Blob.Fast.MaxFeePerBlobGas},
+		[3]*big.Int{
+			new(big.Int).Mul(fees.BlobBaseFeeForecast[gasprice.BlobFeeForecastBlocks-1], big.NewInt(2)),
+			new(big.Int).Mul(fees.BlobBaseFeeForecast[2], big.NewInt(2)),
+			new(big.Int).Mul(fees.BlobBaseFeeForecast[0], big.NewInt(2)),
+		})
+}
@@ -0,0 +1,266 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package integrity
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/recsplit"
+	"github.com/erigontech/erigon-lib/recsplit/eliasfano32"
+	"github.com/erigontech/erigon-lib/seg"
+	"github.com/erigontech/erigon-lib/state"
+)
+
+// DefaultAccessorSamples - amount of keys of the data file looked up in its accessor by CheckAccessors
+const DefaultAccessorSamples = 4096
+
+// CheckAccessor verifies that the accessor file (.kvi, .bt, .vi, .efi) agrees with the data file it was built from:
+// it has the same amount of keys and sampled keys lead back to themselves. Block snapshot indices (.idx) are only
+// checked to open. Accessors of other types are skipped.
+func CheckAccessor(ctx context.Context, dirs datadir.Dirs, accessorPath string, samples int) (err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = fmt.Errorf("%s: %v", filepath.Base(accessorPath), rec)
+		}
+	}()
+
+	ext := filepath.Ext(accessorPath)
+	stem := strings.TrimSuffix(filepath.Base(accessorPath), ext)
+	switch ext {
+	case ".kvi":
+		return checkHashMapAccessor(ctx, accessorPath, filepath.Join(dirs.SnapDomain, stem+".kv"), samples)
+	case ".efi":
+		return checkHashMapAccessor(ctx, accessorPath, filepath.Join(dirs.SnapIdx, stem+".ef"), samples)
+	case ".bt":
+		return checkBtAccessor(ctx, accessorPath, filepath.Join(dirs.SnapDomain, stem+".kv"), samples)
+	case ".vi":
+		return checkHistoryAccessor(ctx, accessorPath, filepath.Join(dirs.SnapHistory, stem+".v"), filepath.Join(dirs.SnapIdx, stem+".ef"), samples)
+	case ".idx":
+		idx, err := recsplit.OpenIndex(accessorPath)
+		if err != nil {
+			return err
+		}
+		idx.Close()
+		return nil
+	default:
+		return nil
+	}
+}
+
+// CheckAccessors runs CheckAccessor on all accessors of the state snapshots
+func CheckAccessors(ctx context.Context, dirs datadir.Dirs, failFast bool) error {
+	defer log.Info("[integrity] Accessors: done")
+	var files []string
+	for _, dir := range []string{dirs.SnapDomain, dirs.SnapAccessors} {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return err
+		}
+		for _, e := range entries {
+			switch filepath.Ext(e.Name()) {
+			case ".kvi", ".bt", ".vi", ".efi":
+				files = append(files, filepath.Join(dir, e.Name()))
+			}
+		}
+	}
+	for _, fPath := range files {
+		if err := CheckAccessor(ctx, dirs, fPath, DefaultAccessorSamples); err != nil {
+			if failFast || ctx.Err() != nil {
+				return err
+			}
+			log.Error("[integrity] Accessors", "err", err)
+		}
+	}
+	return nil
+}
+
+func openDataFile(dataPath string) (*seg.Decompressor, seg.FileCompression, error) {
+	d, err := seg.NewDecompressor(dataPath)
+	if err != nil {
+		return nil, 0, err
+	}
+	return d, seg.DetectCompressType(d.MakeGetter()), nil
+}
+
+func sampleStep(keys uint64, samples int) uint64 {
+	return max(keys/uint64(max(samples, 1)), 1)
+}
+
+// checkHashMapAccessor checks a recsplit index over the keys of a file of key-value pairs, the index maps a key to
+// the offset of the key word - directly or through the ordinal number of the key
+func checkHashMapAccessor(ctx context.Context, idxPath, dataPath string, samples int) error {
+	idx, err := recsplit.OpenIndex(idxPath)
+	if err != nil {
+		return err
+	}
+	defer idx.Close()
+	d, compression, err := openDataFile(dataPath)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	r, check := seg.NewReader(d.MakeGetter(), compression), seg.NewReader(d.MakeGetter(), compression)
+
+	fName := filepath.Base(idxPath)
+	if idx.KeyCount() != uint64(d.Count()/2) {
+		return fmt.Errorf("%s: key count %d, data file has %d keys", fName, idx.KeyCount(), d.Count()/2)
+	}
+	if idx.Empty() {
+		return nil
+	}
+	reader := recsplit.NewIndexReader(idx)
+	defer reader.Close()
+	lookup := reader.Lookup
+	if idx.Enums() {
+		lookup = reader.TwoLayerLookup
+	}
+
+	step := sampleStep(idx.KeyCount(), samples)
+	var key, found []byte
+	for i := uint64(0); r.HasNext(); i++ {
+		if i%step != 0 {
+			r.Skip()
+			r.Skip()
+			continue
+		}
+		key, _ = r.Next(key[:0])
+		r.Skip()
+		offset, ok := lookup(key)
+		if !ok || offset >= uint64(check.Size()) {
+			return fmt.Errorf("%s: key %x not found", fName, key)
+		}
+		check.Reset(offset)
+		if found, _ = check.Next(found[:0]); !bytes.Equal(key, found) {
+			return fmt.Errorf("%s: key %x leads to %x", fName, key, found)
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkBtAccessor(ctx context.Context, btPath, dataPath string, samples int) error {
+	d, compression, err := openDataFile(dataPath)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	r, check := seg.NewReader(d.MakeGetter(), compression), seg.NewReader(d.MakeGetter(), compression)
+	bt, err := state.OpenBtreeIndexWithDecompressor(btPath, state.DefaultBtreeM, d, compression)
+	if err != nil {
+		return err
+	}
+	defer bt.Close()
+
+	fName := filepath.Base(btPath)
+	if bt.KeyCount() != uint64(d.Count()/2) {
+		return fmt.Errorf("%s: key count %d, data file has %d keys", fName, bt.KeyCount(), d.Count()/2)
+	}
+
+	step := sampleStep(bt.KeyCount(), samples)
+	var key []byte
+	for i := uint64(0); r.HasNext(); i++ {
+		if i%step != 0 {
+			r.Skip()
+			r.Skip()
+			continue
+		}
+		key, _ = r.Next(key[:0])
+		r.Skip()
+		found, _, _, ok, err := bt.Get(key, check)
+		if err != nil {
+			return fmt.Errorf("%s: key %x: %w", fName, key, err)
+		}
+		if !ok || !bytes.Equal(key, found) {
+			return fmt.Errorf("%s: key %x not found", fName, key)
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkHistoryAccessor checks the index of history values: the .v file holds one value per txNum of the .ef file of
+// the same range, the index maps txNum+key to the offset of the value
+func checkHistoryAccessor(ctx context.Context, idxPath, vPath, efPath string, samples int) error {
+	idx, err := recsplit.OpenIndex(idxPath)
+	if err != nil {
+		return err
+	}
+	defer idx.Close()
+	v, vCompression, err := openDataFile(vPath)
+	if err != nil {
+		return err
+	}
+	defer v.Close()
+	ef, efCompression, err := openDataFile(efPath)
+	if err != nil {
+		return err
+	}
+	defer ef.Close()
+	vr, efr := seg.NewReader(v.MakeGetter(), vCompression), seg.NewReader(ef.MakeGetter(), efCompression)
+
+	fName := filepath.Base(idxPath)
+	if idx.KeyCount() != uint64(v.Count()) {
+		return fmt.Errorf("%s: key count %d, data file has %d values", fName, idx.KeyCount(), v.Count())
+	}
+	if idx.Empty() {
+		return nil
+	}
+	reader := recsplit.NewIndexReader(idx)
+	defer reader.Close()
+
+	step := sampleStep(idx.KeyCount(), samples)
+	var key, efVal, historyKey []byte
+	var valOffset, n uint64
+	for efr.HasNext() {
+		key, _ = efr.Next(key[:0])
+		efVal, _ = efr.Next(efVal[:0])
+		txNums, _ := eliasfano32.ReadEliasFano(efVal)
+		for efIt := txNums.Iterator(); efIt.HasNext(); n++ {
+			txNum, err := efIt.Next()
+			if err != nil {
+				return fmt.Errorf("%s: %w", filepath.Base(efPath), err)
+			}
+			if n%step == 0 {
+				historyKey = binary.BigEndian.AppendUint64(historyKey[:0], txNum)
+				historyKey = append(historyKey, key...)
+				if offset, ok := reader.Lookup(historyKey); !ok || offset != valOffset {
+					return fmt.Errorf("%s: txNum %d of key %x leads to offset %d, expected %d", fName, txNum, key, offset, valOffset)
+				}
+			}
+			if !vr.HasNext() {
+				return fmt.Errorf("%s: less values than txNums in %s", filepath.Base(vPath), filepath.Base(efPath))
+			}
+			valOffset, _ = vr.Skip()
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
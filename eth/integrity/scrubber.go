@@ -0,0 +1,341 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package integrity
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/c2h5oh/datasize"
+	"golang.org/x/time/rate"
+
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/common/dir"
+	"github.com/erigontech/erigon-lib/downloader"
+	"github.com/erigontech/erigon-lib/downloader/downloadercfg"
+	"github.com/erigontech/erigon-lib/gointerfaces/downloaderproto"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/metrics"
+	"github.com/erigontech/erigon-lib/state"
+)
+
+var (
+	mxScrubFiles        = metrics.GetOrCreateCounter(`scrub_files`)
+	mxScrubBytes        = metrics.GetOrCreateCounter(`scrub_bytes`)
+	mxScrubCorrupted    = metrics.GetOrCreateCounter(`scrub_corrupted{reason="hash"}`)
+	mxScrubInconsistent = metrics.GetOrCreateCounter(`scrub_corrupted{reason="accessor"}`)
+	mxScrubHealed       = metrics.GetOrCreateCounter(`scrub_healed`)
+	mxScrubUnhealed     = metrics.GetOrCreateGauge(`scrub_unhealed`)
+	mxScrubPassDuration = metrics.GetOrCreateGauge(`scrub_pass_seconds`)
+	mxScrubLastPass     = metrics.GetOrCreateGauge(`scrub_last_pass_timestamp`)
+)
+
+// data files are re-downloaded when corrupted, accessors are rebuilt from their data files
+var (
+	scrubDataExts     = []string{".seg", ".kv", ".v", ".ef"}
+	scrubAccessorExts = []string{".idx", ".kvi", ".bt", ".vi", ".efi"}
+)
+
+type ScrubberCfg struct {
+	Interval time.Duration     // pause between passes
+	Rate     datasize.ByteSize // disk read rate
+}
+
+// stateAccessorBuilder rebuilds accessors of the state snapshots, see state.Aggregator.RebuildAccessors
+type stateAccessorBuilder interface {
+	RebuildAccessors(ctx context.Context, files []string, workers int) (bool, error)
+}
+
+// Scrubber is a low-priority background service which detects bit-rot of the snapshot files: it re-hashes them
+// against the piece hashes of their .torrent files and checks that the accessors agree with their data files.
+// Corrupted data files are re-downloaded in place by the downloader, corrupted accessors are removed and rebuilt.
+// Files which are open by the node keep serving from their old mapping: the aggregator doesn't reopen the accessors
+// of files it has open, so the rebuilt ones are only used after the node is restarted.
+// A re-downloaded file counts as healed once a later pass finds it intact.
+type Scrubber struct {
+	cfg        ScrubberCfg
+	dirs       datadir.Dirs
+	agg        stateAccessorBuilder
+	downloader downloaderproto.DownloaderClient // nil if node has no downloader, then data files are not healed
+	// buildMissedBlockIndices rebuilds removed accessors of block snapshots, may be nil
+	buildMissedBlockIndices func(ctx context.Context) error
+	limiter                 *rate.Limiter
+	logger                  log.Logger
+
+	redownloading map[string]struct{} // files which re-download was scheduled for, until they are verified again
+}
+
+func NewScrubber(cfg ScrubberCfg, dirs datadir.Dirs, agg *state.Aggregator, downloaderClient downloaderproto.DownloaderClient, buildMissedBlockIndices func(ctx context.Context) error, logger log.Logger) *Scrubber {
+	limit := rate.Inf
+	if cfg.Rate > 0 {
+		limit = rate.Limit(cfg.Rate.Bytes())
+	}
+	// larger reads, like pieces of big torrents, are waited for in burst-sized chunks, see waitN
+	limiter := rate.NewLimiter(limit, max(int(cfg.Rate.Bytes()), downloadercfg.DefaultPieceSize))
+	return &Scrubber{
+		cfg:                     cfg,
+		dirs:                    dirs,
+		agg:                     agg,
+		downloader:              downloaderClient,
+		buildMissedBlockIndices: buildMissedBlockIndices,
+		limiter:                 limiter,
+		logger:                  logger,
+		redownloading:           map[string]struct{}{},
+	}
+}
+
+// Run does a pass over all files every cfg.Interval, until ctx is done
+func (s *Scrubber) Run(ctx context.Context) {
+	for {
+		if _, err := s.ScrubOnce(ctx); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			s.logger.Warn("[scrub] pass failed", "err", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(s.cfg.Interval):
+		}
+	}
+}
+
+// ScrubResult is the outcome of one pass. Healed includes files which were re-downloaded after earlier passes,
+// Unhealed - corrupted files of this pass which are not healed yet (including the scheduled re-downloads).
+type ScrubResult struct {
+	Files, Corrupted, Healed, Unhealed int
+}
+
+// ScrubOnce checks all snapshot files and heals the corrupted ones
+func (s *Scrubber) ScrubOnce(ctx context.Context) (res ScrubResult, err error) {
+	if s.downloader != nil {
+		// files which are still downloading don't match their hashes yet
+		completed, err := s.downloader.Completed(ctx, &downloaderproto.CompletedRequest{})
+		if err != nil {
+			return res, err
+		}
+		if !completed.Completed {
+			s.logger.Debug("[scrub] skip pass: snapshots are downloading")
+			return res, nil
+		}
+	}
+
+	started := time.Now()
+	var toRedownload, toRebuild []string
+	dataFiles, accessors, err := s.files()
+	if err != nil {
+		return res, err
+	}
+	for _, fPath := range append(dataFiles, accessors...) {
+		bad, err := s.verifyPieces(ctx, fPath)
+		if err != nil {
+			if ctx.Err() != nil {
+				return res, ctx.Err()
+			}
+			if errors.Is(err, os.ErrNotExist) { // merged away during the pass
+				delete(s.redownloading, fPath)
+				continue
+			}
+			s.logger.Warn("[scrub] can't verify", "file", filepath.Base(fPath), "err", err)
+			continue
+		}
+		res.Files++
+		mxScrubFiles.Inc()
+		if len(bad) == 0 {
+			if _, ok := s.redownloading[fPath]; ok {
+				delete(s.redownloading, fPath)
+				res.Healed++
+				s.logger.Info("[scrub] re-downloaded", "file", filepath.Base(fPath))
+			}
+			continue
+		}
+		res.Corrupted++
+		mxScrubCorrupted.Inc()
+		s.logger.Warn("[scrub] hash mismatch", "file", filepath.Base(fPath), "pieces", bad)
+		if isAccessor(fPath) {
+			toRebuild = append(toRebuild, fPath)
+		} else {
+			toRedownload = append(toRedownload, fPath)
+		}
+	}
+
+	// accessors of corrupted data files can't be checked or rebuilt before the data is re-downloaded
+	for _, fPath := range accessors {
+		if len(toRedownload) > 0 {
+			break
+		}
+		if slices.Contains(toRebuild, fPath) {
+			continue
+		}
+		if err := s.waitN(ctx, downloadercfg.DefaultPieceSize); err != nil {
+			return res, err
+		}
+		if err := CheckAccessor(ctx, s.dirs, fPath, DefaultAccessorSamples); err != nil {
+			if ctx.Err() != nil {
+				return res, ctx.Err()
+			}
+			if exists, _ := dir.FileExist(fPath); !exists {
+				continue
+			}
+			res.Corrupted++
+			mxScrubInconsistent.Inc()
+			s.logger.Warn("[scrub] accessor doesn't match its data", "err", err)
+			toRebuild = append(toRebuild, fPath)
+		}
+	}
+
+	s.redownload(ctx, toRedownload)
+	var rebuilt int
+	if len(toRedownload) == 0 {
+		rebuilt = s.rebuild(ctx, toRebuild)
+	}
+	res.Healed += rebuilt
+	res.Unhealed = res.Corrupted - rebuilt
+
+	mxScrubHealed.AddInt(res.Healed)
+	mxScrubUnhealed.SetInt(res.Unhealed)
+	mxScrubPassDuration.SetInt(int(time.Since(started).Seconds()))
+	mxScrubLastPass.SetInt(int(time.Now().Unix()))
+	lvl := log.LvlInfo
+	if res.Unhealed > 0 {
+		lvl = log.LvlWarn
+	}
+	s.logger.Log(lvl, "[scrub] pass done", "files", res.Files, "corrupted", res.Corrupted, "healed", res.Healed, "unhealed", res.Unhealed, "took", time.Since(started).Round(time.Second))
+	return res, nil
+}
+
+// files lists snapshot files which have a known type
+func (s *Scrubber) files() (dataFiles, accessors []string, err error) {
+	for _, d := range []string{s.dirs.Snap, s.dirs.SnapDomain, s.dirs.SnapHistory, s.dirs.SnapIdx, s.dirs.SnapAccessors} {
+		entries, err := os.ReadDir(d)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, e := range entries {
+			if e.IsDir() {
+				continue
+			}
+			ext := filepath.Ext(e.Name())
+			switch {
+			case slices.Contains(scrubDataExts, ext):
+				dataFiles = append(dataFiles, filepath.Join(d, e.Name()))
+			case slices.Contains(scrubAccessorExts, ext):
+				accessors = append(accessors, filepath.Join(d, e.Name()))
+			}
+		}
+	}
+	return dataFiles, accessors, nil
+}
+
+// verifyPieces returns nil if the file has no .torrent - such files are only checked for consistency
+func (s *Scrubber) verifyPieces(ctx context.Context, fPath string) ([]int, error) {
+	if exists, err := dir.FileExist(fPath + ".torrent"); err != nil || !exists {
+		return nil, err
+	}
+	return downloader.VerifyFilePieces(ctx, fPath, func(length int64) error {
+		mxScrubBytes.AddUint64(uint64(length))
+		return s.waitN(ctx, int(length))
+	})
+}
+
+// waitN waits until n bytes may be read. The limiter refuses to wait for more than its burst at once, and the piece
+// length of a torrent may exceed it.
+func (s *Scrubber) waitN(ctx context.Context, n int) error {
+	for n > 0 {
+		chunk := min(n, s.limiter.Burst())
+		if err := s.limiter.WaitN(ctx, chunk); err != nil {
+			return err
+		}
+		n -= chunk
+	}
+	return nil
+}
+
+// redownload asks the downloader to verify the files - it resets their bad pieces and downloads them again. The
+// files are checked again by the next pass, which skips while the downloader is busy.
+func (s *Scrubber) redownload(ctx context.Context, files []string) {
+	if len(files) == 0 {
+		return
+	}
+	if s.downloader == nil {
+		s.logger.Warn("[scrub] can't re-download corrupted files: no downloader", "files", len(files))
+		return
+	}
+	for _, fPath := range files {
+		rel, err := filepath.Rel(s.dirs.Snap, fPath)
+		if err != nil {
+			s.logger.Warn("[scrub] re-download", "file", filepath.Base(fPath), "err", err)
+			continue
+		}
+		// the downloader resets the pieces which fail its verification and downloads them again
+		if _, err := s.downloader.Verify(ctx, &downloaderproto.VerifyRequest{Paths: []string{filepath.ToSlash(rel)}}); err != nil {
+			s.logger.Warn("[scrub] re-download", "file", rel, "err", err)
+			continue
+		}
+		s.logger.Info("[scrub] re-download scheduled", "file", rel)
+		s.redownloading[fPath] = struct{}{}
+	}
+}
+
+// rebuild removes the accessors and builds them again from their data files
+func (s *Scrubber) rebuild(ctx context.Context, files []string) (healed int) {
+	if len(files) == 0 {
+		return 0
+	}
+	var stateFiles, removedBlocks []string
+	for _, fPath := range files {
+		if filepath.Ext(fPath) != ".idx" {
+			stateFiles = append(stateFiles, fPath)
+			continue
+		}
+		if err := os.Remove(fPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			s.logger.Warn("[scrub] remove accessor", "file", filepath.Base(fPath), "err", err)
+			continue
+		}
+		removedBlocks = append(removedBlocks, fPath)
+	}
+	if len(stateFiles) > 0 {
+		// the aggregator may be building or merging files right now, then the accessors are left for the next pass
+		if started, err := s.agg.RebuildAccessors(ctx, stateFiles, 1); err != nil {
+			s.logger.Warn("[scrub] rebuild accessors", "err", err)
+		} else if !started {
+			s.logger.Info("[scrub] files are being built, accessors are rebuilt by the next pass", "files", len(stateFiles))
+		} else {
+			healed += len(stateFiles)
+		}
+	}
+	if len(removedBlocks) > 0 {
+		if s.buildMissedBlockIndices == nil {
+			s.logger.Warn("[scrub] can't rebuild block snapshot indices", "files", len(removedBlocks))
+		} else if err := s.buildMissedBlockIndices(ctx); err != nil {
+			s.logger.Warn("[scrub] rebuild block snapshot indices", "err", err)
+		} else {
+			healed += len(removedBlocks)
+		}
+	}
+	if healed > 0 {
+		s.logger.Warn("[scrub] rebuilt accessors, the node keeps using the corrupted ones until it is restarted", "files", healed)
+	}
+	return healed
+}
+
+func isAccessor(fPath string) bool { return slices.Contains(scrubAccessorExts, filepath.Ext(fPath)) }
@@ -0,0 +1,148 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package integrity
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/time/rate"
+
+	"github.com/erigontech/erigon-lib/common/background"
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/downloader"
+	"github.com/erigontech/erigon-lib/gointerfaces/downloaderproto"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/seg"
+	"github.com/erigontech/erigon-lib/state"
+)
+
+type testAccessorBuilder struct {
+	busy    bool
+	rebuilt []string
+}
+
+func (b *testAccessorBuilder) RebuildAccessors(_ context.Context, files []string, _ int) (bool, error) {
+	if b.busy {
+		return false, nil
+	}
+	b.rebuilt = append(b.rebuilt, files...)
+	return true, nil
+}
+
+// writeKV writes a data file of key-value pairs
+func writeKV(t *testing.T, dirs datadir.Dirs, dataPath string, keys int) {
+	t.Helper()
+	comp, err := seg.NewCompressor(context.Background(), "test", dataPath, dirs.Tmp, seg.DefaultCfg, log.LvlDebug, log.New())
+	require.NoError(t, err)
+	defer comp.Close()
+	for i := 0; i < keys; i++ {
+		require.NoError(t, comp.AddWord([]byte(fmt.Sprintf("key%03d", i))))
+		require.NoError(t, comp.AddWord([]byte(fmt.Sprintf("value%03d", i))))
+	}
+	require.NoError(t, comp.Compress())
+}
+
+func TestScrubber(t *testing.T) {
+	ctx, logger := context.Background(), log.New()
+	dirs := datadir.New(t.TempDir())
+
+	dataPath := filepath.Join(dirs.SnapDomain, "v1-accounts.0-32.kv")
+	writeKV(t, dirs, dataPath, 100)
+	_, err := downloader.BuildTorrentIfNeed(ctx, "domain/v1-accounts.0-32.kv", dirs.Snap, downloader.NewAtomicTorrentFS(dirs.Snap))
+	require.NoError(t, err)
+	// the accessor doesn't match its data file
+	accessorPath := filepath.Join(dirs.SnapDomain, "v1-accounts.0-32.kvi")
+	require.NoError(t, os.WriteFile(accessorPath, []byte("not an index"), 0644))
+
+	data, err := os.ReadFile(dataPath)
+	require.NoError(t, err)
+	corrupted := append([]byte{}, data...)
+	corrupted[len(corrupted)-1] ^= 0xff
+	require.NoError(t, os.WriteFile(dataPath, corrupted, 0644))
+
+	ctrl := gomock.NewController(t)
+	downloaderClient := downloaderproto.NewMockDownloaderClient(ctrl)
+	downloaderClient.EXPECT().Completed(gomock.Any(), gomock.Any()).Return(&downloaderproto.CompletedReply{Completed: true}, nil).AnyTimes()
+	builder := &testAccessorBuilder{}
+	s := NewScrubber(ScrubberCfg{}, dirs, nil, downloaderClient, nil, logger)
+	s.agg = builder
+
+	// the data file is re-downloaded, its accessor can't be checked before that
+	downloaderClient.EXPECT().Verify(gomock.Any(), &downloaderproto.VerifyRequest{Paths: []string{"domain/v1-accounts.0-32.kv"}}).Return(nil, nil)
+	res, err := s.ScrubOnce(ctx)
+	require.NoError(t, err)
+	require.Equal(t, ScrubResult{Files: 2, Corrupted: 1, Unhealed: 1}, res)
+	require.Empty(t, builder.rebuilt)
+
+	// the downloader restored the data file, the next pass confirms it and rebuilds the accessor - unless the
+	// aggregator is busy with other files
+	require.NoError(t, os.WriteFile(dataPath, data, 0644))
+	builder.busy = true
+	res, err = s.ScrubOnce(ctx)
+	require.NoError(t, err)
+	require.Equal(t, ScrubResult{Files: 2, Corrupted: 1, Healed: 1, Unhealed: 1}, res)
+	require.Empty(t, builder.rebuilt)
+
+	builder.busy = false
+	res, err = s.ScrubOnce(ctx)
+	require.NoError(t, err)
+	require.Equal(t, ScrubResult{Files: 2, Corrupted: 1, Healed: 1}, res)
+	require.Equal(t, []string{accessorPath}, builder.rebuilt)
+}
+
+func TestScrubberWaitAboveBurst(t *testing.T) {
+	s := NewScrubber(ScrubberCfg{}, datadir.New(t.TempDir()), nil, nil, nil, log.New())
+	s.limiter = rate.NewLimiter(rate.Limit(1<<30), 1024)
+	require.Error(t, s.limiter.WaitN(context.Background(), 10_000))
+	require.NoError(t, s.waitN(context.Background(), 10_000))
+}
+
+func TestCheckAccessor(t *testing.T) {
+	ctx, logger := context.Background(), log.New()
+	dirs := datadir.New(t.TempDir())
+	buildBt := func(dataPath, btPath string) {
+		d, err := seg.NewDecompressor(dataPath)
+		require.NoError(t, err)
+		defer d.Close()
+		require.NoError(t, state.BuildBtreeIndexWithDecompressor(btPath, d, seg.CompressNone, background.NewProgressSet(), dirs.Tmp, 0, logger, true))
+	}
+
+	dataPath, btPath := filepath.Join(dirs.SnapDomain, "v1-accounts.0-32.kv"), filepath.Join(dirs.SnapDomain, "v1-accounts.0-32.bt")
+	writeKV(t, dirs, dataPath, 100)
+	buildBt(dataPath, btPath)
+	require.NoError(t, CheckAccessor(ctx, dirs, btPath, DefaultAccessorSamples))
+
+	// the accessor was built from another data file
+	otherPath := filepath.Join(dirs.SnapDomain, "v1-accounts.32-64.kv")
+	writeKV(t, dirs, otherPath, 50)
+	buildBt(otherPath, btPath)
+	require.ErrorContains(t, CheckAccessor(ctx, dirs, btPath, DefaultAccessorSamples), "key count 50, data file has 100 keys")
+
+	// a broken accessor is reported, not panicked on
+	kviPath := filepath.Join(dirs.SnapDomain, "v1-accounts.0-32.kvi")
+	require.NoError(t, os.WriteFile(kviPath, []byte("not an index"), 0644))
+	require.Error(t, CheckAccessor(ctx, dirs, kviPath, DefaultAccessorSamples))
+
+	// accessors of unknown types are skipped
+	require.NoError(t, CheckAccessor(ctx, dirs, filepath.Join(dirs.SnapDomain, "v1-accounts.0-32.bin"), DefaultAccessorSamples))
+}
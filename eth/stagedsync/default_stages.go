@@ -36,6 +36,7 @@ func DefaultStages(ctx context.Context,
 	senders SendersCfg,
 	exec ExecuteBlockCfg,
 	txLookup TxLookupCfg,
+	export ExportCfg,
 	finish FinishCfg,
 	test bool) []*Stage {
 	return []*Stage{
@@ -171,6 +172,20 @@ func DefaultStages(ctx context.Context,
 				return PruneTxLookup(p, tx, txLookup, ctx, logger)
 			},
 		},
+		{
+			ID:          stages.Export,
+			Description: "Write executed blocks to Parquet files",
+			Disabled:    dbg.StagesOnlyBlocks || !export.Enabled(),
+			Forward: func(badBlockUnwind bool, s *StageState, u Unwinder, txc wrap.TxContainer, logger log.Logger) error {
+				return SpawnExportStage(s, txc.Tx, export, ctx, logger)
+			},
+			Unwind: func(u *UnwindState, s *StageState, txc wrap.TxContainer, logger log.Logger) error {
+				return UnwindExportStage(u, s, txc.Tx, export, ctx, logger)
+			},
+			Prune: func(p *PruneState, tx kv.RwTx, logger log.Logger) error {
+				return nil
+			},
+		},
 		{
 			ID:          stages.Finish,
 			Description: "Final: update current block for the RPC API",
@@ -187,7 +202,7 @@ func DefaultStages(ctx context.Context,
 	}
 }
 
-func PipelineStages(ctx context.Context, snapshots SnapshotsCfg, blockHashCfg BlockHashesCfg, senders SendersCfg, exec ExecuteBlockCfg, txLookup TxLookupCfg, finish FinishCfg, test bool) []*Stage {
+func PipelineStages(ctx context.Context, snapshots SnapshotsCfg, blockHashCfg BlockHashesCfg, senders SendersCfg, exec ExecuteBlockCfg, txLookup TxLookupCfg, export ExportCfg, finish FinishCfg, test bool) []*Stage {
 	return []*Stage{
 		{
 			ID:          stages.Snapshots,
@@ -258,6 +273,20 @@ func PipelineStages(ctx context.Context, snapshots SnapshotsCfg, blockHashCfg Bl
 				return PruneTxLookup(p, tx, txLookup, ctx, logger)
 			},
 		},
+		{
+			ID:          stages.Export,
+			Description: "Write executed blocks to Parquet files",
+			Disabled:    !export.Enabled(),
+			Forward: func(badBlockUnwind bool, s *StageState, u Unwinder, txc wrap.TxContainer, logger log.Logger) error {
+				return SpawnExportStage(s, txc.Tx, export, ctx, logger)
+			},
+			Unwind: func(u *UnwindState, s *StageState, txc wrap.TxContainer, logger log.Logger) error {
+				return UnwindExportStage(u, s, txc.Tx, export, ctx, logger)
+			},
+			Prune: func(p *PruneState, tx kv.RwTx, logger log.Logger) error {
+				return nil
+			},
+		},
 		{
 			ID:          stages.Finish,
 			Description: "Final: update current block for the RPC API",
@@ -554,6 +583,7 @@ var DefaultForwardOrder = UnwindOrder{
 	stages.Execution,
 	//stages.CustomTrace,
 	stages.TxLookup,
+	stages.Export,
 	stages.Finish,
 }
 
@@ -566,6 +596,7 @@ type PruneOrder []stages.SyncStage
 
 var DefaultUnwindOrder = UnwindOrder{
 	stages.Finish,
+	stages.Export,
 	stages.TxLookup,
 
 	//stages.CustomTrace,
@@ -580,6 +611,7 @@ var DefaultUnwindOrder = UnwindOrder{
 
 var PipelineUnwindOrder = UnwindOrder{
 	stages.Finish,
+	stages.Export,
 	stages.TxLookup,
 
 	stages.Execution,
@@ -606,6 +638,7 @@ var PolygonSyncUnwindOrder = UnwindOrder{
 
 var DefaultPruneOrder = PruneOrder{
 	stages.Finish,
+	stages.Export,
 	stages.TxLookup,
 
 	stages.Execution,
@@ -620,6 +653,7 @@ var DefaultPruneOrder = PruneOrder{
 
 var PipelinePruneOrder = PruneOrder{
 	stages.Finish,
+	stages.Export,
 	stages.TxLookup,
 
 	stages.Execution,
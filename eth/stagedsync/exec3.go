@@ -57,6 +57,7 @@ import (
 	"github.com/erigontech/erigon/core/rawdb"
 	"github.com/erigontech/erigon/core/rawdb/rawdbhelpers"
 	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/core/tracing"
 	"github.com/erigontech/erigon/core/types"
 	"github.com/erigontech/erigon/core/types/accounts"
 	"github.com/erigontech/erigon/eth/ethconfig/estimate"
@@ -790,6 +791,7 @@ Loop:
 		// So we skip that check for the first block, if we find half-executed data.
 		skipPostEvaluation := false
 		var usedGas, blobGasUsed uint64
+		tracingBlock := false // live tracer was told about this block
 
 		for txIndex := -1; txIndex <= len(txs); txIndex++ {
 			// Do not oversend, wait for the result heap to go under certain size
@@ -873,6 +875,12 @@ Loop:
 			if txTask.Error != nil {
 				break Loop
 			}
+			if cfg.tracer != nil && !isMining && !tracingBlock {
+				if err := traceBlockStart(cfg.tracer, applyTx, b, cfg.genesis); err != nil {
+					return err
+				}
+				tracingBlock = true
+			}
 			applyWorker.RunTxTaskNoLock(txTask, isMining)
 			if err := func() error {
 				if errors.Is(txTask.Error, context.Canceled) {
@@ -905,9 +913,15 @@ Loop:
 						}
 					}
 					usedGas, blobGasUsed = 0, 0
+					if tracingBlock && txTask.BlockNum > 0 && cfg.tracer.OnBlockEnd != nil {
+						cfg.tracer.OnBlockEnd(nil)
+					}
 				}
 				return nil
 			}(); err != nil {
+				if tracingBlock && blockNum > 0 && cfg.tracer.OnBlockEnd != nil {
+					cfg.tracer.OnBlockEnd(err)
+				}
 				if errors.Is(err, context.Canceled) {
 					return err
 				}
@@ -1127,6 +1141,37 @@ Loop:
 	return nil
 }
 
+// traceBlockStart reports the start of block execution to the live tracer. The genesis
+// block is reported via OnGenesisBlock and doesn't get a matching OnBlockEnd.
+func traceBlockStart(tracer *tracing.Hooks, tx kv.Tx, b *types.Block, genesis *types.Genesis) error {
+	if b.NumberU64() == 0 {
+		if tracer.OnGenesisBlock != nil && genesis != nil {
+			tracer.OnGenesisBlock(b, genesis.Alloc)
+		}
+		return nil
+	}
+	if tracer.OnBlockStart == nil {
+		return nil
+	}
+	td, err := rawdb.ReadTd(tx, b.ParentHash(), b.NumberU64()-1)
+	if err != nil {
+		return err
+	}
+	event := tracing.BlockEvent{Block: b, TD: td}
+	if hash := rawdb.ReadForkchoiceFinalized(tx); hash != (common.Hash{}) {
+		if event.Finalized, err = rawdb.ReadHeaderByHash(tx, hash); err != nil {
+			return err
+		}
+	}
+	if hash := rawdb.ReadForkchoiceSafe(tx); hash != (common.Hash{}) {
+		if event.Safe, err = rawdb.ReadHeaderByHash(tx, hash); err != nil {
+			return err
+		}
+	}
+	tracer.OnBlockStart(event)
+	return nil
+}
+
 // nolint
 func dumpPlainStateDebug(tx kv.RwTx, doms *state2.SharedDomains) {
 	if doms != nil {
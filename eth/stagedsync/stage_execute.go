@@ -42,6 +42,7 @@ import (
 	"github.com/erigontech/erigon/core/rawdb"
 	"github.com/erigontech/erigon/core/rawdb/rawdbhelpers"
 	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/core/tracing"
 	"github.com/erigontech/erigon/core/types"
 	"github.com/erigontech/erigon/core/vm"
 	"github.com/erigontech/erigon/eth/ethconfig"
@@ -89,6 +90,8 @@ type ExecuteBlockCfg struct {
 	keepAllChangesets bool
 
 	applyWorker, applyWorkerMining *exec3.Worker
+
+	tracer *tracing.Hooks // optional live tracer, gets the hooks of every executed block
 }
 
 func StageExecuteBlocksCfg(
@@ -109,11 +112,14 @@ func StageExecuteBlocksCfg(
 	genesis *types.Genesis,
 	syncCfg ethconfig.Sync,
 	silkworm *silkworm.Silkworm,
+	tracer *tracing.Hooks,
 ) ExecuteBlockCfg {
 	if dirs.SnapDomain == "" {
 		panic("empty `dirs` variable")
 	}
 
+	applyWorker := exec3.NewWorker(nil, log.Root(), context.Background(), false, db, nil, blockReader, chainConfig, genesis, nil, engine, dirs, false)
+	applyWorker.SetTracer(tracer)
 	return ExecuteBlockCfg{
 		db:                db,
 		prune:             pm,
@@ -131,9 +137,10 @@ func StageExecuteBlocksCfg(
 		historyV3:         true,
 		syncCfg:           syncCfg,
 		silkworm:          silkworm,
-		applyWorker:       exec3.NewWorker(nil, log.Root(), context.Background(), false, db, nil, blockReader, chainConfig, genesis, nil, engine, dirs, false),
+		applyWorker:       applyWorker,
 		applyWorkerMining: exec3.NewWorker(nil, log.Root(), context.Background(), false, db, nil, blockReader, chainConfig, genesis, nil, engine, dirs, true),
 		keepAllChangesets: keepAllChangesets,
+		tracer:            tracer,
 	}
 }
 
@@ -0,0 +1,122 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package stagedsync
+
+import (
+	"context"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/consensus"
+	"github.com/erigontech/erigon/eth/ethconfig"
+	"github.com/erigontech/erigon/turbo/exporter"
+	"github.com/erigontech/erigon/turbo/services"
+)
+
+type ExportCfg struct {
+	db          kv.RwDB
+	export      ethconfig.Export
+	chainConfig *chain.Config
+	blockReader services.FullBlockReader
+	engine      consensus.EngineReader
+}
+
+func StageExportCfg(db kv.RwDB, export ethconfig.Export, chainConfig *chain.Config, blockReader services.FullBlockReader, engine consensus.EngineReader) ExportCfg {
+	return ExportCfg{
+		db:          db,
+		export:      export,
+		chainConfig: chainConfig,
+		blockReader: blockReader,
+		engine:      engine,
+	}
+}
+
+func (cfg ExportCfg) Enabled() bool { return cfg.export.Dir != "" }
+
+func (cfg ExportCfg) exporter(ctx context.Context, logger log.Logger) (*exporter.Exporter, error) {
+	tables, err := exporter.ParseTables(cfg.export.Tables)
+	if err != nil {
+		return nil, err
+	}
+	return exporter.New(ctx, exporter.Cfg{Dir: cfg.export.Dir, BlocksPerFile: cfg.export.BlocksPerFile, Tables: tables},
+		cfg.chainConfig, cfg.blockReader, cfg.engine, logger)
+}
+
+// SpawnExportStage writes the executed blocks to Parquet files. Only complete partitions are written, the blocks of
+// the last partition are exported when it's complete - the stage progress may be ahead of the files.
+func SpawnExportStage(s *StageState, tx kv.RwTx, cfg ExportCfg, ctx context.Context, logger log.Logger) (err error) {
+	useExternalTx := tx != nil
+	if !useExternalTx {
+		tx, err = cfg.db.BeginRw(ctx)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+	}
+	endBlock, err := s.ExecutionAt(tx)
+	if err != nil {
+		return err
+	}
+	if s.BlockNumber >= endBlock {
+		return nil
+	}
+	e, err := cfg.exporter(ctx, logger)
+	if err != nil {
+		return err
+	}
+	if err = e.Export(ctx, tx.(kv.TemporalTx), s.BlockNumber, endBlock+1); err != nil {
+		return err
+	}
+	if err = s.Update(tx, endBlock); err != nil {
+		return err
+	}
+	if !useExternalTx {
+		if err = tx.Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnwindExportStage removes the partitions which have unwound blocks, they are exported again after re-execution
+func UnwindExportStage(u *UnwindState, s *StageState, tx kv.RwTx, cfg ExportCfg, ctx context.Context, logger log.Logger) (err error) {
+	useExternalTx := tx != nil
+	if !useExternalTx {
+		tx, err = cfg.db.BeginRw(ctx)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+	}
+	e, err := cfg.exporter(ctx, logger)
+	if err != nil {
+		return err
+	}
+	if err = e.Unwind(u.UnwindPoint); err != nil {
+		return err
+	}
+	if err = u.Done(tx); err != nil {
+		return err
+	}
+	if !useExternalTx {
+		if err = tx.Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -52,6 +52,18 @@ type MiningBlock struct {
 	Withdrawals      []*types.Withdrawal
 	PreparedTxs      types.TransactionsStream
 	Requests         types.Requests
+	Skipped          []SkippedTxn // pool txns which were considered, but left out of the block
+}
+
+// SkippedTxn is a pool txn which the block builder did not include, with the reason why
+type SkippedTxn struct {
+	Hash   libcommon.Hash
+	Sender libcommon.Address
+	Reason string
+}
+
+func (mb *MiningBlock) skip(txn types.Transaction, sender libcommon.Address, reason string) {
+	mb.Skipped = append(mb.Skipped, SkippedTxn{Hash: txn.Hash(), Sender: sender, Reason: reason})
 }
 
 type MiningState struct {
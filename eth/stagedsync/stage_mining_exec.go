@@ -30,6 +30,7 @@ import (
 	"github.com/erigontech/erigon-lib/kv/membatchwithdb"
 	"github.com/erigontech/erigon-lib/log/v3"
 	state2 "github.com/erigontech/erigon-lib/state"
+	"github.com/erigontech/erigon-lib/txpool"
 	"github.com/erigontech/erigon-lib/wrap"
 
 	"github.com/erigontech/erigon-lib/chain"
@@ -66,8 +67,23 @@ type MiningExecCfg struct {
 
 type TxPoolForMining interface {
 	YieldBest(n uint16, txs *types2.TxsRlp, tx kv.Tx, onTopOf, availableGas, availableBlobGas uint64, toSkip mapset.Set[[32]byte]) (bool, int, error)
+	YieldBundles(blockNumber uint64) []*txpool.Bundle
 }
 
+// PublicTxPoolForMining hides private transactions and bundles of the pool, for blocks which are shown to others
+// without being produced, e.g. previews
+func PublicTxPoolForMining(pool *txpool.TxPool) TxPoolForMining { return publicTxPool{pool} }
+
+type publicTxPool struct {
+	pool *txpool.TxPool
+}
+
+func (p publicTxPool) YieldBest(n uint16, txs *types2.TxsRlp, tx kv.Tx, onTopOf, availableGas, availableBlobGas uint64, toSkip mapset.Set[[32]byte]) (bool, int, error) {
+	return p.pool.YieldBestPublic(n, txs, tx, onTopOf, availableGas, availableBlobGas, toSkip)
+}
+
+func (p publicTxPool) YieldBundles(uint64) []*txpool.Bundle { return nil }
+
 func StageMiningExecCfg(
 	db kv.RwDB, miningState MiningState,
 	notifier ChainEventNotifier, chainConfig chain.Config,
@@ -153,8 +169,17 @@ func SpawnMiningExecStage(s *StageState, txc wrap.TxContainer, cfg MiningExecCfg
 				return err
 			}
 
+			// bundles go first, they may target the very beginning of the block
+			if bundles := cfg.txPool.YieldBundles(current.Header.Number.Uint64()); len(bundles) > 0 {
+				logs, err := addBundlesToMiningBlock(logPrefix, current, cfg.chainConfig, cfg.vmConfig, getHeader, cfg.engine, bundles, chainID, cfg.miningState.MiningConfig.Etherbase, stateReader, ibs, yielded, logger)
+				if err != nil {
+					return err
+				}
+				NotifyPendingLogs(logPrefix, cfg.notifier, logs, logger)
+			}
+
 			for {
-				txs, y, err := getNextTransactions(cfg, chainID, current.Header, 50, executionAt, yielded, simStateReader, simStateWriter, logger)
+				txs, y, err := getNextTransactions(cfg, chainID, current, 50, executionAt, yielded, simStateReader, simStateWriter, logger)
 				if err != nil {
 					return err
 				}
@@ -257,7 +282,7 @@ func SpawnMiningExecStage(s *StageState, txc wrap.TxContainer, cfg MiningExecCfg
 func getNextTransactions(
 	cfg MiningExecCfg,
 	chainID *uint256.Int,
-	header *types.Header,
+	current *MiningBlock,
 	amount uint16,
 	executionAt uint64,
 	alreadyYielded mapset.Set[[32]byte],
@@ -265,6 +290,7 @@ func getNextTransactions(
 	simStateWriter state.StateWriter,
 	logger log.Logger,
 ) (types.TransactionsStream, int, error) {
+	header := current.Header
 	txSlots := types2.TxsRlp{}
 	count := 0
 	if err := cfg.txPoolDB.View(context.Background(), func(poolTx kv.Tx) error {
@@ -294,20 +320,20 @@ func getNextTransactions(
 		if err != nil {
 			return nil, 0, err
 		}
+		var sender libcommon.Address
+		copy(sender[:], txSlots.Senders.At(i))
 		if !transaction.GetChainID().IsZero() && transaction.GetChainID().Cmp(chainID) != 0 {
+			current.skip(transaction, sender, "wrong chain id")
 			continue
 		}
 
-		var sender libcommon.Address
-		copy(sender[:], txSlots.Senders.At(i))
-
 		// Check if txn nonce is too low
 		txs = append(txs, transaction)
 		txs[len(txs)-1].SetSender(sender)
 	}
 
 	blockNum := executionAt + 1
-	txs, err := filterBadTransactions(txs, cfg.chainConfig, blockNum, header, simStateReader, simStateWriter, logger)
+	txs, err := filterBadTransactions(txs, cfg.chainConfig, blockNum, current, simStateReader, simStateWriter, logger)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -315,7 +341,8 @@ func getNextTransactions(
 	return types.NewTransactionsFixedOrder(txs), count, nil
 }
 
-func filterBadTransactions(transactions []types.Transaction, config chain.Config, blockNumber uint64, header *types.Header, simStateReader state.StateReader, simStateWriter state.StateWriter, logger log.Logger) ([]types.Transaction, error) {
+func filterBadTransactions(transactions []types.Transaction, config chain.Config, blockNumber uint64, current *MiningBlock, simStateReader state.StateReader, simStateWriter state.StateWriter, logger log.Logger) ([]types.Transaction, error) {
+	header := current.Header
 	initialCnt := len(transactions)
 	var filtered []types.Transaction
 	gasBailout := false
@@ -332,6 +359,7 @@ func filterBadTransactions(transactions []types.Transaction, config chain.Config
 		transaction := transactions[0]
 		sender, ok := transaction.GetSender()
 		if !ok {
+			current.skip(transaction, sender, "no sender")
 			transactions = transactions[1:]
 			noSenderCnt++
 			continue
@@ -341,12 +369,14 @@ func filterBadTransactions(transactions []types.Transaction, config chain.Config
 			return nil, err
 		}
 		if account == nil {
+			current.skip(transaction, sender, "no sender account")
 			transactions = transactions[1:]
 			noAccountCnt++
 			continue
 		}
 		// Check transaction nonce
 		if account.Nonce > transaction.GetNonce() {
+			current.skip(transaction, sender, "nonce too low")
 			transactions = transactions[1:]
 			nonceTooLowCnt++
 			continue
@@ -372,6 +402,7 @@ func filterBadTransactions(transactions []types.Transaction, config chain.Config
 			}
 
 			if !isEoaCodeAllowed {
+				current.skip(transaction, sender, "sender not EOA")
 				transactions = transactions[1:]
 				notEOACnt++
 				continue
@@ -386,6 +417,7 @@ func filterBadTransactions(transactions []types.Transaction, config chain.Config
 			// Make sure the transaction gasFeeCap is greater than the block's baseFee.
 			if !transaction.GetFeeCap().IsZero() || !transaction.GetTip().IsZero() {
 				if err := core.CheckEip1559TxGasFeeCap(sender, transaction.GetFeeCap(), transaction.GetTip(), baseFee256, false /* isFree */); err != nil {
+					current.skip(transaction, sender, "fee too low")
 					transactions = transactions[1:]
 					feeTooLowCnt++
 					continue
@@ -401,6 +433,7 @@ func filterBadTransactions(transactions []types.Transaction, config chain.Config
 		want.SetUint64(txnGas)
 		want, overflow := want.MulOverflow(want, txnPrice)
 		if overflow {
+			current.skip(transaction, sender, "fee overflow")
 			transactions = transactions[1:]
 			overflowCnt++
 			continue
@@ -410,12 +443,14 @@ func filterBadTransactions(transactions []types.Transaction, config chain.Config
 			want.SetUint64(txnGas)
 			want, overflow = want.MulOverflow(want, transaction.GetFeeCap())
 			if overflow {
+				current.skip(transaction, sender, "fee overflow")
 				transactions = transactions[1:]
 				overflowCnt++
 				continue
 			}
 			want, overflow = want.AddOverflow(want, value)
 			if overflow {
+				current.skip(transaction, sender, "fee overflow")
 				transactions = transactions[1:]
 				overflowCnt++
 				continue
@@ -424,6 +459,7 @@ func filterBadTransactions(transactions []types.Transaction, config chain.Config
 
 		if accountBalance.Cmp(want) < 0 {
 			if !gasBailout {
+				current.skip(transaction, sender, "balance too low")
 				transactions = transactions[1:]
 				balanceTooLowCnt++
 				continue
@@ -442,6 +478,10 @@ func filterBadTransactions(transactions []types.Transaction, config chain.Config
 		filtered = append(filtered, transaction)
 		transactions = transactions[1:]
 	}
+	for _, transaction := range transactions {
+		sender, _ := transaction.GetSender()
+		current.skip(transaction, sender, "nonce too high")
+	}
 	logger.Info("Filtration", "initial", initialCnt, "no sender", noSenderCnt, "no account", noAccountCnt, "nonce too low", nonceTooLowCnt, "nonceTooHigh", missedTxs, "sender not EOA", notEOACnt, "fee too low", feeTooLowCnt, "overflow", overflowCnt, "balance too low", balanceTooLowCnt, "filtered", len(filtered))
 	return filtered, nil
 }
@@ -523,6 +563,7 @@ LOOP:
 		from, err := txn.Sender(*signer)
 		if err != nil {
 			logger.Warn(fmt.Sprintf("[%s] Could not recover transaction sender", logPrefix), "hash", txn.Hash(), "err", err)
+			current.skip(txn, libcommon.Address{}, "no sender")
 			txs.Pop()
 			continue
 		}
@@ -531,6 +572,7 @@ LOOP:
 		// phase, start ignoring the sender until we do.
 		if txn.Protected() && !chainConfig.IsSpuriousDragon(header.Number.Uint64()) {
 			logger.Debug(fmt.Sprintf("[%s] Ignoring replay protected transaction", logPrefix), "hash", txn.Hash(), "eip155", chainConfig.SpuriousDragonBlock)
+			current.skip(txn, from, "replay protected")
 
 			txs.Pop()
 			continue
@@ -542,14 +584,17 @@ LOOP:
 		if errors.Is(err, core.ErrGasLimitReached) {
 			// Pop the env out-of-gas transaction without shifting in the next from the account
 			logger.Debug(fmt.Sprintf("[%s] Gas limit exceeded for env block", logPrefix), "hash", txn.Hash(), "sender", from)
+			current.skip(txn, from, "block gas limit reached")
 			txs.Pop()
 		} else if errors.Is(err, core.ErrNonceTooLow) {
 			// New head notification data race between the transaction pool and miner, shift
 			logger.Debug(fmt.Sprintf("[%s] Skipping transaction with low nonce", logPrefix), "hash", txn.Hash(), "sender", from, "nonce", txn.GetNonce(), "err", err)
+			current.skip(txn, from, "nonce too low")
 			txs.Shift()
 		} else if errors.Is(err, core.ErrNonceTooHigh) {
 			// Reorg notification data race between the transaction pool and miner, skip account =
 			logger.Debug(fmt.Sprintf("[%s] Skipping transaction with high nonce", logPrefix), "hash", txn.Hash(), "sender", from, "nonce", txn.GetNonce())
+			current.skip(txn, from, "nonce too high")
 			txs.Pop()
 		} else if err == nil {
 			// Everything ok, collect the logs and shift in the next transaction from the same account
@@ -561,6 +606,7 @@ LOOP:
 			// Strange error, discard the transaction and get the next in line (note, the
 			// nonce-too-high clause will prevent us from executing in vain).
 			logger.Debug(fmt.Sprintf("[%s] Skipping transaction", logPrefix), "hash", txn.Hash(), "sender", from, "err", err)
+			current.skip(txn, from, err.Error())
 			txs.Shift()
 		}
 	}
@@ -576,6 +622,138 @@ LOOP:
 
 }
 
+// addBundlesToMiningBlock applies bundles on top of the block being built, before any other transaction. Transactions
+// of a bundle are applied in order and all of them must succeed, otherwise the bundle is skipped. State changes can't
+// be reverted across transactions, so every bundle is dry-run first on a scratch state which holds only the bundles
+// accepted so far. Should a bundle still fail on ibs, ibs is reset and the bundles accepted before it are re-applied.
+// Hashes of included transactions are added to yielded.
+func addBundlesToMiningBlock(logPrefix string, current *MiningBlock, chainConfig chain.Config, vmConfig *vm.Config, getHeader func(hash libcommon.Hash, number uint64) *types.Header,
+	engine consensus.Engine, bundles []*txpool.Bundle, chainID *uint256.Int, coinbase libcommon.Address, stateReader state.StateReader, ibs *state.IntraBlockState,
+	yielded mapset.Set[[32]byte], logger log.Logger) (types.Logs, error) {
+	header := current.Header
+	noop := state.NewNoopWriter()
+
+	applyTxs := func(ibs *state.IntraBlockState, txs []types.Transaction, usedGas, usedBlobGas *uint64) (types.Receipts, error) {
+		gasPool := new(core.GasPool).AddGas(header.GasLimit - *usedGas)
+		if usedBlobGas != nil {
+			gasPool.AddBlobGas(chainConfig.GetMaxBlobGasPerBlock() - *usedBlobGas)
+		}
+		receipts := make(types.Receipts, 0, len(txs))
+		for _, txn := range txs {
+			ibs.SetTxContext(ibs.TxnIndex() + 1)
+			snap := ibs.Snapshot()
+			receipt, _, err := core.ApplyTransaction(&chainConfig, core.GetHashFn(header, getHeader), engine, &coinbase, gasPool, ibs, noop, header, txn, usedGas, usedBlobGas, *vmConfig)
+			if err != nil {
+				ibs.RevertToSnapshot(snap)
+				return nil, fmt.Errorf("transaction %x: %w", txn.Hash(), err)
+			}
+			if receipt.Status != types.ReceiptStatusSuccessful {
+				return nil, fmt.Errorf("transaction %x reverted", txn.Hash())
+			}
+			receipts = append(receipts, receipt)
+		}
+		return receipts, nil
+	}
+
+	var (
+		accepted       []types.Transaction
+		sim            *state.IntraBlockState
+		simGasUsed     uint64
+		simBlobGasUsed *uint64
+	)
+	resetSim := func() error {
+		sim = state.New(stateReader)
+		simGasUsed, simBlobGasUsed = header.GasUsed, nil
+		if header.BlobGasUsed != nil {
+			blobGasUsed := *header.BlobGasUsed
+			simBlobGasUsed = &blobGasUsed
+		}
+		_, err := applyTxs(sim, accepted, &simGasUsed, simBlobGasUsed)
+		return err
+	}
+	if err := resetSim(); err != nil {
+		return nil, err
+	}
+
+	var receipts types.Receipts
+	gasUsed := header.GasUsed
+	var blobGasUsed uint64
+	if header.BlobGasUsed != nil {
+		blobGasUsed = *header.BlobGasUsed
+	}
+	resetIbs := func() error {
+		ibs.Reset()
+		header.GasUsed = gasUsed
+		if header.BlobGasUsed != nil {
+			*header.BlobGasUsed = blobGasUsed
+		}
+		var err error
+		receipts, err = applyTxs(ibs, accepted, &header.GasUsed, header.BlobGasUsed)
+		return err
+	}
+
+BUNDLES:
+	for _, bundle := range bundles {
+		for _, txHash := range bundle.TxHashes {
+			if yielded.Contains(txHash) {
+				logger.Debug(fmt.Sprintf("[%s] Skipping bundle with already included transaction", logPrefix), "bundle", bundle.Hash, "hash", txHash)
+				continue BUNDLES
+			}
+		}
+		txs := make([]types.Transaction, len(bundle.Txs))
+		for i := range bundle.Txs {
+			txn, err := types.DecodeWrappedTransaction(bundle.Txs[i])
+			if err != nil {
+				logger.Debug(fmt.Sprintf("[%s] Skipping bundle with undecodable transaction", logPrefix), "bundle", bundle.Hash, "err", err)
+				continue BUNDLES
+			}
+			if !txn.GetChainID().IsZero() && txn.GetChainID().Cmp(chainID) != 0 {
+				logger.Debug(fmt.Sprintf("[%s] Skipping bundle with transaction of another chain", logPrefix), "bundle", bundle.Hash, "hash", txn.Hash())
+				continue BUNDLES
+			}
+			txn.SetSender(bundle.Senders[i])
+			txs[i] = txn
+		}
+
+		if _, err := applyTxs(sim, txs, &simGasUsed, simBlobGasUsed); err != nil {
+			logger.Debug(fmt.Sprintf("[%s] Skipping bundle", logPrefix), "bundle", bundle.Hash, "err", err)
+			// the scratch state is polluted by the part of the bundle which went through
+			if err := resetSim(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		bundleReceipts, err := applyTxs(ibs, txs, &header.GasUsed, header.BlobGasUsed)
+		if err != nil {
+			logger.Warn(fmt.Sprintf("[%s] Skipping bundle which passed the dry run", logPrefix), "bundle", bundle.Hash, "err", err)
+			if err := resetIbs(); err != nil {
+				return nil, err
+			}
+			if err := resetSim(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		accepted = append(accepted, txs...)
+		receipts = append(receipts, bundleReceipts...)
+		for _, txHash := range bundle.TxHashes {
+			yielded.Add(txHash)
+		}
+		logger.Debug(fmt.Sprintf("[%s] Added bundle", logPrefix), "bundle", bundle.Hash, "txs", len(txs))
+	}
+	if len(accepted) == 0 {
+		return nil, nil
+	}
+
+	var logs types.Logs
+	for _, receipt := range receipts {
+		logs = append(logs, receipt.Logs...)
+	}
+	current.Txs = append(current.Txs, accepted...)
+	current.Receipts = append(current.Receipts, receipts...)
+	return logs, nil
+}
+
 func NotifyPendingLogs(logPrefix string, notifier ChainEventNotifier, logs types.Logs, logger log.Logger) {
 	if len(logs) == 0 {
 		return
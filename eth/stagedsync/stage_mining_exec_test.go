@@ -0,0 +1,365 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package stagedsync
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	mapset "github.com/deckarep/golang-set/v2"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/common/fixedgas"
+	"github.com/erigontech/erigon-lib/gointerfaces"
+	remote "github.com/erigontech/erigon-lib/gointerfaces/remoteproto"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/kvcache"
+	"github.com/erigontech/erigon-lib/kv/memdb"
+	"github.com/erigontech/erigon-lib/kv/temporal/temporaltest"
+	"github.com/erigontech/erigon-lib/log/v3"
+	libstate "github.com/erigontech/erigon-lib/state"
+	"github.com/erigontech/erigon-lib/txpool"
+	"github.com/erigontech/erigon-lib/txpool/txpoolcfg"
+	types2 "github.com/erigontech/erigon-lib/types"
+
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/core/types/accounts"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/crypto"
+	"github.com/erigontech/erigon/params"
+)
+
+// newMiningTestDB creates a database in which each of the given accounts holds one ether
+func newMiningTestDB(t *testing.T, logger log.Logger, addrs ...libcommon.Address) kv.RwDB {
+	db, _ := temporaltest.NewTestDB(t, datadir.New(t.TempDir()))
+	require.NoError(t, db.Update(context.Background(), func(tx kv.RwTx) error {
+		genesis, err := libstate.NewSharedDomains(tx, logger)
+		if err != nil {
+			return err
+		}
+		defer genesis.Close()
+		w := state.NewWriterV4(genesis)
+		for _, addr := range addrs {
+			acc := accounts.NewAccount()
+			acc.Balance = *uint256.NewInt(params.Ether)
+			if err := w.UpdateAccountData(addr, &accounts.Account{}, &acc); err != nil {
+				return err
+			}
+		}
+		return genesis.Flush(context.Background(), tx)
+	}))
+	return db
+}
+
+func TestMiningTxOrdering(t *testing.T) {
+	t.Parallel()
+	logger := log.New()
+	ctx := context.Background()
+	chainConfig := params.TestChainConfig
+	chainID, _ := uint256.FromBig(chainConfig.ChainID)
+	signer := types.LatestSignerForChainID(chainConfig.ChainID)
+
+	keyA, _ := crypto.GenerateKey()
+	keyB, _ := crypto.GenerateKey()
+	keyC, _ := crypto.GenerateKey()
+	addrA, addrB, addrC := crypto.PubkeyToAddress(keyA.PublicKey), crypto.PubkeyToAddress(keyB.PublicKey), crypto.PubkeyToAddress(keyC.PublicKey)
+	db := newMiningTestDB(t, logger, addrA, addrB, addrC)
+
+	keys := map[libcommon.Address]*ecdsa.PrivateKey{addrA: keyA, addrB: keyB, addrC: keyC}
+	transfer := func(nonce uint64, gasPrice uint64, from libcommon.Address) types.Transaction {
+		txn, err := types.SignTx(types.NewTransaction(nonce, libcommon.Address{0xff}, uint256.NewInt(1), params.TxGas, uint256.NewInt(gasPrice), nil), *signer, keys[from])
+		require.NoError(t, err)
+		return txn
+	}
+	// A's transactions pay the best tip and C's the worst. They arrive in the order C0, B0, A1, A0, A2: A1 waits for
+	// A0 in the queued sub-pool. C5 has a nonce gap and is never yielded.
+	a0, a1, a2 := transfer(0, 30, addrA), transfer(1, 30, addrA), transfer(2, 30, addrA)
+	b0, c0, c5 := transfer(0, 20, addrB), transfer(0, 10, addrC), transfer(5, 40, addrC)
+	arrivals := []types.Transaction{c0, b0, a1, a0, a2, c5}
+
+	newPool := func(t *testing.T, cfg txpoolcfg.Config) *txpool.TxPool {
+		coreDB, _ := temporaltest.NewTestDB(t, datadir.New(t.TempDir()))
+		pool, err := txpool.New(make(chan types2.Announcements, 10), coreDB, cfg, kvcache.New(kvcache.DefaultCoherentConfig), *chainID, nil, nil, nil, nil, fixedgas.DefaultMaxBlobsPerBlock, nil, logger)
+		require.NoError(t, err)
+
+		change := &remote.StateChangeBatch{
+			PendingBlockBaseFee: 1,
+			BlockGasLimit:       30_000_000,
+			ChangeBatch:         []*remote.StateChange{{BlockHeight: 0, BlockHash: gointerfaces.ConvertHashToH256([32]byte{})}},
+		}
+		for _, addr := range []libcommon.Address{addrA, addrB, addrC} {
+			change.ChangeBatch[0].Changes = append(change.ChangeBatch[0].Changes, &remote.AccountChange{
+				Action:  remote.Action_UPSERT,
+				Address: gointerfaces.ConvertAddressToH160(addr),
+				Data:    types2.EncodeAccountBytesV3(0, uint256.NewInt(params.Ether), make([]byte, 32), 1),
+			})
+		}
+		tx, err := memdb.NewTestPoolDB(t).BeginRw(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+		require.NoError(t, pool.OnNewBlock(ctx, change, types2.TxSlots{}, types2.TxSlots{}, types2.TxSlots{}, tx))
+
+		parseCtx := types2.NewTxParseContext(*chainID)
+		for _, txn := range arrivals {
+			rlpTxs, err := types.MarshalTransactionsBinary(types.Transactions{txn})
+			require.NoError(t, err)
+			var slots types2.TxSlots
+			slots.Resize(1)
+			slots.Txs[0] = &types2.TxSlot{}
+			_, err = parseCtx.ParseTransaction(rlpTxs[0], 0, slots.Txs[0], slots.Senders.At(0), false, true, nil)
+			require.NoError(t, err)
+			slots.IsLocal[0] = true
+			reasons, err := pool.AddLocalTxs(ctx, slots, tx)
+			require.NoError(t, err)
+			require.Equal(t, []txpoolcfg.DiscardReason{txpoolcfg.Success}, reasons)
+		}
+		return pool
+	}
+	// mine fills a block out of the pool in batches of two transactions, the way SpawnMiningExecStage does
+	mine := func(t *testing.T, pool *txpool.TxPool) []libcommon.Hash {
+		current := &MiningBlock{Header: &types.Header{Number: big.NewInt(1), GasLimit: 30_000_000, Difficulty: big.NewInt(1)}}
+		cfg := MiningExecCfg{chainConfig: *chainConfig, txPool: pool, txPoolDB: memdb.NewTestPoolDB(t)}
+
+		tx, err := db.BeginRw(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+		sim, err := libstate.NewSharedDomains(tx, logger)
+		require.NoError(t, err)
+		defer sim.Close()
+		domains, err := libstate.NewSharedDomains(tx, logger)
+		require.NoError(t, err)
+		defer domains.Close()
+		ibs := state.New(state.NewReaderV3(domains))
+		getHeader := func(hash libcommon.Hash, number uint64) *types.Header { return nil }
+
+		const batch = 2
+		yielded := mapset.NewSet[[32]byte]()
+		for {
+			txs, count, err := getNextTransactions(cfg, chainID, current, batch, 0, yielded, state.NewReaderV3(sim), state.NewWriterV4(sim), logger)
+			require.NoError(t, err)
+			if txs.Empty() {
+				break
+			}
+			_, _, err = addTransactionsToMiningBlock("test", current, *chainConfig, &vm.Config{}, getHeader, nil, txs, libcommon.Address{0xee}, ibs, ctx, nil, 0, logger)
+			require.NoError(t, err)
+			if count < batch {
+				break
+			}
+		}
+		require.Len(t, current.Receipts, len(current.Txs))
+		require.Empty(t, current.Skipped)
+		hashes := make([]libcommon.Hash, len(current.Txs))
+		for i, txn := range current.Txs {
+			hashes[i] = txn.Hash()
+		}
+		return hashes
+	}
+	hashes := func(txs ...types.Transaction) []libcommon.Hash {
+		hashes := make([]libcommon.Hash, len(txs))
+		for i, txn := range txs {
+			hashes[i] = txn.Hash()
+		}
+		return hashes
+	}
+	withOrdering := func(name string, prioritySenders ...libcommon.Address) txpoolcfg.Config {
+		cfg := txpoolcfg.DefaultConfig
+		cfg.TxOrdering, cfg.PrioritySenders = name, prioritySenders
+		return cfg
+	}
+
+	t.Run("price", func(t *testing.T) {
+		require.Equal(t, hashes(a0, a1, a2, b0, c0), mine(t, newPool(t, txpoolcfg.DefaultConfig)))
+	})
+	t.Run("fifo", func(t *testing.T) {
+		// A1 arrived first, but can't go before A0
+		require.Equal(t, hashes(c0, b0, a0, a1, a2), mine(t, newPool(t, withOrdering(txpool.FifoTxOrdering))))
+	})
+	t.Run("roundrobin", func(t *testing.T) {
+		require.Equal(t, hashes(a0, b0, c0, a1, a2), mine(t, newPool(t, withOrdering(txpool.RoundRobinTxOrdering))))
+	})
+	t.Run("priority senders", func(t *testing.T) {
+		require.Equal(t, hashes(c0, a0, a1, a2, b0), mine(t, newPool(t, withOrdering("", addrC))))
+		require.Equal(t, hashes(b0, a0, c0, a1, a2), mine(t, newPool(t, withOrdering(txpool.RoundRobinTxOrdering, addrB))))
+	})
+}
+
+// hidingStateReader pretends that the hidden account doesn't exist
+type hidingStateReader struct {
+	state.StateReader
+	hidden libcommon.Address
+}
+
+func (r hidingStateReader) ReadAccountData(address libcommon.Address) (*accounts.Account, error) {
+	if address == r.hidden {
+		return nil, nil
+	}
+	return r.StateReader.ReadAccountData(address)
+}
+
+func TestMiningBundles(t *testing.T) {
+	t.Parallel()
+	logger := log.New()
+	ctx := context.Background()
+	chainConfig := params.TestChainConfig
+	signer := types.LatestSignerForChainID(chainConfig.ChainID)
+
+	keyA, _ := crypto.GenerateKey()
+	keyB, _ := crypto.GenerateKey()
+	keyC, _ := crypto.GenerateKey()
+	addrA, addrB, addrC := crypto.PubkeyToAddress(keyA.PublicKey), crypto.PubkeyToAddress(keyB.PublicKey), crypto.PubkeyToAddress(keyC.PublicKey)
+	db := newMiningTestDB(t, logger, addrA, addrB, addrC)
+
+	keys := map[libcommon.Address]*ecdsa.PrivateKey{addrA: keyA, addrB: keyB, addrC: keyC}
+	transfer := func(nonce uint64, from libcommon.Address) types.Transaction {
+		txn, err := types.SignTx(types.NewTransaction(nonce, libcommon.Address{0xff}, uint256.NewInt(1), params.TxGas, uint256.NewInt(10), nil), *signer, keys[from])
+		require.NoError(t, err)
+		return txn
+	}
+	bundle := func(txs ...types.Transaction) *txpool.Bundle {
+		b := &txpool.Bundle{Hash: txs[0].Hash()}
+		rlpTxs, err := types.MarshalTransactionsBinary(txs)
+		require.NoError(t, err)
+		for i, txn := range txs {
+			sender, err := txn.Sender(*signer)
+			require.NoError(t, err)
+			b.Txs = append(b.Txs, rlpTxs[i])
+			b.TxHashes = append(b.TxHashes, txn.Hash())
+			b.Senders = append(b.Senders, sender)
+		}
+		return b
+	}
+	hashes := func(txs ...types.Transaction) []libcommon.Hash {
+		hashes := make([]libcommon.Hash, len(txs))
+		for i, txn := range txs {
+			hashes[i] = txn.Hash()
+		}
+		return hashes
+	}
+
+	a0, a1, a5, b0, c0 := transfer(0, addrA), transfer(1, addrA), transfer(5, addrA), transfer(0, addrB), transfer(0, addrC)
+	mine := func(t *testing.T, hidden libcommon.Address, bundles ...*txpool.Bundle) (*MiningBlock, mapset.Set[[32]byte]) {
+		tx, err := db.BeginRw(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+		domains, err := libstate.NewSharedDomains(tx, logger)
+		require.NoError(t, err)
+		defer domains.Close()
+		stateReader := state.NewReaderV3(domains)
+		ibs := state.New(hidingStateReader{StateReader: stateReader, hidden: hidden})
+
+		current := &MiningBlock{Header: &types.Header{Number: big.NewInt(1), GasLimit: 30_000_000, Difficulty: big.NewInt(1)}}
+		chainID, _ := uint256.FromBig(chainConfig.ChainID)
+		getHeader := func(hash libcommon.Hash, number uint64) *types.Header { return nil }
+		yielded := mapset.NewSet[[32]byte]()
+		_, err = addBundlesToMiningBlock("test", current, *chainConfig, &vm.Config{}, getHeader, nil, bundles, chainID, libcommon.Address{0xee}, stateReader, ibs, yielded, logger)
+		require.NoError(t, err)
+		require.Len(t, current.Receipts, len(current.Txs))
+		require.Equal(t, current.Receipts[len(current.Receipts)-1].CumulativeGasUsed, current.Header.GasUsed)
+		return current, yielded
+	}
+	txHashes := func(current *MiningBlock) []libcommon.Hash {
+		hashes := make([]libcommon.Hash, len(current.Txs))
+		for i, txn := range current.Txs {
+			hashes[i] = txn.Hash()
+		}
+		return hashes
+	}
+
+	t.Run("dry run", func(t *testing.T) {
+		// the second bundle has a nonce gap, the third one repeats an included transaction
+		current, yielded := mine(t, libcommon.Address{}, bundle(a0, b0), bundle(c0, a5), bundle(a0, a1), bundle(c0, a1))
+		require.Equal(t, hashes(a0, b0, c0, a1), txHashes(current))
+		require.Equal(t, 4, yielded.Cardinality())
+		require.False(t, yielded.Contains(a5.Hash()))
+	})
+	t.Run("apply", func(t *testing.T) {
+		// C can't pay on ibs although it could in the dry run, the bundles around it are still included
+		current, yielded := mine(t, addrC, bundle(a0, b0), bundle(a1, c0), bundle(a1))
+		require.Equal(t, hashes(a0, b0, a1), txHashes(current))
+		require.Equal(t, uint64(3*params.TxGas), current.Header.GasUsed)
+		require.False(t, yielded.Contains(c0.Hash()))
+	})
+}
+
+func TestMiningSkippedReasons(t *testing.T) {
+	t.Parallel()
+	logger := log.New()
+	ctx := context.Background()
+	chainConfig := *params.TestChainConfig
+	chainConfig.LondonBlock = big.NewInt(0)
+	signer := types.LatestSignerForChainID(chainConfig.ChainID)
+
+	keyA, _ := crypto.GenerateKey()
+	keyB, _ := crypto.GenerateKey()
+	keyC, _ := crypto.GenerateKey()
+	addrA, addrB, addrC := crypto.PubkeyToAddress(keyA.PublicKey), crypto.PubkeyToAddress(keyB.PublicKey), crypto.PubkeyToAddress(keyC.PublicKey)
+	db := newMiningTestDB(t, logger, addrA, addrB) // C has no account
+
+	keys := map[libcommon.Address]*ecdsa.PrivateKey{addrA: keyA, addrB: keyB, addrC: keyC}
+	transfer := func(nonce uint64, gasPrice uint64, value uint64, from libcommon.Address) types.Transaction {
+		txn, err := types.SignTx(types.NewTransaction(nonce, libcommon.Address{0xff}, uint256.NewInt(value), params.TxGas, uint256.NewInt(gasPrice), nil), *signer, keys[from])
+		require.NoError(t, err)
+		return txn
+	}
+	a0, a1, a3 := transfer(0, 20, 1, addrA), transfer(1, 20, 1, addrA), transfer(3, 20, 1, addrA)
+	aLow := transfer(0, 20, 2, addrA) // same nonce as a0, which goes first
+	b0Cheap, b0Rich := transfer(0, 5, 1, addrB), transfer(0, 20, params.Ether, addrB)
+	c0 := transfer(0, 20, 1, addrC)
+
+	tx, err := db.BeginRw(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+	sim, err := libstate.NewSharedDomains(tx, logger)
+	require.NoError(t, err)
+	defer sim.Close()
+	current := &MiningBlock{Header: &types.Header{Number: big.NewInt(1), GasLimit: 30_000_000, Difficulty: big.NewInt(1), BaseFee: big.NewInt(10)}}
+	txs := []types.Transaction{a0, aLow, a1, a3, b0Cheap, b0Rich, c0}
+	signed := []libcommon.Address{addrA, addrA, addrA, addrA, addrB, addrB, addrC}
+	for i, txn := range txs {
+		txn.SetSender(signed[i])
+	}
+	filtered, err := filterBadTransactions(txs, chainConfig, 1, current, state.NewReaderV3(sim), state.NewWriterV4(sim), logger)
+	require.NoError(t, err)
+	require.Equal(t, []types.Transaction{a0, a1}, filtered)
+	require.Equal(t, []SkippedTxn{
+		{Hash: aLow.Hash(), Sender: addrA, Reason: "nonce too low"},
+		{Hash: b0Cheap.Hash(), Sender: addrB, Reason: "fee too low"},
+		{Hash: b0Rich.Hash(), Sender: addrB, Reason: "balance too low"},
+		{Hash: c0.Hash(), Sender: addrC, Reason: "no sender account"},
+		{Hash: a3.Hash(), Sender: addrA, Reason: "nonce too high"},
+	}, current.Skipped)
+
+	// the second transaction doesn't fit into the gas left in the block
+	a1Big, err := types.SignTx(types.NewTransaction(1, libcommon.Address{0xff}, uint256.NewInt(1), 2*params.TxGas, uint256.NewInt(20), nil), *signer, keyA)
+	require.NoError(t, err)
+	current = &MiningBlock{Header: &types.Header{Number: big.NewInt(1), GasLimit: 2 * params.TxGas, Difficulty: big.NewInt(1), BaseFee: big.NewInt(10)}}
+	domains, err := libstate.NewSharedDomains(tx, logger)
+	require.NoError(t, err)
+	defer domains.Close()
+	ibs := state.New(state.NewReaderV3(domains))
+	getHeader := func(hash libcommon.Hash, number uint64) *types.Header { return nil }
+	_, _, err = addTransactionsToMiningBlock("test", current, chainConfig, &vm.Config{}, getHeader, nil, types.NewTransactionsFixedOrder(types.Transactions{a0, a1Big}), libcommon.Address{0xee}, ibs, ctx, nil, 0, logger)
+	require.NoError(t, err)
+	require.Len(t, current.Txs, 1)
+	require.Equal(t, []SkippedTxn{{Hash: a1Big.Hash(), Sender: addrA, Reason: "block gas limit reached"}}, current.Skipped)
+}
@@ -43,6 +43,29 @@ func MiningStages(
 	sendersCfg SendersCfg,
 	execCfg MiningExecCfg,
 	finish MiningFinishCfg,
+) []*Stage {
+	return append(MiningPreviewStages(ctx, createBlockCfg, borHeimdallCfg, executeBlockCfg, sendersCfg, execCfg), &Stage{
+		ID:          stages.MiningFinish,
+		Description: "Mining: create and propagate valid block",
+		Forward: func(badBlockUnwind bool, s *StageState, u Unwinder, txc wrap.TxContainer, logger log.Logger) error {
+			return SpawnMiningFinishStage(s, txc.Tx, finish, ctx.Done(), logger)
+		},
+		Unwind: func(u *UnwindState, s *StageState, txc wrap.TxContainer, logger log.Logger) error {
+			return nil
+		},
+		Prune: func(u *PruneState, tx kv.RwTx, logger log.Logger) error { return nil },
+	})
+}
+
+// MiningPreviewStages build a block like MiningStages, but stop before the block is sealed and propagated: the
+// result is left in the MiningBlock of the mining state
+func MiningPreviewStages(
+	ctx context.Context,
+	createBlockCfg MiningCreateBlockCfg,
+	borHeimdallCfg BorHeimdallCfg,
+	executeBlockCfg ExecuteBlockCfg,
+	sendersCfg SendersCfg,
+	execCfg MiningExecCfg,
 ) []*Stage {
 	return []*Stage{
 		{
@@ -83,16 +106,5 @@ func MiningStages(
 			},
 			Prune: func(u *PruneState, tx kv.RwTx, logger log.Logger) error { return nil },
 		},
-		{
-			ID:          stages.MiningFinish,
-			Description: "Mining: create and propagate valid block",
-			Forward: func(badBlockUnwind bool, s *StageState, u Unwinder, txc wrap.TxContainer, logger log.Logger) error {
-				return SpawnMiningFinishStage(s, txc.Tx, finish, ctx.Done(), logger)
-			},
-			Unwind: func(u *UnwindState, s *StageState, txc wrap.TxContainer, logger log.Logger) error {
-				return nil
-			},
-			Prune: func(u *PruneState, tx kv.RwTx, logger log.Logger) error { return nil },
-		},
 	}
 }
@@ -42,6 +42,7 @@ var (
 	Translation     SyncStage = "Translation"     // Translation each marked for translation contract (from EVM to TEVM)
 	VerkleTrie      SyncStage = "VerkleTrie"
 	TxLookup        SyncStage = "TxLookup" // Generating transactions lookup index
+	Export          SyncStage = "Export"   // Writing executed blocks to Parquet files
 	Finish          SyncStage = "Finish"   // Nominal stage after all other stages
 
 	MiningCreateBlock SyncStage = "MiningCreateBlock"
@@ -68,6 +69,7 @@ var AllStages = []SyncStage{
 	CustomTrace,
 	Translation,
 	TxLookup,
+	Export,
 	Finish,
 }
 
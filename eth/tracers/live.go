@@ -0,0 +1,47 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package tracers
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/erigontech/erigon/core/tracing"
+)
+
+type liveCtorFn = func(config json.RawMessage) (*tracing.Hooks, error)
+
+// LiveDirectory is the collection of tracers which can be attached to the
+// Execution stage and receive the hooks of every block executed during sync.
+var LiveDirectory = liveDirectory{elems: make(map[string]liveCtorFn)}
+
+type liveDirectory struct {
+	elems map[string]liveCtorFn
+}
+
+// Register registers a live tracer constructor by name.
+func (d *liveDirectory) Register(name string, f liveCtorFn) {
+	d.elems[name] = f
+}
+
+// New instantiates a live tracer by name.
+func (d *liveDirectory) New(name string, config json.RawMessage) (*tracing.Hooks, error) {
+	if f, ok := d.elems[name]; ok {
+		return f(config)
+	}
+	return nil, fmt.Errorf("live tracer %q not found", name)
+}
@@ -0,0 +1,115 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+// Package live is a collection of tracers which are attached to the Execution
+// stage (see --vmtrace) and receive the hooks of every executed block.
+package live
+
+import (
+	"encoding/json"
+
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon-lib/chain"
+	libcommon "github.com/erigontech/erigon-lib/common"
+
+	"github.com/erigontech/erigon/core/tracing"
+	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/eth/tracers"
+)
+
+func init() {
+	tracers.LiveDirectory.Register("noop", newNoopTracer)
+}
+
+// noop is a no-op live tracer. It's there to catch changes in the tracing
+// interface, as well as for benchmarking the overhead of live tracing.
+type noop struct{}
+
+func newNoopTracer(_ json.RawMessage) (*tracing.Hooks, error) {
+	t := &noop{}
+	return &tracing.Hooks{
+		OnTxStart:         t.OnTxStart,
+		OnTxEnd:           t.OnTxEnd,
+		OnEnter:           t.OnEnter,
+		OnExit:            t.OnExit,
+		OnOpcode:          t.OnOpcode,
+		OnFault:           t.OnFault,
+		OnGasChange:       t.OnGasChange,
+		OnBlockchainInit:  t.OnBlockchainInit,
+		OnBlockStart:      t.OnBlockStart,
+		OnBlockEnd:        t.OnBlockEnd,
+		OnGenesisBlock:    t.OnGenesisBlock,
+		OnSystemCallStart: t.OnSystemCallStart,
+		OnSystemCallEnd:   t.OnSystemCallEnd,
+		OnBalanceChange:   t.OnBalanceChange,
+		OnNonceChange:     t.OnNonceChange,
+		OnCodeChange:      t.OnCodeChange,
+		OnStorageChange:   t.OnStorageChange,
+		OnLog:             t.OnLog,
+	}, nil
+}
+
+func (t *noop) OnTxStart(vm *tracing.VMContext, txn types.Transaction, from libcommon.Address) {
+}
+
+func (t *noop) OnTxEnd(receipt *types.Receipt, err error) {
+}
+
+func (t *noop) OnEnter(depth int, typ byte, from libcommon.Address, to libcommon.Address, precompile bool, input []byte, gas uint64, value *uint256.Int, code []byte) {
+}
+
+func (t *noop) OnExit(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
+}
+
+func (t *noop) OnOpcode(pc uint64, op byte, gas, cost uint64, scope tracing.OpContext, rData []byte, depth int, err error) {
+}
+
+func (t *noop) OnFault(pc uint64, op byte, gas, cost uint64, scope tracing.OpContext, depth int, err error) {
+}
+
+func (t *noop) OnGasChange(old, new uint64, reason tracing.GasChangeReason) {}
+
+func (t *noop) OnBlockchainInit(chainConfig *chain.Config) {
+}
+
+func (t *noop) OnBlockStart(ev tracing.BlockEvent) {
+}
+
+func (t *noop) OnBlockEnd(err error) {
+}
+
+func (t *noop) OnGenesisBlock(b *types.Block, alloc types.GenesisAlloc) {
+}
+
+func (t *noop) OnSystemCallStart() {}
+
+func (t *noop) OnSystemCallEnd() {}
+
+func (t *noop) OnBalanceChange(a libcommon.Address, prev, new *uint256.Int, reason tracing.BalanceChangeReason) {
+}
+
+func (t *noop) OnNonceChange(a libcommon.Address, prev, new uint64) {
+}
+
+func (t *noop) OnCodeChange(a libcommon.Address, prevCodeHash libcommon.Hash, prev []byte, codeHash libcommon.Hash, code []byte) {
+}
+
+func (t *noop) OnStorageChange(a libcommon.Address, k *libcommon.Hash, prev, new uint256.Int) {
+}
+
+func (t *noop) OnLog(l *types.Log) {
+}
@@ -0,0 +1,431 @@
+// Copyright 2023 The go-ethereum Authors
+// (original work)
+// Copyright 2024 The Erigon Authors
+// (modifications)
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package native
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync/atomic"
+
+	"github.com/holiman/uint256"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/common/hexutility"
+	"github.com/erigontech/erigon/consensus"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/eth/tracers"
+)
+
+//go:generate gencodec -type flatCallAction -field-override flatCallActionMarshaling -out gen_flatcallaction_json.go
+//go:generate gencodec -type flatCallResult -field-override flatCallResultMarshaling -out gen_flatcallresult_json.go
+
+func init() {
+	register("flatCallTracer", newFlatCallTracer)
+}
+
+var parityErrorMapping = map[string]string{
+	"contract creation code storage out of gas": "Out of gas",
+	"out of gas":                      "Out of gas",
+	"gas uint64 overflow":             "Out of gas",
+	"max code size exceeded":          "Out of gas",
+	"invalid jump destination":        "Bad jump destination",
+	"execution reverted":              "Reverted",
+	"return data out of bounds":       "Out of bounds",
+	"stack limit reached 1024 (1023)": "Out of stack",
+	"precompiled failed":              "Built-in failed",
+	"invalid input length":            "Built-in failed",
+}
+
+var parityErrorMappingStartingWith = map[string]string{
+	"invalid opcode:": "Bad instruction",
+	"stack underflow": "Stack underflow",
+}
+
+// flatCallFrame is a standalone callframe.
+type flatCallFrame struct {
+	Action              flatCallAction  `json:"action"`
+	BlockHash           *libcommon.Hash `json:"blockHash,omitempty"`
+	BlockNumber         *uint64         `json:"blockNumber,omitempty"`
+	Error               string          `json:"error,omitempty"`
+	Result              *flatCallResult `json:"result"`
+	Subtraces           int             `json:"subtraces"`
+	TraceAddress        []int           `json:"traceAddress"`
+	TransactionHash     *libcommon.Hash `json:"transactionHash,omitempty"`
+	TransactionPosition *uint64         `json:"transactionPosition,omitempty"`
+	Type                string          `json:"type"`
+}
+
+type flatCallAction struct {
+	Author         *libcommon.Address `json:"author,omitempty"`
+	RewardType     string             `json:"rewardType,omitempty"`
+	SelfDestructed *libcommon.Address `json:"address,omitempty"`
+	Balance        *big.Int           `json:"balance,omitempty"`
+	CallType       string             `json:"callType,omitempty"`
+	From           *libcommon.Address `json:"from,omitempty"`
+	Gas            *uint64            `json:"gas,omitempty"`
+	Init           *[]byte            `json:"init,omitempty"`
+	Input          *[]byte            `json:"input,omitempty"`
+	RefundAddress  *libcommon.Address `json:"refundAddress,omitempty"`
+	To             *libcommon.Address `json:"to,omitempty"`
+	Value          *big.Int           `json:"value,omitempty"`
+}
+
+type flatCallActionMarshaling struct {
+	Balance *hexutil.Big
+	Gas     *hexutil.Uint64
+	Init    *hexutility.Bytes
+	Input   *hexutility.Bytes
+	Value   *hexutil.Big
+}
+
+type flatCallResult struct {
+	Address *libcommon.Address `json:"address,omitempty"`
+	Code    *[]byte            `json:"code,omitempty"`
+	GasUsed *uint64            `json:"gasUsed,omitempty"`
+	Output  *[]byte            `json:"output,omitempty"`
+}
+
+type flatCallResultMarshaling struct {
+	Code    *hexutility.Bytes
+	GasUsed *hexutil.Uint64
+	Output  *hexutility.Bytes
+}
+
+// flatCallTracer reports call frame information of a txn in a flat format, i.e.
+// as opposed to the nested format of `callTracer`. The output matches the
+// Parity/OpenEthereum format served by the trace_ namespace.
+type flatCallTracer struct {
+	noopTracer
+	tracer      *callTracer
+	config      flatCallTracerConfig
+	ctx         *tracers.Context // Holds tracer context data
+	interrupt   uint32           // Atomic flag to signal execution interruption
+	gasUsed     uint64           // Gas used by the top-level call, without the intrinsic gas
+	precompiles []bool           // keep track of whether scopes are value-less calls to pre-compiles
+}
+
+type flatCallTracerConfig struct {
+	ConvertParityErrors bool `json:"convertParityErrors"` // If true, call tracer converts errors to parity format
+	IncludePrecompiles  bool `json:"includePrecompiles"`  // If true, call tracer includes calls to precompiled contracts
+	IncludeRewards      bool `json:"includeRewards"`      // If true, block tracing appends the block and uncle rewards
+}
+
+// newFlatCallTracer returns a new flatCallTracer.
+func newFlatCallTracer(ctx *tracers.Context, cfg json.RawMessage) (tracers.Tracer, error) {
+	var config flatCallTracerConfig
+	if cfg != nil {
+		if err := json.Unmarshal(cfg, &config); err != nil {
+			return nil, err
+		}
+	}
+
+	// Create inner call tracer with default configuration, don't forward
+	// the OnlyTopCall or WithLog to inner for now.
+	// Precompiles are always collected and filtered here, as the trace_
+	// namespace only drops the calls to precompiles which transfer no value.
+	t, err := newCallTracer(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &flatCallTracer{tracer: t.(*callTracer), ctx: ctx, config: config}, nil
+}
+
+// CaptureStart implements the EVMLogger interface to initialize the tracing operation.
+func (t *flatCallTracer) CaptureStart(env *vm.EVM, from libcommon.Address, to libcommon.Address, precompile bool, create bool, input []byte, gas uint64, value *uint256.Int, code []byte) {
+	t.tracer.CaptureStart(env, from, to, precompile, create, input, gas, value, code)
+	// Parity reports the gas available to the execution, i.e. without the intrinsic gas
+	t.tracer.callstack[0].Gas = gas
+	if t.tracer.callstack[0].Value == nil {
+		t.tracer.callstack[0].Value = new(big.Int)
+	}
+}
+
+// CaptureEnd is called after the call finishes to finalize the tracing.
+func (t *flatCallTracer) CaptureEnd(output []byte, gasUsed uint64, err error) {
+	t.tracer.CaptureEnd(output, gasUsed, err)
+	t.gasUsed = gasUsed
+}
+
+// CaptureEnter is called when EVM enters a new scope (via call, create or selfdestruct).
+func (t *flatCallTracer) CaptureEnter(typ vm.OpCode, from libcommon.Address, to libcommon.Address, precompile, create bool, input []byte, gas uint64, value *uint256.Int, code []byte) {
+	if atomic.LoadUint32(&t.interrupt) > 0 {
+		return
+	}
+	t.tracer.CaptureEnter(typ, from, to, precompile, create, input, gas, value, code)
+	t.precompiles = append(t.precompiles, precompile && (value == nil || value.IsZero()))
+
+	// Child calls must have a value, even if it's zero.
+	// Practically speaking, only STATICCALL has nil value. Set it to zero.
+	if call := &t.tracer.callstack[len(t.tracer.callstack)-1]; call.Value == nil {
+		call.Value = new(big.Int)
+	}
+}
+
+// CaptureExit is called when EVM exits a scope, even if the scope didn't
+// execute any code.
+func (t *flatCallTracer) CaptureExit(output []byte, gasUsed uint64, err error) {
+	if atomic.LoadUint32(&t.interrupt) > 0 {
+		return
+	}
+	t.tracer.CaptureExit(output, gasUsed, err)
+
+	lastIdx := len(t.precompiles) - 1
+	if lastIdx < 0 {
+		return
+	}
+	precompile := t.precompiles[lastIdx]
+	t.precompiles = t.precompiles[:lastIdx]
+	// Parity traces don't include value-less calls to precompiles.
+	// By default we remove them from the callstack.
+	if !precompile || t.config.IncludePrecompiles {
+		return
+	}
+	parent := &t.tracer.callstack[len(t.tracer.callstack)-1]
+	parent.Calls = parent.Calls[:len(parent.Calls)-1]
+}
+
+func (t *flatCallTracer) CaptureTxStart(gasLimit uint64) {
+	t.tracer.CaptureTxStart(gasLimit)
+}
+
+func (t *flatCallTracer) CaptureTxEnd(restGas uint64) {
+	t.tracer.CaptureTxEnd(restGas)
+	// Parity reports the gas used by the execution, i.e. without the intrinsic gas
+	t.tracer.callstack[0].GasUsed = t.gasUsed
+}
+
+// GetResult returns the json-encoded flat list of call traces, and any
+// error arising from the encoding or forceful termination (via `Stop`).
+func (t *flatCallTracer) GetResult() (json.RawMessage, error) {
+	if len(t.tracer.callstack) != 1 {
+		return nil, errors.New("incorrect number of top-level calls")
+	}
+
+	flat, err := flatFromNested(&t.tracer.callstack[0], []int{}, t.config.ConvertParityErrors, t.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := json.Marshal(flat)
+	if err != nil {
+		return nil, err
+	}
+	return res, t.tracer.reason
+}
+
+// Stop terminates execution of the tracer at the first opportune moment.
+func (t *flatCallTracer) Stop(err error) {
+	t.tracer.Stop(err)
+	atomic.StoreUint32(&t.interrupt, 1)
+}
+
+// FlatCallTracerIncludesRewards reports whether the given flatCallTracer
+// config asks for the block rewards to be traced along with the block.
+func FlatCallTracerIncludesRewards(cfg json.RawMessage) bool {
+	var config flatCallTracerConfig
+	if cfg == nil || json.Unmarshal(cfg, &config) != nil {
+		return false
+	}
+	return config.IncludeRewards
+}
+
+// FlatRewardTraces returns the json-encoded reward traces of a block, in the
+// same format as the trace_block rewards.
+func FlatRewardTraces(blockHash libcommon.Hash, blockNumber uint64, rewards []consensus.Reward) (json.RawMessage, error) {
+	flat := make([]flatCallFrame, 0, len(rewards))
+	for _, r := range rewards {
+		author := r.Beneficiary
+		flat = append(flat, flatCallFrame{
+			Type: "reward",
+			Action: flatCallAction{
+				Author:     &author,
+				RewardType: rewardKindToString(r.Kind),
+				Value:      r.Amount.ToBig(),
+			},
+			BlockHash:    &blockHash,
+			BlockNumber:  &blockNumber,
+			TraceAddress: []int{},
+		})
+	}
+	return json.Marshal(flat)
+}
+
+func rewardKindToString(kind consensus.RewardKind) string {
+	switch kind {
+	case consensus.RewardAuthor:
+		return "block"
+	case consensus.RewardEmptyStep:
+		return "emptyStep"
+	case consensus.RewardExternal:
+		return "external"
+	case consensus.RewardUncle:
+		return "uncle"
+	default:
+		return "unknown"
+	}
+}
+
+func flatFromNested(input *callFrame, traceAddress []int, convertErrs bool, ctx *tracers.Context) (output []flatCallFrame, err error) {
+	var frame *flatCallFrame
+	switch input.Type {
+	case vm.CREATE, vm.CREATE2:
+		frame = newFlatCreate(input)
+	case vm.SELFDESTRUCT:
+		frame = newFlatSelfdestruct(input)
+	case vm.CALL, vm.STATICCALL, vm.CALLCODE, vm.DELEGATECALL:
+		frame = newFlatCall(input)
+	default:
+		return nil, fmt.Errorf("unrecognized call frame type: %s", input.Type)
+	}
+
+	frame.Error = input.Error
+	if convertErrs {
+		convertErrorToParity(frame)
+	}
+
+	// Revert output contains error reason
+	if input.Error != "" && input.Error != vm.ErrExecutionReverted.Error() {
+		frame.Result = nil
+	}
+
+	frame.TraceAddress = traceAddress
+	frame.Subtraces = len(input.Calls)
+	fillCallFrameFromContext(frame, ctx)
+	output = append(output, *frame)
+
+	// Recursively form flat call frames
+	for i := 0; i < len(input.Calls); i++ {
+		childAddr := childTraceAddress(traceAddress, i)
+		childCallCopy := input.Calls[i]
+		flat, err := flatFromNested(&childCallCopy, childAddr, convertErrs, ctx)
+		if err != nil {
+			return nil, err
+		}
+		output = append(output, flat...)
+	}
+
+	return output, nil
+}
+
+func newFlatCreate(input *callFrame) *flatCallFrame {
+	var (
+		actionInit = input.Input[:]
+		resultCode = input.Output[:]
+		to         = input.To
+	)
+
+	return &flatCallFrame{
+		Type: strings.ToLower(vm.CREATE.String()),
+		Action: flatCallAction{
+			From:  &input.From,
+			Gas:   &input.Gas,
+			Value: input.Value,
+			Init:  &actionInit,
+		},
+		Result: &flatCallResult{
+			GasUsed: &input.GasUsed,
+			Address: &to,
+			Code:    &resultCode,
+		},
+	}
+}
+
+func newFlatCall(input *callFrame) *flatCallFrame {
+	var (
+		actionInput  = input.Input[:]
+		resultOutput = input.Output[:]
+		to           = input.To
+	)
+
+	return &flatCallFrame{
+		Type: strings.ToLower(vm.CALL.String()),
+		Action: flatCallAction{
+			From:     &input.From,
+			To:       &to,
+			Gas:      &input.Gas,
+			Value:    input.Value,
+			CallType: strings.ToLower(input.Type.String()),
+			Input:    &actionInput,
+		},
+		Result: &flatCallResult{
+			GasUsed: &input.GasUsed,
+			Output:  &resultOutput,
+		},
+	}
+}
+
+func newFlatSelfdestruct(input *callFrame) *flatCallFrame {
+	to := input.To
+	return &flatCallFrame{
+		Type: "suicide",
+		Action: flatCallAction{
+			SelfDestructed: &input.From,
+			Balance:        input.Value,
+			RefundAddress:  &to,
+		},
+	}
+}
+
+func fillCallFrameFromContext(callFrame *flatCallFrame, ctx *tracers.Context) {
+	if ctx == nil {
+		return
+	}
+	if ctx.BlockHash != (libcommon.Hash{}) {
+		callFrame.BlockHash = &ctx.BlockHash
+	}
+	if ctx.BlockNumber != nil {
+		blockNumber := ctx.BlockNumber.Uint64()
+		callFrame.BlockNumber = &blockNumber
+	}
+	if ctx.TxHash != (libcommon.Hash{}) {
+		callFrame.TransactionHash = &ctx.TxHash
+		txPos := uint64(ctx.TxIndex)
+		callFrame.TransactionPosition = &txPos
+	}
+}
+
+func convertErrorToParity(call *flatCallFrame) {
+	if call.Error == "" {
+		return
+	}
+
+	if parityError, ok := parityErrorMapping[call.Error]; ok {
+		call.Error = parityError
+	} else {
+		for gethErrorPrefix, parityError := range parityErrorMappingStartingWith {
+			if strings.HasPrefix(call.Error, gethErrorPrefix) {
+				call.Error = parityError
+				break
+			}
+		}
+	}
+}
+
+func childTraceAddress(a []int, i int) []int {
+	child := make([]int, 0, len(a)+1)
+	child = append(child, a...)
+	child = append(child, i)
+	return child
+}
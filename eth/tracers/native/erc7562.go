@@ -0,0 +1,455 @@
+// Copyright 2025 The go-ethereum Authors
+// (original work)
+// Copyright 2024 The Erigon Authors
+// (modifications)
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package native
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"sort"
+	"sync/atomic"
+
+	"github.com/holiman/uint256"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/common/hexutility"
+	"github.com/erigontech/erigon/accounts/abi"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/eth/tracers"
+)
+
+//go:generate gencodec -type callFrameWithOpcodes -field-override callFrameWithOpcodesMarshaling -out gen_callframewithopcodes_json.go
+
+func init() {
+	register("erc7562Tracer", newErc7562Tracer)
+}
+
+type contractSizeWithOpcode struct {
+	ContractSize int       `json:"contractSize"`
+	Opcode       vm.OpCode `json:"opcode"`
+}
+
+// callFrameWithOpcodes is a call frame extended with the information the
+// ERC-7562 validation rules are checked against.
+type callFrameWithOpcodes struct {
+	Type         vm.OpCode          `json:"-"`
+	From         libcommon.Address  `json:"from"`
+	Gas          uint64             `json:"gas"`
+	GasUsed      uint64             `json:"gasUsed"`
+	To           *libcommon.Address `json:"to,omitempty" rlp:"optional"`
+	Input        []byte             `json:"input" rlp:"optional"`
+	Output       []byte             `json:"output,omitempty" rlp:"optional"`
+	Error        string             `json:"error,omitempty" rlp:"optional"`
+	RevertReason string             `json:"revertReason,omitempty"`
+	Value        *big.Int           `json:"value,omitempty" rlp:"optional"`
+
+	AccessedSlots     accessedSlots                                 `json:"accessedSlots"`
+	ExtCodeAccessInfo []libcommon.Address                           `json:"extCodeAccessInfo"`
+	UsedOpcodes       map[vm.OpCode]uint64                          `json:"usedOpcodes"`
+	ContractSize      map[libcommon.Address]*contractSizeWithOpcode `json:"contractSize"`
+	OutOfGas          bool                                          `json:"outOfGas"`
+	// Keccak preimages for the whole transaction are stored in the
+	// root call frame.
+	KeccakPreimages [][]byte               `json:"keccak,omitempty"`
+	Calls           []callFrameWithOpcodes `json:"calls,omitempty" rlp:"optional"`
+}
+
+func (f callFrameWithOpcodes) TypeString() string {
+	return f.Type.String()
+}
+
+func (f *callFrameWithOpcodes) processOutput(output []byte, err error) {
+	output = libcommon.CopyBytes(output)
+	if errors.Is(err, vm.ErrOutOfGas) || errors.Is(err, vm.ErrCodeStoreOutOfGas) {
+		f.OutOfGas = true
+	}
+	if err == nil {
+		f.Output = output
+		return
+	}
+	f.Error = err.Error()
+	if f.Type == vm.CREATE || f.Type == vm.CREATE2 {
+		f.To = nil
+	}
+	if !errors.Is(err, vm.ErrExecutionReverted) || len(output) == 0 {
+		return
+	}
+	f.Output = output
+	if len(output) < 4 {
+		return
+	}
+	if unpacked, err := abi.UnpackRevert(output); err == nil {
+		f.RevertReason = unpacked
+	}
+}
+
+type callFrameWithOpcodesMarshaling struct {
+	TypeString      string `json:"type"`
+	Gas             hexutil.Uint64
+	GasUsed         hexutil.Uint64
+	Value           *hexutil.Big
+	Input           hexutility.Bytes
+	Output          hexutility.Bytes
+	KeccakPreimages []hexutility.Bytes
+}
+
+type accessedSlots struct {
+	Reads           map[string][]string `json:"reads"`
+	Writes          map[string]uint64   `json:"writes"`
+	TransientReads  map[string]uint64   `json:"transientReads"`
+	TransientWrites map[string]uint64   `json:"transientWrites"`
+}
+
+type opcodeWithPartialStack struct {
+	Opcode        vm.OpCode
+	StackTopItems []uint256.Int
+}
+
+// erc7562Tracer collects the opcodes, storage slots, code accesses and keccak
+// preimages of a txn, which bundlers need to enforce the ERC-7562 validation
+// rules on the validation phase of ERC-4337 user operations.
+type erc7562Tracer struct {
+	noopTracer
+	config    erc7562TracerConfig
+	env       *vm.EVM
+	gasLimit  uint64
+	interrupt uint32 // Atomic flag to signal execution interruption
+	reason    error  // Textual reason for the interruption
+
+	ignoredOpcodes       map[vm.OpCode]struct{}
+	callstackWithOpcodes []callFrameWithOpcodes
+	lastOpWithStack      *opcodeWithPartialStack
+	keccakPreimages      map[string]struct{}
+}
+
+type erc7562TracerConfig struct {
+	StackTopItemsSize int              `json:"stackTopItemsSize"`
+	IgnoredOpcodes    []hexutil.Uint64 `json:"ignoredOpcodes"` // Opcodes which are not counted in usedOpcodes
+}
+
+func defaultErc7562TracerConfig() erc7562TracerConfig {
+	return erc7562TracerConfig{
+		StackTopItemsSize: 3,
+		IgnoredOpcodes:    defaultIgnoredOpcodes(),
+	}
+}
+
+// defaultIgnoredOpcodes returns the opcodes that no validation rule cares about.
+func defaultIgnoredOpcodes() []hexutil.Uint64 {
+	ignored := make([]hexutil.Uint64, 0, 112)
+	// Allow all PUSHx, DUPx and SWAPx opcodes as they have sequential codes
+	for op := vm.PUSH0; op <= vm.SWAP16; op++ {
+		ignored = append(ignored, hexutil.Uint64(op))
+	}
+	for _, op := range []vm.OpCode{
+		vm.POP, vm.ADD, vm.SUB, vm.MUL,
+		vm.DIV, vm.EQ, vm.LT, vm.GT,
+		vm.SLT, vm.SGT, vm.SHL, vm.SHR,
+		vm.AND, vm.OR, vm.NOT, vm.ISZERO,
+	} {
+		ignored = append(ignored, hexutil.Uint64(op))
+	}
+	return ignored
+}
+
+// newErc7562Tracer returns a native go tracer which collects the ERC-7562
+// validation data of a txn, and implements vm.EVMLogger.
+func newErc7562Tracer(ctx *tracers.Context, cfg json.RawMessage) (tracers.Tracer, error) {
+	config := defaultErc7562TracerConfig()
+	if cfg != nil {
+		if err := json.Unmarshal(cfg, &config); err != nil {
+			return nil, err
+		}
+	}
+	ignoredOpcodes := make(map[vm.OpCode]struct{}, len(config.IgnoredOpcodes))
+	for _, op := range config.IgnoredOpcodes {
+		ignoredOpcodes[vm.OpCode(op)] = struct{}{}
+	}
+	return &erc7562Tracer{
+		config:          config,
+		ignoredOpcodes:  ignoredOpcodes,
+		keccakPreimages: make(map[string]struct{}),
+	}, nil
+}
+
+func newCallFrameWithOpcodes(typ vm.OpCode, from libcommon.Address, to libcommon.Address, input []byte, gas uint64, value *uint256.Int) callFrameWithOpcodes {
+	call := callFrameWithOpcodes{
+		Type:  typ,
+		From:  from,
+		To:    &to,
+		Input: libcommon.CopyBytes(input),
+		Gas:   gas,
+		AccessedSlots: accessedSlots{
+			Reads:           map[string][]string{},
+			Writes:          map[string]uint64{},
+			TransientReads:  map[string]uint64{},
+			TransientWrites: map[string]uint64{},
+		},
+		ExtCodeAccessInfo: make([]libcommon.Address, 0),
+		UsedOpcodes:       map[vm.OpCode]uint64{},
+		ContractSize:      map[libcommon.Address]*contractSizeWithOpcode{},
+	}
+	if value != nil {
+		call.Value = value.ToBig()
+	}
+	return call
+}
+
+func (t *erc7562Tracer) CaptureTxStart(gasLimit uint64) {
+	t.gasLimit = gasLimit
+}
+
+func (t *erc7562Tracer) CaptureTxEnd(restGas uint64) {
+	if len(t.callstackWithOpcodes) == 0 {
+		return
+	}
+	t.callstackWithOpcodes[0].GasUsed = t.gasLimit - restGas
+}
+
+// CaptureStart implements the EVMLogger interface to initialize the tracing operation.
+func (t *erc7562Tracer) CaptureStart(env *vm.EVM, from libcommon.Address, to libcommon.Address, precompile bool, create bool, input []byte, gas uint64, value *uint256.Int, code []byte) {
+	t.env = env
+	typ := vm.CALL
+	if create {
+		typ = vm.CREATE
+	}
+	// gas has intrinsicGas already subtracted, the frame reports the full txn gas
+	t.callstackWithOpcodes = append(t.callstackWithOpcodes, newCallFrameWithOpcodes(typ, from, to, input, t.gasLimit, value))
+}
+
+// CaptureEnd is called after the call finishes to finalize the tracing.
+func (t *erc7562Tracer) CaptureEnd(output []byte, gasUsed uint64, err error) {
+	if len(t.callstackWithOpcodes) == 0 {
+		return
+	}
+	t.callstackWithOpcodes[0].processOutput(output, err)
+}
+
+// CaptureEnter is called when EVM enters a new scope (via call, create or selfdestruct).
+func (t *erc7562Tracer) CaptureEnter(typ vm.OpCode, from libcommon.Address, to libcommon.Address, precompile, create bool, input []byte, gas uint64, value *uint256.Int, code []byte) {
+	if atomic.LoadUint32(&t.interrupt) > 0 {
+		return
+	}
+	t.callstackWithOpcodes = append(t.callstackWithOpcodes, newCallFrameWithOpcodes(typ, from, to, input, gas, value))
+}
+
+// CaptureExit is called when EVM exits a scope, even if the scope didn't
+// execute any code.
+func (t *erc7562Tracer) CaptureExit(output []byte, gasUsed uint64, err error) {
+	if atomic.LoadUint32(&t.interrupt) > 0 {
+		return
+	}
+	size := len(t.callstackWithOpcodes)
+	if size <= 1 {
+		return
+	}
+	// pop call
+	call := t.callstackWithOpcodes[size-1]
+	t.callstackWithOpcodes = t.callstackWithOpcodes[:size-1]
+	size -= 1
+
+	call.GasUsed = gasUsed
+	call.processOutput(output, err)
+	// nest call into parent
+	t.callstackWithOpcodes[size-1].Calls = append(t.callstackWithOpcodes[size-1].Calls, call)
+}
+
+// CaptureState implements the EVMLogger interface to trace a single step of VM execution.
+func (t *erc7562Tracer) CaptureState(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, rData []byte, depth int, err error) {
+	if atomic.LoadUint32(&t.interrupt) > 0 {
+		return
+	}
+	size := len(t.callstackWithOpcodes)
+	if size == 0 {
+		return
+	}
+	stackData := scope.Stack.Data
+	stackTopItems := make([]uint256.Int, 0, t.config.StackTopItemsSize)
+	for i := 0; i < t.config.StackTopItemsSize && i < len(stackData); i++ {
+		stackTopItems = append(stackTopItems, stackData[len(stackData)-1-i])
+	}
+	opcodeWithStack := &opcodeWithPartialStack{
+		Opcode:        op,
+		StackTopItems: stackTopItems,
+	}
+
+	if op == vm.REVERT || op == vm.RETURN {
+		t.lastOpWithStack = nil
+	}
+	currentCallFrame := &t.callstackWithOpcodes[size-1]
+	if t.lastOpWithStack != nil {
+		t.handleExtOpcodes(op, currentCallFrame)
+	}
+	t.handleAccessedContractSize(op, stackData, currentCallFrame)
+	if t.lastOpWithStack != nil {
+		t.handleGasObserved(op, currentCallFrame)
+	}
+	t.storeUsedOpcode(op, currentCallFrame)
+	t.handleStorageAccess(op, stackData, scope.Contract.Address(), currentCallFrame)
+	t.storeKeccak(op, stackData, scope.Memory.Data())
+	t.lastOpWithStack = opcodeWithStack
+}
+
+// handleExtOpcodes records the address accessed by the previous EXTCODE* opcode [OP-051].
+func (t *erc7562Tracer) handleExtOpcodes(op vm.OpCode, currentCallFrame *callFrameWithOpcodes) {
+	if !isEXT(t.lastOpWithStack.Opcode) || len(t.lastOpWithStack.StackTopItems) == 0 {
+		return
+	}
+	addr := libcommon.Address(t.lastOpWithStack.StackTopItems[0].Bytes20())
+	// EXTCODESIZE followed by ISZERO is the allowed "is contract deployed" check
+	if !(t.lastOpWithStack.Opcode == vm.EXTCODESIZE && op == vm.ISZERO) {
+		currentCallFrame.ExtCodeAccessInfo = append(currentCallFrame.ExtCodeAccessInfo, addr)
+	}
+}
+
+// handleAccessedContractSize records the code size of every address accessed by EXT* or CALL* opcodes [OP-041].
+func (t *erc7562Tracer) handleAccessedContractSize(op vm.OpCode, stackData []uint256.Int, currentCallFrame *callFrameWithOpcodes) {
+	if !isEXTorCALL(op) {
+		return
+	}
+	n := 0
+	if !isEXT(op) {
+		n = 1
+	}
+	if len(stackData) <= n {
+		return
+	}
+	addr := libcommon.Address(stackData[len(stackData)-1-n].Bytes20())
+	if _, ok := currentCallFrame.ContractSize[addr]; !ok {
+		currentCallFrame.ContractSize[addr] = &contractSizeWithOpcode{
+			ContractSize: t.env.IntraBlockState().GetCodeSize(addr),
+			Opcode:       op,
+		}
+	}
+}
+
+// handleGasObserved counts GAS opcodes which are not immediately followed by a call [OP-012].
+func (t *erc7562Tracer) handleGasObserved(op vm.OpCode, currentCallFrame *callFrameWithOpcodes) {
+	if t.lastOpWithStack.Opcode == vm.GAS && !isCall(op) {
+		currentCallFrame.UsedOpcodes[vm.GAS]++
+	}
+}
+
+func (t *erc7562Tracer) storeUsedOpcode(op vm.OpCode, currentCallFrame *callFrameWithOpcodes) {
+	// GAS is accounted in handleGasObserved, once the next opcode is known
+	if _, ignored := t.ignoredOpcodes[op]; op != vm.GAS && !ignored {
+		currentCallFrame.UsedOpcodes[op]++
+	}
+}
+
+func (t *erc7562Tracer) handleStorageAccess(op vm.OpCode, stackData []uint256.Int, addr libcommon.Address, currentCallFrame *callFrameWithOpcodes) {
+	if op != vm.SLOAD && op != vm.SSTORE && op != vm.TLOAD && op != vm.TSTORE {
+		return
+	}
+	if len(stackData) == 0 {
+		return
+	}
+	slot := libcommon.Hash(stackData[len(stackData)-1].Bytes32())
+	slotHex := slot.Hex()
+
+	switch op {
+	case vm.SLOAD:
+		// read slot values before this UserOp was created
+		// (so saving it if it was written before the first read)
+		_, rOk := currentCallFrame.AccessedSlots.Reads[slotHex]
+		_, wOk := currentCallFrame.AccessedSlots.Writes[slotHex]
+		if !rOk && !wOk {
+			var value uint256.Int
+			t.env.IntraBlockState().GetState(addr, &slot, &value)
+			currentCallFrame.AccessedSlots.Reads[slotHex] = append(currentCallFrame.AccessedSlots.Reads[slotHex], libcommon.Hash(value.Bytes32()).Hex())
+		}
+	case vm.SSTORE:
+		currentCallFrame.AccessedSlots.Writes[slotHex]++
+	case vm.TLOAD:
+		currentCallFrame.AccessedSlots.TransientReads[slotHex]++
+	case vm.TSTORE:
+		currentCallFrame.AccessedSlots.TransientWrites[slotHex]++
+	}
+}
+
+// keccakPadLimit - max amount of zeros appended to the memory to read a KECCAK256 preimage (same as geth)
+const keccakPadLimit = 1024 * 1024
+
+func (t *erc7562Tracer) storeKeccak(op vm.OpCode, stackData []uint256.Int, memory []byte) {
+	if op != vm.KECCAK256 || len(stackData) < 2 {
+		return
+	}
+	dataOffset := stackData[len(stackData)-1]
+	dataLength := stackData[len(stackData)-2]
+	// Tracing happens before gas and memory expansion checks: the length comes straight from the stack,
+	// and the tail of the preimage may be missing. Pad it with zeros, but no more than keccakPadLimit.
+	if !dataOffset.IsUint64() || !dataLength.IsUint64() {
+		return
+	}
+	offset, length := dataOffset.Uint64(), dataLength.Uint64()
+	if length > keccakPadLimit+uint64(len(memory)) || offset > keccakPadLimit+uint64(len(memory))-length {
+		return // the opcode runs out of gas anyway
+	}
+	preimage := make([]byte, length)
+	if offset < uint64(len(memory)) {
+		copy(preimage, memory[offset:min(offset+length, uint64(len(memory)))])
+	}
+	t.keccakPreimages[string(preimage)] = struct{}{}
+}
+
+// GetResult returns the json-encoded nested list of call frames, and any
+// error arising from the encoding or forceful termination (via `Stop`).
+func (t *erc7562Tracer) GetResult() (json.RawMessage, error) {
+	if len(t.callstackWithOpcodes) != 1 {
+		return nil, errors.New("incorrect number of top-level calls")
+	}
+
+	keccak := make([][]byte, 0, len(t.keccakPreimages))
+	for k := range t.keccakPreimages {
+		keccak = append(keccak, []byte(k))
+	}
+	sort.Slice(keccak, func(i, j int) bool { return string(keccak[i]) < string(keccak[j]) })
+	t.callstackWithOpcodes[0].KeccakPreimages = keccak
+
+	res, err := json.Marshal(t.callstackWithOpcodes[0])
+	if err != nil {
+		return nil, err
+	}
+	return res, t.reason
+}
+
+// Stop terminates execution of the tracer at the first opportune moment.
+func (t *erc7562Tracer) Stop(err error) {
+	t.reason = err
+	atomic.StoreUint32(&t.interrupt, 1)
+}
+
+func isEXTorCALL(op vm.OpCode) bool {
+	return isEXT(op) || isCall(op)
+}
+
+func isEXT(op vm.OpCode) bool {
+	return op == vm.EXTCODEHASH ||
+		op == vm.EXTCODESIZE ||
+		op == vm.EXTCODECOPY
+}
+
+func isCall(op vm.OpCode) bool {
+	return op == vm.CALL ||
+		op == vm.CALLCODE ||
+		op == vm.DELEGATECALL ||
+		op == vm.STATICCALL
+}
@@ -0,0 +1,55 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package native
+
+import (
+	"math"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon/core/vm"
+)
+
+func TestErc7562StoreKeccak(t *testing.T) {
+	tracer := &erc7562Tracer{keccakPreimages: make(map[string]struct{})}
+	memory := []byte{1, 2, 3, 4}
+	keccak := func(offset, length uint256.Int) {
+		// stack top is the offset
+		tracer.storeKeccak(vm.KECCAK256, []uint256.Int{length, offset}, memory)
+	}
+
+	keccak(*uint256.NewInt(1), *uint256.NewInt(2))
+	require.Contains(t, tracer.keccakPreimages, string([]byte{2, 3}))
+	// not expanded memory is padded with zeros
+	keccak(*uint256.NewInt(2), *uint256.NewInt(4))
+	require.Contains(t, tracer.keccakPreimages, string([]byte{3, 4, 0, 0}))
+	keccak(*uint256.NewInt(10), *uint256.NewInt(1))
+	require.Contains(t, tracer.keccakPreimages, string([]byte{0}))
+	require.Len(t, tracer.keccakPreimages, 3)
+
+	// oversized lengths and offsets are ignored: the opcode runs out of gas
+	keccak(*uint256.NewInt(0), *uint256.NewInt(keccakPadLimit + uint64(len(memory)) + 1))
+	keccak(*uint256.NewInt(0), *uint256.NewInt(math.MaxUint64))
+	keccak(*uint256.NewInt(math.MaxUint64), *uint256.NewInt(1))
+	keccak(*uint256.NewInt(0), *new(uint256.Int).Lsh(uint256.NewInt(1), 100))
+	require.Len(t, tracer.keccakPreimages, 3)
+
+	keccak(*uint256.NewInt(0), *uint256.NewInt(keccakPadLimit))
+	require.Len(t, tracer.keccakPreimages, 4)
+}
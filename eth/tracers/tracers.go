@@ -23,6 +23,7 @@ package tracers
 import (
 	"encoding/json"
 	"errors"
+	"math/big"
 
 	libcommon "github.com/erigontech/erigon-lib/common"
 
@@ -32,9 +33,10 @@ import (
 // Context contains some contextual infos for a transaction execution that is not
 // available from within the EVM object.
 type Context struct {
-	BlockHash libcommon.Hash // Hash of the block the txn is contained within (zero if dangling txn or call)
-	TxIndex   int            // Index of the transaction within a block (zero if dangling txn or call)
-	TxHash    libcommon.Hash // Hash of the transaction being traced (zero if dangling call)
+	BlockHash   libcommon.Hash // Hash of the block the txn is contained within (zero if dangling txn or call)
+	BlockNumber *big.Int       // Number of the block the txn is contained within (nil if dangling txn or call)
+	TxIndex     int            // Index of the transaction within a block (zero if dangling txn or call)
+	TxHash      libcommon.Hash // Hash of the transaction being traced (zero if dangling call)
 }
 
 // Tracer interface extends vm.EVMLogger and additionally
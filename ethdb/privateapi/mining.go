@@ -27,6 +27,7 @@ import (
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/gointerfaces"
 	proto_txpool "github.com/erigontech/erigon-lib/gointerfaces/txpoolproto"
 	types2 "github.com/erigontech/erigon-lib/gointerfaces/typesproto"
 	"github.com/erigontech/erigon-lib/log/v3"
@@ -38,7 +39,8 @@ import (
 
 // MiningAPIVersion
 // 2.0.0 - move all mining-related methods to 'txpool/mining' server
-var MiningAPIVersion = &types2.VersionReply{Major: 1, Minor: 0, Patch: 0}
+// 1.1.0 - add PreviewBlock
+var MiningAPIVersion = &types2.VersionReply{Major: 1, Minor: 1, Patch: 0}
 
 type MiningServer struct {
 	proto_txpool.UnimplementedMiningServer
@@ -55,6 +57,12 @@ type IsMining interface {
 	IsMining() bool
 }
 
+// BlockPreviewer builds the block the node would produce on top of its head, without sealing or announcing it.
+// It is optional, the backend passed as IsMining may implement it.
+type BlockPreviewer interface {
+	PreviewBlock(ctx context.Context, feeRecipient libcommon.Address) (*proto_txpool.PreviewBlockReply, error)
+}
+
 func NewMiningServer(ctx context.Context, isMining IsMining, ethashApi *ethash.API, logger log.Logger) *MiningServer {
 	return &MiningServer{ctx: ctx, isMining: isMining, ethash: ethashApi, logger: logger}
 }
@@ -106,6 +114,18 @@ func (s *MiningServer) Mining(_ context.Context, req *proto_txpool.MiningRequest
 	return &proto_txpool.MiningReply{Enabled: s.isMining.IsMining(), Running: true}, nil
 }
 
+func (s *MiningServer) PreviewBlock(ctx context.Context, req *proto_txpool.PreviewBlockRequest) (*proto_txpool.PreviewBlockReply, error) {
+	previewer, ok := s.isMining.(BlockPreviewer)
+	if !ok {
+		return nil, errors.New("not supported, block building is not available")
+	}
+	var feeRecipient libcommon.Address
+	if req.FeeRecipient != nil {
+		feeRecipient = gointerfaces.ConvertH160toAddress(req.FeeRecipient)
+	}
+	return previewer.PreviewBlock(ctx, feeRecipient)
+}
+
 func (s *MiningServer) OnPendingLogs(req *proto_txpool.OnPendingLogsRequest, reply proto_txpool.Mining_OnPendingLogsServer) error {
 	remove := s.pendingLogsStreams.Add(reply)
 	defer remove()
@@ -358,7 +358,7 @@ func newValidator(t *testing.T, heimdall *test_heimdall, blocks map[uint64]*type
 
 	checkStateRoot := true
 	return validator{
-		mock.MockWithEverything(t, &types.Genesis{Config: heimdall.chainConfig}, validatorKey, prune.DefaultMode, bor, 1024, false, false, checkStateRoot),
+		mock.MockWithEverything(t, &types.Genesis{Config: heimdall.chainConfig}, validatorKey, prune.DefaultMode, bor, 1024, false, false, checkStateRoot, nil),
 		heimdall,
 		blocks,
 	}
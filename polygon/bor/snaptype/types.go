@@ -226,7 +226,9 @@ var (
 
 					return nil
 				}
-			}))
+			}),
+		seg.CodecHuffman,
+	)
 
 	BorSpans = snaptype.RegisterType(
 		Enums.BorSpans,
@@ -255,6 +257,7 @@ var (
 
 				return buildValueIndex(ctx, sn, salt, d, baseSpanId, tmpDir, p, lvl, logger)
 			}),
+		seg.CodecHuffman,
 	)
 
 	BorCheckpoints = snaptype.RegisterType(
@@ -330,6 +333,7 @@ var (
 
 				return buildValueIndex(ctx, sn, salt, d, firstCheckpointId, tmpDir, p, lvl, logger)
 			}),
+		seg.CodecHuffman,
 	)
 
 	BorMilestones = snaptype.RegisterType(
@@ -405,6 +409,7 @@ var (
 
 				return buildValueIndex(ctx, sn, salt, d, firstMilestoneId, tmpDir, p, lvl, logger)
 			}),
+		seg.CodecHuffman,
 	)
 )
 
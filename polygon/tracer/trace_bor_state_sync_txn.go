@@ -18,6 +18,7 @@ package tracer
 
 import (
 	"context"
+	"math/big"
 	"time"
 
 	"github.com/holiman/uint256"
@@ -33,6 +34,7 @@ import (
 	"github.com/erigontech/erigon/core/types"
 	"github.com/erigontech/erigon/core/vm"
 	"github.com/erigontech/erigon/core/vm/evmtypes"
+	"github.com/erigontech/erigon/eth/tracers"
 	tracersConfig "github.com/erigontech/erigon/eth/tracers/config"
 	"github.com/erigontech/erigon/polygon/bor/borcfg"
 	bortypes "github.com/erigontech/erigon/polygon/bor/types"
@@ -62,7 +64,11 @@ func TraceBorStateSyncTxnDebugAPI(
 	}
 
 	txCtx := initStateSyncTxContext(blockNum, blockHash)
-	tracer, streaming, cancel, err := transactions.AssembleTracer(ctx, traceConfig, txCtx.TxHash, stream, callTimeout)
+	tracer, streaming, cancel, err := transactions.AssembleTracer(ctx, traceConfig, &tracers.Context{
+		BlockHash:   blockHash,
+		BlockNumber: new(big.Int).SetUint64(blockNum),
+		TxHash:      txCtx.TxHash,
+	}, stream, callTimeout)
 	if err != nil {
 		stream.WriteNil()
 		return err
@@ -178,6 +178,8 @@ type Account struct {
 	Balance   **hexutil.Big                      `json:"balance"`
 	State     *map[libcommon.Hash]libcommon.Hash `json:"state"`
 	StateDiff *map[libcommon.Hash]libcommon.Hash `json:"stateDiff"`
+
+	MovePrecompileTo *libcommon.Address `json:"movePrecompileToAddress"`
 }
 
 func NewRevertError(result *evmtypes.ExecutionResult) *RevertError {
@@ -27,6 +27,7 @@ import (
 
 	"github.com/erigontech/erigon/core/state"
 	"github.com/erigontech/erigon/core/tracing"
+	"github.com/erigontech/erigon/core/vm"
 )
 
 type StateOverrides map[libcommon.Address]Account
@@ -74,3 +75,27 @@ func (overrides *StateOverrides) Override(state *state.IntraBlockState) error {
 
 	return nil
 }
+
+// OverridePrecompiles moves precompiled contracts to the addresses requested by `movePrecompileToAddress`,
+// it has to be applied to the set of precompiles used by EVM before overriding the state.
+func (overrides *StateOverrides) OverridePrecompiles(precompiles vm.PrecompiledContracts) error {
+	for addr, account := range *overrides {
+		if account.MovePrecompileTo == nil {
+			continue
+		}
+		p, ok := precompiles[addr]
+		if !ok {
+			return fmt.Errorf("account %s is not a precompile", addr.Hex())
+		}
+		dst := *account.MovePrecompileTo
+		if _, ok := (*overrides)[dst]; ok {
+			return fmt.Errorf("account %s is already overridden", dst.Hex())
+		}
+		if _, ok := precompiles[dst]; ok {
+			return fmt.Errorf("account %s is already a precompile", dst.Hex())
+		}
+		delete(precompiles, addr)
+		precompiles[dst] = p
+	}
+	return nil
+}
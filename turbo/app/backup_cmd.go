@@ -64,7 +64,54 @@ TODO:
 	}),
 }
 
+var incrementalBackupCommand = cli.Command{
+	Name:  "backup",
+	Usage: "Incremental point-in-time backup of the datadir, safe to run on a running node",
+	Description: `Immutable snapshot files are copied once to <backup.dir>/objects by sha256 and referenced by the
+manifest of every backup. Only new snapshot files and the databases are copied by every run. The databases are copied
+from a read transaction which is opened before the snapshot files are listed. Files which are still being downloaded
+are skipped.
+
+Example: erigon backup --datadir=<your_datadir> --backup.dir=<backups>`,
+	Action: doIncrementalBackup,
+	Flags: joinFlags([]cli.Flag{
+		&utils.DataDirFlag,
+		&BackupDirFlag,
+		&BackupLabelsFlag,
+		&BackupHardLinkFlag,
+		&WarmupThreadsFlag,
+	}),
+}
+
+var restoreCommand = cli.Command{
+	Name:  "restore",
+	Usage: "Restore a backup made by 'erigon backup' to an empty datadir",
+	Description: `All files of the backup are checked against its manifest before anything is written to the datadir.
+
+Example: erigon restore --datadir=<new_datadir> --backup.dir=<backups> --backup.name=<name, default: latest>`,
+	Action: doRestore,
+	Flags: joinFlags([]cli.Flag{
+		&utils.DataDirFlag,
+		&BackupDirFlag,
+		&BackupNameFlag,
+	}),
+}
+
 var (
+	BackupDirFlag = flags.DirectoryFlag{
+		Name:     "backup.dir",
+		Usage:    "Directory of the incremental backups",
+		Required: true,
+	}
+	BackupHardLinkFlag = cli.BoolFlag{
+		Name: "backup.hardlink",
+		Usage: `Hard-link new snapshot files to <backup.dir>/objects instead of copying them, when it's on the same file system.
+Takes no space, but the backup doesn't survive a failure of the disk`,
+	}
+	BackupNameFlag = cli.StringFlag{
+		Name:  "backup.name",
+		Usage: "Name of the backup to restore. Empty - the latest",
+	}
 	ToDatadirFlag = flags.DirectoryFlag{
 		Name:     "to.datadir",
 		Usage:    "Target datadir",
@@ -167,3 +214,61 @@ func doBackup(cliCtx *cli.Context) error {
 
 	return nil
 }
+
+func doIncrementalBackup(cliCtx *cli.Context) error {
+	logger, _, _, err := debug.Setup(cliCtx, true /* rootLogger */)
+	if err != nil {
+		return err
+	}
+	dirs := datadir.New(cliCtx.String(utils.DataDirFlag.Name))
+
+	var labels = []kv.Label{kv.ChainDB, kv.TxPoolDB, kv.DownloaderDB}
+	if cliCtx.IsSet(BackupLabelsFlag.Name) {
+		labels = labels[:0]
+		for _, l := range common.CliString2Array(cliCtx.String(BackupLabelsFlag.Name)) {
+			labels = append(labels, kv.UnmarshalLabel(l))
+		}
+	}
+	readAheadThreads := backup.ReadAheadThreads
+	if cliCtx.IsSet(WarmupThreadsFlag.Name) {
+		readAheadThreads = int(cliCtx.Uint64(WarmupThreadsFlag.Name))
+	}
+
+	m, stats, err := backup.Incremental(cliCtx.Context, dirs, cliCtx.String(BackupDirFlag.Name), labels, readAheadThreads, cliCtx.Bool(BackupHardLinkFlag.Name), logger)
+	if err != nil {
+		return err
+	}
+	logger.Info("[backup] done", "name", m.Name, "files", stats.Files, "unchanged", stats.Reused, "linked", stats.Linked,
+		"copied", stats.Copied, "copiedSize", common.ByteCount(uint64(stats.CopiedBytes)))
+	return nil
+}
+
+func doRestore(cliCtx *cli.Context) error {
+	logger, _, _, err := debug.Setup(cliCtx, true /* rootLogger */)
+	if err != nil {
+		return err
+	}
+	dirs, l, err := datadir.New(cliCtx.String(utils.DataDirFlag.Name)).MustFlock()
+	if err != nil {
+		return err
+	}
+	defer l.Unlock()
+
+	root, name := cliCtx.String(BackupDirFlag.Name), cliCtx.String(BackupNameFlag.Name)
+	if name == "" {
+		names, err := backup.Backups(root)
+		if err != nil {
+			return err
+		}
+		if len(names) == 0 {
+			return fmt.Errorf("no backups in %s", root)
+		}
+		name = names[len(names)-1]
+	}
+	m, err := backup.Restore(cliCtx.Context, root, name, dirs, logger)
+	if err != nil {
+		return err
+	}
+	logger.Info("[restore] done", "name", m.Name, "created", m.Created, "snapshots", len(m.Snapshots), "dbs", len(m.DBs))
+	return nil
+}
@@ -0,0 +1,124 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package app
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/temporal"
+	"github.com/erigontech/erigon/cmd/hack/tool/fromdb"
+	"github.com/erigontech/erigon/cmd/utils"
+	"github.com/erigontech/erigon/eth/ethconsensusconfig"
+	"github.com/erigontech/erigon/eth/stagedsync/stages"
+	"github.com/erigontech/erigon/turbo/debug"
+	"github.com/erigontech/erigon/turbo/exporter"
+	"github.com/erigontech/erigon/turbo/snapshotsync/freezeblocks"
+)
+
+var exportCommand = cli.Command{
+	Name:  "export",
+	Usage: "Export blocks, transactions, receipts, logs, balance changes and call traces to Parquet files",
+	Action: func(cliCtx *cli.Context) error {
+		dirs, l, err := datadir.New(cliCtx.String(utils.DataDirFlag.Name)).MustFlock()
+		if err != nil {
+			return err
+		}
+		defer l.Unlock()
+		return doExport(cliCtx, dirs)
+	},
+	Flags: joinFlags([]cli.Flag{
+		&utils.DataDirFlag,
+		&SnapshotFromFlag,
+		&SnapshotToFlag,
+		&ExportToDirFlag,
+		&utils.ExportBlocksPerFileFlag,
+		&utils.ExportTablesFlag,
+	}),
+	Description: `Writes the blocks of [from, to) to <dir>/<table>/*.parquet - one file per table and partition of
+--export.blocks-per-file blocks. Only complete partitions are written, existing files are skipped: the command
+resumes an interrupted export. Receipts, logs, balance changes and traces are produced by re-executing the blocks on
+historical state, they need a node with not pruned history.
+
+Example: erigon export --datadir=<your_datadir> --to.dir=<dir> --export.tables=headers,transactions`,
+}
+
+var ExportToDirFlag = cli.StringFlag{
+	Name:     "to.dir",
+	Usage:    "Directory of the Parquet files",
+	Required: true,
+}
+
+func doExport(cliCtx *cli.Context, dirs datadir.Dirs) error {
+	logger, _, _, err := debug.Setup(cliCtx, true /* rootLogger */)
+	if err != nil {
+		return err
+	}
+	defer logger.Info("Done")
+	ctx := cliCtx.Context
+
+	tables, err := exporter.ParseTables(libcommon.CliString2Array(cliCtx.String(utils.ExportTablesFlag.Name)))
+	if err != nil {
+		return err
+	}
+
+	chainDB := dbCfg(kv.ChainDB, dirs.Chaindata).MustOpen()
+	defer chainDB.Close()
+	chainConfig := fromdb.ChainConfig(chainDB)
+
+	blockSnaps, borSnaps, _, _, agg, clean, err := openSnaps(ctx, dirs, chainDB, logger)
+	if err != nil {
+		return err
+	}
+	defer clean()
+	blockReader := freezeblocks.NewBlockReader(blockSnaps, borSnaps)
+
+	db, err := temporal.New(chainDB, agg)
+	if err != nil {
+		return err
+	}
+	tx, err := db.BeginTemporalRo(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	from, to := cliCtx.Uint64(SnapshotFromFlag.Name), cliCtx.Uint64(SnapshotToFlag.Name)
+	if to == 0 {
+		executed, err := stages.GetStageProgress(tx, stages.Execution)
+		if err != nil {
+			return err
+		}
+		to = executed + 1
+	}
+	if from >= to {
+		return fmt.Errorf("nothing to export: from %d, to %d", from, to)
+	}
+
+	engine := ethconsensusconfig.CreateConsensusEngineBareBones(ctx, chainConfig, logger)
+	cfg := exporter.Cfg{Dir: cliCtx.String(ExportToDirFlag.Name), BlocksPerFile: cliCtx.Uint64(utils.ExportBlocksPerFileFlag.Name), Tables: tables}
+	e, err := exporter.New(ctx, cfg, chainConfig, blockReader, engine, logger)
+	if err != nil {
+		return err
+	}
+	logger.Info("[export] start", "from", from, "to", to, "blocksPerFile", e.BlocksPerFile(), "tables", tables)
+	return e.Export(ctx, tx, from, to)
+}
@@ -82,6 +82,9 @@ func MakeApp(name string, action cli.ActionFunc, cliFlags []cli.Flag) *cli.App {
 		&importCommand,
 		&snapshotCommand,
 		&supportCommand,
+		&exportCommand,
+		&incrementalBackupCommand,
+		&restoreCommand,
 		//&backupCommand,
 	}
 	return app
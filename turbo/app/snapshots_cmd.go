@@ -555,6 +555,10 @@ func doIntegrity(cliCtx *cli.Context) error {
 			if err := integrity.NoGapsInBorEvents(ctx, chainDB, blockReader, 0, 0, failFast); err != nil {
 				return err
 			}
+		case integrity.Accessors:
+			if err := integrity.CheckAccessors(ctx, dirs, failFast); err != nil {
+				return err
+			}
 
 		default:
 			return fmt.Errorf("unknown check: %s", chk)
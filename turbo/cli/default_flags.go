@@ -35,6 +35,7 @@ var DefaultFlags = []cli.Flag{
 	&utils.TxPoolBlobPriceBumpFlag,
 	&utils.TxPoolAccountSlotsFlag,
 	&utils.TxPoolBlobSlotsFlag,
+	&utils.TxPoolDelegatedSlotsFlag,
 	&utils.TxPoolTotalBlobPoolLimit,
 	&utils.TxPoolGlobalSlotsFlag,
 	&utils.TxPoolGlobalBaseFeeSlotsFlag,
@@ -43,6 +44,12 @@ var DefaultFlags = []cli.Flag{
 	&utils.TxPoolLifetimeFlag,
 	&utils.TxPoolTraceSendersFlag,
 	&utils.TxPoolCommitEveryFlag,
+	&utils.TxPoolJournalFlag,
+	&utils.TxPoolRejournalFlag,
+	&utils.TxPoolAllowListFlag,
+	&utils.TxPoolDenyListFlag,
+	&utils.TxPoolOrderingFlag,
+	&utils.TxPoolPrioritySendersFlag,
 	&PruneDistanceFlag,
 	&PruneBlocksDistanceFlag,
 	&PruneModeFlag,
@@ -111,6 +118,8 @@ var DefaultFlags = []cli.Flag{
 	&utils.SnapKeepBlocksFlag,
 	&utils.SnapStopFlag,
 	&utils.SnapStateStopFlag,
+	&utils.SnapScrubIntervalFlag,
+	&utils.SnapScrubRateFlag,
 	&utils.DbPageSizeFlag,
 	&utils.DbSizeLimitFlag,
 	&utils.DbWriteMapFlag,
@@ -177,6 +186,11 @@ var DefaultFlags = []cli.Flag{
 	&utils.PolygonSyncStageFlag,
 	&utils.EthStatsURLFlag,
 	&utils.OverridePragueFlag,
+	&utils.VMTraceFlag,
+	&utils.VMTraceJsonConfigFlag,
+	&utils.ExportDirFlag,
+	&utils.ExportBlocksPerFileFlag,
+	&utils.ExportTablesFlag,
 
 	&utils.CaplinDiscoveryAddrFlag,
 	&utils.CaplinDiscoveryPortFlag,
@@ -218,6 +232,8 @@ var DefaultFlags = []cli.Flag{
 	&utils.CaplinArchiveFlag,
 	&utils.CaplinEnableSnapshotGeneration,
 	&utils.CaplinMevRelayUrl,
+	&utils.CaplinMevRelayTimeoutFlag,
+	&utils.CaplinMevBuilderBoostFactorFlag,
 	&utils.CaplinValidatorMonitorFlag,
 	&utils.CaplinCustomConfigFlag,
 	&utils.CaplinCustomGenesisFlag,
@@ -32,6 +32,7 @@ import (
 	"github.com/erigontech/erigon-lib/gointerfaces"
 	execution "github.com/erigontech/erigon-lib/gointerfaces/executionproto"
 	txpool "github.com/erigontech/erigon-lib/gointerfaces/txpoolproto"
+	"github.com/erigontech/erigon-lib/gointerfaces/typesproto"
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/kv/kvcache"
 	"github.com/erigontech/erigon-lib/log/v3"
@@ -43,6 +44,7 @@ import (
 	"github.com/erigontech/erigon/common/math"
 	"github.com/erigontech/erigon/consensus"
 	"github.com/erigontech/erigon/consensus/merge"
+	"github.com/erigontech/erigon/core/rawdb"
 	"github.com/erigontech/erigon/core/types"
 	"github.com/erigontech/erigon/eth/ethutils"
 	"github.com/erigontech/erigon/rpc"
@@ -68,6 +70,8 @@ type EngineServer struct {
 	test             bool
 	caplin           bool // we need to send errors for caplin.
 	executionService execution.ExecutionClient
+	txpool           txpool.TxpoolClient // blobs are looked up in the pool, set on Start
+	db               kv.RwDB             // payloads rejected before reaching the execution module are stored as bad blocks
 
 	chainRW eth1_chain_reader.ChainReaderWriterEth1
 	lock    sync.Mutex
@@ -76,7 +80,7 @@ type EngineServer struct {
 
 const fcuTimeout = 1000 // according to mathematics: 1000 millisecods = 1 second
 
-func NewEngineServer(logger log.Logger, config *chain.Config, executionService execution.ExecutionClient,
+func NewEngineServer(logger log.Logger, config *chain.Config, executionService execution.ExecutionClient, db kv.RwDB,
 	hd *headerdownload.HeaderDownload,
 	blockDownloader *engine_block_downloader.EngineBlockDownloader, caplin, test, proposing bool) *EngineServer {
 	chainRW := eth1_chain_reader.NewChainReaderEth1(config, executionService, fcuTimeout)
@@ -84,6 +88,7 @@ func NewEngineServer(logger log.Logger, config *chain.Config, executionService e
 		logger:           logger,
 		config:           config,
 		executionService: executionService,
+		db:               db,
 		blockDownloader:  blockDownloader,
 		chainRW:          chainRW,
 		proposing:        proposing,
@@ -104,6 +109,8 @@ func (e *EngineServer) Start(
 	txPool txpool.TxpoolClient,
 	mining txpool.MiningClient,
 ) {
+	e.txpool = txPool
+
 	base := jsonrpc.NewBaseApi(filters, stateCache, blockReader, httpConfig.WithDatadir, httpConfig.EvmCallTimeout, engineReader, httpConfig.Dirs, nil)
 
 	ethImpl := jsonrpc.NewEthAPI(base, db, eth, txPool, mining, httpConfig.Gascap, httpConfig.Feecap, httpConfig.ReturnDataLimit, httpConfig.AllowUnprotectedTxs, httpConfig.MaxGetProofRewindBlockCount, httpConfig.WebsocketSubscribeLogsChannelSize, e.logger)
@@ -152,6 +159,19 @@ func (s *EngineServer) checkRequestsPresence(time uint64, payload *engine_types.
 	return nil
 }
 
+// reportBadBlock stores a payload rejected before it reached the execution module, which stores the blocks it rejects
+// itself, so that debug_getBadBlocks lists it too
+func (s *EngineServer) reportBadBlock(ctx context.Context, block *types.Block, reason string) {
+	if s.db == nil {
+		return
+	}
+	if err := s.db.Update(ctx, func(tx kv.RwTx) error {
+		return rawdb.WriteBadBlock(tx, block, reason)
+	}); err != nil {
+		s.logger.Warn("[NewPayload] failed to store bad block", "hash", block.Hash(), "err", err)
+	}
+}
+
 // EngineNewPayload validates and possibly executes payload
 func (s *EngineServer) newPayload(ctx context.Context, req *engine_types.ExecutionPayload,
 	expectedBlobHashes []libcommon.Hash, parentBeaconBlockRoot *libcommon.Hash, version clparams.StateVersion,
@@ -244,6 +264,9 @@ func (s *EngineServer) newPayload(ctx context.Context, req *engine_types.Executi
 	blockHash := req.BlockHash
 	if header.Hash() != blockHash {
 		s.logger.Error("[NewPayload] invalid block hash", "stated", blockHash, "actual", header.Hash())
+		// the transactions are not checked yet, the block is kept without them if they don't decode
+		transactions, _ := types.DecodeTransactions(txs)
+		s.reportBadBlock(ctx, types.NewBlockFromStorage(blockHash, &header, transactions, nil /* uncles */, withdrawals, requests), "invalid block hash")
 		return &engine_types.PayloadStatus{
 			Status:          engine_types.InvalidStatus,
 			ValidationError: engine_types.NewStringifiedErrorFromString("invalid block hash"),
@@ -269,6 +292,8 @@ func (s *EngineServer) newPayload(ctx context.Context, req *engine_types.Executi
 		}, nil
 	}
 
+	block := types.NewBlockFromStorage(blockHash, &header, transactions, nil /* uncles */, withdrawals, requests)
+
 	if version >= clparams.DenebVersion {
 		err := ethutils.ValidateBlobs(req.BlobGasUsed.Uint64(), s.config.GetMaxBlobGasPerBlock(), s.config.GetMaxBlobsPerBlock(), expectedBlobHashes, &transactions)
 		if errors.Is(err, ethutils.ErrNilBlobHashes) {
@@ -279,6 +304,7 @@ func (s *EngineServer) newPayload(ctx context.Context, req *engine_types.Executi
 			if !bad {
 				latestValidHash = req.ParentHash
 			}
+			s.reportBadBlock(ctx, block, "blobs/blobgas exceeds max")
 			return &engine_types.PayloadStatus{
 				Status:          engine_types.InvalidStatus,
 				ValidationError: engine_types.NewStringifiedErrorFromString("blobs/blobgas exceeds max"),
@@ -286,6 +312,7 @@ func (s *EngineServer) newPayload(ctx context.Context, req *engine_types.Executi
 			}, nil
 		}
 		if errors.Is(err, ethutils.ErrMismatchBlobHashes) || errors.Is(err, ethutils.ErrInvalidVersiondHash) {
+			s.reportBadBlock(ctx, block, err.Error())
 			return &engine_types.PayloadStatus{
 				Status:          engine_types.InvalidStatus,
 				ValidationError: engine_types.NewStringifiedErrorFromString(err.Error()),
@@ -298,6 +325,10 @@ func (s *EngineServer) newPayload(ctx context.Context, req *engine_types.Executi
 		return nil, err
 	}
 	if possibleStatus != nil {
+		// e.g. a descendant of a known bad block
+		if possibleStatus.Status == engine_types.InvalidStatus && possibleStatus.ValidationError != nil {
+			s.reportBadBlock(ctx, block, possibleStatus.ValidationError.Error().Error())
+		}
 		return possibleStatus, nil
 	}
 
@@ -305,7 +336,6 @@ func (s *EngineServer) newPayload(ctx context.Context, req *engine_types.Executi
 	defer s.lock.Unlock()
 
 	s.logger.Debug("[NewPayload] sending block", "height", header.Number, "hash", blockHash)
-	block := types.NewBlockFromStorage(blockHash, &header, transactions, nil /* uncles */, withdrawals, requests)
 
 	payloadStatus, err := s.HandleNewPayload(ctx, "NewPayload", block, expectedBlobHashes)
 	if err != nil {
@@ -784,6 +814,36 @@ func (e *EngineServer) GetPayloadBodiesByRangeV2(ctx context.Context, start, cou
 	return e.getPayloadBodiesByRange(ctx, uint64(start), uint64(count), clparams.ElectraVersion)
 }
 
+// Returns blobs (and their proofs) of pooled blob transactions by versioned hashes, null for blobs missing in the pool
+// See https://github.com/ethereum/execution-apis/blob/main/src/engine/cancun.md#engine_getblobsv1
+func (e *EngineServer) GetBlobsV1(ctx context.Context, blobHashes []libcommon.Hash) ([]*engine_types.BlobAndProofV1, error) {
+	if len(blobHashes) > 128 {
+		return nil, &engine_helpers.TooLargeRequestErr
+	}
+	if e.txpool == nil {
+		return nil, errors.New("txpool is not available")
+	}
+	req := &txpool.GetBlobsRequest{BlobHashes: make([]*typesproto.H256, len(blobHashes))}
+	for i := range blobHashes {
+		req.BlobHashes[i] = gointerfaces.ConvertHashToH256(blobHashes[i])
+	}
+	reply, err := e.txpool.GetBlobs(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if len(reply.BlobsAndProofs) != len(blobHashes) {
+		return nil, fmt.Errorf("txpool returned %d blobs, requested %d", len(reply.BlobsAndProofs), len(blobHashes))
+	}
+	res := make([]*engine_types.BlobAndProofV1, len(blobHashes))
+	for i, blobAndProof := range reply.BlobsAndProofs {
+		if len(blobAndProof.Blob) == 0 {
+			continue
+		}
+		res[i] = &engine_types.BlobAndProofV1{Blob: blobAndProof.Blob, Proof: blobAndProof.Proof}
+	}
+	return res, nil
+}
+
 var ourCapabilities = []string{
 	"engine_forkchoiceUpdatedV1",
 	"engine_forkchoiceUpdatedV2",
@@ -801,6 +861,7 @@ var ourCapabilities = []string{
 	"engine_getPayloadBodiesByHashV2",
 	"engine_getPayloadBodiesByRangeV1",
 	"engine_getPayloadBodiesByRangeV2",
+	"engine_getBlobsV1",
 }
 
 func (e *EngineServer) ExchangeCapabilities(fromCl []string) []string {
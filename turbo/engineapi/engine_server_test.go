@@ -0,0 +1,169 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package engineapi
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/erigontech/erigon-lib/chain"
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/common/hexutility"
+	"github.com/erigontech/erigon-lib/gointerfaces"
+	txpool "github.com/erigontech/erigon-lib/gointerfaces/txpoolproto"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/memdb"
+	"github.com/erigontech/erigon-lib/log/v3"
+
+	"github.com/erigontech/erigon/consensus/merge"
+	"github.com/erigontech/erigon/core/rawdb"
+	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/turbo/engineapi/engine_helpers"
+	"github.com/erigontech/erigon/turbo/engineapi/engine_types"
+)
+
+// blobsTxPool serves GetBlobs out of a fixed set of blobs, the rest of the txpool API is not used
+type blobsTxPool struct {
+	txpool.TxpoolClient
+	blobs    map[libcommon.Hash]*txpool.GetBlobsReply_BlobAndProof
+	requests int
+	truncate bool
+}
+
+func (p *blobsTxPool) GetBlobs(_ context.Context, in *txpool.GetBlobsRequest, _ ...grpc.CallOption) (*txpool.GetBlobsReply, error) {
+	p.requests++
+	reply := &txpool.GetBlobsReply{}
+	for _, h := range in.BlobHashes {
+		blobAndProof, ok := p.blobs[gointerfaces.ConvertH256ToHash(h)]
+		if !ok {
+			blobAndProof = &txpool.GetBlobsReply_BlobAndProof{}
+		}
+		reply.BlobsAndProofs = append(reply.BlobsAndProofs, blobAndProof)
+	}
+	if p.truncate {
+		reply.BlobsAndProofs = reply.BlobsAndProofs[:len(reply.BlobsAndProofs)-1]
+	}
+	return reply, nil
+}
+
+func TestGetBlobsV1(t *testing.T) {
+	ctx := context.Background()
+	known1, known2, unknown := libcommon.Hash{1}, libcommon.Hash{2}, libcommon.Hash{3}
+	pool := &blobsTxPool{blobs: map[libcommon.Hash]*txpool.GetBlobsReply_BlobAndProof{
+		known1: {Blob: []byte{1, 1}, Commitment: []byte{1, 2}, Proof: []byte{1, 3}},
+		known2: {Blob: []byte{2, 1}, Commitment: []byte{2, 2}, Proof: []byte{2, 3}},
+	}}
+	e := NewEngineServer(log.New(), nil, nil, nil, nil, nil, false, false, false)
+
+	_, err := e.GetBlobsV1(ctx, []libcommon.Hash{known1})
+	require.ErrorContains(t, err, "txpool is not available")
+
+	e.txpool = pool
+	blobs, err := e.GetBlobsV1(ctx, []libcommon.Hash{known2, unknown, known1})
+	require.NoError(t, err)
+	require.Equal(t, []*engine_types.BlobAndProofV1{
+		{Blob: []byte{2, 1}, Proof: []byte{2, 3}},
+		nil,
+		{Blob: []byte{1, 1}, Proof: []byte{1, 3}},
+	}, blobs)
+
+	blobs, err = e.GetBlobsV1(ctx, nil)
+	require.NoError(t, err)
+	require.Empty(t, blobs)
+
+	// requests over the limit are refused without asking the pool
+	requests := pool.requests
+	_, err = e.GetBlobsV1(ctx, make([]libcommon.Hash, 129))
+	require.Equal(t, &engine_helpers.TooLargeRequestErr, err)
+	require.Equal(t, requests, pool.requests)
+	_, err = e.GetBlobsV1(ctx, make([]libcommon.Hash, 128))
+	require.NoError(t, err)
+
+	pool.truncate = true
+	_, err = e.GetBlobsV1(ctx, []libcommon.Hash{known1, known2})
+	require.ErrorContains(t, err, "txpool returned 1 blobs, requested 2")
+}
+
+func TestNewPayloadStoresEarlyRejectedBlocks(t *testing.T) {
+	ctx := context.Background()
+	db := memdb.NewTestDB(t)
+	config := &chain.Config{ChainID: big.NewInt(1), TerminalTotalDifficulty: big.NewInt(0), ShanghaiTime: big.NewInt(0), CancunTime: big.NewInt(0)}
+	e := NewEngineServer(log.New(), config, nil, db, nil, nil, false, false, false)
+
+	var blobGasUsed, excessBlobGas hexutil.Uint64
+	parentBeaconBlockRoot := libcommon.Hash{0xbe}
+	payload := &engine_types.ExecutionPayload{
+		ParentHash:    libcommon.Hash{1},
+		LogsBloom:     make([]byte, types.BloomByteLength),
+		BlockNumber:   10,
+		GasLimit:      30_000_000,
+		Timestamp:     1000,
+		BaseFeePerGas: (*hexutil.Big)(big.NewInt(7)),
+		Transactions:  []hexutility.Bytes{},
+		Withdrawals:   []*types.Withdrawal{},
+		BlobGasUsed:   &blobGasUsed,
+		ExcessBlobGas: &excessBlobGas,
+	}
+	header := &types.Header{
+		ParentHash:            payload.ParentHash,
+		UncleHash:             types.EmptyUncleHash,
+		TxHash:                types.EmptyRootHash,
+		ReceiptHash:           payload.ReceiptsRoot,
+		Difficulty:            merge.ProofOfStakeDifficulty,
+		Nonce:                 merge.ProofOfStakeNonce,
+		Number:                big.NewInt(10),
+		GasLimit:              30_000_000,
+		Time:                  1000,
+		Extra:                 []byte{},
+		BaseFee:               big.NewInt(7),
+		WithdrawalsHash:       &types.EmptyRootHash,
+		BlobGasUsed:           (*uint64)(&blobGasUsed),
+		ExcessBlobGas:         (*uint64)(&excessBlobGas),
+		ParentBeaconBlockRoot: &parentBeaconBlockRoot,
+	}
+	badBlocks := func() []*rawdb.BadBlock {
+		var badBlocks []*rawdb.BadBlock
+		require.NoError(t, db.View(ctx, func(tx kv.Tx) (err error) {
+			badBlocks, err = rawdb.ReadAllBadBlocks(tx)
+			return err
+		}))
+		return badBlocks
+	}
+
+	// the stated block hash is not the hash of the payload
+	payload.BlockHash = libcommon.Hash{2}
+	status, err := e.NewPayloadV3(ctx, payload, nil, &parentBeaconBlockRoot)
+	require.NoError(t, err)
+	require.Equal(t, engine_types.InvalidStatus, status.Status)
+	require.Len(t, badBlocks(), 1)
+	require.Equal(t, "invalid block hash", badBlocks()[0].Reason)
+	require.Equal(t, header.Hash(), badBlocks()[0].Block.Header().Hash())
+
+	// the payload commits to blobs the consensus layer doesn't expect
+	payload.BlockHash = header.Hash()
+	status, err = e.NewPayloadV3(ctx, payload, []libcommon.Hash{{3}}, &parentBeaconBlockRoot)
+	require.NoError(t, err)
+	require.Equal(t, engine_types.InvalidStatus, status.Status)
+	require.Len(t, badBlocks(), 2)
+	require.Equal(t, header.Hash(), badBlocks()[0].Block.Hash())
+	require.Contains(t, badBlocks()[0].Reason, "blob")
+}
@@ -306,6 +306,15 @@ func (e *EthereumExecutionModule) ValidateChain(ctx context.Context, req *execut
 	if isInvalidChain {
 		e.logger.Warn("ethereumExecutionModule.ValidateChain: chain is invalid", "hash", libcommon.Hash(blockHash))
 		validationStatus = execution.ExecutionStatus_BadBlock
+
+		reason := "invalid chain"
+		if validationError != nil {
+			reason = validationError.Error()
+		}
+		badBlock := types.NewBlockFromStorage(blockHash, header, body.Transactions, body.Uncles, body.Withdrawals, body.Requests)
+		if err := rawdb.WriteBadBlock(tx, badBlock, reason); err != nil {
+			return nil, err
+		}
 	}
 	validationReceipt := &execution.ValidationReceipt{
 		ValidationStatus: validationStatus,
@@ -0,0 +1,434 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+// Package exporter writes blocks, transactions, receipts, logs, balance changes and call traces to Parquet files
+// for analytics. Every table is partitioned by block ranges aligned like the block snapshots, a partition file is
+// written once and is never changed - an interrupted export resumes from the first missing partition.
+package exporter
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/erigontech/erigon-lib/chain"
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/downloader/snaptype"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/rawdbv3"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/parquet"
+	"github.com/erigontech/erigon/cmd/state/exec3"
+	"github.com/erigontech/erigon/consensus"
+	"github.com/erigontech/erigon/core/rawdb/rawtemporaldb"
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/core/tracing"
+	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/turbo/services"
+	"github.com/erigontech/erigon/turbo/snapshotsync/freezeblocks"
+)
+
+// DefaultBlocksPerFile - size of the partitions, same as the size of the merged block snapshots of the recent blocks
+const DefaultBlocksPerFile = 10_000
+
+// blocksUnit - partition sizes are multiples of it, file names count blocks in these units like the block snapshots
+var blocksUnit uint64 = snaptype.Erigon2MinSegmentSize
+
+const logInterval = 30 * time.Second
+
+type Cfg struct {
+	Dir           string // the files of a table are in the sub-directory of the table name, export is disabled if empty
+	BlocksPerFile uint64
+	Tables        []Table // all tables if empty
+}
+
+func (cfg Cfg) Enabled() bool { return cfg.Dir != "" }
+
+type Exporter struct {
+	cfg          Cfg
+	chainConfig  *chain.Config
+	blockReader  services.FullBlockReader
+	engine       consensus.EngineReader
+	txNumsReader rawdbv3.TxNumsReader
+	logger       log.Logger
+}
+
+func New(ctx context.Context, cfg Cfg, chainConfig *chain.Config, blockReader services.FullBlockReader, engine consensus.EngineReader, logger log.Logger) (*Exporter, error) {
+	if cfg.BlocksPerFile == 0 {
+		cfg.BlocksPerFile = DefaultBlocksPerFile
+	}
+	if cfg.BlocksPerFile%blocksUnit != 0 || snaptype.Erigon2MergeLimit%cfg.BlocksPerFile != 0 {
+		return nil, fmt.Errorf("blocks per file must be a multiple of %d and a divisor of %d, got %d", blocksUnit, snaptype.Erigon2MergeLimit, cfg.BlocksPerFile)
+	}
+	if len(cfg.Tables) == 0 {
+		cfg.Tables = AllTables
+	}
+	for _, t := range cfg.Tables {
+		if err := os.MkdirAll(filepath.Join(cfg.Dir, string(t)), 0755); err != nil {
+			return nil, err
+		}
+	}
+	return &Exporter{
+		cfg:          cfg,
+		chainConfig:  chainConfig,
+		blockReader:  blockReader,
+		engine:       engine,
+		txNumsReader: rawdbv3.TxNums.WithCustomReadTxNumFunc(freezeblocks.ReadTxNumFuncFromBlockReader(ctx, blockReader)),
+		logger:       logger,
+	}, nil
+}
+
+func (e *Exporter) BlocksPerFile() uint64 { return e.cfg.BlocksPerFile }
+
+func fileName(t Table, from, to uint64) string {
+	return fmt.Sprintf("v%d-%06d-%06d-%s.parquet", SchemaVersion, from/blocksUnit, to/blocksUnit, t)
+}
+
+// parseFileName returns the block range of a partition file of any schema version
+func parseFileName(t Table, name string) (from, to uint64, ok bool) {
+	var version int
+	var fromUnits, toUnits uint64
+	var table string
+	if _, err := fmt.Sscanf(name, "v%d-%06d-%06d-%s", &version, &fromUnits, &toUnits, &table); err != nil {
+		return 0, 0, false
+	}
+	if table != string(t)+".parquet" {
+		return 0, 0, false
+	}
+	return fromUnits * blocksUnit, toUnits * blocksUnit, true
+}
+
+func (e *Exporter) path(t Table, from, to uint64) string {
+	return filepath.Join(e.cfg.Dir, string(t), fileName(t, from, to))
+}
+
+// Export writes the partitions of the blocks in [fromBlock, toBlock): the partition of fromBlock is included, the
+// partition of toBlock is not - it isn't complete. Partitions which are exported already are skipped. The blocks
+// are re-executed on historical state for receipts, logs, balance changes and traces, so the state history of
+// the blocks must not be pruned.
+func (e *Exporter) Export(ctx context.Context, tx kv.TemporalTx, fromBlock, toBlock uint64) error {
+	logEvery := time.NewTicker(logInterval)
+	defer logEvery.Stop()
+	n := e.cfg.BlocksPerFile
+	for from := fromBlock - fromBlock%n; from+n <= toBlock; from += n {
+		missing, err := e.missingTables(from, from+n)
+		if err != nil {
+			return err
+		}
+		if len(missing) == 0 {
+			continue
+		}
+		started := time.Now()
+		if err := e.exportPartition(ctx, tx, from, from+n, missing, logEvery); err != nil {
+			return fmt.Errorf("export blocks %d-%d: %w", from, from+n, err)
+		}
+		e.logger.Info("[export] partition done", "blocks", fmt.Sprintf("%d-%d", from, from+n), "tables", missing, "took", time.Since(started).Round(time.Second))
+	}
+	return nil
+}
+
+// missingTables returns the tables which don't have a complete file of the partition
+func (e *Exporter) missingTables(from, to uint64) (missing []Table, err error) {
+	for _, t := range e.cfg.Tables {
+		f, err := parquet.Open(e.path(t, from, to))
+		if err != nil {
+			if !errors.Is(err, os.ErrNotExist) {
+				e.logger.Warn("[export] partition file is broken, exporting it again", "err", err)
+			}
+			missing = append(missing, t)
+			continue
+		}
+		if err := f.Close(); err != nil {
+			return nil, err
+		}
+	}
+	return missing, nil
+}
+
+// Unwind removes the partitions which have blocks after unwindPoint
+func (e *Exporter) Unwind(unwindPoint uint64) error {
+	for _, t := range AllTables {
+		entries, err := os.ReadDir(filepath.Join(e.cfg.Dir, string(t)))
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return err
+		}
+		for _, entry := range entries {
+			if _, to, ok := parseFileName(t, entry.Name()); ok && to > unwindPoint+1 {
+				if err := os.Remove(filepath.Join(e.cfg.Dir, string(t), entry.Name())); err != nil {
+					return err
+				}
+				e.logger.Info("[export] removed partition", "file", entry.Name())
+			}
+		}
+	}
+	return nil
+}
+
+// partitionFile is written to a temporary file which is renamed when complete
+type partitionFile struct {
+	path string
+	f    *os.File
+	buf  *bufio.Writer
+	w    *parquet.Writer
+}
+
+func (e *Exporter) createPartitionFile(t Table, from, to uint64) (*partitionFile, error) {
+	path := e.path(t, from, to)
+	f, err := os.Create(path + ".tmp")
+	if err != nil {
+		return nil, err
+	}
+	pf := &partitionFile{path: path, f: f, buf: bufio.NewWriterSize(f, 1024*1024)}
+	if pf.w, err = parquet.NewWriter(pf.buf, schemas[t]); err != nil {
+		pf.abort()
+		return nil, err
+	}
+	pf.w.SetMeta("erigon.schema_version", strconv.Itoa(SchemaVersion))
+	pf.w.SetMeta("erigon.table", string(t))
+	pf.w.SetMeta("erigon.chain", e.chainConfig.ChainName)
+	pf.w.SetMeta("erigon.from_block", strconv.FormatUint(from, 10))
+	pf.w.SetMeta("erigon.to_block", strconv.FormatUint(to, 10)) // exclusive
+	return pf, nil
+}
+
+func (pf *partitionFile) commit() error {
+	if err := pf.w.Close(); err != nil {
+		return err
+	}
+	if err := pf.buf.Flush(); err != nil {
+		return err
+	}
+	if err := pf.f.Sync(); err != nil {
+		return err
+	}
+	if err := pf.f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(pf.path+".tmp", pf.path)
+}
+
+func (pf *partitionFile) abort() {
+	pf.f.Close()
+	os.Remove(pf.path + ".tmp")
+}
+
+func (e *Exporter) exportPartition(ctx context.Context, tx kv.TemporalTx, from, to uint64, tables []Table, logEvery *time.Ticker) (err error) {
+	files := map[Table]*partitionFile{}
+	defer func() {
+		if err != nil {
+			for _, pf := range files {
+				pf.abort()
+			}
+		}
+	}()
+	var needsExecution bool
+	for _, t := range tables {
+		if files[t], err = e.createPartitionFile(t, from, to); err != nil {
+			return err
+		}
+		needsExecution = needsExecution || t.needsExecution()
+	}
+
+	var worker *exec3.TraceWorker
+	tracer := &txTracer{}
+	if needsExecution {
+		var genericTracer exec3.GenericTracer
+		if files[Traces] != nil || files[BalanceChanges] != nil {
+			genericTracer = tracer
+		}
+		worker = exec3.NewTraceWorker(tx, e.chainConfig, e.engine, e.blockReader, genericTracer)
+		defer worker.Close()
+		if files[BalanceChanges] != nil {
+			worker.SetStateHooks(tracer.hooks())
+		}
+	}
+
+	for blockNum := from; blockNum < to; blockNum++ {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-logEvery.C:
+			e.logger.Info("[export] exporting", "block", blockNum, "partition", fmt.Sprintf("%d-%d", from, to))
+		default:
+		}
+		if err := e.exportBlock(ctx, tx, blockNum, files, worker, tracer); err != nil {
+			return err
+		}
+	}
+
+	for t, pf := range files {
+		if err := pf.commit(); err != nil {
+			return err
+		}
+		delete(files, t)
+	}
+	return nil
+}
+
+func (e *Exporter) exportBlock(ctx context.Context, tx kv.TemporalTx, blockNum uint64, files map[Table]*partitionFile, worker *exec3.TraceWorker, tracer *txTracer) error {
+	write := func(t Table, row ...any) error {
+		if pf := files[t]; pf != nil {
+			return pf.w.Write(row...)
+		}
+		return nil
+	}
+
+	hash, ok, err := e.blockReader.CanonicalHash(ctx, tx, blockNum)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("block %d not found", blockNum)
+	}
+	block, senders, err := e.blockReader.BlockWithSenders(ctx, tx, hash, blockNum)
+	if err != nil {
+		return err
+	}
+	if block == nil {
+		return fmt.Errorf("block %d not found", blockNum)
+	}
+	header, txs := block.HeaderNoCopy(), block.Transactions()
+	if err := write(Headers, headerRow(header, len(txs), len(block.Uncles()), len(block.Withdrawals()))...); err != nil {
+		return err
+	}
+	for i, txn := range txs {
+		if _, ok := txn.GetSender(); !ok && i < len(senders) {
+			txn.SetSender(senders[i])
+		}
+		sender, _ := txn.GetSender()
+		if err := write(Transactions, transactionRow(blockNum, i, txn, sender)...); err != nil {
+			return err
+		}
+	}
+	if worker == nil {
+		return nil
+	}
+
+	worker.ChangeBlock(header)
+	minTxNum, err := e.txNumsReader.Min(tx, blockNum)
+	if err != nil {
+		return err
+	}
+	for i, txn := range txs {
+		txNum := minTxNum + 1 + uint64(i) // the first txNum of a block is the system txn of the block start
+		tracer.reset()
+		res, err := worker.ExecTxn(txNum, i, txn, false)
+		if err != nil {
+			return err
+		}
+		r, err := rawtemporaldb.ReceiptAsOfWithApply(tx, txNum, worker.GetRawLogs(i), i, hash, blockNum, txn)
+		if err != nil {
+			return err
+		}
+		if err := write(Receipts, receiptRow(blockNum, i, txn, r, res.UsedGas, res.Failed(), header.BaseFee)...); err != nil {
+			return err
+		}
+		for _, l := range r.Logs {
+			if err := write(Logs, logRow(blockNum, l)...); err != nil {
+				return err
+			}
+		}
+		txHash := hashValue(txn.Hash())
+		for _, c := range tracer.changes {
+			if err := write(BalanceChanges, blockNum, uint64(i), txHash, c.addr.Bytes(), uint256Value(&c.prev), uint256Value(&c.new), c.reason.String()); err != nil {
+				return err
+			}
+		}
+		for j, f := range tracer.frames {
+			var parent, value, errStr any
+			if f.parent >= 0 {
+				parent = uint64(f.parent)
+			}
+			if f.value != nil {
+				value = uint256Value(f.value)
+			}
+			if f.err != "" {
+				errStr = f.err
+			}
+			if err := write(Traces, blockNum, uint64(i), txHash, uint64(j), parent, uint64(f.depth), f.typ, f.from.Bytes(), f.to.Bytes(),
+				value, f.gas, f.gasUsed, f.input, f.output, errStr); err != nil {
+				return err
+			}
+		}
+	}
+	if files[BalanceChanges] != nil && blockNum > 0 {
+		return e.exportBlockEndBalances(tx, blockNum, block, write)
+	}
+	return nil
+}
+
+// exportBlockEndBalances writes the balance changes of the system txn at the end of the block: rewards and
+// withdrawals. They are read from the state history - the txn isn't re-executed.
+func (e *Exporter) exportBlockEndBalances(tx kv.TemporalTx, blockNum uint64, block *types.Block, write func(t Table, row ...any) error) error {
+	var addrs []libcommon.Address
+	reasons := map[libcommon.Address]tracing.BalanceChangeReason{}
+	add := func(addr libcommon.Address, reason tracing.BalanceChangeReason) {
+		if prev, ok := reasons[addr]; !ok {
+			addrs = append(addrs, addr)
+		} else if prev != reason {
+			reason = tracing.BalanceChangeUnspecified
+		}
+		reasons[addr] = reason
+	}
+	add(block.Coinbase(), tracing.BalanceIncreaseRewardMineBlock)
+	for _, uncle := range block.Uncles() {
+		add(uncle.Coinbase, tracing.BalanceIncreaseRewardMineUncle)
+	}
+	for _, w := range block.Withdrawals() {
+		add(w.Address, tracing.BalanceIncreaseWithdrawal)
+	}
+
+	maxTxNum, err := e.txNumsReader.Max(tx, blockNum)
+	if err != nil {
+		return err
+	}
+	reader := state.NewHistoryReaderV3()
+	reader.SetTx(tx)
+	for _, addr := range addrs {
+		reader.SetTxNum(maxTxNum)
+		before, err := reader.ReadAccountData(addr)
+		if err != nil {
+			return err
+		}
+		reader.SetTxNum(maxTxNum + 1)
+		after, err := reader.ReadAccountData(addr)
+		if err != nil {
+			return err
+		}
+		var prev, balance []byte = uint256Value(nil), uint256Value(nil)
+		if before != nil {
+			prev = uint256Value(&before.Balance)
+		}
+		if after != nil {
+			balance = uint256Value(&after.Balance)
+		}
+		if string(prev) == string(balance) {
+			continue
+		}
+		if err := write(BalanceChanges, blockNum, nil, nil, addr.Bytes(), prev, balance, reasons[addr].String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,218 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package exporter_test
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/parquet"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/core/tracing"
+	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/crypto"
+	"github.com/erigontech/erigon/params"
+	"github.com/erigontech/erigon/turbo/exporter"
+	"github.com/erigontech/erigon/turbo/stages/mock"
+)
+
+func readRows(t *testing.T, path string) [][]any {
+	t.Helper()
+	f, err := parquet.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	rows, err := f.Rows()
+	require.NoError(t, err)
+	return rows
+}
+
+func TestExport(t *testing.T) {
+	defer func(unit uint64) { *exporter.BlocksUnit = unit }(*exporter.BlocksUnit)
+	*exporter.BlocksUnit = 1
+
+	key, _ := crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+	receiver, reverter, coinbase := libcommon.Address{2}, libcommon.Address{3}, libcommon.Address{1}
+	gspec := &types.Genesis{
+		Config: params.TestChainConfig,
+		Alloc: types.GenesisAlloc{
+			sender:   {Balance: big.NewInt(params.Ether)},
+			reverter: {Balance: new(big.Int), Code: []byte{0x60, 0x00, 0x60, 0x00, 0xfd}}, // PUSH1 0 PUSH1 0 REVERT
+		},
+	}
+	m := mock.MockWithGenesis(t, gspec, key, false)
+	signer := types.LatestSignerForChainID(m.ChainConfig.ChainID)
+	chain, err := core.GenerateChain(m.ChainConfig, m.Genesis, m.Engine, m.DB, 9, func(i int, gen *core.BlockGen) {
+		gen.SetCoinbase(coinbase)
+		to := receiver
+		if i == 2 {
+			to = reverter
+		}
+		txn, err := types.SignTx(types.NewTransaction(gen.TxNonce(sender), to, uint256.NewInt(1000), 100_000, uint256.NewInt(params.GWei), nil), *signer, key)
+		require.NoError(t, err)
+		gen.AddTx(txn)
+	})
+	require.NoError(t, err)
+	require.NoError(t, m.InsertChain(chain))
+
+	dir := t.TempDir()
+	cfg := exporter.Cfg{Dir: dir, BlocksPerFile: 4}
+	e, err := exporter.New(context.Background(), cfg, m.ChainConfig, m.BlockReader, m.Engine, m.Log)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	tx, err := m.DB.BeginRo(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+	// the partition 8-12 isn't complete
+	require.NoError(t, e.Export(ctx, tx.(kv.TemporalTx), 0, 10))
+	for _, table := range exporter.AllTables {
+		entries, err := os.ReadDir(filepath.Join(dir, string(table)))
+		require.NoError(t, err)
+		require.Len(t, entries, 2, table)
+		require.Equal(t, exporter.FileName(table, 0, 4), entries[0].Name())
+		require.Equal(t, exporter.FileName(table, 4, 8), entries[1].Name())
+	}
+
+	f, err := parquet.Open(filepath.Join(dir, string(exporter.Headers), exporter.FileName(exporter.Headers, 0, 4)))
+	require.NoError(t, err)
+	require.Equal(t, int64(4), f.NumRows)
+	require.Equal(t, "4", f.Meta["erigon.to_block"])
+	require.NoError(t, f.Close())
+
+	headers := readRows(t, filepath.Join(dir, string(exporter.Headers), exporter.FileName(exporter.Headers, 0, 4)))
+	for i, row := range headers {
+		require.Equal(t, uint64(i), row[0])
+	}
+	require.Equal(t, chain.Blocks[0].Hash().Bytes(), headers[1][1])
+
+	txs := readRows(t, filepath.Join(dir, string(exporter.Transactions), exporter.FileName(exporter.Transactions, 0, 4)))
+	require.Len(t, txs, 3)
+	require.Equal(t, sender.Bytes(), txs[0][4])
+	require.Equal(t, receiver.Bytes(), txs[0][5])
+
+	receipts := readRows(t, filepath.Join(dir, string(exporter.Receipts), exporter.FileName(exporter.Receipts, 0, 4)))
+	require.Len(t, receipts, 3)
+	require.Equal(t, types.ReceiptStatusSuccessful, receipts[0][3])
+	require.Equal(t, params.TxGas, receipts[0][4])
+	require.Equal(t, types.ReceiptStatusFailed, receipts[2][3])
+	require.Equal(t, chain.Receipts[2][0].GasUsed, receipts[2][4])
+
+	traces := readRows(t, filepath.Join(dir, string(exporter.Traces), exporter.FileName(exporter.Traces, 0, 4)))
+	require.Len(t, traces, 3)
+	require.Nil(t, traces[0][14])
+	require.NotNil(t, traces[2][14])
+
+	var transfers, rewards int
+	for _, row := range readRows(t, filepath.Join(dir, string(exporter.BalanceChanges), exporter.FileName(exporter.BalanceChanges, 0, 4))) {
+		switch row[6] {
+		case tracing.BalanceChangeTransfer.String():
+			// the value sent to the reverting contract isn't transferred
+			require.Contains(t, []any{uint64(1), uint64(2)}, row[0])
+			transfers++
+		case tracing.BalanceIncreaseRewardMineBlock.String():
+			require.Nil(t, row[1])
+			require.Equal(t, coinbase.Bytes(), row[3])
+			rewards++
+		}
+	}
+	require.Equal(t, 4, transfers) // from and to of blocks 1 and 2
+	require.Equal(t, 3, rewards)
+
+	// exported partitions are skipped, removed ones are exported again
+	require.NoError(t, e.Unwind(5))
+	_, err = os.Stat(filepath.Join(dir, string(exporter.Logs), exporter.FileName(exporter.Logs, 4, 8)))
+	require.ErrorIs(t, err, os.ErrNotExist)
+	require.NoError(t, e.Export(ctx, tx.(kv.TemporalTx), 4, 10))
+	require.Len(t, readRows(t, filepath.Join(dir, string(exporter.Headers), exporter.FileName(exporter.Headers, 4, 8))), 4)
+
+	_, err = exporter.New(ctx, exporter.Cfg{Dir: dir, BlocksPerFile: 3}, m.ChainConfig, m.BlockReader, m.Engine, m.Log)
+	require.Error(t, err)
+}
+
+func TestExportBalanceChanges(t *testing.T) {
+	defer func(unit uint64) { *exporter.BlocksUnit = unit }(*exporter.BlocksUnit)
+	*exporter.BlocksUnit = 1
+
+	key, _ := crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+	caller, reverter, destructed, beneficiary := libcommon.Address{2}, libcommon.Address{3}, libcommon.Address{4}, libcommon.Address{5}
+	// PUSH1 0 PUSH1 0 PUSH1 0 PUSH1 0 PUSH1 100 PUSH20 reverter GAS CALL POP STOP
+	callerCode := append([]byte{0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x60, 0x00, 0x60, 0x64, 0x73}, reverter.Bytes()...)
+	callerCode = append(callerCode, 0x5a, 0xf1, 0x50, 0x00)
+	gspec := &types.Genesis{
+		Config: params.TestChainConfig,
+		Alloc: types.GenesisAlloc{
+			sender:     {Balance: big.NewInt(params.Ether)},
+			caller:     {Balance: big.NewInt(1000), Code: callerCode},
+			reverter:   {Balance: new(big.Int), Code: []byte{0x60, 0x00, 0x60, 0x00, 0xfd}},                          // PUSH1 0 PUSH1 0 REVERT
+			destructed: {Balance: big.NewInt(500), Code: append(append([]byte{0x73}, beneficiary.Bytes()...), 0xff)}, // PUSH20 beneficiary SELFDESTRUCT
+		},
+	}
+	m := mock.MockWithGenesis(t, gspec, key, false)
+	signer := types.LatestSignerForChainID(m.ChainConfig.ChainID)
+	chain, err := core.GenerateChain(m.ChainConfig, m.Genesis, m.Engine, m.DB, 4, func(i int, gen *core.BlockGen) {
+		to := caller
+		if i == 1 {
+			to = destructed
+		}
+		txn, err := types.SignTx(types.NewTransaction(gen.TxNonce(sender), to, uint256.NewInt(0), 100_000, uint256.NewInt(params.GWei), nil), *signer, key)
+		require.NoError(t, err)
+		gen.AddTx(txn)
+	})
+	require.NoError(t, err)
+	require.NoError(t, m.InsertChain(chain))
+	require.Equal(t, types.ReceiptStatusSuccessful, chain.Receipts[0][0].Status) // only the inner call fails
+
+	dir := t.TempDir()
+	e, err := exporter.New(context.Background(), exporter.Cfg{Dir: dir, BlocksPerFile: 4}, m.ChainConfig, m.BlockReader, m.Engine, m.Log)
+	require.NoError(t, err)
+	ctx := context.Background()
+	tx, err := m.DB.BeginRo(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+	require.NoError(t, e.Export(ctx, tx.(kv.TemporalTx), 0, 4))
+
+	type change struct {
+		block       uint64
+		prev, after []byte
+		reason      string
+	}
+	changes := map[libcommon.Address][]change{}
+	for _, row := range readRows(t, filepath.Join(dir, string(exporter.BalanceChanges), exporter.FileName(exporter.BalanceChanges, 0, 4))) {
+		addr := libcommon.BytesToAddress(row[3].([]byte))
+		changes[addr] = append(changes[addr], change{block: row[0].(uint64), prev: row[4].([]byte), after: row[5].([]byte), reason: row[6].(string)})
+	}
+	value := func(v uint64) []byte {
+		b := uint256.NewInt(v).Bytes32()
+		return b[:]
+	}
+
+	// the value sent by the reverted inner call is neither taken from the caller nor given to the callee
+	require.Empty(t, changes[caller])
+	require.Empty(t, changes[reverter])
+	require.Equal(t, []change{{block: 2, prev: value(500), after: value(0), reason: tracing.BalanceDecreaseSelfdestruct.String()}}, changes[destructed])
+	require.Equal(t, []change{{block: 2, prev: value(0), after: value(500), reason: tracing.BalanceIncreaseSelfdestruct.String()}}, changes[beneficiary])
+}
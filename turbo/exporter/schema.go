@@ -0,0 +1,350 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package exporter
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/holiman/uint256"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/length"
+	"github.com/erigontech/erigon-lib/parquet"
+	"github.com/erigontech/erigon/core/types"
+)
+
+// SchemaVersion is bumped on incompatible changes of the table schemas - columns are only appended within a version.
+// It's the version prefix of the file names and is written to the metadata of every file.
+const SchemaVersion = 1
+
+type Table string
+
+const (
+	Headers        Table = "headers"
+	Transactions   Table = "transactions"
+	Receipts       Table = "receipts"
+	Logs           Table = "logs"
+	BalanceChanges Table = "balance_changes"
+	Traces         Table = "traces"
+)
+
+var AllTables = []Table{Headers, Transactions, Receipts, Logs, BalanceChanges, Traces}
+
+// needsExecution - tables which are produced by re-executing the blocks on historical state
+func (t Table) needsExecution() bool {
+	return t == Receipts || t == Logs || t == BalanceChanges || t == Traces
+}
+
+func ParseTables(names []string) ([]Table, error) {
+	if len(names) == 0 {
+		return AllTables, nil
+	}
+	var tables []Table
+	for _, name := range names {
+		t := Table(strings.TrimSpace(name))
+		if _, ok := schemas[t]; !ok {
+			return nil, fmt.Errorf("unknown table %q, known tables: %v", name, AllTables)
+		}
+		tables = append(tables, t)
+	}
+	return tables, nil
+}
+
+// Column helpers. Hashes, addresses and 256-bit integers are fixed length big-endian byte arrays.
+func uint64Col(name string) parquet.Column {
+	return parquet.Column{Name: name, Type: parquet.Int64, Logical: parquet.LogicalUint}
+}
+func hashCol(name string) parquet.Column {
+	return parquet.Column{Name: name, Type: parquet.FixedLenByteArray, Length: 32}
+}
+func addressCol(name string) parquet.Column {
+	return parquet.Column{Name: name, Type: parquet.FixedLenByteArray, Length: 20}
+}
+func uint256Col(name string) parquet.Column {
+	return parquet.Column{Name: name, Type: parquet.FixedLenByteArray, Length: 32}
+}
+func bytesCol(name string) parquet.Column { return parquet.Column{Name: name, Type: parquet.ByteArray} }
+func stringCol(name string) parquet.Column {
+	return parquet.Column{Name: name, Type: parquet.ByteArray, Logical: parquet.LogicalString}
+}
+func optional(c parquet.Column) parquet.Column {
+	c.Optional = true
+	return c
+}
+
+var schemas = map[Table]parquet.Schema{
+	Headers: {
+		uint64Col("number"),
+		hashCol("hash"),
+		hashCol("parent_hash"),
+		hashCol("uncle_hash"),
+		addressCol("miner"),
+		hashCol("state_root"),
+		hashCol("transactions_root"),
+		hashCol("receipts_root"),
+		parquet.Column{Name: "logs_bloom", Type: parquet.FixedLenByteArray, Length: types.BloomByteLength},
+		uint256Col("difficulty"),
+		uint64Col("gas_limit"),
+		uint64Col("gas_used"),
+		uint64Col("timestamp"),
+		bytesCol("extra_data"),
+		hashCol("mix_digest"),
+		uint64Col("nonce"),
+		optional(uint256Col("base_fee_per_gas")),
+		optional(hashCol("withdrawals_root")),
+		optional(uint64Col("blob_gas_used")),
+		optional(uint64Col("excess_blob_gas")),
+		optional(hashCol("parent_beacon_block_root")),
+		optional(hashCol("requests_root")),
+		uint64Col("transaction_count"),
+		uint64Col("uncle_count"),
+		uint64Col("withdrawal_count"),
+	},
+	Transactions: {
+		uint64Col("block_number"),
+		uint64Col("tx_index"),
+		hashCol("hash"),
+		uint64Col("type"),
+		addressCol("from"),
+		optional(addressCol("to")), // nil for contract creation
+		uint64Col("nonce"),
+		uint256Col("value"),
+		uint64Col("gas"),
+		optional(uint256Col("gas_price")), // legacy and access list txns
+		optional(uint256Col("max_priority_fee_per_gas")),
+		optional(uint256Col("max_fee_per_gas")),
+		optional(uint256Col("max_fee_per_blob_gas")),
+		optional(bytesCol("blob_versioned_hashes")), // concatenated 32-byte hashes
+		bytesCol("input"),
+		optional(uint256Col("chain_id")), // nil for unprotected legacy txns
+	},
+	Receipts: {
+		uint64Col("block_number"),
+		uint64Col("tx_index"),
+		hashCol("tx_hash"),
+		uint64Col("status"),
+		uint64Col("gas_used"),
+		uint64Col("cumulative_gas_used"),
+		uint256Col("effective_gas_price"),
+		optional(addressCol("contract_address")),
+		uint64Col("log_count"),
+		uint64Col("first_log_index"),
+		optional(uint64Col("blob_gas_used")),
+	},
+	Logs: {
+		uint64Col("block_number"),
+		uint64Col("tx_index"),
+		uint64Col("log_index"), // within the block
+		hashCol("tx_hash"),
+		addressCol("address"),
+		optional(hashCol("topic0")),
+		optional(hashCol("topic1")),
+		optional(hashCol("topic2")),
+		optional(hashCol("topic3")),
+		bytesCol("data"),
+	},
+	BalanceChanges: {
+		uint64Col("block_number"),
+		optional(uint64Col("tx_index")), // nil for the changes at the end of the block: rewards and withdrawals
+		optional(hashCol("tx_hash")),
+		addressCol("address"),
+		uint256Col("balance_before"),
+		uint256Col("balance_after"),
+		stringCol("reason"),
+	},
+	Traces: {
+		uint64Col("block_number"),
+		uint64Col("tx_index"),
+		hashCol("tx_hash"),
+		uint64Col("frame"),            // position of the call frame within the txn, depth-first
+		optional(uint64Col("parent")), // frame of the caller, nil for the top frame
+		uint64Col("depth"),
+		stringCol("call_type"),
+		addressCol("from"),
+		addressCol("to"),
+		optional(uint256Col("value")), // nil for static calls
+		uint64Col("gas"),
+		uint64Col("gas_used"),
+		bytesCol("input"),
+		bytesCol("output"),
+		optional(stringCol("error")),
+	},
+}
+
+func headerRow(h *types.Header, txCount, uncleCount, withdrawalCount int) []any {
+	return []any{
+		h.Number.Uint64(),
+		hashValue(h.Hash()),
+		hashValue(h.ParentHash),
+		hashValue(h.UncleHash),
+		h.Coinbase.Bytes(),
+		hashValue(h.Root),
+		hashValue(h.TxHash),
+		hashValue(h.ReceiptHash),
+		h.Bloom.Bytes(),
+		bigValue(h.Difficulty),
+		h.GasLimit,
+		h.GasUsed,
+		h.Time,
+		h.Extra,
+		hashValue(h.MixDigest),
+		h.Nonce.Uint64(),
+		optionalBig(h.BaseFee),
+		optionalHash(h.WithdrawalsHash),
+		optionalUint64(h.BlobGasUsed),
+		optionalUint64(h.ExcessBlobGas),
+		optionalHash(h.ParentBeaconBlockRoot),
+		optionalHash(h.RequestsRoot),
+		uint64(txCount),
+		uint64(uncleCount),
+		uint64(withdrawalCount),
+	}
+}
+
+func transactionRow(blockNum uint64, txIndex int, txn types.Transaction, sender libcommon.Address) []any {
+	row := []any{
+		blockNum,
+		uint64(txIndex),
+		hashValue(txn.Hash()),
+		uint64(txn.Type()),
+		sender.Bytes(),
+		nil,
+		txn.GetNonce(),
+		uint256Value(txn.GetValue()),
+		txn.GetGas(),
+		nil, nil, nil, nil, nil,
+		txn.GetData(),
+		nil,
+	}
+	if to := txn.GetTo(); to != nil {
+		row[5] = to.Bytes()
+	}
+	switch txn.Type() {
+	case types.LegacyTxType, types.AccessListTxType:
+		row[9] = uint256Value(txn.GetPrice())
+	default:
+		row[10], row[11] = uint256Value(txn.GetTip()), uint256Value(txn.GetFeeCap())
+	}
+	if blobTx, ok := txn.Unwrap().(*types.BlobTx); ok {
+		if blobTx.MaxFeePerBlobGas != nil {
+			row[12] = uint256Value(blobTx.MaxFeePerBlobGas)
+		}
+		hashes := make([]byte, 0, len(blobTx.BlobVersionedHashes)*length.Hash)
+		for _, h := range blobTx.BlobVersionedHashes {
+			hashes = append(hashes, h[:]...)
+		}
+		row[13] = hashes
+	}
+	if txn.Type() != types.LegacyTxType || txn.Protected() {
+		row[15] = uint256Value(txn.GetChainID())
+	}
+	return row
+}
+
+func receiptRow(blockNum uint64, txIndex int, txn types.Transaction, r *types.Receipt, gasUsed uint64, failed bool, baseFee *big.Int) []any {
+	var baseFee256 *uint256.Int
+	if baseFee != nil {
+		baseFee256, _ = uint256.FromBig(baseFee)
+	}
+	effectiveGasPrice := new(uint256.Int).Set(txn.GetEffectiveGasTip(baseFee256))
+	if baseFee256 != nil {
+		effectiveGasPrice.Add(effectiveGasPrice, baseFee256)
+	}
+	status := types.ReceiptStatusSuccessful
+	if failed {
+		status = types.ReceiptStatusFailed
+	}
+	row := []any{
+		blockNum,
+		uint64(txIndex),
+		hashValue(txn.Hash()),
+		status,
+		gasUsed,
+		r.CumulativeGasUsed,
+		uint256Value(effectiveGasPrice),
+		nil,
+		uint64(len(r.Logs)),
+		uint64(r.FirstLogIndexWithinBlock),
+		nil,
+	}
+	if txn.GetTo() == nil {
+		row[7] = r.ContractAddress.Bytes()
+	}
+	if txn.Type() == types.BlobTxType {
+		row[10] = txn.GetBlobGas()
+	}
+	return row
+}
+
+func logRow(blockNum uint64, l *types.Log) []any {
+	row := []any{
+		blockNum,
+		uint64(l.TxIndex),
+		uint64(l.Index),
+		hashValue(l.TxHash),
+		l.Address.Bytes(),
+		nil, nil, nil, nil,
+		l.Data,
+	}
+	for i, topic := range l.Topics {
+		if i == 4 {
+			break
+		}
+		row[5+i] = hashValue(topic)
+	}
+	return row
+}
+
+func hashValue(h libcommon.Hash) []byte { return h.Bytes() }
+
+func uint256Value(v *uint256.Int) []byte {
+	if v == nil {
+		return make([]byte, 32)
+	}
+	b := v.Bytes32()
+	return b[:]
+}
+
+func bigValue(v *big.Int) []byte {
+	if v == nil {
+		return make([]byte, 32)
+	}
+	return v.FillBytes(make([]byte, 32))
+}
+
+func optionalBig(v *big.Int) any {
+	if v == nil {
+		return nil
+	}
+	return bigValue(v)
+}
+
+func optionalHash(h *libcommon.Hash) any {
+	if h == nil {
+		return nil
+	}
+	return hashValue(*h)
+}
+
+func optionalUint64(v *uint64) any {
+	if v == nil {
+		return nil
+	}
+	return *v
+}
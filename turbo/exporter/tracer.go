@@ -0,0 +1,129 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package exporter
+
+import (
+	"github.com/holiman/uint256"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon/core/tracing"
+	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/core/vm"
+)
+
+type callFrame struct {
+	parent        int // -1 for the top frame
+	depth         int
+	typ           string
+	from, to      libcommon.Address
+	value         *uint256.Int
+	gas, gasUsed  uint64
+	input, output []byte
+	err           string
+}
+
+type balanceChange struct {
+	addr      libcommon.Address
+	prev, new uint256.Int
+	reason    tracing.BalanceChangeReason
+}
+
+// txTracer collects the call frames and the balance changes of one txn. The balance changes made by reverted frames
+// are dropped: the value transfer of a frame is made right before or after its start event, so the changes since the
+// previous frame event belong to the starting frame.
+type txTracer struct {
+	frames  []callFrame
+	stack   []int // open frames
+	changes []balanceChange
+	starts  []int // index of the first change of every open frame
+	mark    int   // amount of changes at the last frame event
+}
+
+func (t *txTracer) reset() {
+	t.frames, t.stack, t.changes, t.starts, t.mark = t.frames[:0], t.stack[:0], t.changes[:0], t.starts[:0], 0
+}
+
+func (t *txTracer) hooks() *tracing.Hooks {
+	return &tracing.Hooks{OnBalanceChange: t.onBalanceChange}
+}
+
+func (t *txTracer) onBalanceChange(addr libcommon.Address, prev, new *uint256.Int, reason tracing.BalanceChangeReason) {
+	if prev.Eq(new) {
+		return
+	}
+	t.changes = append(t.changes, balanceChange{addr: addr, prev: *prev, new: *new, reason: reason})
+}
+
+func (t *txTracer) enter(typ string, from, to libcommon.Address, input []byte, gas uint64, value *uint256.Int) {
+	parent := -1
+	if len(t.stack) > 0 {
+		parent = t.stack[len(t.stack)-1]
+	}
+	f := callFrame{parent: parent, depth: len(t.stack), typ: typ, from: from, to: to, gas: gas, input: libcommon.Copy(input)}
+	if value != nil {
+		f.value = new(uint256.Int).Set(value)
+	}
+	t.frames = append(t.frames, f)
+	t.stack = append(t.stack, len(t.frames)-1)
+	t.starts = append(t.starts, t.mark)
+	t.mark = len(t.changes)
+}
+
+func (t *txTracer) exit(output []byte, usedGas uint64, err error) {
+	if len(t.stack) == 0 {
+		return
+	}
+	f := &t.frames[t.stack[len(t.stack)-1]]
+	f.gasUsed, f.output = usedGas, libcommon.Copy(output)
+	if err != nil {
+		f.err = err.Error()
+		// the state of the frame is reverted
+		t.changes = t.changes[:t.starts[len(t.starts)-1]]
+	}
+	t.stack, t.starts = t.stack[:len(t.stack)-1], t.starts[:len(t.starts)-1]
+	t.mark = len(t.changes)
+}
+
+func (t *txTracer) CaptureTxStart(gasLimit uint64) { t.mark = len(t.changes) }
+func (t *txTracer) CaptureTxEnd(restGas uint64)    {}
+
+func (t *txTracer) CaptureStart(env *vm.EVM, from libcommon.Address, to libcommon.Address, precompile bool, create bool, input []byte, gas uint64, value *uint256.Int, code []byte) {
+	typ := vm.CALL
+	if create {
+		typ = vm.CREATE
+	}
+	t.enter(typ.String(), from, to, input, gas, value)
+}
+
+func (t *txTracer) CaptureEnd(output []byte, usedGas uint64, err error) { t.exit(output, usedGas, err) }
+
+func (t *txTracer) CaptureEnter(typ vm.OpCode, from libcommon.Address, to libcommon.Address, precompile bool, create bool, input []byte, gas uint64, value *uint256.Int, code []byte) {
+	t.enter(typ.String(), from, to, input, gas, value)
+}
+
+func (t *txTracer) CaptureExit(output []byte, usedGas uint64, err error) {
+	t.exit(output, usedGas, err)
+}
+
+func (t *txTracer) CaptureState(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, rData []byte, depth int, err error) {
+}
+
+func (t *txTracer) CaptureFault(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, depth int, err error) {
+}
+
+func (t *txTracer) SetTransaction(tx types.Transaction) {}
+func (t *txTracer) Found() bool                         { return false }
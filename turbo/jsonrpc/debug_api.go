@@ -30,6 +30,7 @@ import (
 	"github.com/erigontech/erigon-lib/kv/order"
 	"github.com/erigontech/erigon-lib/kv/rawdbv3"
 
+	"github.com/erigontech/erigon/core/rawdb"
 	"github.com/erigontech/erigon/core/state"
 	"github.com/erigontech/erigon/core/types/accounts"
 	"github.com/erigontech/erigon/eth/stagedsync/stages"
@@ -55,6 +56,9 @@ type PrivateDebugAPI interface {
 	TraceTransaction(ctx context.Context, hash common.Hash, config *tracersConfig.TraceConfig, stream *jsoniter.Stream) error
 	TraceBlockByHash(ctx context.Context, hash common.Hash, config *tracersConfig.TraceConfig, stream *jsoniter.Stream) error
 	TraceBlockByNumber(ctx context.Context, number rpc.BlockNumber, config *tracersConfig.TraceConfig, stream *jsoniter.Stream) error
+	TraceChain(ctx context.Context, start, end rpc.BlockNumber, config *tracersConfig.TraceConfig) (*rpc.Subscription, error)
+	IntermediateRoots(ctx context.Context, hash common.Hash, config *tracersConfig.TraceConfig) ([]common.Hash, error)
+	StandardTraceBlockToFile(ctx context.Context, hash common.Hash, config *tracersConfig.StdTraceConfig) ([]string, error)
 	AccountRange(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash, start []byte, maxResults int, nocode, nostorage bool) (state.IteratorDump, error)
 	GetModifiedAccountsByNumber(ctx context.Context, startNum rpc.BlockNumber, endNum *rpc.BlockNumber) ([]common.Address, error)
 	GetModifiedAccountsByHash(ctx context.Context, startHash common.Hash, endHash *common.Hash) ([]common.Address, error)
@@ -62,6 +66,7 @@ type PrivateDebugAPI interface {
 	AccountAt(ctx context.Context, blockHash common.Hash, txIndex uint64, account common.Address) (*AccountResult, error)
 	GetRawHeader(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (hexutility.Bytes, error)
 	GetRawBlock(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (hexutility.Bytes, error)
+	GetBadBlocks(ctx context.Context) ([]*BadBlockArgs, error)
 }
 
 // PrivateDebugAPIImpl is implementation of the PrivateDebugAPI interface based on remote Db access
@@ -401,3 +406,43 @@ func (api *PrivateDebugAPIImpl) GetRawBlock(ctx context.Context, blockNrOrHash r
 	}
 	return rlp.EncodeToBytes(block)
 }
+
+// BadBlockArgs represents the entries in the list returned when bad blocks are queried.
+type BadBlockArgs struct {
+	Hash   common.Hash            `json:"hash"`
+	Block  map[string]interface{} `json:"block"`
+	RLP    hexutility.Bytes       `json:"rlp"`
+	Reason string                 `json:"reason"`
+}
+
+// GetBadBlocks implements debug_getBadBlocks. Returns the most recent blocks rejected by the execution, the highest first.
+func (api *PrivateDebugAPIImpl) GetBadBlocks(ctx context.Context) ([]*BadBlockArgs, error) {
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	badBlocks, err := rawdb.ReadAllBadBlocks(tx)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]*BadBlockArgs, 0, len(badBlocks))
+	for _, badBlock := range badBlocks {
+		blockRlp, err := rlp.EncodeToBytes(badBlock.Block)
+		if err != nil {
+			return nil, err
+		}
+		blockJson, err := ethapi.RPCMarshalBlock(badBlock.Block, true, true, nil)
+		if err != nil {
+			blockJson = map[string]interface{}{"error": err.Error()}
+		}
+		results = append(results, &BadBlockArgs{
+			Hash:   badBlock.Block.Hash(),
+			Block:  blockJson,
+			RLP:    blockRlp,
+			Reason: badBlock.Reason,
+		})
+	}
+	return results, nil
+}
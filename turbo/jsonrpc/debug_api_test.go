@@ -19,8 +19,13 @@ package jsonrpc
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
 	"reflect"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/davecgh/go-spew/spew"
 	jsoniter "github.com/json-iterator/go"
@@ -29,17 +34,25 @@ import (
 	"github.com/erigontech/erigon-lib/log/v3"
 
 	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/common/hexutility"
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/kv/kvcache"
 	"github.com/erigontech/erigon-lib/kv/order"
 	"github.com/erigontech/erigon-lib/kv/rawdbv3"
 	"github.com/erigontech/erigon-lib/kv/stream"
+	libstate "github.com/erigontech/erigon-lib/state"
+	"github.com/erigontech/erigon/cmd/rpcdaemon/cli/httpcfg"
 	"github.com/erigontech/erigon/cmd/rpcdaemon/rpcdaemontest"
+	"github.com/erigontech/erigon/core/rawdb"
 	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/core/vm"
 	tracersConfig "github.com/erigontech/erigon/eth/tracers/config"
+	"github.com/erigontech/erigon/rlp"
 	"github.com/erigontech/erigon/rpc"
 	"github.com/erigontech/erigon/rpc/rpccfg"
 	"github.com/erigontech/erigon/turbo/adapter/ethapi"
+	"github.com/erigontech/erigon/turbo/snapshotsync/freezeblocks"
 )
 
 var dumper = spew.ConfigState{Indent: "    "}
@@ -145,6 +158,206 @@ func TestTraceBlockByHash(t *testing.T) {
 	}
 }
 
+func TestTraceBlockFlatCallTracer(t *testing.T) {
+	m, _, _ := rpcdaemontest.CreateTestSentry(t)
+	stateCache := kvcache.New(kvcache.DefaultCoherentConfig)
+	baseApi := NewBaseApi(nil, stateCache, m.BlockReader, false, rpccfg.DefaultEvmCallTimeout, m.Engine, m.Dirs, nil)
+	api := NewPrivateDebugAPI(baseApi, m.DB, 0)
+	traceApi := NewTraceAPI(baseApi, m.DB, &httpcfg.HttpCfg{})
+
+	tracer := "flatCallTracer"
+	tracerConfig := json.RawMessage(`{"convertParityErrors":true,"includeRewards":true}`)
+	for blockNum := rpc.BlockNumber(1); blockNum <= 10; blockNum++ {
+		want, err := traceApi.Block(m.Ctx, blockNum, nil, nil)
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		stream := jsoniter.NewStream(jsoniter.ConfigDefault, &buf, 4096)
+		err = api.TraceBlockByNumber(m.Ctx, blockNum, &tracersConfig.TraceConfig{Tracer: &tracer, TracerConfig: &tracerConfig}, stream)
+		require.NoError(t, err)
+		require.NoError(t, stream.Flush())
+
+		var results []struct {
+			Result []json.RawMessage `json:"result"`
+		}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &results))
+		var have []json.RawMessage
+		for _, res := range results {
+			have = append(have, res.Result...)
+		}
+
+		require.Equal(t, len(want), len(have), "block %d", blockNum)
+		for i := range want {
+			wantJson, err := json.Marshal(want[i])
+			require.NoError(t, err)
+			require.JSONEq(t, string(wantJson), string(have[i]), "block %d trace %d", blockNum, i)
+		}
+	}
+}
+
+func TestTraceChain(t *testing.T) {
+	m, _, _ := rpcdaemontest.CreateTestSentry(t)
+	api := NewPrivateDebugAPI(newBaseApiForTest(m), m.DB, 0)
+
+	server := rpc.NewServer(50, false /* traceRequests */, false /* debugSingleRequest */, false /* disableStreaming */, log.New(), 100*time.Millisecond)
+	require.NoError(t, server.RegisterName("debug", api))
+	client := rpc.DialInProc(server, log.New())
+	defer client.Close()
+
+	results := make(chan *blockTraceResult)
+	sub, err := client.Subscribe(m.Ctx, "debug", results, "traceChain", rpc.BlockNumber(0), rpc.BlockNumber(10), &tracersConfig.TraceConfig{})
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	for blockNum := rpc.BlockNumber(1); blockNum <= 10; blockNum++ {
+		var res *blockTraceResult
+		select {
+		case res = <-results:
+		case err := <-sub.Err():
+			t.Fatal(err)
+		}
+		require.Empty(t, res.Error)
+		require.Equal(t, hexutil.Uint64(blockNum), res.Block)
+
+		var buf bytes.Buffer
+		stream := jsoniter.NewStream(jsoniter.ConfigDefault, &buf, 4096)
+		require.NoError(t, api.TraceBlockByNumber(m.Ctx, blockNum, &tracersConfig.TraceConfig{}, stream))
+		require.NoError(t, stream.Flush())
+		require.JSONEq(t, buf.String(), string(res.Traces), "block %d", blockNum)
+	}
+
+	_, err = client.Subscribe(m.Ctx, "debug", results, "traceChain", rpc.BlockNumber(10), rpc.BlockNumber(10), &tracersConfig.TraceConfig{})
+	require.Error(t, err)
+}
+
+func TestTraceCallErc7562Tracer(t *testing.T) {
+	m, _, _ := rpcdaemontest.CreateTestSentry(t)
+	api := NewPrivateDebugAPI(newBaseApiForTest(m), m.DB, 0)
+
+	contract := common.HexToAddress("0x000000000000000000000000000000000000beef")
+	// SLOAD(1), SSTORE(2, 0x2a), KECCAK256(0, 32), EXTCODESIZE(contract), STOP
+	code := hexutility.Bytes(common.FromHex("0x60015450602a60025560206000205073" + contract.Hex()[2:] + "3b5000"))
+	slotValues := map[common.Hash]common.Hash{common.HexToHash("0x01"): common.HexToHash("0x07")}
+	overrides := ethapi.StateOverrides{contract: ethapi.Account{Code: &code, State: &slotValues}}
+
+	from := common.HexToAddress("0x71562b71999873db5b286df957af199ec94617f7")
+	gas := hexutil.Uint64(100_000)
+	tracer := "erc7562Tracer"
+	var buf bytes.Buffer
+	stream := jsoniter.NewStream(jsoniter.ConfigDefault, &buf, 4096)
+	err := api.TraceCall(m.Ctx, ethapi.CallArgs{From: &from, To: &contract, Gas: &gas}, rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber),
+		&tracersConfig.TraceConfig{Tracer: &tracer, StateOverrides: &overrides}, stream)
+	require.NoError(t, err)
+	require.NoError(t, stream.Flush())
+
+	var res struct {
+		Type          string `json:"type"`
+		AccessedSlots struct {
+			Reads  map[string][]string `json:"reads"`
+			Writes map[string]uint64   `json:"writes"`
+		} `json:"accessedSlots"`
+		ExtCodeAccessInfo []common.Address  `json:"extCodeAccessInfo"`
+		UsedOpcodes       map[string]uint64 `json:"usedOpcodes"`
+		ContractSize      map[common.Address]struct {
+			ContractSize int `json:"contractSize"`
+		} `json:"contractSize"`
+		OutOfGas bool               `json:"outOfGas"`
+		Keccak   []hexutility.Bytes `json:"keccak"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &res), buf.String())
+
+	require.Equal(t, "CALL", res.Type)
+	require.Equal(t, []string{common.HexToHash("0x07").Hex()}, res.AccessedSlots.Reads[common.HexToHash("0x01").Hex()])
+	require.Equal(t, uint64(1), res.AccessedSlots.Writes[common.HexToHash("0x02").Hex()])
+	require.Equal(t, []common.Address{contract}, res.ExtCodeAccessInfo)
+	require.Equal(t, len(code), res.ContractSize[contract].ContractSize)
+	require.Equal(t, []hexutility.Bytes{make([]byte, 32)}, res.Keccak)
+	require.False(t, res.OutOfGas)
+	for _, op := range []vm.OpCode{vm.SLOAD, vm.SSTORE, vm.KECCAK256, vm.EXTCODESIZE, vm.STOP} {
+		require.Equal(t, uint64(1), res.UsedOpcodes[strconv.Itoa(int(op))], op.String())
+	}
+	require.NotContains(t, res.UsedOpcodes, strconv.Itoa(int(vm.POP)))
+}
+
+func TestIntermediateRoots(t *testing.T) {
+	m, _, _ := rpcdaemontest.CreateTestSentry(t)
+	api := NewPrivateDebugAPI(newBaseApiForTest(m), m.DB, 0)
+
+	tx, err := m.DB.BeginRo(m.Ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+	txNumsReader := rawdbv3.TxNums.WithCustomReadTxNumFunc(freezeblocks.ReadTxNumFuncFromBlockReader(m.Ctx, m.BlockReader))
+	for blockNum := uint64(1); blockNum <= 10; blockNum++ {
+		block, err := m.BlockReader.BlockByNumber(m.Ctx, tx, blockNum)
+		require.NoError(t, err)
+
+		roots, err := api.IntermediateRoots(m.Ctx, block.Hash(), &tracersConfig.TraceConfig{})
+		require.NoError(t, err)
+		require.Len(t, roots, len(block.Transactions()), "block %d", blockNum)
+
+		// replayed roots must match the ones of state history
+		minTxNum, err := txNumsReader.Min(tx, blockNum)
+		require.NoError(t, err)
+		for i, root := range roots {
+			domains, err := libstate.NewSharedDomains(tx, log.New())
+			require.NoError(t, err)
+			want, err := domains.RewindCommitment(m.Ctx, blockNum, minTxNum+1+uint64(i)+1)
+			domains.Close()
+			require.NoError(t, err)
+			require.Equal(t, common.BytesToHash(want), root, "block %d txn %d", blockNum, i)
+		}
+	}
+
+	block, err := m.BlockReader.BlockByNumber(m.Ctx, tx, 1)
+	require.NoError(t, err)
+	reexec := uint64(0)
+	_, err = api.IntermediateRoots(m.Ctx, block.Hash(), &tracersConfig.TraceConfig{Reexec: &reexec})
+	require.ErrorContains(t, err, "requested block is too old")
+}
+
+func TestStandardTraceBlockToFile(t *testing.T) {
+	m, _, _ := rpcdaemontest.CreateTestSentry(t)
+	ethApi := NewEthAPI(newBaseApiForTest(m), m.DB, nil, nil, nil, 5000000, 1e18, 100_000, false, 100_000, 128, log.New())
+	api := NewPrivateDebugAPI(newBaseApiForTest(m), m.DB, 0)
+
+	txn, err := ethApi.GetTransactionByHash(m.Ctx, common.HexToHash(debugTraceTransactionTests[1].txHash))
+	require.NoError(t, err)
+	txCount, err := ethApi.GetBlockTransactionCountByHash(m.Ctx, *txn.BlockHash)
+	require.NoError(t, err)
+
+	checkDump := func(fileName string) {
+		t.Cleanup(func() { os.Remove(fileName) })
+		data, err := os.ReadFile(fileName)
+		require.NoError(t, err)
+		lines := bytes.Split(bytes.TrimSpace(data), []byte("\n"))
+		require.Greater(t, len(lines), 1, fileName)
+		for _, line := range lines {
+			require.True(t, json.Valid(line), string(line))
+		}
+		var end struct {
+			GasUsed *string `json:"gasUsed"`
+		}
+		require.NoError(t, json.Unmarshal(lines[len(lines)-1], &end))
+		require.NotNil(t, end.GasUsed, fileName)
+	}
+
+	fileNames, err := api.StandardTraceBlockToFile(m.Ctx, *txn.BlockHash, nil)
+	require.NoError(t, err)
+	require.Len(t, fileNames, int(*txCount))
+	for _, fileName := range fileNames {
+		checkDump(fileName)
+	}
+
+	fileNames, err = api.StandardTraceBlockToFile(m.Ctx, *txn.BlockHash, &tracersConfig.StdTraceConfig{TxHash: txn.Hash})
+	require.NoError(t, err)
+	require.Len(t, fileNames, 1)
+	require.Contains(t, filepath.Base(fileNames[0]), fmt.Sprintf("-%d-%#x-", uint64(*txn.TransactionIndex), txn.Hash.Bytes()[:4]))
+	checkDump(fileNames[0])
+
+	_, err = api.StandardTraceBlockToFile(m.Ctx, *txn.BlockHash, &tracersConfig.StdTraceConfig{TxHash: common.HexToHash("0x01")})
+	require.Error(t, err)
+}
+
 func TestTraceTransaction(t *testing.T) {
 	m, _, _ := rpcdaemontest.CreateTestSentry(t)
 	api := NewPrivateDebugAPI(newBaseApiForTest(m), m.DB, 0)
@@ -521,3 +734,42 @@ func TestAccountAt(t *testing.T) {
 		require.Equal(0, int(results.Nonce))
 	})
 }
+
+func TestGetBadBlocks(t *testing.T) {
+	m, _, _ := rpcdaemontest.CreateTestSentry(t)
+	api := NewPrivateDebugAPI(newBaseApiForTest(m), m.DB, 0)
+
+	badBlocks, err := api.GetBadBlocks(m.Ctx)
+	require.NoError(t, err)
+	require.Empty(t, badBlocks)
+
+	var blocks []*types.Block
+	require.NoError(t, m.DB.Update(m.Ctx, func(tx kv.RwTx) error {
+		for _, blockNum := range []uint64{3, 5} {
+			block, err := m.BlockReader.BlockByNumber(m.Ctx, tx, blockNum)
+			if err != nil {
+				return err
+			}
+			if err := rawdb.WriteBadBlock(tx, block, "invalid block "+strconv.FormatUint(blockNum, 10)); err != nil {
+				return err
+			}
+			blocks = append(blocks, block)
+		}
+		return nil
+	}))
+
+	badBlocks, err = api.GetBadBlocks(m.Ctx)
+	require.NoError(t, err)
+	require.Len(t, badBlocks, 2)
+	for i, badBlock := range badBlocks {
+		want := blocks[len(blocks)-1-i]
+		require.Equal(t, want.Hash(), badBlock.Hash)
+		require.Equal(t, "invalid block "+strconv.FormatUint(want.NumberU64(), 10), badBlock.Reason)
+		require.Equal(t, want.Hash(), badBlock.Block["hash"])
+		require.Len(t, badBlock.Block["transactions"], len(want.Transactions()))
+
+		decoded := new(types.Block)
+		require.NoError(t, rlp.DecodeBytes(badBlock.RLP, decoded))
+		require.Equal(t, want.Hash(), decoded.Hash())
+	}
+}
@@ -0,0 +1,251 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/rawdbv3"
+	"github.com/erigontech/erigon-lib/log/v3"
+	libstate "github.com/erigontech/erigon-lib/state"
+
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/core/vm"
+	tracersConfig "github.com/erigontech/erigon/eth/tracers/config"
+	"github.com/erigontech/erigon/eth/tracers/logger"
+	"github.com/erigontech/erigon/turbo/rpchelper"
+	"github.com/erigontech/erigon/turbo/snapshotsync/freezeblocks"
+	"github.com/erigontech/erigon/turbo/transactions"
+)
+
+// defaultTraceReexec is the number of blocks below the head debug_intermediateRoots is willing to rewind
+// commitment for, unless TraceConfig.Reexec says otherwise
+const defaultTraceReexec = uint64(128)
+
+// IntermediateRoots implements debug_intermediateRoots. Returns the state root after each transaction of the block,
+// computed by commitment. Changes made after the last transaction (block rewards, withdrawals, etc.) are not included.
+func (api *PrivateDebugAPIImpl) IntermediateRoots(ctx context.Context, hash common.Hash, config *tracersConfig.TraceConfig) ([]common.Hash, error) {
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	if _, ok := tx.(libstate.HasAggTx); !ok {
+		return nil, errors.New("debug_intermediateRoots is not supported by remote database")
+	}
+
+	block, err := api.blockByHashWithSenders(ctx, tx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, fmt.Errorf("block %#x not found", hash)
+	}
+	if block.NumberU64() == 0 {
+		return nil, errors.New("genesis is not traceable")
+	}
+	if err := api.BaseAPI.checkPruneHistory(ctx, tx, block.NumberU64()); err != nil {
+		return nil, err
+	}
+
+	reexec := defaultTraceReexec
+	if config != nil && config.Reexec != nil {
+		reexec = *config.Reexec
+	}
+	latestBlock, err := rpchelper.GetLatestBlockNumber(tx)
+	if err != nil {
+		return nil, err
+	}
+	if latestBlock < block.NumberU64() || latestBlock-block.NumberU64() > reexec {
+		return nil, fmt.Errorf("requested block is too old, block must be within %d blocks of the head block number (currently %d)", reexec, latestBlock)
+	}
+
+	txNumsReader := rawdbv3.TxNums.WithCustomReadTxNumFunc(freezeblocks.ReadTxNumFuncFromBlockReader(ctx, api._blockReader))
+	minTxNum, err := txNumsReader.Min(tx, block.NumberU64())
+	if err != nil {
+		return nil, err
+	}
+
+	domains, err := libstate.NewSharedDomains(tx, log.New())
+	if err != nil {
+		return nil, err
+	}
+	// nothing is flushed, all writes of replayed transactions are discarded on close
+	defer domains.Close()
+	// commitment as of the end of system txn which opens the block, so pre-block system calls are accounted
+	if _, err := domains.RewindCommitment(ctx, block.NumberU64(), minTxNum+1); err != nil {
+		return nil, err
+	}
+
+	roots := make([]common.Hash, 0, len(block.Transactions()))
+	stateWriter := state.NewWriterV4(domains)
+	beforeTx := func(idx int, txn types.Transaction) (vm.EVMLogger, error) {
+		domains.SetTxNum(minTxNum + 1 + uint64(idx))
+		return nil, nil
+	}
+	afterTx := func(idx int, txn types.Transaction) error {
+		root, err := domains.ComputeCommitment(ctx, false, block.NumberU64(), "debug_intermediateRoots")
+		if err != nil {
+			return err
+		}
+		roots = append(roots, common.BytesToHash(root))
+		return nil
+	}
+	if err := api.replayBlockTxs(ctx, tx, block, stateWriter, beforeTx, afterTx); err != nil {
+		return nil, err
+	}
+	return roots, nil
+}
+
+// StandardTraceBlockToFile implements debug_standardTraceBlockToFile. Dumps the EIP-3155 json traces of block
+// transactions (or only of config.TxHash if set) into temporary files, one per transaction, and returns their names.
+func (api *PrivateDebugAPIImpl) StandardTraceBlockToFile(ctx context.Context, hash common.Hash, config *tracersConfig.StdTraceConfig) ([]string, error) {
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	block, err := api.blockByHashWithSenders(ctx, tx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, fmt.Errorf("block %#x not found", hash)
+	}
+	if block.NumberU64() == 0 {
+		return nil, errors.New("genesis is not traceable")
+	}
+	if err := api.BaseAPI.checkPruneHistory(ctx, tx, block.NumberU64()); err != nil {
+		return nil, err
+	}
+
+	if config == nil {
+		config = &tracersConfig.StdTraceConfig{}
+	}
+	if config.TxHash != (common.Hash{}) && block.Transaction(config.TxHash) == nil {
+		return nil, fmt.Errorf("transaction %#x not found in block", config.TxHash)
+	}
+
+	var (
+		fileNames []string
+		dump      *os.File
+		writer    *bufio.Writer
+	)
+	closeDump := func() error {
+		if dump == nil {
+			return nil
+		}
+		defer func() { dump, writer = nil, nil }()
+		if err := writer.Flush(); err != nil {
+			dump.Close()
+			return err
+		}
+		return dump.Close()
+	}
+	defer closeDump()
+
+	beforeTx := func(idx int, txn types.Transaction) (vm.EVMLogger, error) {
+		if config.TxHash != (common.Hash{}) && config.TxHash != txn.Hash() {
+			return nil, nil
+		}
+		prefix := fmt.Sprintf("block_%#x-%d-%#x-", block.Hash().Bytes()[:4], idx, txn.Hash().Bytes()[:4])
+		var err error
+		if dump, err = os.CreateTemp(os.TempDir(), prefix); err != nil {
+			return nil, err
+		}
+		fileNames = append(fileNames, dump.Name())
+		writer = bufio.NewWriter(dump)
+		return logger.NewJSONLogger(config.LogConfig, writer), nil
+	}
+	afterTx := func(idx int, txn types.Transaction) error {
+		if dump == nil {
+			return nil
+		}
+		log.Debug("[rpc] wrote standard trace", "file", dump.Name())
+		return closeDump()
+	}
+	if err := api.replayBlockTxs(ctx, tx, block, state.NewNoopWriter(), beforeTx, afterTx); err != nil {
+		return nil, err
+	}
+	return fileNames, nil
+}
+
+// replayBlockTxs re-executes transactions of the block on top of the state as of the block beginning. beforeTx is called
+// before each transaction and may return the EVM logger for it, afterTx is called once changes of the transaction are
+// written into stateWriter.
+func (api *PrivateDebugAPIImpl) replayBlockTxs(ctx context.Context, tx kv.Tx, block *types.Block, stateWriter state.StateWriter,
+	beforeTx func(idx int, txn types.Transaction) (vm.EVMLogger, error), afterTx func(idx int, txn types.Transaction) error) error {
+	chainConfig, err := api.chainConfig(ctx, tx)
+	if err != nil {
+		return err
+	}
+	engine := api.engine()
+
+	txNumsReader := rawdbv3.TxNums.WithCustomReadTxNumFunc(freezeblocks.ReadTxNumFuncFromBlockReader(ctx, api._blockReader))
+	_, blockCtx, _, ibs, _, err := transactions.ComputeTxEnv(ctx, engine, block, chainConfig, api._blockReader, txNumsReader, tx, 0)
+	if err != nil {
+		return err
+	}
+
+	signer := types.MakeSigner(chainConfig, block.NumberU64(), block.Time())
+	rules := chainConfig.Rules(block.NumberU64(), block.Time())
+	for idx, txn := range block.Transactions() {
+		select {
+		default:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		ibs.SetTxContext(idx)
+		msg, _ := txn.AsMessage(*signer, block.BaseFee(), rules)
+		if msg.FeeCap().IsZero() && engine != nil {
+			syscall := func(contract common.Address, data []byte) ([]byte, error) {
+				return core.SysCallContract(contract, data, chainConfig, ibs, block.Header(), engine, true /* constCall */)
+			}
+			msg.SetIsFree(engine.IsServiceTransaction(msg.From(), syscall))
+		}
+
+		tracer, err := beforeTx(idx, txn)
+		if err != nil {
+			return err
+		}
+		vmConfig := vm.Config{}
+		if tracer != nil {
+			vmConfig.Debug, vmConfig.Tracer = true, tracer
+		}
+		evm := vm.NewEVM(blockCtx, core.NewEVMTxContext(msg), ibs, chainConfig, vmConfig)
+		gp := new(core.GasPool).AddGas(msg.Gas()).AddBlobGas(msg.BlobGas())
+		if _, err := core.ApplyMessage(evm, msg, gp, true /* refunds */, false /* gasBailout */); err != nil {
+			return fmt.Errorf("transaction %#x failed: %w", txn.Hash(), err)
+		}
+		if err := ibs.FinalizeTx(rules, stateWriter); err != nil {
+			return err
+		}
+		if err := afterTx(idx, txn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
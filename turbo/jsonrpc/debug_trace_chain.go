@@ -0,0 +1,199 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"runtime"
+
+	jsoniter "github.com/json-iterator/go"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/log/v3"
+
+	"github.com/erigontech/erigon/common/debug"
+	tracersConfig "github.com/erigontech/erigon/eth/tracers/config"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/turbo/rpchelper"
+)
+
+// traceChainMaxWorkers caps the number of blocks debug_traceChain traces concurrently
+const traceChainMaxWorkers = 16
+
+// blockTraceResult is the notification debug_traceChain sends for every traced block
+type blockTraceResult struct {
+	Block  hexutil.Uint64  `json:"block"`
+	Hash   common.Hash     `json:"hash"`
+	Traces json.RawMessage `json:"traces,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// blockTraceTask is a single block of the range, results are delivered to the
+// subscriber in the order tasks are created, no matter which worker finishes first
+type blockTraceTask struct {
+	number uint64
+	result chan *blockTraceResult
+}
+
+// TraceChain implements debug_traceChain. Traces all blocks in the range (start, end] - the start block is
+// excluded, same as in Geth - and streams Geth style block traces to the subscriber in ascending block order.
+func (api *PrivateDebugAPIImpl) TraceChain(ctx context.Context, start, end rpc.BlockNumber, config *tracersConfig.TraceConfig) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	from, to, err := api.traceChainRange(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	if config == nil {
+		config = &tracersConfig.TraceConfig{}
+	}
+	// traceBlock fills in the default, do it upfront so that workers don't race on it
+	if config.BorTraceEnabled == nil {
+		var disabled bool
+		config.BorTraceEnabled = &disabled
+	}
+
+	rpcSub := notifier.CreateSubscription()
+
+	workers := runtime.NumCPU()
+	if workers > traceChainMaxWorkers {
+		workers = traceChainMaxWorkers
+	}
+	if blocks := int(to - from + 1); workers > blocks {
+		workers = blocks
+	}
+
+	go func() {
+		defer debug.LogPanic()
+
+		// the request context is done as soon as the subscription is created
+		traceCtx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		tasks := make(chan *blockTraceTask, workers)
+		ordered := make(chan *blockTraceTask, 2*workers)
+		for i := 0; i < workers; i++ {
+			go func() {
+				defer debug.LogPanic()
+				for task := range tasks {
+					task.result <- api.traceChainBlock(traceCtx, task.number, config)
+				}
+			}()
+		}
+
+		go func() {
+			defer debug.LogPanic()
+			defer close(tasks)
+			defer close(ordered)
+			for number := from; number <= to; number++ {
+				task := &blockTraceTask{number: number, result: make(chan *blockTraceResult, 1)}
+				select {
+				case ordered <- task:
+				case <-traceCtx.Done():
+					return
+				}
+				select {
+				case tasks <- task:
+				case <-traceCtx.Done():
+					return
+				}
+			}
+		}()
+
+		for task := range ordered {
+			var res *blockTraceResult
+			select {
+			case res = <-task.result:
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+			if err := notifier.Notify(rpcSub.ID, res); err != nil {
+				log.Warn("[rpc] error while notifying subscription", "err", err)
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
+// traceChainRange resolves the boundaries of the debug_traceChain range into the first and the last block to trace
+func (api *PrivateDebugAPIImpl) traceChainRange(ctx context.Context, start, end rpc.BlockNumber) (uint64, uint64, error) {
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer tx.Rollback()
+
+	from, _, _, err := rpchelper.GetBlockNumber(ctx, rpc.BlockNumberOrHashWithNumber(start), tx, api._blockReader, api.filters)
+	if err != nil {
+		return 0, 0, err
+	}
+	to, _, _, err := rpchelper.GetBlockNumber(ctx, rpc.BlockNumberOrHashWithNumber(end), tx, api._blockReader, api.filters)
+	if err != nil {
+		return 0, 0, err
+	}
+	if from >= to {
+		return 0, 0, fmt.Errorf("end block (#%d) needs to come after start block (#%d)", to, from)
+	}
+	return from + 1, to, nil
+}
+
+// traceChainBlock traces a single block of the debug_traceChain range with traceBlock
+func (api *PrivateDebugAPIImpl) traceChainBlock(ctx context.Context, number uint64, config *tracersConfig.TraceConfig) *blockTraceResult {
+	res := &blockTraceResult{Block: hexutil.Uint64(number)}
+
+	// pin the block by hash, so the result can't mix up blocks if the chain reorgs in the meantime
+	if err := api.db.View(ctx, func(tx kv.Tx) error {
+		hash, ok, err := api._blockReader.CanonicalHash(ctx, tx, number)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("block %d not found", number)
+		}
+		res.Hash = hash
+		return nil
+	}); err != nil {
+		res.Error = err.Error()
+		return res
+	}
+
+	var buf bytes.Buffer
+	stream := jsoniter.NewStream(jsoniter.ConfigDefault, &buf, 4096)
+	if err := api.traceBlock(ctx, rpc.BlockNumberOrHashWithHash(res.Hash, true), config, stream); err != nil {
+		res.Error = err.Error()
+		return res
+	}
+	if err := stream.Flush(); err != nil {
+		res.Error = err.Error()
+		return res
+	}
+	res.Traces = buf.Bytes()
+	return res
+}
@@ -23,6 +23,7 @@ import (
 	"math/big"
 
 	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/dbg"
 	"github.com/erigontech/erigon-lib/common/hexutil"
 	"github.com/erigontech/erigon-lib/common/hexutility"
 	"github.com/erigontech/erigon-lib/gointerfaces"
@@ -30,6 +31,7 @@ import (
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/kv/rawdbv3"
 	"github.com/erigontech/erigon-lib/log/v3"
+	libstate "github.com/erigontech/erigon-lib/state"
 	types2 "github.com/erigontech/erigon-lib/types"
 	"github.com/holiman/uint256"
 	"google.golang.org/grpc"
@@ -48,6 +50,7 @@ import (
 	"github.com/erigontech/erigon/turbo/rpchelper"
 	"github.com/erigontech/erigon/turbo/snapshotsync/freezeblocks"
 	"github.com/erigontech/erigon/turbo/transactions"
+	"github.com/erigontech/erigon/turbo/trie"
 )
 
 var latestNumOrHash = rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
@@ -322,100 +325,133 @@ func (api *APIImpl) EstimateGas(ctx context.Context, argsOrNil *ethapi2.CallArgs
 	return hexutil.Uint64(hi), nil
 }
 
-// maxGetProofRewindBlockCount limits the number of blocks into the past that
-// GetProof will allow computing proofs.  Because we must rewind the hash state
-// and re-compute the state trie, the further back in time the request, the more
-// computationally intensive the operation becomes.  The staged sync code
-// assumes that if more than 100_000 blocks are skipped, that the entire trie
-// should be re-computed. Re-computing the entire trie will currently take ~15
-// minutes on mainnet.  The current limit has been chosen arbitrarily as
-// 'useful' without likely being overly computationally intense.
-
-// GetProof is partially implemented; no Storage proofs, and proofs must be for
-// blocks within maxGetProofRewindBlockCount blocks of the head.
+// GetProof implements eth_getProof. Returns the account and storage values of the specified account including the Merkle-proof.
+// Proofs for historical blocks are served from commitment history if it is kept. Otherwise the block must be within
+// MaxGetProofRewindBlockCount blocks of the head, because the trie is rewound by recomputing every key changed since it.
 func (api *APIImpl) GetProof(ctx context.Context, address libcommon.Address, storageKeys []libcommon.Hash, blockNrOrHash rpc.BlockNumberOrHash) (*accounts.AccProofResult, error) {
-	return nil, errors.New("not supported by Erigon3")
-	/*
-		tx, err := api.db.BeginRo(ctx)
-		if err != nil {
-			return nil, err
-		}
-		defer tx.Rollback()
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	if _, ok := tx.(libstate.HasAggTx); !ok {
+		return nil, errors.New("eth_getProof is not supported by remote database")
+	}
 
-		blockNr, _, _, err := rpchelper.GetBlockNumber(blockNrOrHash, tx, api.filters)
-		if err != nil {
-			return nil, err
-		}
+	blockNr, _, _, err := rpchelper.GetBlockNumber(ctx, blockNrOrHash, tx, api._blockReader, api.filters)
+	if err != nil {
+		return nil, err
+	}
+	header, err := api._blockReader.HeaderByNumber(ctx, tx, blockNr)
+	if err != nil {
+		return nil, err
+	}
+	if header == nil {
+		return nil, fmt.Errorf("block %d not found", blockNr)
+	}
+	latestBlock, err := rpchelper.GetLatestBlockNumber(tx)
+	if err != nil {
+		return nil, err
+	}
+	if latestBlock < blockNr {
+		// shouldn't happen, but check anyway
+		return nil, fmt.Errorf("block number is in the future latest=%d requested=%d", latestBlock, blockNr)
+	}
 
-		header, err := api._blockReader.HeaderByNumber(ctx, tx, blockNr)
-		if err != nil {
-			return nil, err
-		}
+	domains, err := libstate.NewSharedDomains(tx, api.logger)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { domains.Close() }() // `domains` is replaced by a new instance when the commitment is rewound
+	sdCtx := domains.GetCommitmentContext()
 
-		latestBlock, err := rpchelper.GetLatestBlockNumber(tx)
+	if domains.BlockNum() != blockNr {
+		txNumsReader := rawdbv3.TxNums.WithCustomReadTxNumFunc(freezeblocks.ReadTxNumFuncFromBlockReader(ctx, api._blockReader))
+		maxTxNum, err := txNumsReader.Max(tx, blockNr)
 		if err != nil {
 			return nil, err
 		}
-
-		if latestBlock < blockNr {
-			// shouldn't happen, but check anyway
-			return nil, fmt.Errorf("block number is in the future latest=%d requested=%d", latestBlock, blockNr)
+		var root []byte
+		if dbg.KeepCommitmentHistory() {
+			root, err = domains.SeekCommitmentAsOf(blockNr, maxTxNum+1)
+			if err != nil {
+				api.logger.Debug("[rpc] eth_getProof: commitment history is not available", "block", blockNr, "err", err)
+			}
 		}
-
-		rl := trie.NewRetainList(0)
-		var loader *trie.FlatDBTrieLoader
-		if blockNr < latestBlock {
+		if root == nil || libcommon.BytesToHash(root) != header.Root {
 			if latestBlock-blockNr > uint64(api.MaxGetProofRewindBlockCount) {
 				return nil, fmt.Errorf("requested block is too old, block must be within %d blocks of the head block number (currently %d)", uint64(api.MaxGetProofRewindBlockCount), latestBlock)
 			}
-			batch := membatchwithdb.NewMemoryBatch(tx, api.dirs.Tmp, api.logger)
-			defer batch.Rollback()
-
-			unwindState := &stagedsync.UnwindState{UnwindPoint: blockNr}
-			stageState := &stagedsync.StageState{BlockNumber: latestBlock}
-
-			hashStageCfg := stagedsync.StageHashStateCfg(nil, api.dirs, api.historyV3(batch))
-			if err := stagedsync.UnwindHashStateStage(unwindState, stageState, batch, hashStageCfg, ctx, api.logger); err != nil {
+			domains.Close()
+			if domains, err = libstate.NewSharedDomains(tx, api.logger); err != nil {
 				return nil, err
 			}
-
-			interHashStageCfg := stagedsync.StageTrieCfg(nil, false, false, false, api.dirs.Tmp, api._blockReader, nil, api.historyV3(batch), api._agg)
-			loader, err = stagedsync.UnwindIntermediateHashesForTrieLoader("eth_getProof", rl, unwindState, stageState, batch, interHashStageCfg, nil, nil, ctx.Done(), api.logger)
-			if err != nil {
+			sdCtx = domains.GetCommitmentContext()
+			if root, err = domains.RewindCommitment(ctx, blockNr, maxTxNum+1); err != nil {
 				return nil, err
 			}
-			tx = batch
-		} else {
-			loader = trie.NewFlatDBTrieLoader("eth_getProof", rl, nil, nil, false)
-		}
-
-		reader, err := rpchelper.CreateStateReader(ctx, tx, blockNrOrHash, 0, api.filters, api.stateCache, "")
-		if err != nil {
-			return nil, err
 		}
-		a, err := reader.ReadAccountData(address)
-		if err != nil {
-			return nil, err
+		if libcommon.BytesToHash(root) != header.Root {
+			return nil, fmt.Errorf("mismatch in expected state root computed %x vs %x indicates bug in proof implementation", root, header.Root)
 		}
+	}
+
+	reader, err := rpchelper.CreateStateReader(ctx, tx, api._blockReader, blockNrOrHash, 0, api.filters, api.stateCache, "")
+	if err != nil {
+		return nil, err
+	}
+	a, err := reader.ReadAccountData(address)
+	if err != nil {
+		return nil, err
+	}
+
+	proof, storageRoot, err := sdCtx.ProveAccount(address[:])
+	if err != nil {
+		return nil, err
+	}
+	result := &accounts.AccProofResult{
+		Address:      address,
+		Balance:      (*hexutil.Big)(new(big.Int)),
+		AccountProof: make([]hexutility.Bytes, len(proof)),
+		StorageProof: make([]accounts.StorProofResult, len(storageKeys)),
+	}
+	for i := range proof {
+		result.AccountProof[i] = proof[i]
+	}
+	if a != nil {
+		result.Nonce = hexutil.Uint64(a.Nonce)
+		result.Balance = (*hexutil.Big)(a.Balance.ToBig())
+		result.CodeHash = a.CodeHash
+		result.StorageHash = libcommon.BytesToHash(storageRoot)
+	}
+	if err := trie.VerifyAccountProof(header.Root, result); err != nil {
+		return nil, fmt.Errorf("account proof verification failed: %w", err)
+	}
+
+	for i, key := range storageKeys {
+		key := key
+		result.StorageProof[i] = accounts.StorProofResult{Key: key, Value: (*hexutil.Big)(new(big.Int)), Proof: []hexutility.Bytes{}}
 		if a == nil {
-			a = &accounts.Account{}
+			continue
 		}
-		pr, err := trie.NewProofRetainer(address, a, storageKeys, rl)
+		v, err := reader.ReadAccountStorage(address, a.Incarnation, &key)
 		if err != nil {
 			return nil, err
 		}
+		result.StorageProof[i].Value = (*hexutil.Big)(new(big.Int).SetBytes(v))
 
-		loader.SetProofRetainer(pr)
-		root, err := loader.CalcTrieRoot(tx, nil)
+		storageProof, err := sdCtx.ProveStorage(append(libcommon.Copy(address[:]), key[:]...))
 		if err != nil {
 			return nil, err
 		}
-
-		if root != header.Root {
-			return nil, fmt.Errorf("mismatch in expected state root computed %v vs %v indicates bug in proof implementation", root, header.Root)
+		for _, p := range storageProof {
+			result.StorageProof[i].Proof = append(result.StorageProof[i].Proof, p)
 		}
-		return pr.ProofResult()
-	*/
+		if err := trie.VerifyStorageProof(result.StorageHash, result.StorageProof[i]); err != nil {
+			return nil, fmt.Errorf("storage proof verification failed for key %x: %w", key, err)
+		}
+	}
+	return result, nil
 }
 
 func (api *APIImpl) tryBlockFromLru(hash libcommon.Hash) *types.Block {
@@ -23,6 +23,7 @@ import (
 	"github.com/erigontech/erigon-lib/common/hexutil"
 
 	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/gointerfaces"
 	txpool "github.com/erigontech/erigon-lib/gointerfaces/txpoolproto"
 	"google.golang.org/grpc/status"
 
@@ -111,3 +112,84 @@ func (api *APIImpl) SubmitHashrate(ctx context.Context, hashRate hexutil.Uint64,
 	}
 	return repl.Ok, nil
 }
+
+// BlockPreview is the block the node would build on top of its head
+type BlockPreview struct {
+	Number       hexutil.Uint64        `json:"number"`
+	ParentHash   libcommon.Hash        `json:"parentHash"`
+	Timestamp    hexutil.Uint64        `json:"timestamp"`
+	GasLimit     hexutil.Uint64        `json:"gasLimit"`
+	GasUsed      hexutil.Uint64        `json:"gasUsed"`
+	BaseFee      *hexutil.Big          `json:"baseFeePerGas,omitempty"`
+	PriorityFees *hexutil.Big          `json:"priorityFees"`
+	BlobGasUsed  hexutil.Uint64        `json:"blobGasUsed"`
+	BlobCount    hexutil.Uint64        `json:"blobCount"`
+	Transactions []BlockPreviewTxn     `json:"transactions"`
+	Skipped      []BlockPreviewSkipped `json:"skipped"`
+}
+
+type BlockPreviewTxn struct {
+	Hash        libcommon.Hash    `json:"hash"`
+	From        libcommon.Address `json:"from"`
+	Nonce       hexutil.Uint64    `json:"nonce"`
+	GasUsed     hexutil.Uint64    `json:"gasUsed"`
+	PriorityFee *hexutil.Big      `json:"priorityFee"`
+	BlobCount   hexutil.Uint64    `json:"blobCount"`
+}
+
+type BlockPreviewSkipped struct {
+	Hash   libcommon.Hash    `json:"hash"`
+	From   libcommon.Address `json:"from"`
+	Reason string            `json:"reason"`
+}
+
+// PreviewBlock implements eth_previewBlock. Returns the block the node would build on top of its head with the
+// transactions of the pool: the ordered transactions, the gas used, the priority fees and the transactions left
+// out with the reason. The block is neither sealed nor announced. The fee recipient defaults to the etherbase and
+// is required on proof-of-stake nodes without one.
+func (api *APIImpl) PreviewBlock(ctx context.Context, feeRecipient *libcommon.Address) (*BlockPreview, error) {
+	req := &txpool.PreviewBlockRequest{}
+	if feeRecipient != nil {
+		req.FeeRecipient = gointerfaces.ConvertAddressToH160(*feeRecipient)
+	}
+	repl, err := api.mining.PreviewBlock(ctx, req)
+	if err != nil {
+		if s, ok := status.FromError(err); ok {
+			return nil, errors.New(s.Message())
+		}
+		return nil, err
+	}
+	preview := &BlockPreview{
+		Number:       hexutil.Uint64(repl.BlockNumber),
+		ParentHash:   gointerfaces.ConvertH256ToHash(repl.ParentHash),
+		Timestamp:    hexutil.Uint64(repl.Timestamp),
+		GasLimit:     hexutil.Uint64(repl.GasLimit),
+		GasUsed:      hexutil.Uint64(repl.GasUsed),
+		PriorityFees: (*hexutil.Big)(gointerfaces.ConvertH256ToUint256Int(repl.PriorityFees).ToBig()),
+		BlobGasUsed:  hexutil.Uint64(repl.BlobGasUsed),
+		BlobCount:    hexutil.Uint64(repl.BlobCount),
+		Transactions: make([]BlockPreviewTxn, 0, len(repl.Txs)),
+		Skipped:      make([]BlockPreviewSkipped, 0, len(repl.Skipped)),
+	}
+	if repl.BaseFee != nil {
+		preview.BaseFee = (*hexutil.Big)(gointerfaces.ConvertH256ToUint256Int(repl.BaseFee).ToBig())
+	}
+	for _, txn := range repl.Txs {
+		preview.Transactions = append(preview.Transactions, BlockPreviewTxn{
+			Hash:        gointerfaces.ConvertH256ToHash(txn.Hash),
+			From:        gointerfaces.ConvertH160toAddress(txn.Sender),
+			Nonce:       hexutil.Uint64(txn.Nonce),
+			GasUsed:     hexutil.Uint64(txn.GasUsed),
+			PriorityFee: (*hexutil.Big)(gointerfaces.ConvertH256ToUint256Int(txn.PriorityFee).ToBig()),
+			BlobCount:   hexutil.Uint64(txn.BlobCount),
+		})
+	}
+	for _, skipped := range repl.Skipped {
+		preview.Skipped = append(preview.Skipped, BlockPreviewSkipped{
+			Hash:   gointerfaces.ConvertH256ToHash(skipped.Hash),
+			From:   gointerfaces.ConvertH160toAddress(skipped.Sender),
+			Reason: skipped.Reason,
+		})
+	}
+	return preview, nil
+}
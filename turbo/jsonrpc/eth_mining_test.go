@@ -17,10 +17,13 @@
 package jsonrpc
 
 import (
+	"context"
 	"math/big"
 	"testing"
 	"time"
 
+	"github.com/holiman/uint256"
+
 	"github.com/erigontech/erigon/consensus/ethash"
 	"github.com/erigontech/erigon/rpc/rpccfg"
 
@@ -28,10 +31,15 @@ import (
 
 	"github.com/erigontech/erigon-lib/log/v3"
 
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/direct"
+	"github.com/erigontech/erigon-lib/gointerfaces"
 	txpool "github.com/erigontech/erigon-lib/gointerfaces/txpoolproto"
 	"github.com/erigontech/erigon-lib/kv/kvcache"
 	"github.com/erigontech/erigon/cmd/rpcdaemon/rpcdaemontest"
 	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/ethdb/privateapi"
 	"github.com/erigontech/erigon/rlp"
 	"github.com/erigontech/erigon/turbo/rpchelper"
 	"github.com/erigontech/erigon/turbo/stages/mock"
@@ -83,3 +91,63 @@ func TestPendingLogs(t *testing.T) {
 		t.Fatalf("timeout waiting for  expected notification")
 	}
 }
+
+// testBlockPreviewer returns a fixed preview
+type testBlockPreviewer struct {
+	reply        *txpool.PreviewBlockReply
+	feeRecipient libcommon.Address
+}
+
+func (p *testBlockPreviewer) IsMining() bool { return false }
+
+func (p *testBlockPreviewer) PreviewBlock(_ context.Context, feeRecipient libcommon.Address) (*txpool.PreviewBlockReply, error) {
+	p.feeRecipient = feeRecipient
+	return p.reply, nil
+}
+
+func TestPreviewBlock(t *testing.T) {
+	m := mock.Mock(t)
+	ctx := context.Background()
+	txHash, skippedHash := libcommon.Hash{1}, libcommon.Hash{2}
+	sender, feeRecipient := libcommon.Address{3}, libcommon.Address{4}
+	previewer := &testBlockPreviewer{reply: &txpool.PreviewBlockReply{
+		BlockNumber:  7,
+		ParentHash:   gointerfaces.ConvertHashToH256(libcommon.Hash{5}),
+		GasLimit:     30_000_000,
+		GasUsed:      21_000,
+		BaseFee:      gointerfaces.ConvertUint256IntToH256(uint256.NewInt(10)),
+		PriorityFees: gointerfaces.ConvertUint256IntToH256(uint256.NewInt(42_000)),
+		Txs: []*txpool.PreviewBlockReply_Txn{{
+			Hash:        gointerfaces.ConvertHashToH256(txHash),
+			Sender:      gointerfaces.ConvertAddressToH160(sender),
+			Nonce:       5,
+			GasUsed:     21_000,
+			PriorityFee: gointerfaces.ConvertUint256IntToH256(uint256.NewInt(42_000)),
+		}},
+		Skipped: []*txpool.PreviewBlockReply_Skipped{{
+			Hash:   gointerfaces.ConvertHashToH256(skippedHash),
+			Sender: gointerfaces.ConvertAddressToH160(sender),
+			Reason: "nonce too high",
+		}},
+	}}
+	mining := direct.NewMiningClient(privateapi.NewMiningServer(ctx, previewer, nil, m.Log))
+	stateCache := kvcache.New(kvcache.DefaultCoherentConfig)
+	api := NewEthAPI(NewBaseApi(nil, stateCache, m.BlockReader, false, rpccfg.DefaultEvmCallTimeout, ethash.NewFaker(), m.Dirs, nil), nil, nil, nil, mining, 5000000, 1e18, 100_000, false, 100_000, 128, log.New())
+
+	preview, err := api.PreviewBlock(ctx, &feeRecipient)
+	require.NoError(t, err)
+	require.Equal(t, feeRecipient, previewer.feeRecipient)
+	require.Equal(t, hexutil.Uint64(7), preview.Number)
+	require.Equal(t, libcommon.Hash{5}, preview.ParentHash)
+	require.Equal(t, hexutil.Uint64(21_000), preview.GasUsed)
+	require.Equal(t, big.NewInt(10), preview.BaseFee.ToInt())
+	require.Equal(t, big.NewInt(42_000), preview.PriorityFees.ToInt())
+	require.Equal(t, []BlockPreviewTxn{{Hash: txHash, From: sender, Nonce: 5, GasUsed: 21_000, PriorityFee: (*hexutil.Big)(big.NewInt(42_000))}}, preview.Transactions)
+	require.Equal(t, []BlockPreviewSkipped{{Hash: skippedHash, From: sender, Reason: "nonce too high"}}, preview.Skipped)
+
+	// a backend without block building
+	mining = direct.NewMiningClient(privateapi.NewMiningServer(ctx, &rpcdaemontest.IsMiningMock{}, nil, m.Log))
+	api = NewEthAPI(NewBaseApi(nil, stateCache, m.BlockReader, false, rpccfg.DefaultEvmCallTimeout, ethash.NewFaker(), m.Dirs, nil), nil, nil, nil, mining, 5000000, 1e18, 100_000, false, 100_000, 128, log.New())
+	_, err = api.PreviewBlock(ctx, nil)
+	require.ErrorContains(t, err, "not supported")
+}
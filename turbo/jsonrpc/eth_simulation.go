@@ -0,0 +1,731 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync/atomic"
+
+	"github.com/holiman/uint256"
+
+	"github.com/erigontech/erigon-lib/chain"
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/common/hexutility"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/rawdbv3"
+	"github.com/erigontech/erigon-lib/log/v3"
+	libstate "github.com/erigontech/erigon-lib/state"
+	types2 "github.com/erigontech/erigon-lib/types"
+
+	"github.com/erigontech/erigon/consensus/misc"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/core/tracing"
+	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/core/vm"
+	"github.com/erigontech/erigon/crypto"
+	"github.com/erigontech/erigon/params"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/turbo/adapter/ethapi"
+	"github.com/erigontech/erigon/turbo/rpchelper"
+	"github.com/erigontech/erigon/turbo/snapshotsync/freezeblocks"
+)
+
+const (
+	// maxSimulateBlocks is the maximum number of blocks (including the gaps filled with empty blocks) in one eth_simulateV1 request
+	maxSimulateBlocks = 256
+	// simulateTimestampIncrement is the default increment of the timestamp between simulated blocks
+	simulateTimestampIncrement = 12
+)
+
+// Error codes of eth_simulateV1 (see execution-apis)
+const (
+	simErrCodeNonceTooLow             = -38010
+	simErrCodeNonceTooHigh            = -38011
+	simErrCodeFeeCapTooLow            = -38012
+	simErrCodeIntrinsicGas            = -38013
+	simErrCodeInsufficientFunds       = -38014
+	simErrCodeBlockGasLimitReached    = -38015
+	simErrCodeBlockNumberInvalid      = -38020
+	simErrCodeBlockTimestampInvalid   = -38021
+	simErrCodeSenderIsNotEOA          = -38024
+	simErrCodeMaxInitCodeSizeExceeded = -38025
+	simErrCodeClientLimitExceeded     = -38026
+	simErrCodeInvalidParams           = -32602
+	simErrCodeVMError                 = -32015
+	simErrCodeReverted                = 3
+)
+
+var (
+	// simulationTransferAddress is the pseudo-address emitting ether transfer logs when `traceTransfers` is requested
+	simulationTransferAddress = common.HexToAddress("0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE")
+	// simulationTransferTopic is keccak256('Transfer(address,address,uint256)')
+	simulationTransferTopic = common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+)
+
+// SimulationRequest is the input of eth_simulateV1
+type SimulationRequest struct {
+	BlockStateCalls        []SimulatedBlock `json:"blockStateCalls"`
+	TraceTransfers         bool             `json:"traceTransfers"`
+	Validation             bool             `json:"validation"`
+	ReturnFullTransactions bool             `json:"returnFullTransactions"`
+}
+
+// SimulatedBlock is a block of calls executed on top of the state left by previous simulated block
+type SimulatedBlock struct {
+	BlockOverrides *SimulationBlockOverrides `json:"blockOverrides"`
+	StateOverrides *ethapi.StateOverrides    `json:"stateOverrides"`
+	Calls          []ethapi.CallArgs         `json:"calls"`
+}
+
+// SimulationBlockOverrides are the header fields of simulated block which may be set by the caller,
+// fields which are not set are derived from the parent block
+type SimulationBlockOverrides struct {
+	Number        *hexutil.Big      `json:"number"`
+	Time          *hexutil.Uint64   `json:"time"`
+	GasLimit      *hexutil.Uint64   `json:"gasLimit"`
+	FeeRecipient  *common.Address   `json:"feeRecipient"`
+	PrevRandao    *common.Hash      `json:"prevRandao"`
+	BaseFeePerGas *hexutil.Big      `json:"baseFeePerGas"`
+	BlobBaseFee   *hexutil.Big      `json:"blobBaseFee"`
+	Withdrawals   types.Withdrawals `json:"withdrawals"`
+}
+
+// SimulationCallResult is the outcome of a single call of simulated block
+type SimulationCallResult struct {
+	ReturnValue hexutility.Bytes     `json:"returnData"`
+	Logs        []*types.Log         `json:"logs"`
+	GasUsed     hexutil.Uint64       `json:"gasUsed"`
+	Status      hexutil.Uint64       `json:"status"`
+	Error       *SimulationCallError `json:"error,omitempty"`
+}
+
+type SimulationCallError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    string `json:"data,omitempty"`
+}
+
+// SimulateV1 implements eth_simulateV1. Executes series of calls in a chain of simulated blocks on top of the given block
+// and returns the simulated blocks along with results of each call.
+func (api *APIImpl) SimulateV1(ctx context.Context, req SimulationRequest, blockNrOrHash *rpc.BlockNumberOrHash) ([]map[string]interface{}, error) {
+	if len(req.BlockStateCalls) == 0 {
+		return nil, &rpc.CustomError{Code: simErrCodeInvalidParams, Message: "empty input"}
+	}
+	if len(req.BlockStateCalls) > maxSimulateBlocks {
+		return nil, &rpc.CustomError{Code: simErrCodeClientLimitExceeded, Message: "too many blocks"}
+	}
+	bNrOrHash := latestNumOrHash
+	if blockNrOrHash != nil {
+		bNrOrHash = *blockNrOrHash
+	}
+
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	chainConfig, err := api.chainConfig(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	blockNum, hash, _, err := rpchelper.GetCanonicalBlockNumber(ctx, bNrOrHash, tx, api._blockReader, api.filters)
+	if err != nil {
+		return nil, err
+	}
+	parent, err := api._blockReader.Header(ctx, tx, hash, blockNum)
+	if err != nil {
+		return nil, err
+	}
+	if parent == nil {
+		return nil, fmt.Errorf("block %d(%x) not found", blockNum, hash)
+	}
+	blocks, err := sanitizeSimulatedChain(parent, req.BlockStateCalls)
+	if err != nil {
+		return nil, err
+	}
+
+	stateReader, err := rpchelper.CreateStateReader(ctx, tx, api._blockReader, rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(blockNum)), 0, api.filters, api.stateCache, chainConfig.ChainName)
+	if err != nil {
+		return nil, err
+	}
+	ibs := state.New(stateReader)
+
+	// State root of simulated blocks is computed by commitment, when it can be positioned at the requested block
+	var stateWriter state.StateWriter = state.NewNoopWriter()
+	domains, err := api.simulationDomains(ctx, tx, blockNum)
+	if err != nil {
+		return nil, err
+	}
+	if domains != nil {
+		defer domains.Close()
+		stateWriter = state.NewWriterV4(domains)
+	}
+
+	var cancel context.CancelFunc
+	if api.evmCallTimeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, api.evmCallTimeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+	var currentEvm atomic.Pointer[vm.EVM]
+	go func() {
+		<-ctx.Done()
+		if evm := currentEvm.Load(); evm != nil {
+			evm.Cancel()
+		}
+	}()
+
+	sim := &simulator{
+		api:         api,
+		tx:          tx,
+		chainConfig: chainConfig,
+		req:         req,
+		ibs:         ibs,
+		stateWriter: stateWriter,
+		domains:     domains,
+		base:        parent,
+		hashes:      map[uint64]common.Hash{},
+		currentEvm:  &currentEvm,
+	}
+	results := make([]map[string]interface{}, 0, len(blocks))
+	for _, b := range blocks {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("execution aborted (timeout = %v)", api.evmCallTimeout)
+		}
+		fields, err := sim.processBlock(ctx, parent, b)
+		if err != nil {
+			return nil, err
+		}
+		parent = sim.lastHeader
+		results = append(results, fields)
+	}
+	return results, nil
+}
+
+// sanitizeSimulatedChain checks that numbers and timestamps of requested blocks are increasing and fills the gaps
+// between block numbers with empty blocks
+func sanitizeSimulatedChain(base *types.Header, blocks []SimulatedBlock) ([]SimulatedBlock, error) {
+	res := make([]SimulatedBlock, 0, len(blocks))
+	prevNumber, prevTime := base.Number.Uint64(), base.Time
+	for _, b := range blocks {
+		if b.BlockOverrides == nil {
+			b.BlockOverrides = &SimulationBlockOverrides{}
+		}
+		if b.BlockOverrides.Number == nil {
+			b.BlockOverrides.Number = (*hexutil.Big)(new(big.Int).SetUint64(prevNumber + 1))
+		}
+		if !b.BlockOverrides.Number.ToInt().IsUint64() || b.BlockOverrides.Number.ToInt().Uint64() <= prevNumber {
+			return nil, &rpc.CustomError{Code: simErrCodeBlockNumberInvalid, Message: fmt.Sprintf("block numbers must be in order: %s <= %d", b.BlockOverrides.Number.ToInt(), prevNumber)}
+		}
+		number := b.BlockOverrides.Number.ToInt().Uint64()
+		if number-base.Number.Uint64() > maxSimulateBlocks {
+			return nil, &rpc.CustomError{Code: simErrCodeClientLimitExceeded, Message: "too many blocks"}
+		}
+		for n := prevNumber + 1; n < number; n++ {
+			prevTime += simulateTimestampIncrement
+			t := hexutil.Uint64(prevTime)
+			res = append(res, SimulatedBlock{BlockOverrides: &SimulationBlockOverrides{Number: (*hexutil.Big)(new(big.Int).SetUint64(n)), Time: &t}})
+		}
+		if b.BlockOverrides.Time == nil {
+			t := hexutil.Uint64(prevTime + simulateTimestampIncrement)
+			b.BlockOverrides.Time = &t
+		}
+		if uint64(*b.BlockOverrides.Time) <= prevTime {
+			return nil, &rpc.CustomError{Code: simErrCodeBlockTimestampInvalid, Message: fmt.Sprintf("block timestamps must be in order: %d <= %d", *b.BlockOverrides.Time, prevTime)}
+		}
+		prevNumber, prevTime = number, uint64(*b.BlockOverrides.Time)
+		res = append(res, b)
+	}
+	return res, nil
+}
+
+// simulationDomains returns domains with commitment positioned at the end of blockNum, which are used to
+// compute state roots of simulated blocks. Returns nil if state root can't be computed (remote db or too old block),
+// in this case state root of simulated blocks is left empty.
+func (api *APIImpl) simulationDomains(ctx context.Context, tx kv.Tx, blockNum uint64) (*libstate.SharedDomains, error) {
+	if _, ok := tx.(libstate.HasAggTx); !ok {
+		return nil, nil
+	}
+	domains, err := libstate.NewSharedDomains(tx, api.logger)
+	if err != nil {
+		return nil, err
+	}
+	if domains.BlockNum() == blockNum {
+		return domains, nil
+	}
+	latestBlock, err := rpchelper.GetLatestBlockNumber(tx)
+	if err != nil {
+		domains.Close()
+		return nil, err
+	}
+	if blockNum > latestBlock || latestBlock-blockNum > uint64(api.MaxGetProofRewindBlockCount) {
+		domains.Close()
+		return nil, nil
+	}
+	txNumsReader := rawdbv3.TxNums.WithCustomReadTxNumFunc(freezeblocks.ReadTxNumFuncFromBlockReader(ctx, api._blockReader))
+	maxTxNum, err := txNumsReader.Max(tx, blockNum)
+	if err != nil {
+		domains.Close()
+		return nil, err
+	}
+	if _, err := domains.RewindCommitment(ctx, blockNum, maxTxNum+1); err != nil {
+		domains.Close()
+		return nil, err
+	}
+	return domains, nil
+}
+
+type simulator struct {
+	api         *APIImpl
+	tx          kv.Tx
+	chainConfig *chain.Config
+	req         SimulationRequest
+	ibs         *state.IntraBlockState
+	stateWriter state.StateWriter
+	domains     *libstate.SharedDomains
+	base        *types.Header
+	hashes      map[uint64]common.Hash // hashes of already simulated blocks
+	currentEvm  *atomic.Pointer[vm.EVM]
+	txIndex     int // index of transaction in ibs, continues across simulated blocks
+	lastHeader  *types.Header
+}
+
+func (s *simulator) getHash(n uint64) common.Hash {
+	if h, ok := s.hashes[n]; ok {
+		return h
+	}
+	if n > s.base.Number.Uint64() {
+		return common.Hash{}
+	}
+	h, ok, err := s.api._blockReader.CanonicalHash(context.Background(), s.tx, n)
+	if err != nil || !ok {
+		log.Debug("Can't get block hash by number", "number", n, "only-canonical", true, "err", err, "ok", ok)
+	}
+	return h
+}
+
+func (s *simulator) makeHeader(parent *types.Header, overrides *SimulationBlockOverrides) *types.Header {
+	number := overrides.Number.ToInt().Uint64()
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		UncleHash:  types.EmptyUncleHash,
+		Coinbase:   parent.Coinbase,
+		Difficulty: new(big.Int),
+		Number:     new(big.Int).SetUint64(number),
+		GasLimit:   parent.GasLimit,
+		Time:       uint64(*overrides.Time),
+	}
+	if overrides.GasLimit != nil {
+		header.GasLimit = uint64(*overrides.GasLimit)
+	}
+	if overrides.FeeRecipient != nil {
+		header.Coinbase = *overrides.FeeRecipient
+	}
+	if overrides.PrevRandao != nil {
+		header.MixDigest = *overrides.PrevRandao
+	}
+	if s.chainConfig.IsLondon(number) {
+		switch {
+		case overrides.BaseFeePerGas != nil:
+			header.BaseFee = new(big.Int).Set(overrides.BaseFeePerGas.ToInt())
+		case s.req.Validation:
+			header.BaseFee = misc.CalcBaseFee(s.chainConfig, parent)
+		default:
+			header.BaseFee = new(big.Int)
+		}
+	}
+	if s.chainConfig.IsCancun(header.Time) {
+		excessBlobGas := misc.CalcExcessBlobGas(s.chainConfig, parent)
+		header.ExcessBlobGas = &excessBlobGas
+		header.BlobGasUsed = new(uint64)
+		header.ParentBeaconBlockRoot = new(common.Hash)
+	}
+	return header
+}
+
+// processBlock executes calls of simulated block and returns it in the RPC representation
+func (s *simulator) processBlock(ctx context.Context, parent *types.Header, b SimulatedBlock) (map[string]interface{}, error) {
+	header := s.makeHeader(parent, b.BlockOverrides)
+	blockNum := header.Number.Uint64()
+	rules := s.chainConfig.Rules(blockNum, header.Time)
+
+	precompiles := vm.ActivePrecompiledContracts(rules)
+	if b.StateOverrides != nil {
+		if err := b.StateOverrides.OverridePrecompiles(precompiles); err != nil {
+			return nil, &rpc.CustomError{Code: simErrCodeInvalidParams, Message: err.Error()}
+		}
+		if err := b.StateOverrides.Override(s.ibs); err != nil {
+			return nil, &rpc.CustomError{Code: simErrCodeInvalidParams, Message: err.Error()}
+		}
+		if err := s.ibs.FinalizeTx(rules, s.stateWriter); err != nil {
+			return nil, err
+		}
+	}
+
+	blockCtx := core.NewEVMBlockContext(header, s.getHash, s.api.engine(), &header.Coinbase, s.chainConfig)
+	if b.BlockOverrides.BlobBaseFee != nil {
+		blobBaseFee, overflow := uint256.FromBig(b.BlockOverrides.BlobBaseFee.ToInt())
+		if overflow {
+			return nil, &rpc.CustomError{Code: simErrCodeInvalidParams, Message: "blobBaseFee higher than 2^256-1"}
+		}
+		blockCtx.BlobBaseFee = blobBaseFee
+	}
+	var tracer *simulationTransferTracer
+	vmConfig := vm.Config{NoBaseFee: !s.req.Validation}
+	if s.req.TraceTransfers {
+		tracer = &simulationTransferTracer{ibs: s.ibs}
+		vmConfig.Debug, vmConfig.Tracer = true, tracer
+	}
+	evm := vm.NewEVM(blockCtx, core.NewEVMTxContext(types.Message{}), s.ibs, s.chainConfig, vmConfig)
+	evm.SetPrecompiles(precompiles)
+	s.currentEvm.Store(evm)
+
+	var baseFee *uint256.Int
+	if header.BaseFee != nil {
+		baseFee, _ = uint256.FromBig(header.BaseFee)
+	}
+	gp := new(core.GasPool).AddGas(header.GasLimit).AddBlobGas(s.chainConfig.GetMaxBlobGasPerBlock())
+	var (
+		txs         = make(types.Transactions, 0, len(b.Calls))
+		receipts    = make(types.Receipts, 0, len(b.Calls))
+		callResults = make([]SimulationCallResult, 0, len(b.Calls))
+		gasUsed     uint64
+	)
+	for i, args := range b.Calls {
+		if err := s.sanitizeCall(&args, header, gasUsed); err != nil {
+			return nil, err
+		}
+		txn, msg, err := s.toTransaction(args, baseFee, rules)
+		if err != nil {
+			return nil, &rpc.CustomError{Code: simErrCodeInvalidParams, Message: err.Error()}
+		}
+		s.ibs.SetTxContext(s.txIndex)
+		if tracer != nil {
+			tracer.txIndex = s.txIndex
+		}
+		evm.TxContext = core.NewEVMTxContext(msg)
+		result, err := core.ApplyMessage(evm, msg, gp, true /* refunds */, false /* gasBailout */)
+		if err != nil {
+			return nil, simulationTxError(i, err)
+		}
+		if evm.Cancelled() {
+			return nil, fmt.Errorf("execution aborted (timeout = %v)", s.api.evmCallTimeout)
+		}
+		if err := s.ibs.FinalizeTx(rules, s.stateWriter); err != nil {
+			return nil, err
+		}
+		gasUsed += result.UsedGas
+
+		logs := s.ibs.GetRawLogs(s.txIndex)
+		if tracer != nil {
+			logs = tracer.mergeLogs(logs)
+		}
+		receipt := &types.Receipt{
+			Type:              txn.Type(),
+			CumulativeGasUsed: gasUsed,
+			TxHash:            txn.Hash(),
+			GasUsed:           result.UsedGas,
+			Logs:              logs,
+			TransactionIndex:  uint(i),
+			BlockNumber:       header.Number,
+		}
+		if result.Failed() {
+			receipt.Status = types.ReceiptStatusFailed
+		} else {
+			receipt.Status = types.ReceiptStatusSuccessful
+		}
+		if msg.To() == nil {
+			receipt.ContractAddress = crypto.CreateAddress(msg.From(), txn.GetNonce())
+		}
+		receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+
+		callResult := SimulationCallResult{
+			ReturnValue: result.Return(),
+			GasUsed:     hexutil.Uint64(result.UsedGas),
+			Status:      hexutil.Uint64(receipt.Status),
+		}
+		if result.Failed() {
+			if errors.Is(result.Err, vm.ErrExecutionReverted) {
+				revertErr := ethapi.NewRevertError(result)
+				callResult.Error = &SimulationCallError{Code: simErrCodeReverted, Message: revertErr.Error(), Data: hexutility.Encode(result.Revert())}
+			} else {
+				callResult.Error = &SimulationCallError{Code: simErrCodeVMError, Message: result.Err.Error()}
+			}
+		}
+		txs = append(txs, txn)
+		receipts = append(receipts, receipt)
+		callResults = append(callResults, callResult)
+		s.txIndex++
+	}
+
+	var withdrawals types.Withdrawals
+	if s.chainConfig.IsShanghai(header.Time) {
+		withdrawals = make(types.Withdrawals, 0, len(b.BlockOverrides.Withdrawals))
+		for _, w := range b.BlockOverrides.Withdrawals {
+			amount := new(uint256.Int).Mul(new(uint256.Int).SetUint64(w.Amount), uint256.NewInt(params.GWei))
+			s.ibs.AddBalance(w.Address, amount, tracing.BalanceIncreaseWithdrawal)
+			withdrawals = append(withdrawals, w)
+		}
+		if err := s.ibs.FinalizeTx(rules, s.stateWriter); err != nil {
+			return nil, err
+		}
+	} else if len(b.BlockOverrides.Withdrawals) > 0 {
+		return nil, &rpc.CustomError{Code: simErrCodeInvalidParams, Message: "withdrawals are not supported before Shanghai"}
+	}
+	var requests types.Requests
+	if s.chainConfig.IsPrague(header.Time) {
+		requests = types.Requests{}
+	}
+
+	header.GasUsed = gasUsed
+	if s.domains != nil {
+		root, err := s.domains.ComputeCommitment(ctx, false, blockNum, "eth_simulateV1")
+		if err != nil {
+			return nil, err
+		}
+		header.Root = common.BytesToHash(root)
+	}
+	block := types.NewBlock(header, txs, nil, receipts, withdrawals, requests)
+	blockHash := block.Hash()
+	s.hashes[blockNum] = blockHash
+	s.lastHeader = block.Header()
+
+	var logIndex uint
+	for i, receipt := range receipts {
+		receipt.BlockHash = blockHash
+		for _, l := range receipt.Logs {
+			l.BlockNumber, l.BlockHash = blockNum, blockHash
+			l.TxHash, l.TxIndex, l.Index = receipt.TxHash, uint(i), logIndex
+			logIndex++
+		}
+		callResults[i].Logs = receipt.Logs
+		if callResults[i].Logs == nil {
+			callResults[i].Logs = []*types.Log{}
+		}
+	}
+	return ethapi.RPCMarshalBlock(block, true, s.req.ReturnFullTransactions, map[string]interface{}{"calls": callResults})
+}
+
+// sanitizeCall fills nonce and gas of the call if they are not set and checks that the call fits into the block
+func (s *simulator) sanitizeCall(args *ethapi.CallArgs, header *types.Header, gasUsed uint64) error {
+	if args.From == nil {
+		args.From = new(common.Address)
+	}
+	if args.Nonce == nil {
+		nonce := hexutil.Uint64(s.ibs.GetNonce(*args.From))
+		args.Nonce = &nonce
+	}
+	if args.Gas == nil {
+		gas := header.GasLimit - gasUsed
+		if s.api.GasCap > 0 && gas > s.api.GasCap {
+			gas = s.api.GasCap
+		}
+		args.Gas = (*hexutil.Uint64)(&gas)
+	}
+	if gasUsed+uint64(*args.Gas) > header.GasLimit {
+		return &rpc.CustomError{Code: simErrCodeBlockGasLimitReached, Message: fmt.Sprintf("block gas limit reached: %d >= %d", gasUsed, header.GasLimit)}
+	}
+	return nil
+}
+
+// toTransaction builds unsigned transaction from the call and the message to execute it
+func (s *simulator) toTransaction(args ethapi.CallArgs, baseFee *uint256.Int, rules *chain.Rules) (types.Transaction, types.Message, error) {
+	if args.GasPrice != nil && (args.MaxFeePerGas != nil || args.MaxPriorityFeePerGas != nil) {
+		return nil, types.Message{}, errors.New("both gasPrice and (maxFeePerGas or maxPriorityFeePerGas) specified")
+	}
+	value := new(uint256.Int)
+	if args.Value != nil {
+		if overflow := value.SetFromBig(args.Value.ToInt()); overflow {
+			return nil, types.Message{}, errors.New("args.Value higher than 2^256-1")
+		}
+	}
+	var data []byte
+	if args.Input != nil {
+		data = *args.Input
+	} else if args.Data != nil {
+		data = *args.Data
+	}
+	var accessList types2.AccessList
+	if args.AccessList != nil {
+		accessList = *args.AccessList
+	}
+	toUint256 := func(v *hexutil.Big, name string) (*uint256.Int, error) {
+		res := new(uint256.Int)
+		if v != nil {
+			if overflow := res.SetFromBig(v.ToInt()); overflow {
+				return nil, fmt.Errorf("args.%s higher than 2^256-1", name)
+			}
+		}
+		return res, nil
+	}
+	chainID, _ := uint256.FromBig(s.chainConfig.ChainID)
+	commonTx := func() types.CommonTx {
+		return types.CommonTx{Nonce: uint64(*args.Nonce), Gas: uint64(*args.Gas), To: args.To, Value: value, Data: data}
+	}
+
+	var (
+		txn                      types.Transaction
+		gasPrice, feeCap, tipCap *uint256.Int
+		err                      error
+	)
+	if args.GasPrice != nil || !rules.IsLondon {
+		if gasPrice, err = toUint256(args.GasPrice, "GasPrice"); err != nil {
+			return nil, types.Message{}, err
+		}
+		feeCap, tipCap = gasPrice, gasPrice
+		if args.AccessList != nil && rules.IsBerlin {
+			txn = &types.AccessListTx{LegacyTx: types.LegacyTx{CommonTx: commonTx(), GasPrice: gasPrice}, ChainID: chainID, AccessList: accessList}
+		} else {
+			txn = &types.LegacyTx{CommonTx: commonTx(), GasPrice: gasPrice}
+		}
+	} else {
+		if feeCap, err = toUint256(args.MaxFeePerGas, "MaxFeePerGas"); err != nil {
+			return nil, types.Message{}, err
+		}
+		if tipCap, err = toUint256(args.MaxPriorityFeePerGas, "MaxPriorityFeePerGas"); err != nil {
+			return nil, types.Message{}, err
+		}
+		gasPrice = new(uint256.Int)
+		if !feeCap.IsZero() || !tipCap.IsZero() {
+			gasPrice.Add(tipCap, baseFee)
+			if gasPrice.Gt(feeCap) {
+				gasPrice.Set(feeCap)
+			}
+		}
+		txn = &types.DynamicFeeTransaction{CommonTx: commonTx(), ChainID: chainID, Tip: tipCap, FeeCap: feeCap, AccessList: accessList}
+	}
+	txn.SetSender(*args.From)
+
+	msg := types.NewMessage(*args.From, args.To, uint64(*args.Nonce), value, uint64(*args.Gas), gasPrice, feeCap, tipCap, data, accessList, s.req.Validation /* checkNonce */, false /* isFree */, nil /* maxFeePerBlobGas */)
+	return txn, msg, nil
+}
+
+// simulationTxError converts the error of invalid transaction into the RPC error with the code defined by eth_simulateV1
+func simulationTxError(i int, err error) error {
+	code := simErrCodeInvalidParams
+	switch {
+	case errors.Is(err, core.ErrNonceTooLow):
+		code = simErrCodeNonceTooLow
+	case errors.Is(err, core.ErrNonceTooHigh):
+		code = simErrCodeNonceTooHigh
+	case errors.Is(err, core.ErrFeeCapTooLow):
+		code = simErrCodeFeeCapTooLow
+	case errors.Is(err, core.ErrIntrinsicGas):
+		code = simErrCodeIntrinsicGas
+	case errors.Is(err, core.ErrInsufficientFunds):
+		code = simErrCodeInsufficientFunds
+	case errors.Is(err, core.ErrGasLimitReached):
+		code = simErrCodeBlockGasLimitReached
+	case errors.Is(err, core.ErrSenderNoEOA):
+		code = simErrCodeSenderIsNotEOA
+	case errors.Is(err, core.ErrMaxInitCodeSizeExceeded):
+		code = simErrCodeMaxInitCodeSizeExceeded
+	}
+	return &rpc.CustomError{Code: code, Message: fmt.Sprintf("call %d: %v", i, err)}
+}
+
+// simulationTransferTracer records ether transfers as ERC-20 like Transfer logs emitted by simulationTransferAddress
+type simulationTransferTracer struct {
+	ibs     *state.IntraBlockState
+	txIndex int
+	logs    []simulationTransferLog
+	frames  []int // number of recorded transfer logs at the start of each call frame
+}
+
+type simulationTransferLog struct {
+	pos int // number of logs emitted by the transaction before this transfer
+	log *types.Log
+}
+
+func (t *simulationTransferTracer) captureTransfer(from, to common.Address, value *uint256.Int) {
+	t.frames = append(t.frames, len(t.logs))
+	if value == nil || value.IsZero() {
+		return
+	}
+	data := value.Bytes32()
+	t.logs = append(t.logs, simulationTransferLog{
+		pos: len(t.ibs.GetRawLogs(t.txIndex)),
+		log: &types.Log{
+			Address: simulationTransferAddress,
+			Topics:  []common.Hash{simulationTransferTopic, common.BytesToHash(from[:]), common.BytesToHash(to[:])},
+			Data:    data[:],
+		},
+	})
+}
+
+func (t *simulationTransferTracer) exitFrame(err error) {
+	if len(t.frames) == 0 {
+		return
+	}
+	start := t.frames[len(t.frames)-1]
+	t.frames = t.frames[:len(t.frames)-1]
+	if err != nil {
+		t.logs = t.logs[:start]
+	}
+}
+
+// mergeLogs puts recorded transfer logs between the logs emitted by the transaction
+func (t *simulationTransferTracer) mergeLogs(logs types.Logs) types.Logs {
+	if len(t.logs) == 0 {
+		return logs
+	}
+	merged := make(types.Logs, 0, len(logs)+len(t.logs))
+	j := 0
+	for i, l := range logs {
+		for ; j < len(t.logs) && t.logs[j].pos <= i; j++ {
+			merged = append(merged, t.logs[j].log)
+		}
+		merged = append(merged, l)
+	}
+	for ; j < len(t.logs); j++ {
+		merged = append(merged, t.logs[j].log)
+	}
+	return merged
+}
+
+func (t *simulationTransferTracer) CaptureTxStart(gasLimit uint64) {
+	t.logs, t.frames = t.logs[:0], t.frames[:0]
+}
+func (t *simulationTransferTracer) CaptureTxEnd(restGas uint64) {}
+func (t *simulationTransferTracer) CaptureStart(env *vm.EVM, from common.Address, to common.Address, precompile bool, create bool, input []byte, gas uint64, value *uint256.Int, code []byte) {
+	t.captureTransfer(from, to, value)
+}
+func (t *simulationTransferTracer) CaptureEnd(output []byte, usedGas uint64, err error) {
+	t.exitFrame(err)
+}
+func (t *simulationTransferTracer) CaptureEnter(typ vm.OpCode, from common.Address, to common.Address, precompile bool, create bool, input []byte, gas uint64, value *uint256.Int, code []byte) {
+	if typ == vm.DELEGATECALL || typ == vm.STATICCALL {
+		value = nil
+	}
+	t.captureTransfer(from, to, value)
+}
+func (t *simulationTransferTracer) CaptureExit(output []byte, usedGas uint64, err error) {
+	t.exitFrame(err)
+}
+func (t *simulationTransferTracer) CaptureState(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, rData []byte, depth int, err error) {
+}
+func (t *simulationTransferTracer) CaptureFault(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, depth int, err error) {
+}
+
+var _ vm.EVMLogger = (*simulationTransferTracer)(nil)
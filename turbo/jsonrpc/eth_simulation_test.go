@@ -0,0 +1,145 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/common/hexutility"
+	"github.com/erigontech/erigon-lib/log/v3"
+
+	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/turbo/adapter/ethapi"
+)
+
+func TestSimulateV1(t *testing.T) {
+	m, bankAddr, _ := chainWithDeployedContract(t)
+	api := NewEthAPI(newBaseApiForTest(m), m.DB, nil, nil, nil, 5000000, 1e18, 100_000, false, 100_000, 128, log.New())
+	ctx := context.Background()
+
+	tx, err := m.DB.BeginRo(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+	latest, err := api.headerByRPCNumber(ctx, rpc.LatestBlockNumber, tx)
+	require.NoError(t, err)
+
+	t.Run("transfersAndGaps", func(t *testing.T) {
+		to := libcommon.HexToAddress("0x1234")
+		value := (*hexutil.Big)(big.NewInt(1000))
+		number := (*hexutil.Big)(new(big.Int).Add(latest.Number, big.NewInt(3)))
+		res, err := api.SimulateV1(ctx, SimulationRequest{
+			TraceTransfers: true,
+			BlockStateCalls: []SimulatedBlock{
+				{Calls: []ethapi.CallArgs{{From: &bankAddr, To: &to, Value: value}}},
+				{BlockOverrides: &SimulationBlockOverrides{Number: number}, Calls: []ethapi.CallArgs{{From: &bankAddr, To: &to, Value: value}}},
+			},
+		}, nil)
+		require.NoError(t, err)
+		require.Len(t, res, 3)
+
+		parentHash := latest.Hash()
+		for i, b := range res {
+			require.Equal(t, latest.Number.Uint64()+uint64(i)+1, b["number"].(*hexutil.Big).ToInt().Uint64())
+			require.Equal(t, parentHash, b["parentHash"])
+			parentHash = b["hash"].(libcommon.Hash)
+		}
+		require.Empty(t, res[1]["calls"])
+
+		for _, i := range []int{0, 2} {
+			calls := res[i]["calls"].([]SimulationCallResult)
+			require.Len(t, calls, 1)
+			require.Equal(t, hexutil.Uint64(types.ReceiptStatusSuccessful), calls[0].Status)
+			require.Nil(t, calls[0].Error)
+			require.Len(t, calls[0].Logs, 1)
+			transfer := calls[0].Logs[0]
+			require.Equal(t, simulationTransferAddress, transfer.Address)
+			require.Equal(t, []libcommon.Hash{simulationTransferTopic, libcommon.BytesToHash(bankAddr[:]), libcommon.BytesToHash(to[:])}, transfer.Topics)
+			require.Equal(t, big.NewInt(1000), new(big.Int).SetBytes(transfer.Data))
+			require.Equal(t, res[i]["hash"], transfer.BlockHash)
+		}
+	})
+
+	t.Run("stateRoot", func(t *testing.T) {
+		for _, blockNum := range []rpc.BlockNumber{rpc.LatestBlockNumber, 1} {
+			header, err := api.headerByRPCNumber(ctx, blockNum, tx)
+			require.NoError(t, err)
+			bNrOrHash := rpc.BlockNumberOrHashWithNumber(blockNum)
+			res, err := api.SimulateV1(ctx, SimulationRequest{BlockStateCalls: []SimulatedBlock{{}}}, &bNrOrHash)
+			require.NoError(t, err)
+			require.Len(t, res, 1)
+			require.Equal(t, header.Root, res[0]["stateRoot"], "empty block must keep state root of block %d", header.Number.Uint64())
+		}
+	})
+
+	t.Run("movePrecompile", func(t *testing.T) {
+		sha256Addr := libcommon.BytesToAddress([]byte{0x2})
+		movedTo := libcommon.HexToAddress("0x5678")
+		input := hexutility.Bytes("abc")
+		overrides := ethapi.StateOverrides{sha256Addr: ethapi.Account{MovePrecompileTo: &movedTo}}
+		res, err := api.SimulateV1(ctx, SimulationRequest{
+			BlockStateCalls: []SimulatedBlock{{
+				StateOverrides: &overrides,
+				Calls:          []ethapi.CallArgs{{From: &bankAddr, To: &movedTo, Input: &input}},
+			}},
+		}, nil)
+		require.NoError(t, err)
+		calls := res[0]["calls"].([]SimulationCallResult)
+		expected := sha256.Sum256(input)
+		require.Equal(t, hexutility.Bytes(expected[:]), calls[0].ReturnValue)
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		poor := libcommon.HexToAddress("0xdead")
+		value := (*hexutil.Big)(big.NewInt(1))
+		_, err := api.SimulateV1(ctx, SimulationRequest{
+			Validation: true,
+			BlockStateCalls: []SimulatedBlock{{
+				Calls: []ethapi.CallArgs{{From: &poor, To: &bankAddr, Value: value}},
+			}},
+		}, nil)
+		var rpcErr *rpc.CustomError
+		require.ErrorAs(t, err, &rpcErr)
+		require.Equal(t, simErrCodeInsufficientFunds, rpcErr.Code)
+
+		nonce := hexutil.Uint64(100)
+		_, err = api.SimulateV1(ctx, SimulationRequest{
+			Validation: true,
+			BlockStateCalls: []SimulatedBlock{{
+				Calls: []ethapi.CallArgs{{From: &bankAddr, To: &poor, Nonce: &nonce}},
+			}},
+		}, nil)
+		require.ErrorAs(t, err, &rpcErr)
+		require.Equal(t, simErrCodeNonceTooHigh, rpcErr.Code)
+	})
+
+	t.Run("invalidBlockNumbers", func(t *testing.T) {
+		_, err := api.SimulateV1(ctx, SimulationRequest{
+			BlockStateCalls: []SimulatedBlock{{BlockOverrides: &SimulationBlockOverrides{Number: (*hexutil.Big)(latest.Number)}}},
+		}, nil)
+		var rpcErr *rpc.CustomError
+		require.ErrorAs(t, err, &rpcErr)
+		require.Equal(t, simErrCodeBlockNumberInvalid, rpcErr.Code)
+	})
+}
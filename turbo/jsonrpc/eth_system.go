@@ -25,6 +25,7 @@ import (
 	"github.com/erigontech/erigon-lib/common/hexutil"
 
 	"github.com/erigontech/erigon-lib/chain"
+	txpool "github.com/erigontech/erigon-lib/gointerfaces/txpoolproto"
 	"github.com/erigontech/erigon-lib/kv"
 
 	"github.com/erigontech/erigon/consensus/misc"
@@ -230,6 +231,84 @@ func (api *APIImpl) FeeHistory(ctx context.Context, blockCount rpc.DecimalOrHex,
 	return results, nil
 }
 
+type feeTierResult struct {
+	GasPrice             *hexutil.Big `json:"gasPrice,omitempty"`
+	MaxPriorityFeePerGas *hexutil.Big `json:"maxPriorityFeePerGas,omitempty"`
+	MaxFeePerGas         *hexutil.Big `json:"maxFeePerGas,omitempty"`
+	MaxFeePerBlobGas     *hexutil.Big `json:"maxFeePerBlobGas,omitempty"`
+}
+
+type feeTiersResult struct {
+	Slow     feeTierResult `json:"slow"`
+	Standard feeTierResult `json:"standard"`
+	Fast     feeTierResult `json:"fast"`
+}
+
+type feeSuggestionsResult struct {
+	BlockNumber         hexutil.Uint64  `json:"blockNumber"`
+	BaseFee             *hexutil.Big    `json:"baseFeePerGas,omitempty"`
+	BlobBaseFee         *hexutil.Big    `json:"baseFeePerBlobGas,omitempty"`
+	BlobBaseFeeForecast []*hexutil.Big  `json:"baseFeePerBlobGasForecast,omitempty"`
+	PoolPressure        float64         `json:"poolPressure"`
+	Legacy              *feeTiersResult `json:"legacy"`
+	DynamicFee          *feeTiersResult `json:"eip1559,omitempty"`
+	Blob                *feeTiersResult `json:"blob,omitempty"`
+}
+
+func newFeeTiersResult(tiers *gasprice.FeeTiers) *feeTiersResult {
+	if tiers == nil {
+		return nil
+	}
+	tier := func(t gasprice.FeeTier) feeTierResult {
+		return feeTierResult{
+			GasPrice:             (*hexutil.Big)(t.GasPrice),
+			MaxPriorityFeePerGas: (*hexutil.Big)(t.MaxPriorityFeePerGas),
+			MaxFeePerGas:         (*hexutil.Big)(t.MaxFeePerGas),
+			MaxFeePerBlobGas:     (*hexutil.Big)(t.MaxFeePerBlobGas),
+		}
+	}
+	return &feeTiersResult{Slow: tier(tiers.Slow), Standard: tier(tiers.Standard), Fast: tier(tiers.Fast)}
+}
+
+// SuggestFees implements eth_suggestFees. Returns slow, standard and fast fee suggestions for legacy, EIP-1559 and
+// blob transactions, based on the tips paid in recent blocks and on the number of pending transactions in the pool,
+// together with a forecast of the blob base fee.
+func (api *APIImpl) SuggestFees(ctx context.Context) (*feeSuggestionsResult, error) {
+	// the pool is optional, without it the suggestions rely on recent blocks only
+	var pendingTxs int
+	if api.txPool != nil {
+		if reply, err := api.txPool.Status(ctx, &txpool.StatusRequest{}); err == nil {
+			pendingTxs = int(reply.PendingCount)
+		} else {
+			api.logger.Debug("[rpc] suggestFees: txpool status is not available", "err", err)
+		}
+	}
+
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	oracle := gasprice.NewOracle(NewGasPriceOracleBackend(tx, api.BaseAPI), ethconfig.Defaults.GPO, api.gasCache, api.logger.New("app", "gasPriceOracle"))
+	fees, err := oracle.SuggestFees(ctx, pendingTxs)
+	if err != nil {
+		return nil, err
+	}
+	result := &feeSuggestionsResult{
+		BlockNumber:  hexutil.Uint64(fees.BlockNumber),
+		BaseFee:      (*hexutil.Big)(fees.BaseFee),
+		BlobBaseFee:  (*hexutil.Big)(fees.BlobBaseFee),
+		PoolPressure: fees.PoolPressure,
+		Legacy:       newFeeTiersResult(&fees.Legacy),
+		DynamicFee:   newFeeTiersResult(fees.DynamicFee),
+		Blob:         newFeeTiersResult(fees.Blob),
+	}
+	for _, fee := range fees.BlobBaseFeeForecast {
+		result.BlobBaseFeeForecast = append(result.BlobBaseFeeForecast, (*hexutil.Big)(fee))
+	}
+	return result, nil
+}
+
 // BlobBaseFee returns the base fee for blob gas at the current head.
 func (api *APIImpl) BlobBaseFee(ctx context.Context) (*hexutil.Big, error) {
 	// read current header
@@ -22,11 +22,13 @@ import (
 	"math/big"
 
 	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/common/hexutility"
 
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon/core/types"
 	"github.com/erigontech/erigon/eth/ethutils"
+	"github.com/erigontech/erigon/eth/filters"
 	"github.com/erigontech/erigon/rpc"
 	"github.com/erigontech/erigon/turbo/adapter/ethapi"
 	"github.com/erigontech/erigon/turbo/rpchelper"
@@ -35,17 +37,26 @@ import (
 type GraphQLAPI interface {
 	GetBlockDetails(ctx context.Context, number rpc.BlockNumber) (map[string]interface{}, error)
 	GetChainID(ctx context.Context) (*big.Int, error)
+	GetTransactionDetails(ctx context.Context, hash common.Hash) (map[string]interface{}, error)
+	GetPendingTransactions(ctx context.Context) ([]map[string]interface{}, error)
+	GetLogs(ctx context.Context, crit filters.FilterCriteria) (types.Logs, error)
+	GasPrice(ctx context.Context) (*hexutil.Big, error)
+	MaxPriorityFeePerGas(ctx context.Context) (*hexutil.Big, error)
+	Syncing(ctx context.Context) (interface{}, error)
+	SendRawTransaction(ctx context.Context, encodedTx hexutility.Bytes) (common.Hash, error)
 }
 
 type GraphQLAPIImpl struct {
 	*BaseAPI
-	db kv.RoDB
+	db  kv.RoDB
+	eth *APIImpl
 }
 
-func NewGraphQLAPI(base *BaseAPI, db kv.RoDB) *GraphQLAPIImpl {
+func NewGraphQLAPI(base *BaseAPI, db kv.RoDB, eth *APIImpl) *GraphQLAPIImpl {
 	return &GraphQLAPIImpl{
 		BaseAPI: base,
 		db:      db,
+		eth:     eth,
 	}
 }
 
@@ -164,3 +175,103 @@ func (api *GraphQLAPIImpl) delegateGetBlockByNumber(tx kv.Tx, b *types.Block, nu
 
 	return response, err
 }
+
+// GetTransactionDetails returns the transaction with the given hash merged with its receipt. Transactions which
+// are still in the pool come without receipt fields. Returns nil if the transaction is unknown.
+func (api *GraphQLAPIImpl) GetTransactionDetails(ctx context.Context, hash common.Hash) (map[string]interface{}, error) {
+	txn, err := api.eth.GetTransactionByHash(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if txn == nil {
+		return nil, nil
+	}
+
+	result := map[string]interface{}{}
+	if txn.BlockHash != nil {
+		receipt, err := api.eth.GetTransactionReceipt(ctx, hash)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range receipt {
+			result[k] = v
+		}
+	}
+	for k, v := range graphQLTransactionFields(txn) {
+		result[k] = v
+	}
+	return result, nil
+}
+
+// GetPendingTransactions returns the transactions of the pending block, or an empty list if there is none.
+func (api *GraphQLAPIImpl) GetPendingTransactions(ctx context.Context) ([]map[string]interface{}, error) {
+	block := api.pendingBlock()
+	if block == nil {
+		return []map[string]interface{}{}, nil
+	}
+
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	chainConfig, err := api.chainConfig(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]map[string]interface{}, 0, block.Transactions().Len())
+	for _, txn := range block.Transactions() {
+		result = append(result, graphQLTransactionFields(newRPCPendingTransaction(txn, block.HeaderNoCopy(), chainConfig)))
+	}
+	return result, nil
+}
+
+func (api *GraphQLAPIImpl) GetLogs(ctx context.Context, crit filters.FilterCriteria) (types.Logs, error) {
+	return api.eth.GetLogs(ctx, crit)
+}
+
+func (api *GraphQLAPIImpl) GasPrice(ctx context.Context) (*hexutil.Big, error) {
+	return api.eth.GasPrice(ctx)
+}
+
+func (api *GraphQLAPIImpl) MaxPriorityFeePerGas(ctx context.Context) (*hexutil.Big, error) {
+	return api.eth.MaxPriorityFeePerGas(ctx)
+}
+
+func (api *GraphQLAPIImpl) Syncing(ctx context.Context) (interface{}, error) {
+	return api.eth.Syncing(ctx)
+}
+
+func (api *GraphQLAPIImpl) SendRawTransaction(ctx context.Context, encodedTx hexutility.Bytes) (common.Hash, error) {
+	return api.eth.SendRawTransaction(ctx, encodedTx)
+}
+
+// graphQLTransactionFields flattens an RPC transaction into the same keys GetBlockDetails uses for receipts.
+func graphQLTransactionFields(txn *RPCTransaction) map[string]interface{} {
+	fields := map[string]interface{}{
+		"transactionHash":      txn.Hash,
+		"from":                 txn.From,
+		"to":                   txn.To,
+		"nonce":                uint64(txn.Nonce),
+		"value":                txn.Value,
+		"data":                 txn.Input,
+		"gas":                  txn.Gas,
+		"gasPrice":             txn.GasPrice,
+		"maxFeePerGas":         txn.FeeCap,
+		"maxPriorityFeePerGas": txn.Tip,
+		"type":                 txn.Type,
+		"v":                    txn.V,
+		"r":                    txn.R,
+		"s":                    txn.S,
+	}
+	if txn.TransactionIndex != nil {
+		fields["transactionIndex"] = *txn.TransactionIndex
+	}
+	if txn.BlockHash != nil {
+		fields["blockHash"] = *txn.BlockHash
+		fields["blockNumber"] = txn.BlockNumber
+	}
+	return fields
+}
@@ -0,0 +1,52 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/log/v3"
+
+	"github.com/erigontech/erigon/cmd/rpcdaemon/rpcdaemontest"
+)
+
+func TestGraphQLGetTransactionDetails(t *testing.T) {
+	m, chain, _ := rpcdaemontest.CreateTestSentry(t)
+	base := newBaseApiForTest(m)
+	eth := NewEthAPI(base, m.DB, nil, nil, nil, 5000000, 1e18, 100_000, false, 100_000, 128, log.New())
+	api := NewGraphQLAPI(base, m.DB, eth)
+
+	block := chain.Blocks[0]
+	require.NotEmpty(t, block.Transactions())
+	txn := block.Transactions()[0]
+
+	res, err := api.GetTransactionDetails(context.Background(), txn.Hash())
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	require.Equal(t, txn.Hash(), res["transactionHash"])
+	require.Equal(t, block.Hash(), res["blockHash"])
+	require.Equal(t, block.NumberU64(), res["blockNumber"].(*hexutil.Big).ToInt().Uint64())
+	require.Equal(t, hexutil.Uint64(0), res["transactionIndex"])
+	require.Equal(t, hexutil.Uint64(txn.GetGas()), res["gas"])
+	// receipt fields are merged in for mined transactions
+	require.Equal(t, hexutil.Uint64(1), res["status"])
+	require.Contains(t, res, "cumulativeGasUsed")
+}
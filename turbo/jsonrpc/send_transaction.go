@@ -7,7 +7,9 @@ import (
 	"math/big"
 
 	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
 	"github.com/erigontech/erigon-lib/common/hexutility"
+	"github.com/erigontech/erigon-lib/gointerfaces"
 	txPoolProto "github.com/erigontech/erigon-lib/gointerfaces/txpoolproto"
 
 	"github.com/erigontech/erigon/core/types"
@@ -16,44 +18,39 @@ import (
 
 // SendRawTransaction implements eth_sendRawTransaction. Creates new message call transaction or a contract creation for previously-signed transactions.
 func (api *APIImpl) SendRawTransaction(ctx context.Context, encodedTx hexutility.Bytes) (common.Hash, error) {
-	txn, err := types.DecodeWrappedTransaction(encodedTx)
+	txn, err := api.checkRawTransaction(ctx, encodedTx)
 	if err != nil {
 		return common.Hash{}, err
 	}
 
-	// If the transaction fee cap is already specified, ensure the
-	// fee of the given transaction is _reasonable_.
-	if err := checkTxFee(txn.GetPrice().ToBig(), txn.GetGas(), api.FeeCap); err != nil {
+	hash := txn.Hash()
+	res, err := api.txPool.Add(ctx, &txPoolProto.AddRequest{RlpTxs: [][]byte{encodedTx}})
+	if err != nil {
 		return common.Hash{}, err
 	}
-	if !txn.Protected() && !api.AllowUnprotectedTxs {
-		return common.Hash{}, errors.New("only replay-protected (EIP-155) transactions allowed over RPC")
-	}
 
-	// this has been moved to prior to adding of transactions to capture the
-	// pre state of the db - which is used for logging in the messages below
-	tx, err := api.db.BeginRo(ctx)
-	if err != nil {
-		return common.Hash{}, err
+	if res.Imported[0] != txPoolProto.ImportResult_SUCCESS {
+		return hash, fmt.Errorf("%s: %s", txPoolProto.ImportResult_name[int32(res.Imported[0])], res.Errors[0])
 	}
 
-	defer tx.Rollback()
+	return txn.Hash(), nil
+}
 
-	cc, err := api.chainConfig(ctx, tx)
+// SendPrivateRawTransaction implements eth_sendPrivateRawTransaction. Same as eth_sendRawTransaction, but the transaction
+// is never announced to peers, it is only included into blocks built by this node. The transaction is dropped once
+// maxBlockNumber is mined, by default 25 blocks on top of the current head.
+func (api *APIImpl) SendPrivateRawTransaction(ctx context.Context, encodedTx hexutility.Bytes, maxBlockNumber *hexutil.Uint64) (common.Hash, error) {
+	txn, err := api.checkRawTransaction(ctx, encodedTx)
 	if err != nil {
 		return common.Hash{}, err
 	}
 
-	if txn.Protected() {
-		txnChainId := txn.GetChainID()
-		chainId := cc.ChainID
-		if chainId.Cmp(txnChainId.ToBig()) != 0 {
-			return common.Hash{}, fmt.Errorf("invalid chain id, expected: %d got: %d", chainId, *txnChainId)
-		}
+	req := &txPoolProto.AddPrivateRequest{RlpTxs: [][]byte{encodedTx}}
+	if maxBlockNumber != nil {
+		req.MaxBlockNumber = uint64(*maxBlockNumber)
 	}
-
 	hash := txn.Hash()
-	res, err := api.txPool.Add(ctx, &txPoolProto.AddRequest{RlpTxs: [][]byte{encodedTx}})
+	res, err := api.txPool.AddPrivate(ctx, req)
 	if err != nil {
 		return common.Hash{}, err
 	}
@@ -62,7 +59,45 @@ func (api *APIImpl) SendRawTransaction(ctx context.Context, encodedTx hexutility
 		return hash, fmt.Errorf("%s: %s", txPoolProto.ImportResult_name[int32(res.Imported[0])], res.Errors[0])
 	}
 
-	return txn.Hash(), nil
+	return hash, nil
+}
+
+// SendBundleArgs represents the arguments of eth_sendBundle
+type SendBundleArgs struct {
+	Txs            []hexutility.Bytes `json:"txs"`
+	BlockNumber    hexutil.Uint64     `json:"blockNumber"`
+	MaxBlockNumber *hexutil.Uint64    `json:"maxBlockNumber,omitempty"`
+}
+
+// SendBundleResult is the result of eth_sendBundle
+type SendBundleResult struct {
+	BundleHash common.Hash `json:"bundleHash"`
+}
+
+// SendBundle implements eth_sendBundle. Submits an ordered list of signed transactions which blocks built by this node
+// include as a whole, or not at all, in any block from blockNumber to maxBlockNumber (blockNumber only by default).
+// Like private transactions, bundles are never announced to peers.
+func (api *APIImpl) SendBundle(ctx context.Context, args SendBundleArgs) (*SendBundleResult, error) {
+	if len(args.Txs) == 0 {
+		return nil, errors.New("bundle has no transactions")
+	}
+	rlpTxs := make([][]byte, len(args.Txs))
+	for i, encodedTx := range args.Txs {
+		if _, err := api.checkRawTransaction(ctx, encodedTx); err != nil {
+			return nil, fmt.Errorf("transaction %d: %w", i, err)
+		}
+		rlpTxs[i] = encodedTx
+	}
+
+	req := &txPoolProto.AddBundleRequest{RlpTxs: rlpTxs, BlockNumber: uint64(args.BlockNumber)}
+	if args.MaxBlockNumber != nil {
+		req.MaxBlockNumber = uint64(*args.MaxBlockNumber)
+	}
+	res, err := api.txPool.AddBundle(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &SendBundleResult{BundleHash: gointerfaces.ConvertH256ToHash(res.BundleHash)}, nil
 }
 
 // SendTransaction implements eth_sendTransaction. Creates new message call transaction or a contract creation if the data field contains code.
@@ -70,6 +105,43 @@ func (api *APIImpl) SendTransaction(_ context.Context, txObject interface{}) (co
 	return common.Hash{0}, fmt.Errorf(NotImplemented, "eth_sendTransaction")
 }
 
+// checkRawTransaction decodes a signed transaction submitted over RPC and checks its fee, replay protection and chain id
+func (api *APIImpl) checkRawTransaction(ctx context.Context, encodedTx hexutility.Bytes) (types.Transaction, error) {
+	txn, err := types.DecodeWrappedTransaction(encodedTx)
+	if err != nil {
+		return nil, err
+	}
+
+	// If the transaction fee cap is already specified, ensure the
+	// fee of the given transaction is _reasonable_.
+	if err := checkTxFee(txn.GetPrice().ToBig(), txn.GetGas(), api.FeeCap); err != nil {
+		return nil, err
+	}
+	if !txn.Protected() && !api.AllowUnprotectedTxs {
+		return nil, errors.New("only replay-protected (EIP-155) transactions allowed over RPC")
+	}
+
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	cc, err := api.chainConfig(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if txn.Protected() {
+		txnChainId := txn.GetChainID()
+		chainId := cc.ChainID
+		if chainId.Cmp(txnChainId.ToBig()) != 0 {
+			return nil, fmt.Errorf("invalid chain id, expected: %d got: %d", chainId, *txnChainId)
+		}
+	}
+	return txn, nil
+}
+
 // checkTxFee is an internal function used to check whether the fee of
 // the given transaction is _reasonable_(under the cap).
 func checkTxFee(gasPrice *big.Int, gas uint64, gasCap float64) error {
@@ -37,7 +37,9 @@ import (
 	"github.com/erigontech/erigon/core/types"
 	"github.com/erigontech/erigon/core/vm"
 	"github.com/erigontech/erigon/core/vm/evmtypes"
+	"github.com/erigontech/erigon/eth/tracers"
 	tracersConfig "github.com/erigontech/erigon/eth/tracers/config"
+	"github.com/erigontech/erigon/eth/tracers/native"
 	bortypes "github.com/erigontech/erigon/polygon/bor/types"
 	polygontracer "github.com/erigontech/erigon/polygon/tracer"
 	"github.com/erigontech/erigon/rpc"
@@ -191,7 +193,13 @@ func (api *PrivateDebugAPIImpl) traceBlock(ctx context.Context, blockNrOrHash rp
 				api.evmCallTimeout,
 			)
 		} else {
-			err = transactions.TraceTx(ctx, msg, blockCtx, txCtx, ibs, config, chainConfig, stream, api.evmCallTimeout)
+			tracerCtx := &tracers.Context{
+				BlockHash:   block.Hash(),
+				BlockNumber: block.Number(),
+				TxIndex:     idx,
+				TxHash:      txnHash,
+			}
+			err = transactions.TraceTx(ctx, msg, blockCtx, txCtx, tracerCtx, ibs, config, chainConfig, stream, api.evmCallTimeout)
 		}
 		if err == nil {
 			err = ibs.FinalizeTx(rules, state.NewNoopWriter())
@@ -213,6 +221,32 @@ func (api *PrivateDebugAPIImpl) traceBlock(ctx context.Context, blockNrOrHash rp
 		}
 	}
 
+	if config.Tracer != nil && *config.Tracer == "flatCallTracer" && config.TracerConfig != nil && native.FlatCallTracerIncludesRewards(*config.TracerConfig) {
+		syscall := func(contract common.Address, data []byte) ([]byte, error) {
+			return core.SysCallContract(contract, data, chainConfig, ibs, block.Header(), engine, true /* constCall */)
+		}
+		rewards, err := engine.CalculateRewards(chainConfig, block.Header(), block.Uncles(), syscall)
+		if err != nil {
+			stream.WriteArrayEnd()
+			return err
+		}
+		res, err := native.FlatRewardTraces(block.Hash(), block.NumberU64(), rewards)
+		if err != nil {
+			stream.WriteArrayEnd()
+			return err
+		}
+		// Rewards are not part of any transaction, so they come as a trailing entry without txHash
+		if len(txns) > 0 {
+			stream.WriteMore()
+		}
+		stream.WriteObjectStart()
+		stream.WriteObjectField("result")
+		if _, err := stream.Write(res); err != nil {
+			return err
+		}
+		stream.WriteObjectEnd()
+	}
+
 	stream.WriteArrayEnd()
 	if err := stream.Flush(); err != nil {
 		return err
@@ -328,8 +362,14 @@ func (api *PrivateDebugAPIImpl) TraceTransaction(ctx context.Context, hash commo
 			api.evmCallTimeout,
 		)
 	}
+	tracerCtx := &tracers.Context{
+		BlockHash:   block.Hash(),
+		BlockNumber: block.Number(),
+		TxIndex:     txnIndex,
+		TxHash:      hash,
+	}
 	// Trace the transaction and return
-	return transactions.TraceTx(ctx, msg, blockCtx, txCtx, ibs, config, chainConfig, stream, api.evmCallTimeout)
+	return transactions.TraceTx(ctx, msg, blockCtx, txCtx, tracerCtx, ibs, config, chainConfig, stream, api.evmCallTimeout)
 }
 
 // TraceCall implements debug_traceCall. Returns Geth style call traces.
@@ -397,7 +437,7 @@ func (api *PrivateDebugAPIImpl) TraceCall(ctx context.Context, args ethapi.CallA
 	blockCtx := transactions.NewEVMBlockContext(engine, header, blockNrOrHash.RequireCanonical, dbtx, api._blockReader, chainConfig)
 	txCtx := core.NewEVMTxContext(msg)
 	// Trace the transaction and return
-	return transactions.TraceTx(ctx, msg, blockCtx, txCtx, ibs, config, chainConfig, stream, api.evmCallTimeout)
+	return transactions.TraceTx(ctx, msg, blockCtx, txCtx, &tracers.Context{TxHash: txCtx.TxHash}, ibs, config, chainConfig, stream, api.evmCallTimeout)
 }
 
 func (api *PrivateDebugAPIImpl) TraceCallMany(ctx context.Context, bundles []Bundle, simulateContext StateContext, config *tracersConfig.TraceConfig, stream *jsoniter.Stream) error {
@@ -560,7 +600,7 @@ func (api *PrivateDebugAPIImpl) TraceCallMany(ctx context.Context, bundles []Bun
 			txCtx = core.NewEVMTxContext(msg)
 			ibs := evm.IntraBlockState().(*state.IntraBlockState)
 			ibs.SetTxContext(txnIndex)
-			err = transactions.TraceTx(ctx, msg, blockCtx, txCtx, evm.IntraBlockState(), config, chainConfig, stream, api.evmCallTimeout)
+			err = transactions.TraceTx(ctx, msg, blockCtx, txCtx, &tracers.Context{TxHash: txCtx.TxHash}, evm.IntraBlockState(), config, chainConfig, stream, api.evmCallTimeout)
 			if err != nil {
 				stream.WriteArrayEnd()
 				stream.WriteArrayEnd()
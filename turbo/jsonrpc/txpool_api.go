@@ -27,6 +27,7 @@ import (
 	"github.com/erigontech/erigon-lib/gointerfaces"
 	proto_txpool "github.com/erigontech/erigon-lib/gointerfaces/txpoolproto"
 	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/txpool"
 
 	"github.com/erigontech/erigon/core/rawdb"
 	"github.com/erigontech/erigon/core/types"
@@ -36,6 +37,8 @@ import (
 type TxPoolAPI interface {
 	Content(ctx context.Context) (map[string]map[string]map[string]*RPCTransaction, error)
 	ContentFrom(ctx context.Context, addr libcommon.Address) (map[string]map[string]*RPCTransaction, error)
+	Inspect(ctx context.Context) (map[string]map[string]map[string]string, error)
+	InspectSubPools(ctx context.Context) (*TxPoolSubPools, error)
 }
 
 // TxPoolAPIImpl data structure to store things needed for net_ commands
@@ -218,40 +221,138 @@ func (api *TxPoolAPIImpl) Status(ctx context.Context) (map[string]hexutil.Uint,
 	}, nil
 }
 
-/*
+// Inspect implements txpool_inspect. Returns the content of the transaction pool flattened into an easily
+// inspectable list.
+func (api *TxPoolAPIImpl) Inspect(ctx context.Context) (map[string]map[string]map[string]string, error) {
+	reply, err := api.pool.All(ctx, &proto_txpool.AllRequest{})
+	if err != nil {
+		return nil, err
+	}
 
-// Inspect retrieves the content of the transaction pool and flattens it into an
-// easily inspectable list.
-func (s *PublicTxPoolAPI) Inspect() map[string]map[string]map[string]string {
 	content := map[string]map[string]map[string]string{
 		"pending": make(map[string]map[string]string),
+		"baseFee": make(map[string]map[string]string),
 		"queued":  make(map[string]map[string]string),
 	}
-	pending, queue := s.b.TxPoolContent()
-
-	// Define a formatter to flatten a transaction into a string
-	var format = func(tx *types.Transaction) string {
-		if to := tx.To(); to != nil {
-			return fmt.Sprintf("%s: %v wei + %v gas × %v wei", tx.To().Hex(), tx.Value(), tx.Gas(), tx.GasPrice())
+	for i := range reply.Txs {
+		txn, err := types.DecodeWrappedTransaction(reply.Txs[i].RlpTx)
+		if err != nil {
+			return nil, fmt.Errorf("decoding transaction from: %x: %w", reply.Txs[i].RlpTx, err)
+		}
+		subPool := content[subPoolName(reply.Txs[i].TxnType)]
+		if subPool == nil {
+			continue
+		}
+		account := libcommon.Address(gointerfaces.ConvertH160toAddress(reply.Txs[i].Sender)).Hex()
+		if _, ok := subPool[account]; !ok {
+			subPool[account] = make(map[string]string)
 		}
-		return fmt.Sprintf("contract creation: %v wei + %v gas × %v wei", tx.Value(), tx.Gas(), tx.GasPrice())
+		subPool[account][strconv.FormatUint(txn.GetNonce(), 10)] = inspectTxn(txn)
 	}
-	// Flatten the pending transactions
-	for account, txs := range pending {
-		dump := make(map[string]string)
-		for _, txn := range txs {
-			dump[fmt.Sprintf("%d", tx.Nonce())] = format(tx)
+	return content, nil
+}
+
+// inspectTxn flattens the transaction into a string of txpool_inspect
+func inspectTxn(txn types.Transaction) string {
+	if to := txn.GetTo(); to != nil {
+		return fmt.Sprintf("%s: %v wei + %v gas × %v wei", to.Hex(), txn.GetValue(), txn.GetGas(), txn.GetFeeCap())
+	}
+	return fmt.Sprintf("contract creation: %v wei + %v gas × %v wei", txn.GetValue(), txn.GetGas(), txn.GetFeeCap())
+}
+
+// TxPoolSubPools is the result of txpool_inspectSubPools
+type TxPoolSubPools struct {
+	Senders   map[libcommon.Address]*TxPoolSender `json:"senders"`
+	Discarded map[libcommon.Hash]string           `json:"discarded"` // reasons of recently discarded transactions
+}
+
+// TxPoolSender is a sender of pool transactions along with the state nonce its transactions are sorted against
+type TxPoolSender struct {
+	StateNonce   hexutil.Uint64     `json:"stateNonce"`
+	NonceGaps    []TxPoolNonceGap   `json:"nonceGaps"`
+	Transactions []*TxPoolSubPoolTx `json:"transactions"`
+}
+
+// TxPoolNonceGap is an inclusive range of nonces missing between the state nonce and the highest nonce in the pool
+type TxPoolNonceGap struct {
+	From hexutil.Uint64 `json:"from"`
+	To   hexutil.Uint64 `json:"to"`
+}
+
+// TxPoolSubPoolTx is a transaction along with the sub-pool it was sorted into and the bits of its SubPoolMarker
+type TxPoolSubPoolTx struct {
+	Hash              libcommon.Hash `json:"hash"`
+	Nonce             hexutil.Uint64 `json:"nonce"`
+	SubPool           string         `json:"subPool"`
+	SubPoolMarker     hexutil.Uint64 `json:"subPoolMarker"`
+	NoNonceGaps       bool           `json:"noNonceGaps"`
+	EnoughBalance     bool           `json:"enoughBalance"`
+	NotTooMuchGas     bool           `json:"notTooMuchGas"`
+	EnoughFeeCapBlock bool           `json:"enoughFeeCapBlock"`
+	IsLocal           bool           `json:"isLocal"`
+}
+
+// InspectSubPools implements txpool_inspectSubPools. Returns how the pool sorts transactions into pending, baseFee and
+// queued sub-pools: the SubPoolMarker bits of every transaction, the nonce gaps of every sender and the reasons of
+// recently discarded transactions.
+func (api *TxPoolAPIImpl) InspectSubPools(ctx context.Context) (*TxPoolSubPools, error) {
+	reply, err := api.pool.Inspect(ctx, &proto_txpool.InspectRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &TxPoolSubPools{
+		Senders:   make(map[libcommon.Address]*TxPoolSender, len(reply.Senders)),
+		Discarded: make(map[libcommon.Hash]string, len(reply.Discarded)),
+	}
+	for _, s := range reply.Senders {
+		sender := &TxPoolSender{
+			StateNonce:   hexutil.Uint64(s.StateNonce),
+			NonceGaps:    make([]TxPoolNonceGap, 0, len(s.NonceGaps)),
+			Transactions: make([]*TxPoolSubPoolTx, 0, 4),
 		}
-		content["pending"][account.Hex()] = dump
+		for _, gap := range s.NonceGaps {
+			sender.NonceGaps = append(sender.NonceGaps, TxPoolNonceGap{From: hexutil.Uint64(gap.From), To: hexutil.Uint64(gap.To)})
+		}
+		result.Senders[gointerfaces.ConvertH160toAddress(s.Address)] = sender
 	}
-	// Flatten the queued transactions
-	for account, txs := range queue {
-		dump := make(map[string]string)
-		for _, txn := range txs {
-			dump[fmt.Sprintf("%d", tx.Nonce())] = format(tx)
+	for i := range reply.Txs {
+		txn, err := types.DecodeWrappedTransaction(reply.Txs[i].RlpTx)
+		if err != nil {
+			return nil, fmt.Errorf("decoding transaction from: %x: %w", reply.Txs[i].RlpTx, err)
 		}
-		content["queued"][account.Hex()] = dump
+		sender, ok := result.Senders[gointerfaces.ConvertH160toAddress(reply.Txs[i].Sender)]
+		if !ok {
+			continue
+		}
+		marker := txpool.SubPoolMarker(reply.Txs[i].SubPoolMarker)
+		sender.Transactions = append(sender.Transactions, &TxPoolSubPoolTx{
+			Hash:              txn.Hash(),
+			Nonce:             hexutil.Uint64(txn.GetNonce()),
+			SubPool:           subPoolName(reply.Txs[i].TxnType),
+			SubPoolMarker:     hexutil.Uint64(marker),
+			NoNonceGaps:       marker&txpool.NoNonceGaps != 0,
+			EnoughBalance:     marker&txpool.EnoughBalance != 0,
+			NotTooMuchGas:     marker&txpool.NotTooMuchGas != 0,
+			EnoughFeeCapBlock: marker&txpool.EnoughFeeCapBlock != 0,
+			IsLocal:           marker&txpool.IsLocal != 0,
+		})
+	}
+	for _, discarded := range reply.Discarded {
+		result.Discarded[gointerfaces.ConvertH256ToHash(discarded.Hash)] = discarded.Reason
+	}
+	return result, nil
+}
+
+// subPoolName returns the name the sub-pool is reported under by txpool_ methods
+func subPoolName(t proto_txpool.AllReply_TxnType) string {
+	switch t {
+	case proto_txpool.AllReply_PENDING:
+		return "pending"
+	case proto_txpool.AllReply_BASE_FEE:
+		return "baseFee"
+	case proto_txpool.AllReply_QUEUED:
+		return "queued"
 	}
-	return content
+	return t.String()
 }
-*/
@@ -79,3 +79,58 @@ func TestTxPoolContent(t *testing.T) {
 	require.Equal(status["pending"], hexutil.Uint(1))
 	require.Equal(status["queued"], hexutil.Uint(0))
 }
+
+func TestTxPoolInspect(t *testing.T) {
+	m, require := mock.MockWithTxPool(t), require.New(t)
+	chain, err := core.GenerateChain(m.ChainConfig, m.Genesis, m.Engine, m.DB, 1, func(i int, b *core.BlockGen) {
+		b.SetCoinbase(libcommon.Address{1})
+	})
+	require.NoError(err)
+	err = m.InsertChain(chain)
+	require.NoError(err)
+
+	ctx, conn := rpcdaemontest.CreateTestGrpcConn(t, m)
+	txPool := txpool.NewTxpoolClient(conn)
+	ff := rpchelper.New(ctx, rpchelper.DefaultFiltersConfig, nil, txPool, txpool.NewMiningClient(conn), func() {}, m.Log)
+	api := NewTxPoolAPI(NewBaseApi(ff, kvcache.New(kvcache.DefaultCoherentConfig), m.BlockReader, false, rpccfg.DefaultEvmCallTimeout, m.Engine, m.Dirs, nil), m.DB, txPool)
+
+	// nonce 1 is missing, so nonce 2 is queued
+	var hashes []libcommon.Hash
+	for _, nonce := range []uint64{0, 2} {
+		txn, err := types.SignTx(types.NewTransaction(nonce, libcommon.Address{1}, uint256.NewInt(1234), params.TxGas, uint256.NewInt(10*params.GWei), nil), *types.LatestSignerForChainID(m.ChainConfig.ChainID), m.Key)
+		require.NoError(err)
+		buf := bytes.NewBuffer(nil)
+		require.NoError(txn.MarshalBinary(buf))
+		reply, err := txPool.Add(ctx, &txpool.AddRequest{RlpTxs: [][]byte{buf.Bytes()}})
+		require.NoError(err)
+		require.Equal(txpool.ImportResult_SUCCESS, reply.Imported[0], reply.Errors[0])
+		hashes = append(hashes, txn.Hash())
+	}
+
+	content, err := api.Inspect(ctx)
+	require.NoError(err)
+	sender := m.Address.String()
+	require.Equal(map[string]string{"0": "0x0100000000000000000000000000000000000000: 1234 wei + 21000 gas × 10000000000 wei"}, content["pending"][sender])
+	require.Equal(map[string]string{"2": "0x0100000000000000000000000000000000000000: 1234 wei + 21000 gas × 10000000000 wei"}, content["queued"][sender])
+	require.Empty(content["baseFee"])
+
+	subPools, err := api.InspectSubPools(ctx)
+	require.NoError(err)
+	require.Len(subPools.Senders, 1)
+	require.Empty(subPools.Discarded)
+	subPoolsSender := subPools.Senders[m.Address]
+	require.NotNil(subPoolsSender)
+	require.Equal(hexutil.Uint64(0), subPoolsSender.StateNonce)
+	require.Equal([]TxPoolNonceGap{{From: 1, To: 1}}, subPoolsSender.NonceGaps)
+	require.Len(subPoolsSender.Transactions, 2)
+
+	pending, queued := subPoolsSender.Transactions[0], subPoolsSender.Transactions[1]
+	require.Equal(hashes[0], pending.Hash)
+	require.Equal("pending", pending.SubPool)
+	require.True(pending.NoNonceGaps && pending.EnoughBalance && pending.NotTooMuchGas && pending.EnoughFeeCapBlock && pending.IsLocal)
+	require.Equal(hexutil.Uint64(0b11111), pending.SubPoolMarker)
+	require.Equal(hashes[1], queued.Hash)
+	require.Equal("queued", queued.SubPool)
+	require.False(queued.NoNonceGaps)
+	require.True(queued.EnoughBalance && queued.NotTooMuchGas && queued.EnoughFeeCapBlock && queued.IsLocal)
+}
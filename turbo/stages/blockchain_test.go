@@ -42,6 +42,7 @@ import (
 
 	libchain "github.com/erigontech/erigon-lib/chain"
 	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutility"
 	"github.com/erigontech/erigon-lib/common/length"
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/kv/bitmapdb"
@@ -52,6 +53,7 @@ import (
 	"github.com/erigontech/erigon/core"
 	"github.com/erigontech/erigon/core/rawdb"
 	"github.com/erigontech/erigon/core/state"
+	"github.com/erigontech/erigon/core/tracing"
 	"github.com/erigontech/erigon/core/types"
 	"github.com/erigontech/erigon/core/vm"
 	"github.com/erigontech/erigon/crypto"
@@ -2283,3 +2285,128 @@ func current(m *mock.MockSentry, tx kv.Tx) *types.Block {
 	}
 	return b
 }
+
+// TestLiveTracer checks that a live tracer attached to the Execution stage gets the
+// lifecycle hooks of every executed block, in order.
+func TestLiveTracer(t *testing.T) {
+	var (
+		key, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+		to     = libcommon.HexToAddress("0x1234")
+		gspec  = &types.Genesis{
+			Config: params.TestChainConfig,
+			Alloc:  types.GenesisAlloc{addr: {Balance: big.NewInt(params.Ether)}},
+		}
+		signer = types.LatestSigner(gspec.Config)
+	)
+
+	var events []string
+	tracer := &tracing.Hooks{
+		OnBlockStart: func(ev tracing.BlockEvent) {
+			events = append(events, fmt.Sprintf("block start %d", ev.Block.NumberU64()))
+		},
+		OnBlockEnd: func(err error) {
+			events = append(events, fmt.Sprintf("block end %v", err))
+		},
+		OnTxStart: func(vm *tracing.VMContext, txn types.Transaction, from libcommon.Address) {
+			events = append(events, fmt.Sprintf("tx start %d %x", vm.BlockNumber, from))
+		},
+		OnTxEnd: func(receipt *types.Receipt, err error) {
+			events = append(events, fmt.Sprintf("tx end %d %d", receipt.TransactionIndex, receipt.Status))
+		},
+		OnEnter: func(depth int, typ byte, from libcommon.Address, to libcommon.Address, precompile bool, input []byte, gas uint64, value *uint256.Int, code []byte) {
+			events = append(events, fmt.Sprintf("enter %d %x %s", depth, to, value))
+		},
+	}
+	m := mock.MockWithTracer(t, gspec, key, tracer)
+
+	chain, err := core.GenerateChain(m.ChainConfig, m.Genesis, m.Engine, m.DB, 2, func(i int, b *core.BlockGen) {
+		if i == 1 {
+			txn, err := types.SignTx(types.NewTransaction(b.TxNonce(addr), to, uint256.NewInt(1000), params.TxGas, uint256.NewInt(params.GWei), nil), *signer, key)
+			require.NoError(t, err)
+			b.AddTx(txn)
+		}
+	})
+	require.NoError(t, err)
+	require.NoError(t, m.InsertChain(chain))
+
+	require.Equal(t, []string{
+		"block start 1",
+		"block end <nil>",
+		"block start 2",
+		fmt.Sprintf("tx start 2 %x", addr),
+		fmt.Sprintf("enter 0 %x 1000", to),
+		"tx end 0 1",
+		"block end <nil>",
+	}, events)
+}
+
+// TestLiveTracerStateChanges checks that the exec3 worker reports the state changes of executed txs to a live tracer
+func TestLiveTracerStateChanges(t *testing.T) {
+	var (
+		key, _   = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr     = crypto.PubkeyToAddress(key.PublicKey)
+		contract = libcommon.HexToAddress("0x1234")
+		gspec    = &types.Genesis{
+			Config: params.TestChainConfig,
+			Alloc: types.GenesisAlloc{
+				addr: {Balance: big.NewInt(params.Ether)},
+				// PUSH1 1 PUSH1 0 SSTORE PUSH1 0 PUSH1 0 LOG0 STOP
+				contract: {Balance: new(big.Int), Code: hexutility.MustDecodeHex("0x600160005560006000a000")},
+			},
+		}
+		signer  = types.LatestSigner(gspec.Config)
+		created = crypto.CreateAddress(addr, 1)
+	)
+
+	var events []string
+	var gasChanges []tracing.GasChangeReason
+	tracer := &tracing.Hooks{
+		OnNonceChange: func(a libcommon.Address, prev, new uint64) {
+			events = append(events, fmt.Sprintf("nonce %x %d %d", a, prev, new))
+		},
+		OnStorageChange: func(a libcommon.Address, slot *libcommon.Hash, prev, new uint256.Int) {
+			events = append(events, fmt.Sprintf("storage %x %x %d %d", a, *slot, &prev, &new))
+		},
+		OnCodeChange: func(a libcommon.Address, prevCodeHash libcommon.Hash, prevCode []byte, codeHash libcommon.Hash, code []byte) {
+			events = append(events, fmt.Sprintf("code %x %x", a, code))
+		},
+		OnLog: func(l *types.Log) {
+			events = append(events, fmt.Sprintf("log %x", l.Address))
+		},
+		OnGasChange: func(old, new uint64, reason tracing.GasChangeReason) {
+			gasChanges = append(gasChanges, reason)
+		},
+	}
+	m := mock.MockWithTracer(t, gspec, key, tracer)
+
+	chain, err := core.GenerateChain(m.ChainConfig, m.Genesis, m.Engine, m.DB, 1, func(i int, b *core.BlockGen) {
+		call, err := types.SignTx(types.NewTransaction(b.TxNonce(addr), contract, uint256.NewInt(0), 100_000, uint256.NewInt(params.GWei), nil), *signer, key)
+		require.NoError(t, err)
+		b.AddTx(call)
+		// PUSH1 0 PUSH1 0 MSTORE8 PUSH1 1 PUSH1 0 RETURN - deploys the code 0x00
+		create, err := types.SignTx(types.NewContractCreation(b.TxNonce(addr), uint256.NewInt(0), 100_000, uint256.NewInt(params.GWei), hexutility.MustDecodeHex("0x600060005360016000f3")), *signer, key)
+		require.NoError(t, err)
+		b.AddTx(create)
+	})
+	require.NoError(t, err)
+	require.NoError(t, m.InsertChain(chain))
+
+	require.Equal(t, []string{
+		fmt.Sprintf("nonce %x 0 1", addr),
+		fmt.Sprintf("storage %x %x 0 1", contract, libcommon.Hash{}),
+		fmt.Sprintf("log %x", contract),
+		fmt.Sprintf("nonce %x 1 2", addr),
+		fmt.Sprintf("nonce %x 0 1", created),
+		fmt.Sprintf("code %x 00", created),
+	}, events)
+	require.Equal(t, []tracing.GasChangeReason{
+		tracing.GasChangeTxInitialBalance,
+		tracing.GasChangeTxIntrinsicGas,
+		tracing.GasChangeTxLeftOverReturned,
+		tracing.GasChangeTxInitialBalance,
+		tracing.GasChangeTxIntrinsicGas,
+		tracing.GasChangeCallCodeStorage,
+		tracing.GasChangeTxLeftOverReturned,
+	}, gasChanges)
+}
@@ -98,13 +98,14 @@ func TraceTx(
 	message core.Message,
 	blockCtx evmtypes.BlockContext,
 	txCtx evmtypes.TxContext,
+	tracerCtx *tracers.Context,
 	ibs evmtypes.IntraBlockState,
 	config *tracersConfig.TraceConfig,
 	chainConfig *chain.Config,
 	stream *jsoniter.Stream,
 	callTimeout time.Duration,
 ) error {
-	tracer, streaming, cancel, err := AssembleTracer(ctx, config, txCtx.TxHash, stream, callTimeout)
+	tracer, streaming, cancel, err := AssembleTracer(ctx, config, tracerCtx, stream, callTimeout)
 	if err != nil {
 		stream.WriteNil()
 		return err
@@ -123,7 +124,7 @@ func TraceTx(
 func AssembleTracer(
 	ctx context.Context,
 	config *tracersConfig.TraceConfig,
-	txHash libcommon.Hash,
+	tracerCtx *tracers.Context,
 	stream *jsoniter.Stream,
 	callTimeout time.Duration,
 ) (vm.EVMLogger, bool, context.CancelFunc, error) {
@@ -145,7 +146,7 @@ func AssembleTracer(
 		if config != nil && config.TracerConfig != nil {
 			cfg = *config.TracerConfig
 		}
-		tracer, err := tracers.New(*config.Tracer, &tracers.Context{TxHash: txHash}, cfg)
+		tracer, err := tracers.New(*config.Tracer, tracerCtx, cfg)
 		if err != nil {
 			return nil, false, func() {}, err
 		}